@@ -39,6 +39,11 @@
 // 	 .Inv(a)
 // 	b.Exp(b, new(big.Int).SetUint64(42))
 //
+// Slices of field elements can be manipulated in batch through the Vector type:
+// 	var a, b, c Vector
+// 	c.Mul(a, b)
+// 	s := c.InnerProduct(a)
+//
 // Modulus q =
 //
 // 	q[base10] = 258664426012969094010652733694893533536393512754914660539884262666720468348340822774968888139573360124440321458177
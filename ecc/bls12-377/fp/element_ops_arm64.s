@@ -0,0 +1,552 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+#include "textflag.h"
+#include "funcdata.h"
+
+// modulus q
+DATA q<>+0(SB)/8, $0x8508c00000000001
+DATA q<>+8(SB)/8, $0x170b5d4430000000
+DATA q<>+16(SB)/8, $0x1ef3622fba094800
+DATA q<>+24(SB)/8, $0x1a22d9f300f5138f
+DATA q<>+32(SB)/8, $0xc63b05c06ca1493b
+DATA q<>+40(SB)/8, $0x01ae3a4617c510ea
+GLOBL q<>(SB), (RODATA+NOPTR), $48
+
+// qInv0 q'[0]
+DATA qInv0<>(SB)/8, $0x8508bfffffffffff
+GLOBL qInv0<>(SB), (RODATA+NOPTR), $8
+
+// add(res, x, y *Element)
+TEXT ·add(SB), NOSPLIT, $0-24
+	MOVD x+8(FP), R0
+	LDP  0(R0), (R1, R2)
+	LDP  16(R0), (R3, R4)
+	LDP  32(R0), (R5, R6)
+	MOVD y+16(FP), R0
+	LDP  0(R0), (R7, R8)
+	LDP  16(R0), (R9, R10)
+	LDP  32(R0), (R11, R12)
+	ADDS R1, R7, R1
+	ADCS R2, R8, R2
+	ADCS R3, R9, R3
+	ADCS R4, R10, R4
+	ADCS R5, R11, R5
+	ADC  R6, R12, R6
+	LDP  q<>+0(SB), (R7, R8)
+	LDP  q<>+16(SB), (R9, R10)
+	LDP  q<>+32(SB), (R11, R12)
+	MOVD res+0(FP), R0
+
+	// q = t - q
+	SUBS R7, R1, R7
+	SBCS R8, R2, R8
+	SBCS R9, R3, R9
+	SBCS R10, R4, R10
+	SBCS R11, R5, R11
+	SBCS R12, R6, R12
+
+	// if no borrow, return q, else return t
+	CSEL CS, R7, R1, R1
+	CSEL CS, R8, R2, R2
+	CSEL CS, R9, R3, R3
+	CSEL CS, R10, R4, R4
+	CSEL CS, R11, R5, R5
+	CSEL CS, R12, R6, R6
+	STP  (R1, R2), 0(R0)
+	STP  (R3, R4), 16(R0)
+	STP  (R5, R6), 32(R0)
+	RET
+
+// sub(res, x, y *Element)
+TEXT ·sub(SB), NOSPLIT, $0-24
+	MOVD x+8(FP), R0
+	LDP  0(R0), (R1, R2)
+	LDP  16(R0), (R3, R4)
+	LDP  32(R0), (R5, R6)
+	MOVD y+16(FP), R0
+	LDP  0(R0), (R7, R8)
+	LDP  16(R0), (R9, R10)
+	LDP  32(R0), (R11, R12)
+	SUBS R7, R1, R1
+	SBCS R8, R2, R2
+	SBCS R9, R3, R3
+	SBCS R10, R4, R4
+	SBCS R11, R5, R5
+	SBCS R12, R6, R6
+	LDP  q<>+0(SB), (R7, R8)
+	LDP  q<>+16(SB), (R9, R10)
+	LDP  q<>+32(SB), (R11, R12)
+
+	// add q if underflow, 0 if not
+	CSEL CS, ZR, R7, R7
+	CSEL CS, ZR, R8, R8
+	CSEL CS, ZR, R9, R9
+	CSEL CS, ZR, R10, R10
+	CSEL CS, ZR, R11, R11
+	CSEL CS, ZR, R12, R12
+	ADDS R7, R1, R1
+	ADCS R8, R2, R2
+	ADCS R9, R3, R3
+	ADCS R10, R4, R4
+	ADCS R11, R5, R5
+	ADC  R12, R6, R6
+	MOVD res+0(FP), R0
+	STP  (R1, R2), 0(R0)
+	STP  (R3, R4), 16(R0)
+	STP  (R5, R6), 32(R0)
+	RET
+
+// mul(res, x, y *Element)
+TEXT ·mul(SB), NOSPLIT, $0-24
+	MOVD x+8(FP), R0
+	LDP  0(R0), (R5, R6)
+	LDP  16(R0), (R7, R8)
+	LDP  32(R0), (R9, R10)
+	MOVD y+16(FP), R1
+	LDP  q<>+0(SB), (R11, R12)
+	LDP  q<>+16(SB), (R13, R14)
+	LDP  q<>+32(SB), (R15, R16)
+	MOVD qInv0<>(SB), R3
+	MOVD 0(R1), R2
+
+	// (C,t[j])  := t[j] + x[j]*y[i] + C
+	MUL   R5, R2, R17
+	MUL   R6, R2, R19
+	MUL   R7, R2, R20
+	MUL   R8, R2, R21
+	MUL   R9, R2, R22
+	MUL   R10, R2, R23
+	UMULH R5, R2, R0
+	ADDS  R0, R19, R19
+	UMULH R6, R2, R0
+	ADCS  R0, R20, R20
+	UMULH R7, R2, R0
+	ADCS  R0, R21, R21
+	UMULH R8, R2, R0
+	ADCS  R0, R22, R22
+	UMULH R9, R2, R0
+	ADCS  R0, R23, R23
+	UMULH R10, R2, R0
+	ADC   R0, ZR, R24
+
+	// m := t[0]*q'[0] mod W
+	MUL R17, R3, R4
+
+	// (C,t[j-1]) := t[j] + m*q[j] + C
+	MUL   R11, R4, R0
+	ADDS  R0, R17, R17
+	MUL   R12, R4, R0
+	ADCS  R0, R19, R19
+	MUL   R13, R4, R0
+	ADCS  R0, R20, R20
+	MUL   R14, R4, R0
+	ADCS  R0, R21, R21
+	MUL   R15, R4, R0
+	ADCS  R0, R22, R22
+	MUL   R16, R4, R0
+	ADCS  R0, R23, R23
+	ADC   ZR, R24, R24
+	UMULH R11, R4, R0
+	ADDS  R0, R19, R17
+	UMULH R12, R4, R0
+	ADCS  R0, R20, R19
+	UMULH R13, R4, R0
+	ADCS  R0, R21, R20
+	UMULH R14, R4, R0
+	ADCS  R0, R22, R21
+	UMULH R15, R4, R0
+	ADCS  R0, R23, R22
+	UMULH R16, R4, R0
+	ADC   R0, R24, R23
+	MOVD  8(R1), R2
+
+	// (C,t[j])  := t[j] + x[j]*y[i] + C
+	MUL   R5, R2, R0
+	ADDS  R0, R17, R17
+	MUL   R6, R2, R0
+	ADCS  R0, R19, R19
+	MUL   R7, R2, R0
+	ADCS  R0, R20, R20
+	MUL   R8, R2, R0
+	ADCS  R0, R21, R21
+	MUL   R9, R2, R0
+	ADCS  R0, R22, R22
+	MUL   R10, R2, R0
+	ADCS  R0, R23, R23
+	ADC   ZR, ZR, R24
+	UMULH R5, R2, R0
+	ADDS  R0, R19, R19
+	UMULH R6, R2, R0
+	ADCS  R0, R20, R20
+	UMULH R7, R2, R0
+	ADCS  R0, R21, R21
+	UMULH R8, R2, R0
+	ADCS  R0, R22, R22
+	UMULH R9, R2, R0
+	ADCS  R0, R23, R23
+	UMULH R10, R2, R0
+	ADC   R0, R24, R24
+
+	// m := t[0]*q'[0] mod W
+	MUL R17, R3, R4
+
+	// (C,t[j-1]) := t[j] + m*q[j] + C
+	MUL   R11, R4, R0
+	ADDS  R0, R17, R17
+	MUL   R12, R4, R0
+	ADCS  R0, R19, R19
+	MUL   R13, R4, R0
+	ADCS  R0, R20, R20
+	MUL   R14, R4, R0
+	ADCS  R0, R21, R21
+	MUL   R15, R4, R0
+	ADCS  R0, R22, R22
+	MUL   R16, R4, R0
+	ADCS  R0, R23, R23
+	ADC   ZR, R24, R24
+	UMULH R11, R4, R0
+	ADDS  R0, R19, R17
+	UMULH R12, R4, R0
+	ADCS  R0, R20, R19
+	UMULH R13, R4, R0
+	ADCS  R0, R21, R20
+	UMULH R14, R4, R0
+	ADCS  R0, R22, R21
+	UMULH R15, R4, R0
+	ADCS  R0, R23, R22
+	UMULH R16, R4, R0
+	ADC   R0, R24, R23
+	MOVD  16(R1), R2
+
+	// (C,t[j])  := t[j] + x[j]*y[i] + C
+	MUL   R5, R2, R0
+	ADDS  R0, R17, R17
+	MUL   R6, R2, R0
+	ADCS  R0, R19, R19
+	MUL   R7, R2, R0
+	ADCS  R0, R20, R20
+	MUL   R8, R2, R0
+	ADCS  R0, R21, R21
+	MUL   R9, R2, R0
+	ADCS  R0, R22, R22
+	MUL   R10, R2, R0
+	ADCS  R0, R23, R23
+	ADC   ZR, ZR, R24
+	UMULH R5, R2, R0
+	ADDS  R0, R19, R19
+	UMULH R6, R2, R0
+	ADCS  R0, R20, R20
+	UMULH R7, R2, R0
+	ADCS  R0, R21, R21
+	UMULH R8, R2, R0
+	ADCS  R0, R22, R22
+	UMULH R9, R2, R0
+	ADCS  R0, R23, R23
+	UMULH R10, R2, R0
+	ADC   R0, R24, R24
+
+	// m := t[0]*q'[0] mod W
+	MUL R17, R3, R4
+
+	// (C,t[j-1]) := t[j] + m*q[j] + C
+	MUL   R11, R4, R0
+	ADDS  R0, R17, R17
+	MUL   R12, R4, R0
+	ADCS  R0, R19, R19
+	MUL   R13, R4, R0
+	ADCS  R0, R20, R20
+	MUL   R14, R4, R0
+	ADCS  R0, R21, R21
+	MUL   R15, R4, R0
+	ADCS  R0, R22, R22
+	MUL   R16, R4, R0
+	ADCS  R0, R23, R23
+	ADC   ZR, R24, R24
+	UMULH R11, R4, R0
+	ADDS  R0, R19, R17
+	UMULH R12, R4, R0
+	ADCS  R0, R20, R19
+	UMULH R13, R4, R0
+	ADCS  R0, R21, R20
+	UMULH R14, R4, R0
+	ADCS  R0, R22, R21
+	UMULH R15, R4, R0
+	ADCS  R0, R23, R22
+	UMULH R16, R4, R0
+	ADC   R0, R24, R23
+	MOVD  24(R1), R2
+
+	// (C,t[j])  := t[j] + x[j]*y[i] + C
+	MUL   R5, R2, R0
+	ADDS  R0, R17, R17
+	MUL   R6, R2, R0
+	ADCS  R0, R19, R19
+	MUL   R7, R2, R0
+	ADCS  R0, R20, R20
+	MUL   R8, R2, R0
+	ADCS  R0, R21, R21
+	MUL   R9, R2, R0
+	ADCS  R0, R22, R22
+	MUL   R10, R2, R0
+	ADCS  R0, R23, R23
+	ADC   ZR, ZR, R24
+	UMULH R5, R2, R0
+	ADDS  R0, R19, R19
+	UMULH R6, R2, R0
+	ADCS  R0, R20, R20
+	UMULH R7, R2, R0
+	ADCS  R0, R21, R21
+	UMULH R8, R2, R0
+	ADCS  R0, R22, R22
+	UMULH R9, R2, R0
+	ADCS  R0, R23, R23
+	UMULH R10, R2, R0
+	ADC   R0, R24, R24
+
+	// m := t[0]*q'[0] mod W
+	MUL R17, R3, R4
+
+	// (C,t[j-1]) := t[j] + m*q[j] + C
+	MUL   R11, R4, R0
+	ADDS  R0, R17, R17
+	MUL   R12, R4, R0
+	ADCS  R0, R19, R19
+	MUL   R13, R4, R0
+	ADCS  R0, R20, R20
+	MUL   R14, R4, R0
+	ADCS  R0, R21, R21
+	MUL   R15, R4, R0
+	ADCS  R0, R22, R22
+	MUL   R16, R4, R0
+	ADCS  R0, R23, R23
+	ADC   ZR, R24, R24
+	UMULH R11, R4, R0
+	ADDS  R0, R19, R17
+	UMULH R12, R4, R0
+	ADCS  R0, R20, R19
+	UMULH R13, R4, R0
+	ADCS  R0, R21, R20
+	UMULH R14, R4, R0
+	ADCS  R0, R22, R21
+	UMULH R15, R4, R0
+	ADCS  R0, R23, R22
+	UMULH R16, R4, R0
+	ADC   R0, R24, R23
+	MOVD  32(R1), R2
+
+	// (C,t[j])  := t[j] + x[j]*y[i] + C
+	MUL   R5, R2, R0
+	ADDS  R0, R17, R17
+	MUL   R6, R2, R0
+	ADCS  R0, R19, R19
+	MUL   R7, R2, R0
+	ADCS  R0, R20, R20
+	MUL   R8, R2, R0
+	ADCS  R0, R21, R21
+	MUL   R9, R2, R0
+	ADCS  R0, R22, R22
+	MUL   R10, R2, R0
+	ADCS  R0, R23, R23
+	ADC   ZR, ZR, R24
+	UMULH R5, R2, R0
+	ADDS  R0, R19, R19
+	UMULH R6, R2, R0
+	ADCS  R0, R20, R20
+	UMULH R7, R2, R0
+	ADCS  R0, R21, R21
+	UMULH R8, R2, R0
+	ADCS  R0, R22, R22
+	UMULH R9, R2, R0
+	ADCS  R0, R23, R23
+	UMULH R10, R2, R0
+	ADC   R0, R24, R24
+
+	// m := t[0]*q'[0] mod W
+	MUL R17, R3, R4
+
+	// (C,t[j-1]) := t[j] + m*q[j] + C
+	MUL   R11, R4, R0
+	ADDS  R0, R17, R17
+	MUL   R12, R4, R0
+	ADCS  R0, R19, R19
+	MUL   R13, R4, R0
+	ADCS  R0, R20, R20
+	MUL   R14, R4, R0
+	ADCS  R0, R21, R21
+	MUL   R15, R4, R0
+	ADCS  R0, R22, R22
+	MUL   R16, R4, R0
+	ADCS  R0, R23, R23
+	ADC   ZR, R24, R24
+	UMULH R11, R4, R0
+	ADDS  R0, R19, R17
+	UMULH R12, R4, R0
+	ADCS  R0, R20, R19
+	UMULH R13, R4, R0
+	ADCS  R0, R21, R20
+	UMULH R14, R4, R0
+	ADCS  R0, R22, R21
+	UMULH R15, R4, R0
+	ADCS  R0, R23, R22
+	UMULH R16, R4, R0
+	ADC   R0, R24, R23
+	MOVD  40(R1), R2
+
+	// (C,t[j])  := t[j] + x[j]*y[i] + C
+	MUL   R5, R2, R0
+	ADDS  R0, R17, R17
+	MUL   R6, R2, R0
+	ADCS  R0, R19, R19
+	MUL   R7, R2, R0
+	ADCS  R0, R20, R20
+	MUL   R8, R2, R0
+	ADCS  R0, R21, R21
+	MUL   R9, R2, R0
+	ADCS  R0, R22, R22
+	MUL   R10, R2, R0
+	ADCS  R0, R23, R23
+	ADC   ZR, ZR, R24
+	UMULH R5, R2, R0
+	ADDS  R0, R19, R19
+	UMULH R6, R2, R0
+	ADCS  R0, R20, R20
+	UMULH R7, R2, R0
+	ADCS  R0, R21, R21
+	UMULH R8, R2, R0
+	ADCS  R0, R22, R22
+	UMULH R9, R2, R0
+	ADCS  R0, R23, R23
+	UMULH R10, R2, R0
+	ADC   R0, R24, R24
+
+	// m := t[0]*q'[0] mod W
+	MUL R17, R3, R4
+
+	// (C,t[j-1]) := t[j] + m*q[j] + C
+	MUL   R11, R4, R0
+	ADDS  R0, R17, R17
+	MUL   R12, R4, R0
+	ADCS  R0, R19, R19
+	MUL   R13, R4, R0
+	ADCS  R0, R20, R20
+	MUL   R14, R4, R0
+	ADCS  R0, R21, R21
+	MUL   R15, R4, R0
+	ADCS  R0, R22, R22
+	MUL   R16, R4, R0
+	ADCS  R0, R23, R23
+	ADC   ZR, R24, R24
+	UMULH R11, R4, R0
+	ADDS  R0, R19, R17
+	UMULH R12, R4, R0
+	ADCS  R0, R20, R19
+	UMULH R13, R4, R0
+	ADCS  R0, R21, R20
+	UMULH R14, R4, R0
+	ADCS  R0, R22, R21
+	UMULH R15, R4, R0
+	ADCS  R0, R23, R22
+	UMULH R16, R4, R0
+	ADC   R0, R24, R23
+
+	// reduce if necessary
+	MOVD res+0(FP), R0
+
+	// q = t - q
+	SUBS R11, R17, R11
+	SBCS R12, R19, R12
+	SBCS R13, R20, R13
+	SBCS R14, R21, R14
+	SBCS R15, R22, R15
+	SBCS R16, R23, R16
+
+	// if no borrow, return q, else return t
+	CSEL CS, R11, R17, R17
+	CSEL CS, R12, R19, R19
+	CSEL CS, R13, R20, R20
+	CSEL CS, R14, R21, R21
+	CSEL CS, R15, R22, R22
+	CSEL CS, R16, R23, R23
+	STP  (R17, R19), 0(R0)
+	STP  (R20, R21), 16(R0)
+	STP  (R22, R23), 32(R0)
+	RET
+
+// Butterfly(a, b *Element) sets a = a + b; b = a - b
+TEXT ·Butterfly(SB), NOSPLIT, $0-16
+	MOVD a+0(FP), R0
+	LDP  0(R0), (R2, R3)
+	LDP  16(R0), (R4, R5)
+	LDP  32(R0), (R6, R7)
+	MOVD b+8(FP), R1
+	LDP  0(R1), (R8, R9)
+	LDP  16(R1), (R10, R11)
+	LDP  32(R1), (R12, R13)
+	ADDS R2, R8, R14
+	ADCS R3, R9, R15
+	ADCS R4, R10, R16
+	ADCS R5, R11, R17
+	ADCS R6, R12, R19
+	ADC  R7, R13, R20
+	SUBS R8, R2, R8
+	SBCS R9, R3, R9
+	SBCS R10, R4, R10
+	SBCS R11, R5, R11
+	SBCS R12, R6, R12
+	SBCS R13, R7, R13
+	LDP  q<>+0(SB), (R2, R3)
+	LDP  q<>+16(SB), (R4, R5)
+	LDP  q<>+32(SB), (R6, R7)
+
+	// add q if underflow, 0 if not
+	CSEL CS, ZR, R2, R2
+	CSEL CS, ZR, R3, R3
+	CSEL CS, ZR, R4, R4
+	CSEL CS, ZR, R5, R5
+	CSEL CS, ZR, R6, R6
+	CSEL CS, ZR, R7, R7
+	ADDS R2, R8, R8
+	ADCS R3, R9, R9
+	ADCS R4, R10, R10
+	ADCS R5, R11, R11
+	ADCS R6, R12, R12
+	ADC  R7, R13, R13
+	STP  (R8, R9), 0(R1)
+	STP  (R10, R11), 16(R1)
+	STP  (R12, R13), 32(R1)
+	LDP  q<>+0(SB), (R2, R3)
+	LDP  q<>+16(SB), (R4, R5)
+	LDP  q<>+32(SB), (R6, R7)
+
+	// q = t - q
+	SUBS R2, R14, R2
+	SBCS R3, R15, R3
+	SBCS R4, R16, R4
+	SBCS R5, R17, R5
+	SBCS R6, R19, R6
+	SBCS R7, R20, R7
+
+	// if no borrow, return q, else return t
+	CSEL CS, R2, R14, R14
+	CSEL CS, R3, R15, R15
+	CSEL CS, R4, R16, R16
+	CSEL CS, R5, R17, R17
+	CSEL CS, R6, R19, R19
+	CSEL CS, R7, R20, R20
+	STP  (R14, R15), 0(R0)
+	STP  (R16, R17), 16(R0)
+	STP  (R19, R20), 32(R0)
+	RET
+
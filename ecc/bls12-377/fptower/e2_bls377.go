@@ -32,6 +32,23 @@ func (z *E2) Mul(x, y *E2) *E2 {
 	return z
 }
 
+// MulCT sets z to the E2-product of x,y, returns z
+//
+// Unlike Mul, MulCT only uses the branch-free fp.Element operations (AddCT, SubCT, MulCT).
+func (z *E2) MulCT(x, y *E2) *E2 {
+	var a, b, c fp.Element
+	a.AddCT(&x.A0, &x.A1)
+	b.AddCT(&y.A0, &y.A1)
+	a.MulCT(&a, &b)
+	b.MulCT(&x.A0, &y.A0)
+	c.MulCT(&x.A1, &y.A1)
+	z.A1.SubCT(&a, &b).SubCT(&z.A1, &c)
+	// 5c = 4c + c
+	a.DoubleCT(&c).DoubleCT(&a).AddCT(&a, &c)
+	z.A0.SubCT(&b, &a)
+	return z
+}
+
 // Square sets z to the E2-product of x,x returns z
 func (z *E2) Square(x *E2) *E2 {
 	//algo 22 https://eprint.iacr.org/2010/354.pdf
@@ -16,6 +16,14 @@
 
 package fptower
 
+import (
+	"crypto/rand"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+)
+
 // E6 is a degree three finite field extension of fp2
 type E6 struct {
 	B0, B1, B2 E2
@@ -49,15 +57,22 @@ func (z *E6) SetOne() *E6 {
 	return z
 }
 
-// SetRandom set z to a random elmt
+// SetRandom sets z to a uniform random value, reading the randomness from crypto/rand.Reader
 func (z *E6) SetRandom() (*E6, error) {
-	if _, err := z.B0.SetRandom(); err != nil {
+	return z.SetRandomFrom(rand.Reader)
+}
+
+// SetRandomFrom sets z to a uniform random value, reading the randomness from r
+//
+// Each coordinate is sampled uniformly with SetRandomFrom, in order.
+func (z *E6) SetRandomFrom(r io.Reader) (*E6, error) {
+	if _, err := z.B0.SetRandomFrom(r); err != nil {
 		return nil, err
 	}
-	if _, err := z.B1.SetRandom(); err != nil {
+	if _, err := z.B1.SetRandomFrom(r); err != nil {
 		return nil, err
 	}
-	if _, err := z.B2.SetRandom(); err != nil {
+	if _, err := z.B2.SetRandomFrom(r); err != nil {
 		return nil, err
 	}
 	return z, nil
@@ -270,6 +285,35 @@ func (z *E6) Inverse(x *E6) *E6 {
 	return z
 }
 
+// InverseCT an element in E6, in constant time
+//
+// if x == 0, sets and returns z = x
+func (z *E6) InverseCT(x *E6) *E6 {
+	// Algorithm 17 from https://eprint.iacr.org/2010/354.pdf
+	// step 9 is wrong in the paper it's t1-t4
+	var t0, t1, t2, t3, t4, t5, t6, c0, c1, c2, d1, d2 E2
+	t0.Square(&x.B0)
+	t1.Square(&x.B1)
+	t2.Square(&x.B2)
+	t3.Mul(&x.B0, &x.B1)
+	t4.Mul(&x.B0, &x.B2)
+	t5.Mul(&x.B1, &x.B2)
+	c0.MulByNonResidue(&t5).Neg(&c0).Add(&c0, &t0)
+	c1.MulByNonResidue(&t2).Sub(&c1, &t3)
+	c2.Sub(&t1, &t4)
+	t6.Mul(&x.B0, &c0)
+	d1.Mul(&x.B2, &c1)
+	d2.Mul(&x.B1, &c2)
+	d1.Add(&d1, &d2).MulByNonResidue(&d1)
+	t6.Add(&t6, &d1)
+	t6.InverseCT(&t6)
+	z.B0.Mul(&c0, &t6)
+	z.B1.Mul(&c1, &t6)
+	z.B2.Mul(&c2, &t6)
+
+	return z
+}
+
 // BatchInvertE6 returns a new slice with every element inverted.
 // Uses Montgomery batch inversion trick
 //
@@ -321,3 +365,46 @@ func (z *E6) Div(x *E6, y *E6) *E6 {
 	r.Inverse(y).Mul(x, &r)
 	return z.Set(&r)
 }
+
+// ExpCT sets z=xᵏ and returns it
+//
+// Unlike Exp, ExpCT uses a Montgomery ladder over max(6*fp.Bits, k.BitLen()) bits with
+// constant-time selections, so that its sequence of operations doesn't depend on x and k (only the sign
+// of k and the bit length of exponents larger than 6*fp.Bits are leaked).
+// It is as constant-time as the E2 arithmetic it relies on.
+func (z *E6) ExpCT(x E6, k *big.Int) *E6 {
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ == (x⁻¹)ᵏ
+		x.InverseCT(&x)
+
+		// we negate k in a temp big.Int since
+		// Int.Bit(_) of k and -k is different
+		e = bigIntPool.Get().(*big.Int)
+		defer bigIntPool.Put(e)
+		e.Neg(k)
+	}
+
+	nbBits := 6 * fp.Bits
+	if e.BitLen() > nbBits {
+		nbBits = e.BitLen()
+	}
+
+	// invariant: r1 = r0 * x
+	var r0, r1, a, b E6
+	r0.SetOne()
+	r1.Set(&x)
+	for i := nbBits - 1; i >= 0; i-- {
+		bit := int(e.Bit(i))
+		// (a, b) = (r0, r1) if bit == 0, (r1, r0) otherwise
+		a.Select(bit, &r0, &r1)
+		b.Select(bit, &r1, &r0)
+		b.Mul(&a, &b)
+		a.Square(&a)
+		r0.Select(bit, &a, &b)
+		r1.Select(bit, &b, &a)
+	}
+
+	return z.Set(&r0)
+}
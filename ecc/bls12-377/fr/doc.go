@@ -39,6 +39,11 @@
 // 	 .Inv(a)
 // 	b.Exp(b, new(big.Int).SetUint64(42))
 //
+// Slices of field elements can be manipulated in batch through the Vector type:
+// 	var a, b, c Vector
+// 	c.Mul(a, b)
+// 	s := c.InnerProduct(a)
+//
 // Modulus q =
 //
 // 	q[base10] = 8444461749428370424248824938781546531375899335154063827935233455917409239041
@@ -326,6 +326,17 @@ func (z *Element) LexicographicallyLargest() bool {
 // This might error only if reading from crypto/rand.Reader errors,
 // in which case, value of z is undefined.
 func (z *Element) SetRandom() (*Element, error) {
+	return z.SetRandomFrom(rand.Reader)
+}
+
+// SetRandomFrom sets z to a uniform random value in [0, q), reading the randomness from r.
+//
+// Candidates are sampled by rejection, so the number of bytes read from r isn't fixed;
+// given the same stream of bytes, SetRandomFrom always returns the same value.
+//
+// This might error only if reading from r errors,
+// in which case, value of z is undefined.
+func (z *Element) SetRandomFrom(r io.Reader) (*Element, error) {
 	// this code is generated for all modulus
 	// and derived from go/src/crypto/rand/util.go
 
@@ -348,7 +359,7 @@ func (z *Element) SetRandom() (*Element, error) {
 
 	for {
 		// note that bytes[k:l] is always 0
-		if _, err := io.ReadFull(rand.Reader, bytes[:k]); err != nil {
+		if _, err := io.ReadFull(r, bytes[:k]); err != nil {
 			return nil, err
 		}
 
@@ -468,6 +479,11 @@ func (z *Element) FromMont() *Element {
 
 // Add z = x + y (mod q)
 func (z *Element) Add(x, y *Element) *Element {
+	add(z, x, y)
+	return z
+}
+
+func _addGeneric(z, x, y *Element) {
 
 	var carry uint64
 	z[0], carry = bits.Add64(x[0], y[0], 0)
@@ -483,7 +499,6 @@ func (z *Element) Add(x, y *Element) *Element {
 		z[2], b = bits.Sub64(z[2], q2, b)
 		z[3], _ = bits.Sub64(z[3], q3, b)
 	}
-	return z
 }
 
 // Double z = x + x (mod q), aka Lsh 1
@@ -508,6 +523,11 @@ func (z *Element) Double(x *Element) *Element {
 
 // Sub z = x - y (mod q)
 func (z *Element) Sub(x, y *Element) *Element {
+	sub(z, x, y)
+	return z
+}
+
+func _subGeneric(z, x, y *Element) {
 	var b uint64
 	z[0], b = bits.Sub64(x[0], y[0], 0)
 	z[1], b = bits.Sub64(x[1], y[1], b)
@@ -520,7 +540,6 @@ func (z *Element) Sub(x, y *Element) *Element {
 		z[2], c = bits.Add64(z[2], q2, c)
 		z[3], _ = bits.Add64(z[3], q3, c)
 	}
-	return z
 }
 
 // Neg z = q - x
@@ -876,6 +895,40 @@ func (z *Element) SetBytes(e []byte) *Element {
 	return z
 }
 
+// SetBytesWide interprets e as the bytes of a big-endian unsigned integer, reduces it modulo q,
+// sets z to that value, and returns z.
+//
+// Unlike SetBytes, it is meant for inputs wider than q: if e is uniformly random, z is within
+// statistical distance q / 2^(8*len(e)) of the uniform distribution (for example, it is negligible
+// for a 64-byte input and a 256-bit q). Inputs of at most 2*Bytes bytes are reduced with Montgomery
+// multiplications, without allocations; longer inputs go through SetBytes.
+func (z *Element) SetBytesWide(e []byte) *Element {
+	if len(e) > 2*Bytes {
+		return z.SetBytes(e)
+	}
+
+	// e = hi * 2^(64*Limbs) + lo, hi and lo not necessarily reduced
+	var buf [2 * Bytes]byte
+	copy(buf[2*Bytes-len(e):], e)
+
+	var hi, lo Element
+	for i := 0; i < Limbs; i++ {
+		hi[i] = binary.BigEndian.Uint64(buf[Bytes-8*(i+1) : Bytes-8*i])
+		lo[i] = binary.BigEndian.Uint64(buf[2*Bytes-8*(i+1) : 2*Bytes-8*i])
+	}
+
+	// the CIOS multiplication only needs x * y < q * R to output a reduced result,
+	// which holds for x < R and y = R² mod q
+	// lo * R² * R⁻¹ = lo * R, the montgomery form of lo
+	mulCT(&lo, &lo, &rSquare)
+
+	// hi * R² * R⁻¹ * R² * R⁻¹ = (hi * R) * R, the montgomery form of hi * 2^(64*Limbs)
+	mulCT(&hi, &hi, &rSquare)
+	mulCT(&hi, &hi, &rSquare)
+
+	return z.Add(&hi, &lo)
+}
+
 // SetBigInt sets z to v and returns z
 func (z *Element) SetBigInt(v *big.Int) *Element {
 	z.SetZero()
@@ -1417,6 +1470,368 @@ func updateFactorsDecompose(c int64) (int64, int64) {
 	return f, g
 }
 
+// ctOpElement is an operation of the constant-time methods, recorded by onCT
+type ctOpElement uint8
+
+const (
+	ctMulElement    ctOpElement = iota // mulCT
+	ctAddElement                       // AddCT, a masked subtraction of q
+	ctSubElement                       // SubCT, a masked addition of q
+	ctSwapElement                      // cswapCT
+	ctSelectElement                    // a masked selection of SqrtCT
+)
+
+// qMinusTwoElement q - 2, exponent of the constant-time inversion
+var qMinusTwoElement = [4]uint64{
+	725501752471715839,
+	6461107452199829505,
+	6968279316240510977,
+	1345280370688173398,
+}
+
+// sqrtCTExponentElement (s-1)/2 where q - 1 = 2ᵉ * s, s odd
+var sqrtCTExponentElement = [4]uint64{
+	8574519438978648593,
+	5556980384467605930,
+	7304445162184663220,
+	4779,
+}
+
+// InverseCT z = x⁻¹ (mod q)
+//
+// Unlike Inverse, InverseCT runs in constant time: it computes x^(q-2) with a fixed sequence
+// of multiplications.
+//
+// note: allowing cases where x == 0, it sets and returns z = x
+func (z *Element) InverseCT(x *Element) *Element {
+	return z.expCT(x, qMinusTwoElement[:], Bits)
+}
+
+// ExpCT z = xᵏ (mod q)
+//
+// Unlike Exp, ExpCT runs in constant time with respect to x and k: it uses a Montgomery ladder
+// over max(Bits, k.BitLen()) bits, that is, only the bit length of exponents larger than the
+// modulus and the sign of k are leaked.
+func (z *Element) ExpCT(x Element, k *big.Int) *Element {
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ (mod q) == (x⁻¹)ᵏ (mod q)
+		x.InverseCT(&x)
+
+		// we negate k in a temp big.Int since
+		// Int.Bit(_) of k and -k is different
+		e = bigIntPool.Get().(*big.Int)
+		defer bigIntPool.Put(e)
+		e.Neg(k)
+	}
+
+	nbBits := Bits
+	if e.BitLen() > nbBits {
+		nbBits = e.BitLen()
+	}
+
+	// copy the words of e in a fixed size buffer
+	var buf [Limbs]uint64
+	words := buf[:]
+	if n := (nbBits + 63) / 64; n > Limbs {
+		words = make([]uint64, n)
+	}
+	for i := 0; i < nbBits; i++ {
+		words[i/64] |= uint64(e.Bit(i)) << uint(i%64)
+	}
+
+	return z.expCT(&x, words, nbBits)
+}
+
+// SqrtCT z = √x (mod q)
+//
+// Unlike Sqrt, SqrtCT runs in constant time: it uses the constant-time variant of
+// Tonelli-Shanks described in RFC 9380 (Appendix I.4), for all q.
+// Only whether x is a square or not is leaked:
+// if the square root doesn't exist (x is not a square mod q)
+// SqrtCT leaves z unchanged and returns nil
+func (z *Element) SqrtCT(x *Element) *Element {
+	// q - 1 = 2ᵉ * s, s odd
+	var y, b, t, c, tmp Element
+
+	// y = x^((s-1)/2)
+	y.expCT(x, sqrtCTExponentElement[:], Bits)
+
+	// t = x^s, y = x^((s+1)/2)
+	mulCT(&t, &y, &y)
+	mulCT(&t, &t, x)
+	mulCT(&y, &y, x)
+
+	// c = g^s, g a non-residue
+	c = Element{
+		4340692304772210610,
+		11102725085307959083,
+		15540458298643990566,
+		944526744080888988,
+	}
+	b = t
+
+	for i := 47; i >= 2; i-- {
+		for j := 1; j <= i-2; j++ {
+			mulCT(&b, &b, &b)
+		}
+		// if b ≠ 1, y = y * c and t = t * c²
+		isOne := int(b.isOneCT())
+		mulCT(&tmp, &y, &c)
+		onCT(ctSelectElement)
+		y.Select(isOne, &tmp, &y)
+		mulCT(&c, &c, &c)
+		mulCT(&tmp, &t, &c)
+		onCT(ctSelectElement)
+		t.Select(isOne, &tmp, &t)
+		b = t
+	}
+
+	// as we didn't compute the legendre symbol, ensure we found y such that y * y = x
+	mulCT(&tmp, &y, &y)
+	if tmp.NotEqual(x) != 0 {
+		return nil
+	}
+	return z.Set(&y)
+}
+
+// AddCT z = x + y (mod q)
+//
+// Unlike Add, AddCT reduces the sum with a masked subtraction of q: it doesn't branch on x and y.
+func (z *Element) AddCT(x, y *Element) *Element {
+	onCT(ctAddElement)
+	var t [4]uint64
+	var carry uint64
+	t[0], carry = bits.Add64(x[0], y[0], 0)
+	t[1], carry = bits.Add64(x[1], y[1], carry)
+	t[2], carry = bits.Add64(x[2], y[2], carry)
+	t[3], carry = bits.Add64(x[3], y[3], carry)
+
+	// z = t - q if t ≥ q, t otherwise
+	var b uint64
+	z[0], b = bits.Sub64(t[0], q0, 0)
+	z[1], b = bits.Sub64(t[1], q1, b)
+	z[2], b = bits.Sub64(t[2], q2, b)
+	z[3], b = bits.Sub64(t[3], q3, b)
+	_, b = bits.Sub64(carry, 0, b)
+
+	// b == 1 iff t < q
+	mask := -b
+	z[0] ^= mask & (z[0] ^ t[0])
+	z[1] ^= mask & (z[1] ^ t[1])
+	z[2] ^= mask & (z[2] ^ t[2])
+	z[3] ^= mask & (z[3] ^ t[3])
+	return z
+}
+
+// DoubleCT z = x + x (mod q), without branching (see AddCT)
+func (z *Element) DoubleCT(x *Element) *Element {
+	return z.AddCT(x, x)
+}
+
+// SubCT z = x - y (mod q)
+//
+// Unlike Sub, SubCT adds q to a negative difference with a mask: it doesn't branch on x and y.
+func (z *Element) SubCT(x, y *Element) *Element {
+	onCT(ctSubElement)
+	var b uint64
+	z[0], b = bits.Sub64(x[0], y[0], 0)
+	z[1], b = bits.Sub64(x[1], y[1], b)
+	z[2], b = bits.Sub64(x[2], y[2], b)
+	z[3], b = bits.Sub64(x[3], y[3], b)
+
+	// z = z + q if x < y
+	mask := -b
+	var c uint64
+	z[0], c = bits.Add64(z[0], q0&mask, 0)
+	z[1], c = bits.Add64(z[1], q1&mask, c)
+	z[2], c = bits.Add64(z[2], q2&mask, c)
+	z[3], _ = bits.Add64(z[3], q3&mask, c)
+	return z
+}
+
+// NegCT z = q - x, and z = 0 if x = 0, without branching (see SubCT)
+func (z *Element) NegCT(x *Element) *Element {
+	var zero Element
+	return z.SubCT(&zero, x)
+}
+
+// MulCT z = x * y (mod q)
+//
+// Unlike Mul, whose final reduction may branch on targets without assembly, MulCT is
+// branch-free on all targets.
+func (z *Element) MulCT(x, y *Element) *Element {
+	mulCT(z, x, y)
+	return z
+}
+
+// SquareCT z = x * x (mod q), without branching (see MulCT)
+func (z *Element) SquareCT(x *Element) *Element {
+	mulCT(z, x, x)
+	return z
+}
+
+// expCT z = xᵏ (mod q), processing the nbBits low bits of k (little endian words)
+// with a Montgomery ladder
+func (z *Element) expCT(x *Element, k []uint64, nbBits int) *Element {
+	// invariant: r1 = r0 * x
+	var r0, r1 Element
+	r0.SetOne()
+	r1.Set(x)
+
+	for i := nbBits - 1; i >= 0; i-- {
+		bit := (k[i/64] >> uint(i%64)) & 1
+		cswapCT(&r0, &r1, bit)
+		mulCT(&r1, &r0, &r1)
+		mulCT(&r0, &r0, &r0)
+		cswapCT(&r0, &r1, bit)
+	}
+
+	return z.Set(&r0)
+}
+
+// isOneCT returns 1 if z == 1, 0 otherwise, without branching
+func (z *Element) isOneCT() uint64 {
+	one := One()
+	v := z.NotEqual(&one)
+	return 1 ^ ((v | -v) >> 63)
+}
+
+// cswapCT swaps a and b if c == 1, leaves them unchanged if c == 0, without branching
+func cswapCT(a, b *Element, c uint64) {
+	onCT(ctSwapElement)
+	mask := -c
+	t0 := mask & (a[0] ^ b[0])
+	a[0] ^= t0
+	b[0] ^= t0
+	t1 := mask & (a[1] ^ b[1])
+	a[1] ^= t1
+	b[1] ^= t1
+	t2 := mask & (a[2] ^ b[2])
+	a[2] ^= t2
+	b[2] ^= t2
+	t3 := mask & (a[3] ^ b[3])
+	a[3] ^= t3
+	b[3] ^= t3
+}
+
+// mulCT z = x * y (mod q)
+//
+// It implements the same CIOS multiplication as Mul, but its final reduction is branch-free
+// on all targets.
+//
+// x and y must be strictly inferior to q
+func mulCT(z, x, y *Element) {
+	onCT(ctMulElement)
+	var t [5]uint64
+	var D uint64
+	var m, C uint64
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = bits.Mul64(y[0], x[0])
+	C, t[1] = madd1(y[0], x[1], C)
+	C, t[2] = madd1(y[0], x[2], C)
+	C, t[3] = madd1(y[0], x[3], C)
+
+	t[4], D = bits.Add64(t[4], C, 0)
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+
+	t[3], C = bits.Add64(t[4], C, 0)
+	t[4], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(y[1], x[0], t[0])
+	C, t[1] = madd2(y[1], x[1], t[1], C)
+	C, t[2] = madd2(y[1], x[2], t[2], C)
+	C, t[3] = madd2(y[1], x[3], t[3], C)
+
+	t[4], D = bits.Add64(t[4], C, 0)
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+
+	t[3], C = bits.Add64(t[4], C, 0)
+	t[4], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(y[2], x[0], t[0])
+	C, t[1] = madd2(y[2], x[1], t[1], C)
+	C, t[2] = madd2(y[2], x[2], t[2], C)
+	C, t[3] = madd2(y[2], x[3], t[3], C)
+
+	t[4], D = bits.Add64(t[4], C, 0)
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+
+	t[3], C = bits.Add64(t[4], C, 0)
+	t[4], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(y[3], x[0], t[0])
+	C, t[1] = madd2(y[3], x[1], t[1], C)
+	C, t[2] = madd2(y[3], x[2], t[2], C)
+	C, t[3] = madd2(y[3], x[3], t[3], C)
+
+	t[4], D = bits.Add64(t[4], C, 0)
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+
+	t[3], C = bits.Add64(t[4], C, 0)
+	t[4], _ = bits.Add64(0, D, C)
+
+	// t < 2q is on 5 words; z = t - q if t ≥ q, t otherwise
+	var b uint64
+	z[0], b = bits.Sub64(t[0], q0, 0)
+	z[1], b = bits.Sub64(t[1], q1, b)
+	z[2], b = bits.Sub64(t[2], q2, b)
+	z[3], b = bits.Sub64(t[3], q3, b)
+	_, b = bits.Sub64(t[4], 0, b)
+
+	// b == 1 iff t < q
+	mask := -b
+	z[0] ^= mask & (z[0] ^ t[0])
+	z[1] ^= mask & (z[1] ^ t[1])
+	z[2] ^= mask & (z[2] ^ t[2])
+	z[3] ^= mask & (z[3] ^ t[3])
+}
+
 // negL negates in place [x | xHi] and return the new most significant word xHi
 func negL(x *Element, xHi uint64) uint64 {
 	var b uint64
@@ -1467,3 +1882,85 @@ func (z *Element) linearCombNonModular(x *Element, xC int64, y *Element, yC int6
 
 	return yHi
 }
+
+// Accumulator accumulates sums of products of elements without modular reduction;
+// a sum of n products costs n multiplications without reduction and a single Montgomery
+// reduction, instead of n Mul.
+//
+// The zero value is an empty sum, ready to use.
+//
+// 	var acc Accumulator
+// 	for i := range a {
+// 		acc.MulAdd(&a[i], &b[i])
+// 	}
+// 	res := acc.Reduce() // ∑ aᵢbᵢ
+type Accumulator struct {
+	// the sum of the (montgomery) products is lo + hi * R, hi is reduced
+	lo, hi Element
+}
+
+// MulAdd adds x * y to the accumulator
+//
+// x and y must be strictly inferior to q
+func (acc *Accumulator) MulAdd(x, y *Element) {
+	// t = x * y on 8 words
+	var t [8]uint64
+	var c uint64
+	c, t[0] = bits.Mul64(x[0], y[0])
+	c, t[1] = madd1(x[1], y[0], c)
+	c, t[2] = madd1(x[2], y[0], c)
+	c, t[3] = madd1(x[3], y[0], c)
+	t[4] = c
+	c, t[1] = madd1(x[0], y[1], t[1])
+	c, t[2] = madd2(x[1], y[1], t[2], c)
+	c, t[3] = madd2(x[2], y[1], t[3], c)
+	c, t[4] = madd2(x[3], y[1], t[4], c)
+	t[5] = c
+	c, t[2] = madd1(x[0], y[2], t[2])
+	c, t[3] = madd2(x[1], y[2], t[3], c)
+	c, t[4] = madd2(x[2], y[2], t[4], c)
+	c, t[5] = madd2(x[3], y[2], t[5], c)
+	t[6] = c
+	c, t[3] = madd1(x[0], y[3], t[3])
+	c, t[4] = madd2(x[1], y[3], t[4], c)
+	c, t[5] = madd2(x[2], y[3], t[5], c)
+	c, t[6] = madd2(x[3], y[3], t[6], c)
+	t[7] = c
+
+	// lo += t mod R
+	acc.lo[0], c = bits.Add64(acc.lo[0], t[0], 0)
+	acc.lo[1], c = bits.Add64(acc.lo[1], t[1], c)
+	acc.lo[2], c = bits.Add64(acc.lo[2], t[2], c)
+	acc.lo[3], c = bits.Add64(acc.lo[3], t[3], c)
+
+	// hi += t / R + c; t < q * R so that t / R + c ⩽ q
+	var h Element
+	h[0], c = bits.Add64(t[4], c, 0)
+	h[1], c = bits.Add64(t[5], 0, c)
+	h[2], c = bits.Add64(t[6], 0, c)
+	h[3], c = bits.Add64(t[7], 0, c)
+	acc.hi.Add(&acc.hi, &h)
+}
+
+// Add adds x to the accumulator
+//
+// x must be strictly inferior to q
+func (acc *Accumulator) Add(x *Element) {
+	// x * R, so that the Montgomery reduction gives x
+	acc.hi.Add(&acc.hi, x)
+}
+
+// Reduce returns the accumulated sum (mod q), and doesn't modify the accumulator
+func (acc *Accumulator) Reduce() Element {
+	// (lo + hi * R) * R⁻¹ = lo * R⁻¹ + hi
+	// lo < R so that the montgomery reduction of lo is at most q, and is reduced by fromMont
+	z := acc.lo
+	fromMont(&z)
+	z.Add(&z, &acc.hi)
+	return z
+}
+
+// Reset sets the accumulator to the empty sum
+func (acc *Accumulator) Reset() {
+	*acc = Accumulator{}
+}
@@ -0,0 +1,257 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fr
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVectorSort(t *testing.T) {
+	assert := require.New(t)
+
+	v := make(Vector, 3)
+	v[0].SetUint64(2)
+	v[1].SetUint64(3)
+	v[2].SetUint64(1)
+
+	sort.Sort(v)
+
+	var e1, e2, e3 Element
+	e1.SetUint64(1)
+	e2.SetUint64(2)
+	e3.SetUint64(3)
+
+	assert.Equal(Vector{e1, e2, e3}, v)
+	assert.Equal(fmt.Sprintf("[%s,%s,%s]", e1.String(), e2.String(), e3.String()), v.String())
+}
+
+func TestVectorRoundTrip(t *testing.T) {
+	assert := require.New(t)
+
+	v1 := make(Vector, 3)
+	v1[0].SetUint64(1)
+	v1[1].SetUint64(2)
+	v1[2].SetUint64(3)
+
+	b, err := v1.MarshalBinary()
+	assert.NoError(err)
+
+	var v2, v3 Vector
+
+	err = v2.UnmarshalBinary(b)
+	assert.NoError(err)
+
+	_, err = v3.ReadFrom(bytes.NewReader(b))
+	assert.NoError(err)
+
+	assert.True(reflect.DeepEqual(v1, v2))
+	assert.True(reflect.DeepEqual(v3, v2))
+}
+
+func TestVectorEmptyRoundTrip(t *testing.T) {
+	assert := require.New(t)
+
+	v1 := make(Vector, 0)
+
+	b, err := v1.MarshalBinary()
+	assert.NoError(err)
+
+	var v2, v3 Vector
+
+	err = v2.UnmarshalBinary(b)
+	assert.NoError(err)
+
+	_, err = v3.ReadFrom(bytes.NewReader(b))
+	assert.NoError(err)
+
+	assert.True(reflect.DeepEqual(v1, v2))
+	assert.True(reflect.DeepEqual(v3, v2))
+}
+
+func TestVectorReadFromNonCanonical(t *testing.T) {
+	assert := require.New(t)
+
+	v := make(Vector, 1)
+	b, err := v.MarshalBinary()
+	assert.NoError(err)
+
+	// overwrite the element with the modulus itself
+	q := Modulus().Bytes()
+	copy(b[4+Bytes-len(q):], q)
+
+	var v2 Vector
+	err = v2.UnmarshalBinary(b)
+	assert.Error(err)
+}
+
+func TestVectorReadFromLengthNotTrusted(t *testing.T) {
+	assert := require.New(t)
+
+	// a header announcing 2^32-1 elements, followed by vectorReadBlockSize + 1 of them
+	v := make(Vector, vectorReadBlockSize+1)
+	for i := range v {
+		v[i].SetUint64(uint64(i))
+	}
+	b, err := v.MarshalBinary()
+	assert.NoError(err)
+	copy(b[:4], []byte{0xff, 0xff, 0xff, 0xff})
+
+	var v2 Vector
+	_, err = v2.ReadFrom(bytes.NewReader(b))
+	assert.Error(err)
+	assert.Equal(v, v2, "the elements read should be kept")
+	assert.LessOrEqual(cap(v2), 2*vectorReadBlockSize, "the allocation should be bounded by the input read")
+}
+
+func TestVectorOps(t *testing.T) {
+	assert := require.New(t)
+
+	// odd sizes ensure we don't depend on a particular alignment or unrolling
+	for _, n := range []int{0, 1, 2, 3, 7, 64, 257} {
+		a, b := make(Vector, n), make(Vector, n)
+		for i := 0; i < n; i++ {
+			a[i].SetRandom()
+			b[i].SetRandom()
+		}
+		// edge values
+		if n > 1 {
+			a[0].SetZero()
+			b[0].SetZero()
+			a[1].SetOne().Neg(&a[1])
+			b[1].SetOne().Neg(&b[1])
+		}
+
+		var s Element
+		s.SetRandom()
+
+		got, expected := make(Vector, n), make(Vector, n)
+
+		got.Add(a, b)
+		addVecGeneric(expected, a, b)
+		assert.Equal(expected, got, fmt.Sprintf("Add, n=%d", n))
+
+		got.Sub(a, b)
+		subVecGeneric(expected, a, b)
+		assert.Equal(expected, got, fmt.Sprintf("Sub, n=%d", n))
+
+		got.Mul(a, b)
+		mulVecGeneric(expected, a, b)
+		assert.Equal(expected, got, fmt.Sprintf("Mul, n=%d", n))
+
+		got.ScalarMul(a, &s)
+		scalarMulVecGeneric(expected, a, &s)
+		assert.Equal(expected, got, fmt.Sprintf("ScalarMul, n=%d", n))
+
+		var sum, innerProduct, tmp Element
+		for i := 0; i < n; i++ {
+			sum.Add(&sum, &a[i])
+			tmp.Mul(&a[i], &b[i])
+			innerProduct.Add(&innerProduct, &tmp)
+		}
+		assert.Equal(sum, a.Sum(), fmt.Sprintf("Sum, n=%d", n))
+		assert.Equal(innerProduct, a.InnerProduct(b), fmt.Sprintf("InnerProduct, n=%d", n))
+
+		// in place
+		expected.Add(a, b)
+		a.Add(a, b)
+		assert.Equal(expected, a, fmt.Sprintf("Add in place, n=%d", n))
+	}
+}
+
+func TestVectorOpsNoAllocations(t *testing.T) {
+	a, b := make(Vector, 64), make(Vector, 64)
+	for i := range a {
+		a[i].SetRandom()
+		b[i].SetRandom()
+	}
+	allocs := testing.AllocsPerRun(10, func() {
+		_ = a.Sum()
+		_ = a.InnerProduct(b)
+	})
+	if allocs != 0 {
+		t.Fatalf("Sum and InnerProduct allocated %v times", allocs)
+	}
+}
+
+func TestVectorOpsLengthMismatch(t *testing.T) {
+	assert := require.New(t)
+
+	a, b, c := make(Vector, 2), make(Vector, 3), make(Vector, 3)
+	assert.Panics(func() { c.Add(a, b) })
+	assert.Panics(func() { c.Sub(a, b) })
+	assert.Panics(func() { c.Mul(a, b) })
+	assert.Panics(func() { c.ScalarMul(a, &b[0]) })
+	assert.Panics(func() { a.InnerProduct(b) })
+}
+
+func BenchmarkVectorOps(b *testing.B) {
+	const N = 1 << 16
+	a, c, res := make(Vector, N), make(Vector, N), make(Vector, N)
+	for i := 0; i < N; i++ {
+		a[i].SetRandom()
+		c[i].SetRandom()
+	}
+
+	b.Run("Add", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			res.Add(a, c)
+		}
+	})
+	b.Run("addVecGeneric", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			addVecGeneric(res, a, c)
+		}
+	})
+	b.Run("Sub", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			res.Sub(a, c)
+		}
+	})
+	b.Run("subVecGeneric", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			subVecGeneric(res, a, c)
+		}
+	})
+	b.Run("Mul", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			res.Mul(a, c)
+		}
+	})
+	b.Run("InnerProduct", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			benchResElement = a.InnerProduct(c)
+		}
+	})
+	b.Run("Sum", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			benchResElement = a.Sum()
+		}
+	})
+}
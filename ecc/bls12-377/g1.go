@@ -17,6 +17,8 @@
 package bls12377
 
 import (
+	"crypto/rand"
+	"io"
 	"math/big"
 	"runtime"
 
@@ -41,6 +43,11 @@ type g1JacExtended struct {
 	X, Y, ZZ, ZZZ fp.Element
 }
 
+// g1Proj point in projective coordinates
+type g1Proj struct {
+	x, y, z fp.Element
+}
+
 // -------------------------------------------------------------------------------------------------
 // Affine
 
@@ -67,6 +74,27 @@ func (p *G1Jac) ScalarMultiplicationAffine(a *G1Affine, s *big.Int) *G1Jac {
 	return p
 }
 
+// SetRandom sets p to a uniform random point of the prime order subgroup,
+// reading the randomness from crypto/rand.Reader
+func (p *G1Affine) SetRandom() (*G1Affine, error) {
+	return p.SetRandomFrom(rand.Reader)
+}
+
+// SetRandomFrom sets p to a uniform random point of the prime order subgroup,
+// reading the randomness from r
+//
+// p = [s]G where G is the G1 generator and s is sampled uniformly in [0, r)
+// with fr.Element.SetRandomFrom; hence p is the point at infinity with probability 1/r.
+func (p *G1Affine) SetRandomFrom(r io.Reader) (*G1Affine, error) {
+	var s fr.Element
+	if _, err := s.SetRandomFrom(r); err != nil {
+		return nil, err
+	}
+	var bs big.Int
+	s.ToBigIntRegular(&bs)
+	return p.ScalarMultiplication(&g1GenAff, &bs), nil
+}
+
 // Add adds two point in affine coordinates.
 // This should rarely be used as it is very inefficient compared to Jacobian
 func (p *G1Affine) Add(a, b *G1Affine) *G1Affine {
@@ -821,6 +849,38 @@ func (p *g1JacExtended) doubleMixed(q *G1Affine) *g1JacExtended {
 	return p
 }
 
+// -------------------------------------------------------------------------------------------------
+// Homogenous projective
+
+// Set sets p to the provided point
+func (p *g1Proj) Set(a *g1Proj) *g1Proj {
+	p.x, p.y, p.z = a.x, a.y, a.z
+	return p
+}
+
+// Neg computes -G
+func (p *g1Proj) Neg(a *g1Proj) *g1Proj {
+	*p = *a
+	p.y.Neg(&a.y)
+	return p
+}
+
+// FromAffine sets p = Q, p in homogenous projective, Q in affine
+//
+// The infinity is (0:1:0), the only point of the curve Y²Z = X³ + bZ³ with Z = 0.
+func (p *g1Proj) FromAffine(Q *G1Affine) *g1Proj {
+	if Q.X.IsZero() && Q.Y.IsZero() {
+		p.z.SetZero()
+		p.x.SetZero()
+		p.y.SetOne()
+		return p
+	}
+	p.z.SetOne()
+	p.x.Set(&Q.X)
+	p.y.Set(&Q.Y)
+	return p
+}
+
 // BatchJacobianToAffineG1 converts points in Jacobian coordinates to Affine coordinates
 // performing a single field inversion (Montgomery batch inversion trick).
 func BatchJacobianToAffineG1(points []G1Jac) []G1Affine {
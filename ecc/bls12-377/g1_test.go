@@ -19,6 +19,7 @@ package bls12377
 import (
 	"fmt"
 	"math/big"
+	mrand "math/rand"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
@@ -435,6 +436,30 @@ func TestG1AffineCofactorCleaning(t *testing.T) {
 
 }
 
+func TestG1AffineSetRandomFrom(t *testing.T) {
+	t.Parallel()
+
+	// two readers with the same seed must yield the same points
+	r1 := mrand.New(mrand.NewSource(42)) //#nosec G404 -- deterministic source on purpose
+	r2 := mrand.New(mrand.NewSource(42)) //#nosec G404 -- deterministic source on purpose
+
+	var a, b G1Affine
+	for i := 0; i < 10; i++ {
+		if _, err := a.SetRandomFrom(r1); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := b.SetRandomFrom(r2); err != nil {
+			t.Fatal(err)
+		}
+		if !a.Equal(&b) {
+			t.Fatal("SetRandomFrom with the same seed should output the same points")
+		}
+		if !a.IsOnCurve() || !a.IsInSubGroup() {
+			t.Fatal("SetRandomFrom should output a point of the prime order subgroup")
+		}
+	}
+}
+
 func TestG1AffineBatchScalarMultiplication(t *testing.T) {
 
 	parameters := gopter.DefaultTestParameters()
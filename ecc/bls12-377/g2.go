@@ -17,12 +17,14 @@
 package bls12377
 
 import (
+	"crypto/rand"
+	"io"
 	"math/big"
 	"runtime"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fptower"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
-	"github.com/consensys/gnark-crypto/ecc/bls12-377/internal/fptower"
 	"github.com/consensys/gnark-crypto/internal/parallel"
 )
 
@@ -64,6 +66,27 @@ func (p *G2Affine) ScalarMultiplication(a *G2Affine, s *big.Int) *G2Affine {
 	return p
 }
 
+// SetRandom sets p to a uniform random point of the prime order subgroup,
+// reading the randomness from crypto/rand.Reader
+func (p *G2Affine) SetRandom() (*G2Affine, error) {
+	return p.SetRandomFrom(rand.Reader)
+}
+
+// SetRandomFrom sets p to a uniform random point of the prime order subgroup,
+// reading the randomness from r
+//
+// p = [s]G where G is the G2 generator and s is sampled uniformly in [0, r)
+// with fr.Element.SetRandomFrom; hence p is the point at infinity with probability 1/r.
+func (p *G2Affine) SetRandomFrom(r io.Reader) (*G2Affine, error) {
+	var s fr.Element
+	if _, err := s.SetRandomFrom(r); err != nil {
+		return nil, err
+	}
+	var bs big.Int
+	s.ToBigIntRegular(&bs)
+	return p.ScalarMultiplication(&g2GenAff, &bs), nil
+}
+
 // Add adds two point in affine coordinates.
 // This should rarely be used as it is very inefficient compared to Jacobian
 func (p *G2Affine) Add(a, b *G2Affine) *G2Affine {
@@ -855,10 +878,12 @@ func (p *g2Proj) Neg(a *g2Proj) *g2Proj {
 }
 
 // FromAffine sets p = Q, p in homogenous projective, Q in affine
+//
+// The infinity is (0:1:0), the only point of the curve Y²Z = X³ + bZ³ with Z = 0.
 func (p *g2Proj) FromAffine(Q *G2Affine) *g2Proj {
 	if Q.X.IsZero() && Q.Y.IsZero() {
 		p.z.SetZero()
-		p.x.SetOne()
+		p.x.SetZero()
 		p.y.SetOne()
 		return p
 	}
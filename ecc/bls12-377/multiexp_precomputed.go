@@ -0,0 +1,575 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12377
+
+import (
+	"errors"
+	"io"
+	"math/big"
+	"runtime"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// precomputedMaxC bounds the window size of the precomputed multiExp (2^{c-1} buckets per task)
+const precomputedMaxC = 16
+
+// precomputedNbChunks returns the number of c-bit windows of a scalar, as in partitionScalars
+func precomputedNbChunks(c uint64) int {
+	nbChunks := fr.Limbs * 64 / c
+	if (fr.Limbs*64)%c != 0 {
+		nbChunks++
+	}
+	return int(nbChunks)
+}
+
+// precomputedValidC returns true if c is a valid window size for the precomputed multiExp
+//
+// partitionScalars drops the carry of the last window: its digit, at most ((r - 1) >> (c * (nbChunks - 1))) + 1,
+// must stay below 2^{c-1}.
+func precomputedValidC(c uint64) bool {
+	if c < 2 || c > precomputedMaxC {
+		return false
+	}
+	var top big.Int
+	top.Sub(fr.Modulus(), big.NewInt(1)).Rsh(&top, uint(c)*uint(precomputedNbChunks(c)-1))
+	return top.Cmp(big.NewInt((1<<(c-1))-1)) < 0
+}
+
+// precomputedBestC returns the window size minimizing the cost of a precomputed multiExp of nbPoints points
+func precomputedBestC(nbPoints int) uint64 {
+	// approximate cost (in group operations): nbChunks(c) * nbPoints additions in the buckets,
+	// and 2^{c} to reduce them
+	var C uint64
+	min := -1
+	for c := uint64(2); c <= precomputedMaxC; c++ {
+		if !precomputedValidC(c) {
+			continue
+		}
+		cost := precomputedNbChunks(c)*nbPoints + (1 << c)
+		if min == -1 || cost < min {
+			min = cost
+			C = c
+		}
+	}
+	return C
+}
+
+// G1PrecomputedPoints stores the multiples of the base points of a multiExp by the powers of 2^C,
+// to run MultiExpPrecomputed without doublings
+//
+// Points[k][i] = 2^{C*k} * points[i], for k < nbChunks, the number of C-bit windows of a scalar.
+// With the scalars split in signed C-bit digits s[i] = ∑ₖ s[i][k] 2^{C*k}, the multiExp is
+// ∑ᵢ ∑ₖ s[i][k] * Points[k][i]: all the windows share the same buckets, which are reduced once.
+// The memory cost is nbChunks times the one of the points.
+//
+// The precomputed points are not modified by MultiExpPrecomputed: they can be used concurrently.
+type G1PrecomputedPoints struct {
+	C      uint64
+	Points [][]G1Affine
+}
+
+// NewG1PrecomputedPoints precomputes the multiples of points used by MultiExpPrecomputed, with
+// windows of c[0] bits if set, of a size picked from len(points) otherwise
+//
+// It panics if c[0] is not in [2, 16], or if the signed digits of the scalars on c[0] bits would overflow
+// (see partitionScalars).
+func NewG1PrecomputedPoints(points []G1Affine, c ...uint64) *G1PrecomputedPoints {
+	pre := &G1PrecomputedPoints{C: precomputedBestC(len(points))}
+	if len(c) > 0 {
+		pre.C = c[0]
+	}
+	if !precomputedValidC(pre.C) {
+		panic("invalid window size")
+	}
+
+	nbChunks := precomputedNbChunks(pre.C)
+	pre.Points = make([][]G1Affine, nbChunks)
+	pre.Points[0] = make([]G1Affine, len(points))
+	copy(pre.Points[0], points)
+
+	// Points[k] = 2^C * Points[k-1]
+	acc := make([]G1Jac, len(points))
+	parallel.Execute(len(points), func(start, end int) {
+		for i := start; i < end; i++ {
+			acc[i].FromAffine(&points[i])
+		}
+	})
+	for k := 1; k < nbChunks; k++ {
+		parallel.Execute(len(points), func(start, end int) {
+			for i := start; i < end; i++ {
+				for j := uint64(0); j < pre.C; j++ {
+					acc[i].DoubleAssign()
+				}
+			}
+		})
+		pre.Points[k] = BatchJacobianToAffineG1(acc)
+	}
+
+	return pre
+}
+
+// NbPoints returns the number of base points of the multiExp
+func (pre *G1PrecomputedPoints) NbPoints() int {
+	if len(pre.Points) == 0 {
+		return 0
+	}
+	return len(pre.Points[0])
+}
+
+// WriteTo writes the binary encoding of the precomputed points (C and the Points) to w
+func (pre *G1PrecomputedPoints) WriteTo(w io.Writer) (int64, error) {
+	enc := NewEncoder(w)
+
+	toEncode := []interface{}{
+		pre.C,
+	}
+	for k := range pre.Points {
+		toEncode = append(toEncode, pre.Points[k])
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes precomputed points written by WriteTo from r
+//
+// It checks that Points[k] = 2^C * Points[k-1] on a random linear combination of the points of each window, with
+// a multiExp on 64-bit coefficients drawn from crypto/rand: points that don't match Points[0] and C are accepted
+// with probability at most about 2^-64 per window.
+func (pre *G1PrecomputedPoints) ReadFrom(r io.Reader) (int64, error) {
+	dec := NewDecoder(r)
+
+	if err := dec.Decode(&pre.C); err != nil {
+		return dec.BytesRead(), err
+	}
+	if !precomputedValidC(pre.C) {
+		return dec.BytesRead(), errors.New("invalid precomputed points window size")
+	}
+
+	pre.Points = make([][]G1Affine, precomputedNbChunks(pre.C))
+	for k := range pre.Points {
+		if err := dec.Decode(&pre.Points[k]); err != nil {
+			return dec.BytesRead(), err
+		}
+		if len(pre.Points[k]) != len(pre.Points[0]) {
+			return dec.BytesRead(), errors.New("invalid precomputed points length")
+		}
+	}
+
+	// the same coefficients for all the windows: if Points[k-1] is correct, a wrong point of Points[k]
+	// makes the combinations differ, except with probability about 2^-64
+	scalars := make([]fr.Element, len(pre.Points[0]))
+	if err := batchSubGroupCoefficients(scalars, 64); err != nil {
+		return dec.BytesRead(), err
+	}
+	config := ecc.MultiExpConfig{MaxScalarBits: 64}
+	var expected, combination G1Jac
+	if _, err := expected.MultiExp(pre.Points[0], scalars, config); err != nil {
+		return dec.BytesRead(), err
+	}
+	for k := 1; k < len(pre.Points); k++ {
+		for j := uint64(0); j < pre.C; j++ {
+			expected.DoubleAssign()
+		}
+		if _, err := combination.MultiExp(pre.Points[k], scalars, config); err != nil {
+			return dec.BytesRead(), err
+		}
+		if !combination.Equal(&expected) {
+			return dec.BytesRead(), errors.New("invalid precomputed points: the windows don't match Points[0] and C")
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
+
+// MultiExpPrecomputed computes ∑ scalars[i] * points[i], where pre holds the precomputed multiples of
+// the points (see NewG1PrecomputedPoints)
+//
+// This call return an error if len(scalars) != pre.NbPoints() or if provided config is invalid.
+func (p *G1Affine) MultiExpPrecomputed(pre *G1PrecomputedPoints, scalars []fr.Element, config ecc.MultiExpConfig) (*G1Affine, error) {
+	var _p G1Jac
+	if _, err := _p.MultiExpPrecomputed(pre, scalars, config); err != nil {
+		return nil, err
+	}
+	p.FromJacobian(&_p)
+	return p, nil
+}
+
+// MultiExpPrecomputed computes ∑ scalars[i] * points[i], where pre holds the precomputed multiples of
+// the points (see NewG1PrecomputedPoints)
+//
+// This call return an error if len(scalars) != pre.NbPoints() or if provided config is invalid.
+func (p *G1Jac) MultiExpPrecomputed(pre *G1PrecomputedPoints, scalars []fr.Element, config ecc.MultiExpConfig) (*G1Jac, error) {
+	nbPoints := pre.NbPoints()
+	if nbPoints != len(scalars) {
+		return nil, errors.New("len(points) != len(scalars)")
+	}
+
+	// if nbTasks is not set, use all available CPUs
+	if config.NbTasks <= 0 {
+		config.NbTasks = runtime.NumCPU()
+	} else if config.NbTasks > 1024 {
+		return nil, errors.New("invalid config: config.NbTasks > 1024")
+	}
+
+	// each task reduces its own buckets: splitting is only worth it if a task has more additions to do
+	nbTasks := config.NbTasks
+	for nbTasks > 1 && nbPoints*len(pre.Points)/nbTasks < (1<<pre.C) {
+		nbTasks >>= 1
+	}
+
+	digits, _ := partitionScalars(scalars, pre.C, config.ScalarsMont, config.NbTasks)
+
+	chTotals := make(chan g1JacExtended, nbTasks)
+	parallel.Execute(nbPoints, func(start, end int) {
+		buckets := make([]g1JacExtended, 1<<(pre.C-1))
+		chTotals <- msmPrecomputedProcessG1Affine(buckets, pre, digits, start, end)
+	}, nbTasks)
+	close(chTotals)
+
+	var total g1JacExtended
+	total.setInfinity()
+	for t := range chTotals {
+		total.add(&t)
+	}
+
+	p.unsafeFromJacExtended(&total)
+	return p, nil
+}
+
+// msmPrecomputedProcessG1Affine places the precomputed points of index in [start, end) into the buckets,
+// for all the windows of the digits, and returns the weighted sum of the buckets
+func msmPrecomputedProcessG1Affine(buckets []g1JacExtended, pre *G1PrecomputedPoints, digits []fr.Element, start, end int) g1JacExtended {
+	c := pre.C
+	mask := uint64((1 << c) - 1) // low c bits are 1
+	msbWindow := uint64(1 << (c - 1))
+
+	for i := 0; i < len(buckets); i++ {
+		buckets[i].setInfinity()
+	}
+
+	for chunk := range pre.Points {
+		jc := uint64(chunk) * c
+		s := selector{}
+		s.index = jc / 64
+		s.shift = jc - (s.index * 64)
+		s.mask = mask << s.shift
+		s.multiWordSelect = (64%c) != 0 && s.shift > (64-c) && s.index < (fr.Limbs-1)
+		if s.multiWordSelect {
+			nbBitsHigh := s.shift - uint64(64-c)
+			s.maskHigh = (1 << nbBitsHigh) - 1
+			s.shiftHigh = (c - nbBitsHigh)
+		}
+
+		points := pre.Points[chunk]
+		for i := start; i < end; i++ {
+			bits := (digits[i][s.index] & s.mask) >> s.shift
+			if s.multiWordSelect {
+				bits += (digits[i][s.index+1] & s.maskHigh) << s.shiftHigh
+			}
+
+			if bits == 0 {
+				continue
+			}
+
+			// if msbWindow bit is set, we need to substract
+			if bits&msbWindow == 0 {
+				// add
+				buckets[bits-1].addMixed(&points[i])
+			} else {
+				// sub
+				buckets[bits & ^msbWindow].subMixed(&points[i])
+			}
+		}
+	}
+
+	// reduce buckets into total
+	// total =  bucket[0] + 2*bucket[1] + 3*bucket[2] ... + n*bucket[n-1]
+	var runningSum, total g1JacExtended
+	runningSum.setInfinity()
+	total.setInfinity()
+	for k := len(buckets) - 1; k >= 0; k-- {
+		if !buckets[k].ZZ.IsZero() {
+			runningSum.add(&buckets[k])
+		}
+		total.add(&runningSum)
+	}
+
+	return total
+}
+
+// G2PrecomputedPoints stores the multiples of the base points of a multiExp by the powers of 2^C,
+// to run MultiExpPrecomputed without doublings
+//
+// Points[k][i] = 2^{C*k} * points[i], for k < nbChunks, the number of C-bit windows of a scalar.
+// With the scalars split in signed C-bit digits s[i] = ∑ₖ s[i][k] 2^{C*k}, the multiExp is
+// ∑ᵢ ∑ₖ s[i][k] * Points[k][i]: all the windows share the same buckets, which are reduced once.
+// The memory cost is nbChunks times the one of the points.
+//
+// The precomputed points are not modified by MultiExpPrecomputed: they can be used concurrently.
+type G2PrecomputedPoints struct {
+	C      uint64
+	Points [][]G2Affine
+}
+
+// NewG2PrecomputedPoints precomputes the multiples of points used by MultiExpPrecomputed, with
+// windows of c[0] bits if set, of a size picked from len(points) otherwise
+//
+// It panics if c[0] is not in [2, 16], or if the signed digits of the scalars on c[0] bits would overflow
+// (see partitionScalars).
+func NewG2PrecomputedPoints(points []G2Affine, c ...uint64) *G2PrecomputedPoints {
+	pre := &G2PrecomputedPoints{C: precomputedBestC(len(points))}
+	if len(c) > 0 {
+		pre.C = c[0]
+	}
+	if !precomputedValidC(pre.C) {
+		panic("invalid window size")
+	}
+
+	nbChunks := precomputedNbChunks(pre.C)
+	pre.Points = make([][]G2Affine, nbChunks)
+	pre.Points[0] = make([]G2Affine, len(points))
+	copy(pre.Points[0], points)
+
+	// Points[k] = 2^C * Points[k-1]
+	acc := make([]G2Jac, len(points))
+	parallel.Execute(len(points), func(start, end int) {
+		for i := start; i < end; i++ {
+			acc[i].FromAffine(&points[i])
+		}
+	})
+	for k := 1; k < nbChunks; k++ {
+		parallel.Execute(len(points), func(start, end int) {
+			for i := start; i < end; i++ {
+				for j := uint64(0); j < pre.C; j++ {
+					acc[i].DoubleAssign()
+				}
+			}
+		})
+		pre.Points[k] = make([]G2Affine, len(points))
+		parallel.Execute(len(points), func(start, end int) {
+			for i := start; i < end; i++ {
+				pre.Points[k][i].FromJacobian(&acc[i])
+			}
+		})
+	}
+
+	return pre
+}
+
+// NbPoints returns the number of base points of the multiExp
+func (pre *G2PrecomputedPoints) NbPoints() int {
+	if len(pre.Points) == 0 {
+		return 0
+	}
+	return len(pre.Points[0])
+}
+
+// WriteTo writes the binary encoding of the precomputed points (C and the Points) to w
+func (pre *G2PrecomputedPoints) WriteTo(w io.Writer) (int64, error) {
+	enc := NewEncoder(w)
+
+	toEncode := []interface{}{
+		pre.C,
+	}
+	for k := range pre.Points {
+		toEncode = append(toEncode, pre.Points[k])
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes precomputed points written by WriteTo from r
+//
+// It checks that Points[k] = 2^C * Points[k-1] on a random linear combination of the points of each window, with
+// a multiExp on 64-bit coefficients drawn from crypto/rand: points that don't match Points[0] and C are accepted
+// with probability at most about 2^-64 per window.
+func (pre *G2PrecomputedPoints) ReadFrom(r io.Reader) (int64, error) {
+	dec := NewDecoder(r)
+
+	if err := dec.Decode(&pre.C); err != nil {
+		return dec.BytesRead(), err
+	}
+	if !precomputedValidC(pre.C) {
+		return dec.BytesRead(), errors.New("invalid precomputed points window size")
+	}
+
+	pre.Points = make([][]G2Affine, precomputedNbChunks(pre.C))
+	for k := range pre.Points {
+		if err := dec.Decode(&pre.Points[k]); err != nil {
+			return dec.BytesRead(), err
+		}
+		if len(pre.Points[k]) != len(pre.Points[0]) {
+			return dec.BytesRead(), errors.New("invalid precomputed points length")
+		}
+	}
+
+	// the same coefficients for all the windows: if Points[k-1] is correct, a wrong point of Points[k]
+	// makes the combinations differ, except with probability about 2^-64
+	scalars := make([]fr.Element, len(pre.Points[0]))
+	if err := batchSubGroupCoefficients(scalars, 64); err != nil {
+		return dec.BytesRead(), err
+	}
+	config := ecc.MultiExpConfig{MaxScalarBits: 64}
+	var expected, combination G2Jac
+	if _, err := expected.MultiExp(pre.Points[0], scalars, config); err != nil {
+		return dec.BytesRead(), err
+	}
+	for k := 1; k < len(pre.Points); k++ {
+		for j := uint64(0); j < pre.C; j++ {
+			expected.DoubleAssign()
+		}
+		if _, err := combination.MultiExp(pre.Points[k], scalars, config); err != nil {
+			return dec.BytesRead(), err
+		}
+		if !combination.Equal(&expected) {
+			return dec.BytesRead(), errors.New("invalid precomputed points: the windows don't match Points[0] and C")
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
+
+// MultiExpPrecomputed computes ∑ scalars[i] * points[i], where pre holds the precomputed multiples of
+// the points (see NewG2PrecomputedPoints)
+//
+// This call return an error if len(scalars) != pre.NbPoints() or if provided config is invalid.
+func (p *G2Affine) MultiExpPrecomputed(pre *G2PrecomputedPoints, scalars []fr.Element, config ecc.MultiExpConfig) (*G2Affine, error) {
+	var _p G2Jac
+	if _, err := _p.MultiExpPrecomputed(pre, scalars, config); err != nil {
+		return nil, err
+	}
+	p.FromJacobian(&_p)
+	return p, nil
+}
+
+// MultiExpPrecomputed computes ∑ scalars[i] * points[i], where pre holds the precomputed multiples of
+// the points (see NewG2PrecomputedPoints)
+//
+// This call return an error if len(scalars) != pre.NbPoints() or if provided config is invalid.
+func (p *G2Jac) MultiExpPrecomputed(pre *G2PrecomputedPoints, scalars []fr.Element, config ecc.MultiExpConfig) (*G2Jac, error) {
+	nbPoints := pre.NbPoints()
+	if nbPoints != len(scalars) {
+		return nil, errors.New("len(points) != len(scalars)")
+	}
+
+	// if nbTasks is not set, use all available CPUs
+	if config.NbTasks <= 0 {
+		config.NbTasks = runtime.NumCPU()
+	} else if config.NbTasks > 1024 {
+		return nil, errors.New("invalid config: config.NbTasks > 1024")
+	}
+
+	// each task reduces its own buckets: splitting is only worth it if a task has more additions to do
+	nbTasks := config.NbTasks
+	for nbTasks > 1 && nbPoints*len(pre.Points)/nbTasks < (1<<pre.C) {
+		nbTasks >>= 1
+	}
+
+	digits, _ := partitionScalars(scalars, pre.C, config.ScalarsMont, config.NbTasks)
+
+	chTotals := make(chan g2JacExtended, nbTasks)
+	parallel.Execute(nbPoints, func(start, end int) {
+		buckets := make([]g2JacExtended, 1<<(pre.C-1))
+		chTotals <- msmPrecomputedProcessG2Affine(buckets, pre, digits, start, end)
+	}, nbTasks)
+	close(chTotals)
+
+	var total g2JacExtended
+	total.setInfinity()
+	for t := range chTotals {
+		total.add(&t)
+	}
+
+	p.unsafeFromJacExtended(&total)
+	return p, nil
+}
+
+// msmPrecomputedProcessG2Affine places the precomputed points of index in [start, end) into the buckets,
+// for all the windows of the digits, and returns the weighted sum of the buckets
+func msmPrecomputedProcessG2Affine(buckets []g2JacExtended, pre *G2PrecomputedPoints, digits []fr.Element, start, end int) g2JacExtended {
+	c := pre.C
+	mask := uint64((1 << c) - 1) // low c bits are 1
+	msbWindow := uint64(1 << (c - 1))
+
+	for i := 0; i < len(buckets); i++ {
+		buckets[i].setInfinity()
+	}
+
+	for chunk := range pre.Points {
+		jc := uint64(chunk) * c
+		s := selector{}
+		s.index = jc / 64
+		s.shift = jc - (s.index * 64)
+		s.mask = mask << s.shift
+		s.multiWordSelect = (64%c) != 0 && s.shift > (64-c) && s.index < (fr.Limbs-1)
+		if s.multiWordSelect {
+			nbBitsHigh := s.shift - uint64(64-c)
+			s.maskHigh = (1 << nbBitsHigh) - 1
+			s.shiftHigh = (c - nbBitsHigh)
+		}
+
+		points := pre.Points[chunk]
+		for i := start; i < end; i++ {
+			bits := (digits[i][s.index] & s.mask) >> s.shift
+			if s.multiWordSelect {
+				bits += (digits[i][s.index+1] & s.maskHigh) << s.shiftHigh
+			}
+
+			if bits == 0 {
+				continue
+			}
+
+			// if msbWindow bit is set, we need to substract
+			if bits&msbWindow == 0 {
+				// add
+				buckets[bits-1].addMixed(&points[i])
+			} else {
+				// sub
+				buckets[bits & ^msbWindow].subMixed(&points[i])
+			}
+		}
+	}
+
+	// reduce buckets into total
+	// total =  bucket[0] + 2*bucket[1] + 3*bucket[2] ... + n*bucket[n-1]
+	var runningSum, total g2JacExtended
+	runningSum.setInfinity()
+	total.setInfinity()
+	for k := len(buckets) - 1; k >= 0; k-- {
+		if !buckets[k].ZZ.IsZero() {
+			runningSum.add(&buckets[k])
+		}
+		total.add(&runningSum)
+	}
+
+	return total
+}
@@ -17,6 +17,7 @@
 package bls12377
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 	"testing"
@@ -284,6 +285,116 @@ func TestMillerLoop(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestMillerLoopFixedQ(t *testing.T) {
+
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genR1 := GenFr()
+	genR2 := GenFr()
+
+	properties.Property("[BLS12-377] MillerLoopFixedQ should be equal to MillerLoop", prop.ForAll(
+		func(a, b fr.Element) bool {
+
+			var ag1, g1Inf G1Affine
+			var bg2, g2Inf G2Affine
+
+			var abigint, bbigint big.Int
+
+			a.ToBigIntRegular(&abigint)
+			b.ToBigIntRegular(&bbigint)
+
+			ag1.ScalarMultiplication(&g1GenAff, &abigint)
+			bg2.ScalarMultiplication(&g2GenAff, &bbigint)
+
+			g1Inf.FromJacobian(&g1Infinity)
+			g2Inf.FromJacobian(&g2Infinity)
+
+			tabP := []G1Affine{g1GenAff, ag1, g1Inf, ag1}
+			tabQ := []G2Affine{bg2, g2GenAff, bg2, g2Inf}
+			lines := make([]LineEvaluations, len(tabQ))
+			for i := range tabQ {
+				lines[i] = PrecomputeLines(tabQ[i])
+			}
+
+			expected, err := MillerLoop(tabP, tabQ)
+			if err != nil {
+				return false
+			}
+			res, err := MillerLoopFixedQ(tabP, lines)
+			if err != nil {
+				return false
+			}
+			return res.Equal(&expected)
+		},
+		genR1,
+		genR2,
+	))
+
+	properties.Property("[BLS12-377] LineEvaluations ReadFrom(WriteTo) should stay the same", prop.ForAll(
+		func(a fr.Element) bool {
+
+			var ag1 G1Affine
+			var abigint big.Int
+			a.ToBigIntRegular(&abigint)
+			ag1.ScalarMultiplication(&g1GenAff, &abigint)
+
+			var g2Inf G2Affine
+			g2Inf.FromJacobian(&g2Infinity)
+
+			for _, q := range []G2Affine{g2GenAff, g2Inf} {
+				lines := PrecomputeLines(q)
+				var buf bytes.Buffer
+				written, err := lines.WriteTo(&buf)
+				if err != nil {
+					return false
+				}
+				var decoded LineEvaluations
+				read, err := decoded.ReadFrom(&buf)
+				if err != nil || read != written {
+					return false
+				}
+				expected, _ := MillerLoop([]G1Affine{ag1}, []G2Affine{q})
+				res, err := MillerLoopFixedQ([]G1Affine{ag1}, []LineEvaluations{decoded})
+				if err != nil || !res.Equal(&expected) {
+					return false
+				}
+			}
+			return true
+		},
+		genR1,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// lines which don't come from PrecomputeLines
+	var uninitialized LineEvaluations
+	if _, err := MillerLoopFixedQ([]G1Affine{g1GenAff}, []LineEvaluations{uninitialized}); err == nil {
+		t.Fatal("MillerLoopFixedQ should fail on uninitialized lines")
+	}
+	var buf bytes.Buffer
+	if _, err := uninitialized.WriteTo(&buf); err == nil {
+		t.Fatal("writing uninitialized lines should fail")
+	}
+	lines := PrecomputeLines(g2GenAff)
+	if _, err := lines.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := new(LineEvaluations).ReadFrom(bytes.NewReader(buf.Bytes()[:buf.Len()-1])); err == nil {
+		t.Fatal("reading truncated lines should fail")
+	}
+	if _, err := MillerLoopFixedQ([]G1Affine{g1GenAff}, nil); err == nil {
+		t.Fatal("MillerLoopFixedQ should fail on inputs of different sizes")
+	}
+}
+
 // ------------------------------------------------------------
 // benches
 
@@ -315,6 +426,21 @@ func BenchmarkMillerLoop(b *testing.B) {
 	}
 }
 
+func BenchmarkMillerLoopFixedQ(b *testing.B) {
+
+	var g1GenAff G1Affine
+	var g2GenAff G2Affine
+
+	g1GenAff.FromJacobian(&g1Gen)
+	g2GenAff.FromJacobian(&g2Gen)
+	lines := []LineEvaluations{PrecomputeLines(g2GenAff)}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MillerLoopFixedQ([]G1Affine{g1GenAff}, lines)
+	}
+}
+
 func BenchmarkFinalExponentiation(b *testing.B) {
 
 	var a GT
@@ -0,0 +1,385 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12377
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fptower"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestBatchSubGroupRounds(t *testing.T) {
+	for _, l := range []uint64{2, 3, 13, 10069, 1 << 25, math.MaxUint64} {
+		nbRounds, nbBits := batchSubGroupRounds(l)
+		if nbBits < 1 || nbBits > 64 {
+			t.Fatalf("l=%d: invalid coefficients size %d", l, nbBits)
+		}
+		// probability that a point outside the subgroup passes all the rounds
+		e := math.Ldexp(1, nbBits)
+		p := math.Pow(math.Ceil(e/float64(l))/e, float64(nbRounds))
+		if p > math.Ldexp(1, -batchSubGroupSecurity) {
+			t.Fatalf("l=%d: %d rounds of %d-bit coefficients don't reach the security level", l, nbRounds, nbBits)
+		}
+	}
+}
+
+func TestBatchSubGroupCoefficients(t *testing.T) {
+	scalars := make([]fr.Element, 100)
+	for _, nbBits := range []int{1, 10, 33, 64} {
+		if err := batchSubGroupCoefficients(scalars, nbBits); err != nil {
+			t.Fatal(err)
+		}
+		var or uint64
+		for i := range scalars {
+			if nbBits < 64 && scalars[i][0]>>nbBits != 0 {
+				t.Fatalf("%d-bit coefficient too large", nbBits)
+			}
+			for j := 1; j < fr.Limbs; j++ {
+				if scalars[i][j] != 0 {
+					t.Fatalf("%d-bit coefficient too large", nbBits)
+				}
+			}
+			or |= scalars[i][0]
+		}
+		// the top bit is set in one of the 100 coefficients, with overwhelming probability
+		if or>>(nbBits-1) != 1 {
+			t.Fatalf("%d-bit coefficients aren't random", nbBits)
+		}
+	}
+}
+
+// randomG1AffineOnCurve returns a random point of the curve, which isn't in the subgroup with
+// overwhelming probability if the cofactor isn't 1
+func randomG1AffineOnCurve() G1Affine {
+	var p G1Affine
+	var y2 fp.Element
+	for {
+		p.X.SetRandom()
+		y2.Square(&p.X).Mul(&y2, &p.X).Add(&y2, &bCurveCoeff)
+		if y2.Legendre() == 1 {
+			p.Y.Sqrt(&y2)
+			return p
+		}
+	}
+}
+
+func TestBatchIsInSubGroupG1(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 2
+	} else {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	const nbPoints = 50
+
+	properties.Property("[BLS12-377] BatchIsInSubGroup should be consistent with IsInSubGroup", prop.ForAll(
+		func(mixer fr.Element) bool {
+			points := make([]G1Affine, nbPoints)
+			var p G1Jac
+			var s fr.Element
+			var b big.Int
+			for i := range points {
+				s.SetUint64(uint64(i)).Mul(&s, &mixer)
+				points[i].FromJacobian(p.ScalarMultiplication(&g1Gen, s.ToBigIntRegular(&b)))
+			}
+			// an infinity point
+			points[nbPoints/2].X.SetZero()
+			points[nbPoints/2].Y.SetZero()
+			if !BatchIsInSubGroupG1(points) {
+				return false
+			}
+
+			// a point off the curve (points[0] and points[nbPoints/2] are the infinity)
+			i := 1 + int(mixer[0]%(nbPoints/2-1))
+			saved := points[i]
+			points[i].Y.Double(&points[i].Y)
+			if points[i].IsOnCurve() || BatchIsInSubGroupG1(points) {
+				return false
+			}
+
+			// a point of the curve outside the subgroup, sum of a point of the subgroup and of a point of
+			// order dividing the cofactor
+			var torsion, bad G1Jac
+			bad.FromAffine(&saved)
+			rp := randomG1AffineOnCurve()
+			torsion.FromAffine(&rp)
+			// without GLV, which only applies to the subgroup
+			torsion.mulWindowed(&torsion, fr.Modulus())
+			bad.AddAssign(&torsion)
+			points[i].FromJacobian(&bad)
+			if !points[i].IsOnCurve() || points[i].IsInSubGroup() || BatchIsInSubGroupG1(points) {
+				return false
+			}
+			points[i] = saved
+			return BatchIsInSubGroupG1(points)
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	if !BatchIsInSubGroupG1(nil) {
+		t.Fatal("an empty slice should pass the batched subgroup check")
+	}
+}
+
+func TestDecoderBatchSubgroupChecksG1(t *testing.T) {
+	t.Parallel()
+	points := make([]G1Affine, 20)
+	var p G1Jac
+	p.Set(&g1Gen)
+	for i := range points {
+		points[i].FromJacobian(&p)
+		p.AddAssign(&g1Gen)
+	}
+	bad := randomG1AffineOnCurve()
+
+	for _, raw := range []bool{false, true} {
+		var buf bytes.Buffer
+		var enc *Encoder
+		if raw {
+			enc = NewEncoder(&buf, RawEncoding())
+		} else {
+			enc = NewEncoder(&buf)
+		}
+		if err := enc.Encode(points); err != nil {
+			t.Fatal(err)
+		}
+
+		var decoded []G1Affine
+		if err := NewDecoder(bytes.NewReader(buf.Bytes()), BatchSubgroupChecks()).Decode(&decoded); err != nil {
+			t.Fatal(err)
+		}
+		if len(decoded) != len(points) {
+			t.Fatal("decoded points don't match the original ones")
+		}
+		for i := range points {
+			if !decoded[i].Equal(&points[i]) {
+				t.Fatal("decoded points don't match the original ones")
+			}
+		}
+
+		// a point outside the subgroup
+		buf.Reset()
+		if err := enc.Encode(append([]G1Affine{bad}, points...)); err != nil {
+			t.Fatal(err)
+		}
+		if err := NewDecoder(bytes.NewReader(buf.Bytes()), BatchSubgroupChecks()).Decode(&decoded); err == nil {
+			t.Fatal("decoding a point outside the subgroup should fail")
+		}
+		if err := NewDecoder(bytes.NewReader(buf.Bytes()), BatchSubgroupChecks(), NoSubgroupChecks()).Decode(&decoded); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBatchIsInSubGroupG1(b *testing.B) {
+	const nbPoints = 1 << 12
+	points := make([]G1Affine, nbPoints)
+	var p G1Jac
+	p.Set(&g1Gen)
+	for i := range points {
+		points[i].FromJacobian(&p)
+		p.AddAssign(&g1Gen)
+	}
+
+	b.Run("IsInSubGroup", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			parallel.Execute(len(points), func(start, end int) {
+				for i := start; i < end; i++ {
+					points[i].IsInSubGroup()
+				}
+			})
+		}
+	})
+	b.Run("BatchIsInSubGroup", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			BatchIsInSubGroupG1(points)
+		}
+	})
+}
+
+// randomG2AffineOnCurve returns a random point of the curve, which isn't in the subgroup with
+// overwhelming probability if the cofactor isn't 1
+func randomG2AffineOnCurve() G2Affine {
+	var p G2Affine
+	var y2 fptower.E2
+	for {
+		p.X.SetRandom()
+		y2.Square(&p.X).Mul(&y2, &p.X).Add(&y2, &bTwistCurveCoeff)
+		if y2.Legendre() == 1 {
+			p.Y.Sqrt(&y2)
+			return p
+		}
+	}
+}
+
+func TestBatchIsInSubGroupG2(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 2
+	} else {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	const nbPoints = 50
+
+	properties.Property("[BLS12-377] BatchIsInSubGroup should be consistent with IsInSubGroup", prop.ForAll(
+		func(mixer fr.Element) bool {
+			points := make([]G2Affine, nbPoints)
+			var p G2Jac
+			var s fr.Element
+			var b big.Int
+			for i := range points {
+				s.SetUint64(uint64(i)).Mul(&s, &mixer)
+				points[i].FromJacobian(p.ScalarMultiplication(&g2Gen, s.ToBigIntRegular(&b)))
+			}
+			// an infinity point
+			points[nbPoints/2].X.SetZero()
+			points[nbPoints/2].Y.SetZero()
+			if !BatchIsInSubGroupG2(points) {
+				return false
+			}
+
+			// a point off the curve (points[0] and points[nbPoints/2] are the infinity)
+			i := 1 + int(mixer[0]%(nbPoints/2-1))
+			saved := points[i]
+			points[i].Y.Double(&points[i].Y)
+			if points[i].IsOnCurve() || BatchIsInSubGroupG2(points) {
+				return false
+			}
+
+			// a point of the curve outside the subgroup, sum of a point of the subgroup and of a point of
+			// order dividing the cofactor
+			var torsion, bad G2Jac
+			bad.FromAffine(&saved)
+			rp := randomG2AffineOnCurve()
+			torsion.FromAffine(&rp)
+			// without GLV, which only applies to the subgroup
+			torsion.mulWindowed(&torsion, fr.Modulus())
+			bad.AddAssign(&torsion)
+			points[i].FromJacobian(&bad)
+			if !points[i].IsOnCurve() || points[i].IsInSubGroup() || BatchIsInSubGroupG2(points) {
+				return false
+			}
+			points[i] = saved
+			return BatchIsInSubGroupG2(points)
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	if !BatchIsInSubGroupG2(nil) {
+		t.Fatal("an empty slice should pass the batched subgroup check")
+	}
+}
+
+func TestDecoderBatchSubgroupChecksG2(t *testing.T) {
+	t.Parallel()
+	points := make([]G2Affine, 20)
+	var p G2Jac
+	p.Set(&g2Gen)
+	for i := range points {
+		points[i].FromJacobian(&p)
+		p.AddAssign(&g2Gen)
+	}
+	bad := randomG2AffineOnCurve()
+
+	for _, raw := range []bool{false, true} {
+		var buf bytes.Buffer
+		var enc *Encoder
+		if raw {
+			enc = NewEncoder(&buf, RawEncoding())
+		} else {
+			enc = NewEncoder(&buf)
+		}
+		if err := enc.Encode(points); err != nil {
+			t.Fatal(err)
+		}
+
+		var decoded []G2Affine
+		if err := NewDecoder(bytes.NewReader(buf.Bytes()), BatchSubgroupChecks()).Decode(&decoded); err != nil {
+			t.Fatal(err)
+		}
+		if len(decoded) != len(points) {
+			t.Fatal("decoded points don't match the original ones")
+		}
+		for i := range points {
+			if !decoded[i].Equal(&points[i]) {
+				t.Fatal("decoded points don't match the original ones")
+			}
+		}
+
+		// a point outside the subgroup
+		buf.Reset()
+		if err := enc.Encode(append([]G2Affine{bad}, points...)); err != nil {
+			t.Fatal(err)
+		}
+		if err := NewDecoder(bytes.NewReader(buf.Bytes()), BatchSubgroupChecks()).Decode(&decoded); err == nil {
+			t.Fatal("decoding a point outside the subgroup should fail")
+		}
+		if err := NewDecoder(bytes.NewReader(buf.Bytes()), BatchSubgroupChecks(), NoSubgroupChecks()).Decode(&decoded); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBatchIsInSubGroupG2(b *testing.B) {
+	const nbPoints = 1 << 12
+	points := make([]G2Affine, nbPoints)
+	var p G2Jac
+	p.Set(&g2Gen)
+	for i := range points {
+		points[i].FromJacobian(&p)
+		p.AddAssign(&g2Gen)
+	}
+
+	b.Run("IsInSubGroup", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			parallel.Execute(len(points), func(start, end int) {
+				for i := start; i < end; i++ {
+					points[i].IsInSubGroup()
+				}
+			})
+		}
+	})
+	b.Run("BatchIsInSubGroup", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			BatchIsInSubGroupG2(points)
+		}
+	})
+}
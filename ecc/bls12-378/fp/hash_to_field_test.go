@@ -0,0 +1,77 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fp
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+func TestHash(t *testing.T) {
+	msg := []byte("abc")
+	dst := []byte("QUUX-V01-CS02-with-BLS12-378_FP_XMD:SHA-256")
+
+	res, err := Hash(msg, dst, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 3 {
+		t.Fatal("Hash should output count elements")
+	}
+
+	// each element is the reduction of L bytes of expand_message_xmd
+	const L = 16 + (Bits+7)/8
+	pseudoRandomBytes, err := ecc.ExpandMsgXmd(msg, dst, 3*L)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range res {
+		var expected, actual big.Int
+		expected.SetBytes(pseudoRandomBytes[i*L:(i+1)*L]).Mod(&expected, Modulus())
+		res[i].ToBigIntRegular(&actual)
+		if expected.Cmp(&actual) != 0 {
+			t.Fatal("Hash doesn't match the reduction of expand_message_xmd")
+		}
+	}
+
+	// the output only depends on msg, dst and count
+	again, err := Hash(msg, dst, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again[0].Equal(&res[0]) {
+		t.Fatal("Hash should be deterministic")
+	}
+	other, err := Hash([]byte("abd"), dst, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other[0].Equal(&res[0]) {
+		t.Fatal("Hash of different messages should differ")
+	}
+}
+
+func BenchmarkHash(b *testing.B) {
+	msg := []byte("abc")
+	dst := []byte("QUUX-V01-CS02-with-BLS12-378_FP_XMD:SHA-256")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Hash(msg, dst, 1)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package fptower provides the tower of extensions of fp used by the bls12-378 pairing:
+// E2 = fp[u] / (u² - β), E6 = E2[v] / (v³ - ξ) and E12 = E6[w] / (w² - v), the field of the pairing group GT
+// (the non-residues β and ξ are given in the documentation of package bls12378, whose GT is an alias of E12).
+//
+// E12 provides the Frobenius maps, the Karabina compressed cyclotomic squaring
+// (CyclotomicSquareCompressed, DecompressKarabina) and the torus compression (CompressTorus,
+// DecompressTorus) of the elements of the cyclotomic subgroup; E2 provides Legendre and Sqrt.
+//
+// Constant time
+//
+// Only the methods with a CT suffix (InverseCT, ExpCT, SqrtCT, ...), Select and NotEqual run in constant time:
+// they only use the constant-time operations of fp (see fp.Element.MulCT). The other methods (Mul, Inverse,
+// Exp, Sqrt, Legendre, ...) may branch on their inputs.
+// ScalarMultiplicationCT of bls12378 on G2 relies on these operations of E2:
+// AddCT, SubCT, DoubleCT, NegCT, MulCT, SquareCT, InverseCT, Select and NotEqual.
+//
+// Warning
+//
+// This code has not been audited and is provided as-is. In particular, there is no security guarantees
+// such as side-channel attack resistance beyond the constant-time methods above.
+package fptower
@@ -18,6 +18,7 @@ package fptower
 
 import (
 	"math/big"
+	mrand "math/rand"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/fp"
@@ -567,3 +568,79 @@ func TestE12Div(t *testing.T) {
 
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
+
+func TestE12ConstantTime(t *testing.T) {
+
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := GenE12()
+	genExp := GenFp()
+
+	properties.Property("[BLS12-378] InverseCT must match Inverse", prop.ForAll(
+		func(a *E12) bool {
+			var b, c E12
+			b.InverseCT(a)
+			c.Inverse(a)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[BLS12-378] ExpCT must match square and multiply", prop.ForAll(
+		func(a *E12, e fp.Element) bool {
+			var k big.Int
+			e.ToBigIntRegular(&k)
+			// square and multiply reference
+			var b, c E12
+			c.SetOne()
+			for i := k.BitLen() - 1; i >= 0; i-- {
+				c.Square(&c)
+				if k.Bit(i) == 1 {
+					c.Mul(&c, a)
+				}
+			}
+			b.ExpCT(*a, &k)
+			if !b.Equal(&c) {
+				return false
+			}
+			// xᵏ = (x⁻¹)⁻ᵏ
+			k.Neg(&k)
+			c.Inverse(&c)
+			b.ExpCT(*a, &k)
+			return b.Equal(&c)
+		},
+		genA,
+		genExp,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestE12SetRandomFrom(t *testing.T) {
+	t.Parallel()
+
+	// two readers with the same seed must yield the same values
+	r1 := mrand.New(mrand.NewSource(42)) //#nosec G404 -- deterministic source on purpose
+	r2 := mrand.New(mrand.NewSource(42)) //#nosec G404 -- deterministic source on purpose
+
+	var a, b E12
+	for i := 0; i < 10; i++ {
+		if _, err := a.SetRandomFrom(r1); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := b.SetRandomFrom(r2); err != nil {
+			t.Fatal(err)
+		}
+		if !a.Equal(&b) {
+			t.Fatal("SetRandomFrom with the same seed should output the same values")
+		}
+	}
+}
@@ -17,7 +17,9 @@
 package fptower
 
 import (
+	"crypto/rand"
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/fp"
+	"io"
 	"math/big"
 )
 
@@ -82,12 +84,19 @@ func (z *E2) SetOne() *E2 {
 	return z
 }
 
-// SetRandom sets a0 and a1 to random values
+// SetRandom sets z to a uniform random value, reading the randomness from crypto/rand.Reader
 func (z *E2) SetRandom() (*E2, error) {
-	if _, err := z.A0.SetRandom(); err != nil {
+	return z.SetRandomFrom(rand.Reader)
+}
+
+// SetRandomFrom sets z to a uniform random value, reading the randomness from r
+//
+// Each coordinate is sampled uniformly with SetRandomFrom, in order.
+func (z *E2) SetRandomFrom(r io.Reader) (*E2, error) {
+	if _, err := z.A0.SetRandomFrom(r); err != nil {
 		return nil, err
 	}
-	if _, err := z.A1.SetRandom(); err != nil {
+	if _, err := z.A1.SetRandomFrom(r); err != nil {
 		return nil, err
 	}
 	return z, nil
@@ -122,6 +131,44 @@ func (z *E2) Neg(x *E2) *E2 {
 	return z
 }
 
+// AddCT adds two elements of E2, without branching (see fp.Element.AddCT)
+func (z *E2) AddCT(x, y *E2) *E2 {
+	z.A0.AddCT(&x.A0, &y.A0)
+	z.A1.AddCT(&x.A1, &y.A1)
+	return z
+}
+
+// SubCT subtracts two elements of E2, without branching (see fp.Element.SubCT)
+func (z *E2) SubCT(x, y *E2) *E2 {
+	z.A0.SubCT(&x.A0, &y.A0)
+	z.A1.SubCT(&x.A1, &y.A1)
+	return z
+}
+
+// DoubleCT doubles an E2 element, without branching (see fp.Element.DoubleCT)
+func (z *E2) DoubleCT(x *E2) *E2 {
+	z.A0.DoubleCT(&x.A0)
+	z.A1.DoubleCT(&x.A1)
+	return z
+}
+
+// NegCT negates an E2 element, without branching (see fp.Element.NegCT)
+func (z *E2) NegCT(x *E2) *E2 {
+	z.A0.NegCT(&x.A0)
+	z.A1.NegCT(&x.A1)
+	return z
+}
+
+// SquareCT sets z to the E2-product of x,x, without branching (see MulCT)
+func (z *E2) SquareCT(x *E2) *E2 {
+	return z.MulCT(x, x)
+}
+
+// NotEqual returns 0 if and only if z == x; constant-time
+func (z *E2) NotEqual(x *E2) uint64 {
+	return z.A0.NotEqual(&x.A0) | z.A1.NotEqual(&x.A1)
+}
+
 // String implements Stringer interface for fancy printing
 func (z *E2) String() string {
 	return z.A0.String() + "+" + z.A1.String() + "*u"
@@ -204,6 +251,25 @@ func (z *E2) Exp(x E2, k *big.Int) *E2 {
 	return z
 }
 
+// InverseCT sets z to the inverse of x and returns z
+//
+// Unlike Inverse, it inverts the norm of x with fp.InverseCT, and only uses the branch-free
+// operations (MulCT, NegCT), in constant time.
+//
+// if x == 0, sets and returns z = x
+func (z *E2) InverseCT(x *E2) *E2 {
+	// x⁻¹ = x̄ / N(x), with N(x) = x * x̄ in fp
+	var n E2
+	n.A0 = x.A0
+	n.A1.NegCT(&x.A1)
+	n.MulCT(x, &n)
+	n.A0.InverseCT(&n.A0)
+	z.A0.MulCT(&x.A0, &n.A0)
+	z.A1.MulCT(&x.A1, &n.A0).NegCT(&z.A1)
+
+	return z
+}
+
 // Sqrt sets z to the square root of and returns z
 // The function does not test wether the square root
 // exists or not, it's up to the caller to call
@@ -244,6 +310,54 @@ func (z *E2) Sqrt(x *E2) *E2 {
 	return z
 }
 
+// SqrtCT sets z to the square root of x and returns z
+// if the square root doesn't exist (x is not a square)
+// SqrtCT leaves z unchanged and returns nil
+//
+// Unlike Sqrt, SqrtCT computes both branches of the algorithm with constant-time
+// exponentiations and square roots and selects the result; only whether x is a square
+// or not is leaked.
+// cf https://eprint.iacr.org/2012/685.pdf (algo 10)
+func (z *E2) SqrtCT(x *E2) *E2 {
+
+	// precomputation
+	var b, c, d, e, f, x0, x1, y0, y1 E2
+	var _b, o fp.Element
+
+	// c must be a non square (works for p=1 mod 12 hence 1 mod 4, only bls377 has such a p currently)
+	c.A1.SetOne()
+
+	q := fp.Modulus()
+	var exp, one big.Int
+	one.SetUint64(1)
+	exp.Set(q).Sub(&exp, &one).Rsh(&exp, 1)
+	d.Exp(c, &exp)
+	e.Mul(&d, &c).Inverse(&e)
+	f.Mul(&d, &c).Square(&f)
+
+	// computation
+	exp.Rsh(&exp, 1)
+	b.ExpCT(*x, &exp)
+	b.norm(&_b)
+	o.SetOne()
+	notOne := int(_b.NotEqual(&o))
+
+	x0.Square(&b).Mul(&x0, x)
+	x1.Mul(&x0, &f)
+	x0.Select(notOne, &x0, &x1)
+	_b.Set(&x0.A0).SqrtCT(&_b)
+	y0.Conjugate(&b).MulByElement(&y0, &_b)
+	y1.Mul(&y0, &e)
+	y0.Select(notOne, &y0, &y1)
+
+	// ensure y * y = x
+	x0.Square(&y0)
+	if !x0.Equal(x) {
+		return nil
+	}
+	return z.Set(&y0)
+}
+
 // BatchInvertE2 returns a new slice with every element inverted.
 // Uses Montgomery batch inversion trick
 //
@@ -294,3 +408,46 @@ func (z *E2) Div(x *E2, y *E2) *E2 {
 	r.Inverse(y).Mul(x, &r)
 	return z.Set(&r)
 }
+
+// ExpCT sets z=xᵏ and returns it
+//
+// Unlike Exp, ExpCT uses a Montgomery ladder over max(2*fp.Bits, k.BitLen()) bits with
+// constant-time selections, so that its sequence of operations doesn't depend on x and k (only the sign
+// of k and the bit length of exponents larger than 2*fp.Bits are leaked).
+// It is as constant-time as the fp.Element arithmetic it relies on.
+func (z *E2) ExpCT(x E2, k *big.Int) *E2 {
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ == (x⁻¹)ᵏ
+		x.InverseCT(&x)
+
+		// we negate k in a temp big.Int since
+		// Int.Bit(_) of k and -k is different
+		e = bigIntPool.Get().(*big.Int)
+		defer bigIntPool.Put(e)
+		e.Neg(k)
+	}
+
+	nbBits := 2 * fp.Bits
+	if e.BitLen() > nbBits {
+		nbBits = e.BitLen()
+	}
+
+	// invariant: r1 = r0 * x
+	var r0, r1, a, b E2
+	r0.SetOne()
+	r1.Set(&x)
+	for i := nbBits - 1; i >= 0; i-- {
+		bit := int(e.Bit(i))
+		// (a, b) = (r0, r1) if bit == 0, (r1, r0) otherwise
+		a.Select(bit, &r0, &r1)
+		b.Select(bit, &r1, &r0)
+		b.Mul(&a, &b)
+		a.Square(&a)
+		r0.Select(bit, &a, &b)
+		r1.Select(bit, &b, &a)
+	}
+
+	return z.Set(&r0)
+}
@@ -18,6 +18,8 @@ package fptower
 
 import (
 	"crypto/rand"
+	"math/big"
+	mrand "math/rand"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/fp"
@@ -368,6 +370,48 @@ func TestE2Ops(t *testing.T) {
 		genA,
 	))
 
+	var nonSquare E2
+	for nonSquare.Legendre() != -1 {
+		_, _ = nonSquare.SetRandom()
+	}
+
+	properties.Property("[BLS12-378] SqrtCT must match Sqrt", prop.ForAll(
+		func(a *E2) bool {
+			var b, c, d E2
+			b.Square(a)
+			if c.SqrtCT(&b) == nil {
+				return false
+			}
+			d.Sqrt(&b)
+			if !c.Equal(&d) {
+				d.Neg(&d)
+			}
+			if !c.Equal(&d) {
+				return false
+			}
+			// a non square has no square root
+			b.Mul(&b, &nonSquare)
+			return b.SqrtCT(&b) == nil
+		},
+		genA,
+	))
+
+	properties.Property("[BLS12-378] the CT operations must match their variable-time counterparts", prop.ForAll(
+		func(a, b *E2) bool {
+			var c, d E2
+			return c.AddCT(a, b).Equal(d.Add(a, b)) &&
+				c.SubCT(a, b).Equal(d.Sub(a, b)) &&
+				c.DoubleCT(a).Equal(d.Double(a)) &&
+				c.NegCT(a).Equal(d.Neg(a)) &&
+				c.MulCT(a, b).Equal(d.Mul(a, b)) &&
+				c.SquareCT(a).Equal(d.Square(a)) &&
+				(a.NotEqual(b) == 0) == a.Equal(b) &&
+				a.NotEqual(a) == 0
+		},
+		genA,
+		genB,
+	))
+
 	properties.Property("[BLS12-378] neg(E2) == neg(E2.A0, E2.A1)", prop.ForAll(
 		func(a *E2) bool {
 			var b, c E2
@@ -529,3 +573,79 @@ func TestE2Div(t *testing.T) {
 
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
+
+func TestE2ConstantTime(t *testing.T) {
+
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := GenE2()
+	genExp := GenFp()
+
+	properties.Property("[BLS12-378] InverseCT must match Inverse", prop.ForAll(
+		func(a *E2) bool {
+			var b, c E2
+			b.InverseCT(a)
+			c.Inverse(a)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[BLS12-378] ExpCT must match square and multiply", prop.ForAll(
+		func(a *E2, e fp.Element) bool {
+			var k big.Int
+			e.ToBigIntRegular(&k)
+			// square and multiply reference
+			var b, c E2
+			c.SetOne()
+			for i := k.BitLen() - 1; i >= 0; i-- {
+				c.Square(&c)
+				if k.Bit(i) == 1 {
+					c.Mul(&c, a)
+				}
+			}
+			b.ExpCT(*a, &k)
+			if !b.Equal(&c) {
+				return false
+			}
+			// xᵏ = (x⁻¹)⁻ᵏ
+			k.Neg(&k)
+			c.Inverse(&c)
+			b.ExpCT(*a, &k)
+			return b.Equal(&c)
+		},
+		genA,
+		genExp,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestE2SetRandomFrom(t *testing.T) {
+	t.Parallel()
+
+	// two readers with the same seed must yield the same values
+	r1 := mrand.New(mrand.NewSource(42)) //#nosec G404 -- deterministic source on purpose
+	r2 := mrand.New(mrand.NewSource(42)) //#nosec G404 -- deterministic source on purpose
+
+	var a, b E2
+	for i := 0; i < 10; i++ {
+		if _, err := a.SetRandomFrom(r1); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := b.SetRandomFrom(r2); err != nil {
+			t.Fatal(err)
+		}
+		if !a.Equal(&b) {
+			t.Fatal("SetRandomFrom with the same seed should output the same values")
+		}
+	}
+}
@@ -39,6 +39,11 @@
 // 	 .Inv(a)
 // 	b.Exp(b, new(big.Int).SetUint64(42))
 //
+// Slices of field elements can be manipulated in batch through the Vector type:
+// 	var a, b, c Vector
+// 	c.Mul(a, b)
+// 	s := c.InnerProduct(a)
+//
 // Modulus q =
 //
 // 	q[base10] = 14883435066912132899950318861128167269793560281114003360875131245101026639873
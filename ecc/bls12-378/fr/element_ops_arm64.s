@@ -0,0 +1,327 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+#include "textflag.h"
+#include "funcdata.h"
+
+// modulus q
+DATA q<>+0(SB)/8, $0x3291440000000001
+DATA q<>+8(SB)/8, $0xeae77f3da0940001
+DATA q<>+16(SB)/8, $0x87787fb4e3dbb0ff
+DATA q<>+24(SB)/8, $0x20e7b9c8ef7b2eb1
+GLOBL q<>(SB), (RODATA+NOPTR), $32
+
+// qInv0 q'[0]
+DATA qInv0<>(SB)/8, $0x329143ffffffffff
+GLOBL qInv0<>(SB), (RODATA+NOPTR), $8
+
+// add(res, x, y *Element)
+TEXT ·add(SB), NOSPLIT, $0-24
+	MOVD x+8(FP), R0
+	LDP  0(R0), (R1, R2)
+	LDP  16(R0), (R3, R4)
+	MOVD y+16(FP), R0
+	LDP  0(R0), (R5, R6)
+	LDP  16(R0), (R7, R8)
+	ADDS R1, R5, R1
+	ADCS R2, R6, R2
+	ADCS R3, R7, R3
+	ADC  R4, R8, R4
+	LDP  q<>+0(SB), (R5, R6)
+	LDP  q<>+16(SB), (R7, R8)
+	MOVD res+0(FP), R0
+
+	// q = t - q
+	SUBS R5, R1, R5
+	SBCS R6, R2, R6
+	SBCS R7, R3, R7
+	SBCS R8, R4, R8
+
+	// if no borrow, return q, else return t
+	CSEL CS, R5, R1, R1
+	CSEL CS, R6, R2, R2
+	CSEL CS, R7, R3, R3
+	CSEL CS, R8, R4, R4
+	STP  (R1, R2), 0(R0)
+	STP  (R3, R4), 16(R0)
+	RET
+
+// sub(res, x, y *Element)
+TEXT ·sub(SB), NOSPLIT, $0-24
+	MOVD x+8(FP), R0
+	LDP  0(R0), (R1, R2)
+	LDP  16(R0), (R3, R4)
+	MOVD y+16(FP), R0
+	LDP  0(R0), (R5, R6)
+	LDP  16(R0), (R7, R8)
+	SUBS R5, R1, R1
+	SBCS R6, R2, R2
+	SBCS R7, R3, R3
+	SBCS R8, R4, R4
+	LDP  q<>+0(SB), (R5, R6)
+	LDP  q<>+16(SB), (R7, R8)
+
+	// add q if underflow, 0 if not
+	CSEL CS, ZR, R5, R5
+	CSEL CS, ZR, R6, R6
+	CSEL CS, ZR, R7, R7
+	CSEL CS, ZR, R8, R8
+	ADDS R5, R1, R1
+	ADCS R6, R2, R2
+	ADCS R7, R3, R3
+	ADC  R8, R4, R4
+	MOVD res+0(FP), R0
+	STP  (R1, R2), 0(R0)
+	STP  (R3, R4), 16(R0)
+	RET
+
+// mul(res, x, y *Element)
+TEXT ·mul(SB), NOSPLIT, $0-24
+	MOVD x+8(FP), R0
+	LDP  0(R0), (R5, R6)
+	LDP  16(R0), (R7, R8)
+	MOVD y+16(FP), R1
+	LDP  q<>+0(SB), (R9, R10)
+	LDP  q<>+16(SB), (R11, R12)
+	MOVD qInv0<>(SB), R3
+	MOVD 0(R1), R2
+
+	// (C,t[j])  := t[j] + x[j]*y[i] + C
+	MUL   R5, R2, R13
+	MUL   R6, R2, R14
+	MUL   R7, R2, R15
+	MUL   R8, R2, R16
+	UMULH R5, R2, R0
+	ADDS  R0, R14, R14
+	UMULH R6, R2, R0
+	ADCS  R0, R15, R15
+	UMULH R7, R2, R0
+	ADCS  R0, R16, R16
+	UMULH R8, R2, R0
+	ADC   R0, ZR, R17
+
+	// m := t[0]*q'[0] mod W
+	MUL R13, R3, R4
+
+	// (C,t[j-1]) := t[j] + m*q[j] + C
+	MUL   R9, R4, R0
+	ADDS  R0, R13, R13
+	MUL   R10, R4, R0
+	ADCS  R0, R14, R14
+	MUL   R11, R4, R0
+	ADCS  R0, R15, R15
+	MUL   R12, R4, R0
+	ADCS  R0, R16, R16
+	ADC   ZR, R17, R17
+	UMULH R9, R4, R0
+	ADDS  R0, R14, R13
+	UMULH R10, R4, R0
+	ADCS  R0, R15, R14
+	UMULH R11, R4, R0
+	ADCS  R0, R16, R15
+	UMULH R12, R4, R0
+	ADC   R0, R17, R16
+	MOVD  8(R1), R2
+
+	// (C,t[j])  := t[j] + x[j]*y[i] + C
+	MUL   R5, R2, R0
+	ADDS  R0, R13, R13
+	MUL   R6, R2, R0
+	ADCS  R0, R14, R14
+	MUL   R7, R2, R0
+	ADCS  R0, R15, R15
+	MUL   R8, R2, R0
+	ADCS  R0, R16, R16
+	ADC   ZR, ZR, R17
+	UMULH R5, R2, R0
+	ADDS  R0, R14, R14
+	UMULH R6, R2, R0
+	ADCS  R0, R15, R15
+	UMULH R7, R2, R0
+	ADCS  R0, R16, R16
+	UMULH R8, R2, R0
+	ADC   R0, R17, R17
+
+	// m := t[0]*q'[0] mod W
+	MUL R13, R3, R4
+
+	// (C,t[j-1]) := t[j] + m*q[j] + C
+	MUL   R9, R4, R0
+	ADDS  R0, R13, R13
+	MUL   R10, R4, R0
+	ADCS  R0, R14, R14
+	MUL   R11, R4, R0
+	ADCS  R0, R15, R15
+	MUL   R12, R4, R0
+	ADCS  R0, R16, R16
+	ADC   ZR, R17, R17
+	UMULH R9, R4, R0
+	ADDS  R0, R14, R13
+	UMULH R10, R4, R0
+	ADCS  R0, R15, R14
+	UMULH R11, R4, R0
+	ADCS  R0, R16, R15
+	UMULH R12, R4, R0
+	ADC   R0, R17, R16
+	MOVD  16(R1), R2
+
+	// (C,t[j])  := t[j] + x[j]*y[i] + C
+	MUL   R5, R2, R0
+	ADDS  R0, R13, R13
+	MUL   R6, R2, R0
+	ADCS  R0, R14, R14
+	MUL   R7, R2, R0
+	ADCS  R0, R15, R15
+	MUL   R8, R2, R0
+	ADCS  R0, R16, R16
+	ADC   ZR, ZR, R17
+	UMULH R5, R2, R0
+	ADDS  R0, R14, R14
+	UMULH R6, R2, R0
+	ADCS  R0, R15, R15
+	UMULH R7, R2, R0
+	ADCS  R0, R16, R16
+	UMULH R8, R2, R0
+	ADC   R0, R17, R17
+
+	// m := t[0]*q'[0] mod W
+	MUL R13, R3, R4
+
+	// (C,t[j-1]) := t[j] + m*q[j] + C
+	MUL   R9, R4, R0
+	ADDS  R0, R13, R13
+	MUL   R10, R4, R0
+	ADCS  R0, R14, R14
+	MUL   R11, R4, R0
+	ADCS  R0, R15, R15
+	MUL   R12, R4, R0
+	ADCS  R0, R16, R16
+	ADC   ZR, R17, R17
+	UMULH R9, R4, R0
+	ADDS  R0, R14, R13
+	UMULH R10, R4, R0
+	ADCS  R0, R15, R14
+	UMULH R11, R4, R0
+	ADCS  R0, R16, R15
+	UMULH R12, R4, R0
+	ADC   R0, R17, R16
+	MOVD  24(R1), R2
+
+	// (C,t[j])  := t[j] + x[j]*y[i] + C
+	MUL   R5, R2, R0
+	ADDS  R0, R13, R13
+	MUL   R6, R2, R0
+	ADCS  R0, R14, R14
+	MUL   R7, R2, R0
+	ADCS  R0, R15, R15
+	MUL   R8, R2, R0
+	ADCS  R0, R16, R16
+	ADC   ZR, ZR, R17
+	UMULH R5, R2, R0
+	ADDS  R0, R14, R14
+	UMULH R6, R2, R0
+	ADCS  R0, R15, R15
+	UMULH R7, R2, R0
+	ADCS  R0, R16, R16
+	UMULH R8, R2, R0
+	ADC   R0, R17, R17
+
+	// m := t[0]*q'[0] mod W
+	MUL R13, R3, R4
+
+	// (C,t[j-1]) := t[j] + m*q[j] + C
+	MUL   R9, R4, R0
+	ADDS  R0, R13, R13
+	MUL   R10, R4, R0
+	ADCS  R0, R14, R14
+	MUL   R11, R4, R0
+	ADCS  R0, R15, R15
+	MUL   R12, R4, R0
+	ADCS  R0, R16, R16
+	ADC   ZR, R17, R17
+	UMULH R9, R4, R0
+	ADDS  R0, R14, R13
+	UMULH R10, R4, R0
+	ADCS  R0, R15, R14
+	UMULH R11, R4, R0
+	ADCS  R0, R16, R15
+	UMULH R12, R4, R0
+	ADC   R0, R17, R16
+
+	// reduce if necessary
+	MOVD res+0(FP), R0
+
+	// q = t - q
+	SUBS R9, R13, R9
+	SBCS R10, R14, R10
+	SBCS R11, R15, R11
+	SBCS R12, R16, R12
+
+	// if no borrow, return q, else return t
+	CSEL CS, R9, R13, R13
+	CSEL CS, R10, R14, R14
+	CSEL CS, R11, R15, R15
+	CSEL CS, R12, R16, R16
+	STP  (R13, R14), 0(R0)
+	STP  (R15, R16), 16(R0)
+	RET
+
+// Butterfly(a, b *Element) sets a = a + b; b = a - b
+TEXT ·Butterfly(SB), NOSPLIT, $0-16
+	MOVD a+0(FP), R0
+	LDP  0(R0), (R2, R3)
+	LDP  16(R0), (R4, R5)
+	MOVD b+8(FP), R1
+	LDP  0(R1), (R6, R7)
+	LDP  16(R1), (R8, R9)
+	ADDS R2, R6, R10
+	ADCS R3, R7, R11
+	ADCS R4, R8, R12
+	ADC  R5, R9, R13
+	SUBS R6, R2, R6
+	SBCS R7, R3, R7
+	SBCS R8, R4, R8
+	SBCS R9, R5, R9
+	LDP  q<>+0(SB), (R2, R3)
+	LDP  q<>+16(SB), (R4, R5)
+
+	// add q if underflow, 0 if not
+	CSEL CS, ZR, R2, R2
+	CSEL CS, ZR, R3, R3
+	CSEL CS, ZR, R4, R4
+	CSEL CS, ZR, R5, R5
+	ADDS R2, R6, R6
+	ADCS R3, R7, R7
+	ADCS R4, R8, R8
+	ADC  R5, R9, R9
+	STP  (R6, R7), 0(R1)
+	STP  (R8, R9), 16(R1)
+	LDP  q<>+0(SB), (R2, R3)
+	LDP  q<>+16(SB), (R4, R5)
+
+	// q = t - q
+	SUBS R2, R10, R2
+	SBCS R3, R11, R3
+	SBCS R4, R12, R4
+	SBCS R5, R13, R5
+
+	// if no borrow, return q, else return t
+	CSEL CS, R2, R10, R10
+	CSEL CS, R3, R11, R11
+	CSEL CS, R4, R12, R12
+	CSEL CS, R5, R13, R13
+	STP  (R10, R11), 0(R0)
+	STP  (R12, R13), 16(R0)
+	RET
+
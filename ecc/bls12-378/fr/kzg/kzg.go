@@ -25,6 +25,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-378"
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr/polynomial"
 	"github.com/consensys/gnark-crypto/fiat-shamir"
 )
 
@@ -43,18 +44,21 @@ type Digest = bls12378.G1Affine
 type SRS struct {
 	G1 []bls12378.G1Affine  // [G₁ [α]G₁ , [α²]G₁, ... ]
 	G2 [2]bls12378.G2Affine // [G₂, [α]G₂ ]
+
+	// lines of the Miller loops of G2[0] and G2[1], set by NewSRS and ReadFrom (see bls12378.PrecomputeLines)
+	lines *srsLines
+}
+
+// srsLines are the lines of the Miller loops of the points of G2 they were computed from
+type srsLines struct {
+	g2    [2]bls12378.G2Affine
+	lines [2]bls12378.LineEvaluations
 }
 
 // eval returns p(point) where p is interpreted as a polynomial
 // ∑_{i<len(p)}p[i]Xⁱ
 func eval(p []fr.Element, point fr.Element) fr.Element {
-	var res fr.Element
-	n := len(p)
-	res.Set(&p[n-1])
-	for i := n - 2; i >= 0; i-- {
-		res.Mul(&res, &point).Add(&res, &p[i])
-	}
-	return res
+	return (*polynomial.Polynomial)(&p).Eval(&point)
 }
 
 // NewSRS returns a new SRS using alpha as randomness source
@@ -89,10 +93,44 @@ func NewSRS(size uint64, bAlpha *big.Int) (*SRS, error) {
 	}
 	g1s := bls12378.BatchScalarMultiplicationG1(&gen1Aff, alphas)
 	copy(srs.G1[1:], g1s)
+	srs.precomputeLines()
 
 	return &srs, nil
 }
 
+// precomputeLines sets the lines of the Miller loops of G2[0] and G2[1]
+func (srs *SRS) precomputeLines() {
+	srs.lines = &srsLines{
+		g2: srs.G2,
+		lines: [2]bls12378.LineEvaluations{
+			bls12378.PrecomputeLines(srs.G2[0]),
+			bls12378.PrecomputeLines(srs.G2[1]),
+		},
+	}
+}
+
+// pairingCheck returns true if e(p0, G₂).e(p1, [α]G₂) == 1
+//
+// The Miller loops use the lines of G₂ and [α]G₂ precomputed by NewSRS or ReadFrom. They're computed on the fly
+// if the SRS wasn't built by them, or if G2 was modified since.
+func (srs *SRS) pairingCheck(p0, p1 bls12378.G1Affine) (bool, error) {
+	var lines [2]bls12378.LineEvaluations
+	if srs.lines != nil && srs.lines.g2 == srs.G2 {
+		lines = srs.lines.lines
+	} else {
+		lines[0] = bls12378.PrecomputeLines(srs.G2[0])
+		lines[1] = bls12378.PrecomputeLines(srs.G2[1])
+	}
+	f, err := bls12378.MillerLoopFixedQ([]bls12378.G1Affine{p0, p1}, lines[:])
+	if err != nil {
+		return false, err
+	}
+	f = bls12378.FinalExponentiation(&f)
+	var one bls12378.GT
+	one.SetOne()
+	return f.Equal(&one), nil
+}
+
 // OpeningProof KZG proof for opening at a single point.
 //
 // implements io.ReaderFrom and io.WriterTo
@@ -136,6 +174,43 @@ func Commit(p []fr.Element, srs *SRS, nbTasks ...int) (Digest, error) {
 	return res, nil
 }
 
+// BatchCommit commits to several polynomials with one multi exponentiation batch with the SRS,
+// which shares the reads of the SRS points (see bls12378.MultiExpBatchG1).
+// It is assumed that the polynomials are in canonical form, in Montgomery form.
+func BatchCommit(polynomials [][]fr.Element, srs *SRS, nbTasks ...int) ([]Digest, error) {
+
+	size := 0
+	for _, p := range polynomials {
+		if len(p) == 0 || len(p) > len(srs.G1) {
+			return nil, ErrInvalidPolynomialSize
+		}
+		if len(p) > size {
+			size = len(p)
+		}
+	}
+
+	// the polynomials are padded with zeros to the size of the largest one
+	scalars := make([][]fr.Element, len(polynomials))
+	for i, p := range polynomials {
+		scalars[i] = p
+		if len(p) < size {
+			scalars[i] = make([]fr.Element, size)
+			copy(scalars[i], p)
+		}
+	}
+
+	config := ecc.MultiExpConfig{ScalarsMont: true}
+	if len(nbTasks) > 0 {
+		config.NbTasks = nbTasks[0]
+	}
+	res, err := bls12378.MultiExpBatchG1(srs.G1[:size], scalars, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return bls12378.BatchJacobianToAffineG1(res), nil
+}
+
 // Open computes an opening proof of polynomial p at given point.
 // fft.Domain Cardinality must be larger than p.Degree()
 func Open(p []fr.Element, point fr.Element, srs *SRS) (OpeningProof, error) {
@@ -183,29 +258,18 @@ func Verify(commitment *Digest, proof *OpeningProof, point fr.Element, srs *SRS)
 	var negH bls12378.G1Affine
 	negH.Neg(&proof.H)
 
-	// [α-a]G₂
-	var alphaMinusaG2Jac, genG2Jac, alphaG2Jac bls12378.G2Jac
+	// [f(α) - f(a) + a⋅H(α)]G₁
+	var totalG1Jac bls12378.G1Jac
 	var pointBigInt big.Int
 	point.ToBigIntRegular(&pointBigInt)
-	genG2Jac.FromAffine(&srs.G2[0])
-	alphaG2Jac.FromAffine(&srs.G2[1])
-	alphaMinusaG2Jac.ScalarMultiplication(&genG2Jac, &pointBigInt).
-		Neg(&alphaMinusaG2Jac).
-		AddAssign(&alphaG2Jac)
-
-	// [α-a]G₂
-	var xminusaG2Aff bls12378.G2Affine
-	xminusaG2Aff.FromJacobian(&alphaMinusaG2Jac)
-
-	// [f(α) - f(a)]G₁
-	var fminusfaG1Aff bls12378.G1Affine
-	fminusfaG1Aff.FromJacobian(&fminusfaG1Jac)
-
-	// e([f(α) - f(a)]G₁, G₂).e([-H(α)]G₁, [α-a]G₂) ==? 1
-	check, err := bls12378.PairingCheck(
-		[]bls12378.G1Affine{fminusfaG1Aff, negH},
-		[]bls12378.G2Affine{srs.G2[0], xminusaG2Aff},
-	)
+	totalG1Jac.ScalarMultiplicationAffine(&proof.H, &pointBigInt)
+	totalG1Jac.AddAssign(&fminusfaG1Jac)
+	var totalG1Aff bls12378.G1Affine
+	totalG1Aff.FromJacobian(&totalG1Jac)
+
+	// f(α) - f(a) = H(α)(α-a), so
+	// e([f(α) - f(a) + a⋅H(α)]G₁, G₂).e([-H(α)]G₁, [α]G₂) ==? 1
+	check, err := srs.pairingCheck(totalG1Aff, negH)
 	if err != nil {
 		return err
 	}
@@ -265,27 +329,31 @@ func BatchOpenSinglePoint(polynomials [][]fr.Element, digests []Digest, point fr
 	go func() {
 		// wait for polynomial evaluations to be completed (res.ClaimedValues)
 		wg.Wait()
-		foldedEvaluations = res.ClaimedValues[nbDigests-1]
-		for i := nbDigests - 2; i >= 0; i-- {
-			foldedEvaluations.Mul(&foldedEvaluations, &gamma).
-				Add(&foldedEvaluations, &res.ClaimedValues[i])
-		}
+		foldedEvaluations = eval(res.ClaimedValues, gamma)
 		close(chSumGammai)
 	}()
 
-	// compute ∑ᵢγⁱfᵢ
-	// note: if we are willing to paralellize that, we could clone the poly and scale them by
-	// gamma n in parallel, before reducing into foldedPolynomials
-	foldedPolynomials := make([]fr.Element, largestPoly)
-	copy(foldedPolynomials, polynomials[0])
-	acc := gamma
-	var pj fr.Element
+	// compute ∑ᵢγⁱfᵢ, coefficient by coefficient, reducing once per coefficient
+	// note: if we are willing to paralellize that, we could split the coefficients
+	// between goroutines
+	gammai := make([]fr.Element, len(polynomials))
+	gammai[0].SetOne()
 	for i := 1; i < len(polynomials); i++ {
-		for j := 0; j < len(polynomials[i]); j++ {
-			pj.Mul(&polynomials[i][j], &acc)
-			foldedPolynomials[j].Add(&foldedPolynomials[j], &pj)
+		gammai[i].Mul(&gammai[i-1], &gamma)
+	}
+	foldedPolynomials := make([]fr.Element, largestPoly)
+	var acc fr.Accumulator
+	for j := 0; j < largestPoly; j++ {
+		acc.Reset()
+		if j < len(polynomials[0]) {
+			acc.Add(&polynomials[0][j])
+		}
+		for i := 1; i < len(polynomials); i++ {
+			if j < len(polynomials[i]) {
+				acc.MulAdd(&polynomials[i][j], &gammai[i])
+			}
 		}
-		acc.Mul(&acc, &gamma)
+		foldedPolynomials[j] = acc.Reduce()
 	}
 
 	// compute H
@@ -443,10 +511,7 @@ func BatchVerifyMultiPoints(digests []Digest, proofs []OpeningProof, points []fr
 
 	// pairing check
 	// e([∑ᵢλᵢ(fᵢ(α) - fᵢ(pᵢ) + pᵢHᵢ(α))]G₁, G₂).e([-∑ᵢλᵢ[Hᵢ(α)]G₁), [α]G₂)
-	check, err := bls12378.PairingCheck(
-		[]bls12378.G1Affine{foldedDigests, foldedQuotients},
-		[]bls12378.G2Affine{srs.G2[0], srs.G2[1]},
-	)
+	check, err := srs.pairingCheck(foldedDigests, foldedQuotients)
 	if err != nil {
 		return err
 	}
@@ -470,11 +535,11 @@ func fold(di []Digest, fai []fr.Element, ci []fr.Element) (Digest, fr.Element, e
 	nbDigests := len(di)
 
 	// fold the claimed values ∑ᵢcᵢf(aᵢ)
-	var foldedEvaluations, tmp fr.Element
+	var acc fr.Accumulator
 	for i := 0; i < nbDigests; i++ {
-		tmp.Mul(&fai[i], &ci[i])
-		foldedEvaluations.Add(&foldedEvaluations, &tmp)
+		acc.MulAdd(&fai[i], &ci[i])
 	}
+	foldedEvaluations := acc.Reduce()
 
 	// fold the digests ∑ᵢ[cᵢ]([fᵢ(α)]G₁)
 	var foldedDigests Digest
@@ -19,9 +19,10 @@ package bls12378
 import (
 	"fmt"
 	"math/big"
+	mrand "math/rand"
 	"testing"
 
-	"github.com/consensys/gnark-crypto/ecc/bls12-378/internal/fptower"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fptower"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
 	"github.com/leanovate/gopter"
@@ -441,6 +442,30 @@ func TestG2AffineCofactorCleaning(t *testing.T) {
 
 }
 
+func TestG2AffineSetRandomFrom(t *testing.T) {
+	t.Parallel()
+
+	// two readers with the same seed must yield the same points
+	r1 := mrand.New(mrand.NewSource(42)) //#nosec G404 -- deterministic source on purpose
+	r2 := mrand.New(mrand.NewSource(42)) //#nosec G404 -- deterministic source on purpose
+
+	var a, b G2Affine
+	for i := 0; i < 10; i++ {
+		if _, err := a.SetRandomFrom(r1); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := b.SetRandomFrom(r2); err != nil {
+			t.Fatal(err)
+		}
+		if !a.Equal(&b) {
+			t.Fatal("SetRandomFrom with the same seed should output the same points")
+		}
+		if !a.IsOnCurve() || !a.IsInSubGroup() {
+			t.Fatal("SetRandomFrom should output a point of the prime order subgroup")
+		}
+	}
+}
+
 func TestG2AffineBatchScalarMultiplication(t *testing.T) {
 
 	parameters := gopter.DefaultTestParameters()
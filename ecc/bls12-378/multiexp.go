@@ -22,6 +22,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
 	"github.com/consensys/gnark-crypto/internal/parallel"
 	"math"
+	"math/big"
 	"runtime"
 )
 
@@ -162,6 +163,51 @@ func partitionScalars(scalars []fr.Element, c uint64, scalarsMont bool, nbTasks
 	return toReturn, smallValues
 }
 
+// msmOptions are the parameters of the bucket method, derived from the MultiExp config and the scalars
+type msmOptions struct {
+	splitFirstChunk bool // process the first chunk in 2 go routines (see partitionScalars smallValues)
+	batchAffine     bool // use batch affine buckets (see msmProcessChunkG1AffineBatchAffine)
+	nbChunks        int  // if not 0, the chunks from nbChunks on have no non-zero digits and are skipped
+}
+
+// glvScalarBits bounds the bit length of the scalars of the GLV decomposition (see ecc.SplitScalar):
+// the multiExps of smaller scalars don't use it
+const glvScalarBits = fr.Bits/2 + 2
+
+// glvSplitCostG1 and glvSplitCostG2 approximate the cost of the decomposition of a scalar (on big.Int),
+// in group operations, to decide if MultiExp uses GLV
+const (
+	glvSplitCostG1 = 2
+	glvSplitCostG2 = 1
+)
+
+// scalarsBitLen returns the maximum bit length of the scalars (in regular form)
+func scalarsBitLen(scalars []fr.Element, scalarsMont bool, nbTasks int) int {
+	chBitLen := make(chan int, nbTasks)
+	parallel.Execute(len(scalars), func(start, end int) {
+		bitLen := 0
+		for i := start; i < end; i++ {
+			scalar := scalars[i]
+			if scalarsMont {
+				scalar.FromMont()
+			}
+			if l := scalar.BitLen(); l > bitLen {
+				bitLen = l
+			}
+		}
+		chBitLen <- bitLen
+	}, nbTasks)
+	close(chBitLen)
+
+	bitLen := 0
+	for l := range chBitLen {
+		if l > bitLen {
+			bitLen = l
+		}
+	}
+	return bitLen
+}
+
 // MultiExp implements section 4 of https://eprint.iacr.org/2012/549.pdf
 //
 // This call return an error if len(scalars) != len(points) or if provided config is invalid.
@@ -203,6 +249,8 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 	// msmProcessChunk places points into buckets base on their selector and return the weighted bucket sum in given channel
 	// step 3
 	// reduce the buckets weigthed sums into our result (msmReduceChunk)
+	// for large c, the buckets can instead be in affine coordinates, the points being added by batches
+	// that share one inversion (msmProcessChunkG1AffineBatchAffine); see config.Buckets
 
 	// ensure len(points) == len(scalars)
 	nbPoints := len(points)
@@ -217,9 +265,36 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 		return nil, errors.New("invalid config: config.NbTasks > 1024")
 	}
 
+	// the bit length of the scalars bounds the number of windows with non-zero digits
+	scalarsBits := config.MaxScalarBits
+	if scalarsBits <= 0 || scalarsBits > fr.Bits {
+		scalarsBits = scalarsBitLen(scalars, config.ScalarsMont, config.NbTasks)
+	}
+	switch scalarsBits {
+	case 0:
+		// all the scalars are 0
+		p.X.SetOne()
+		p.Y.SetOne()
+		p.Z.SetZero()
+		return p, nil
+	case 1:
+		// 0/1 scalars: no buckets, we sum the points with a scalar 1
+		return p.msmBinary(points, scalars, config.ScalarsMont, config.NbTasks), nil
+	}
+
+	// number of bits of the digits: partitionScalars carries a digit >= 2^{c-1} to the next window,
+	// so the top window needs 2 bits above the scalars to absorb the carry
+	digitsBits := func(scalarsBits int) int {
+		if scalarsBits+2 < fr.Limbs*64 {
+			return scalarsBits + 2
+		}
+		return fr.Limbs * 64
+	}
+	nbBits := digitsBits(scalarsBits)
+
 	// here, we compute the best C for nbPoints
 	// we split recursively until nbChunks(c) >= nbTasks,
-	bestC := func(nbPoints int) uint64 {
+	bestC := func(nbPoints, nbBits int) (uint64, float64) {
 		// implemented msmC methods (the c we use must be in this slice)
 		implementedCs := []uint64{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 20, 21}
 		var C uint64
@@ -229,7 +304,7 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 		// for example, on a MBP 2016, for G2 MultiExp > 8M points, hand picking c gives better results
 		min := math.MaxFloat64
 		for _, c := range implementedCs {
-			cc := fr.Limbs * 64 * (nbPoints + (1 << (c)))
+			cc := nbBits * (nbPoints + (1 << (c)))
 			cost := float64(cc) / float64(c)
 			if cost < min {
 				min = cost
@@ -240,20 +315,40 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 		// if C > 16 && nbPoints < 1 << 23 {
 		// 	C = 16
 		// }
-		return C
+		return C, min
+	}
+
+	// GLV: with ϕ(P) = λP, sᵢ Pᵢ = s1ᵢ Pᵢ + s2ᵢ ϕ(Pᵢ), where s1ᵢ and s2ᵢ have about half the bits of r.
+	// The multiExp of the 2n points has as many additions in the buckets, but half as many windows to reduce:
+	// it's worth the decomposition of the scalars when the reduction of the buckets isn't negligible.
+	if config.EnableGLV && scalarsBits > glvScalarBits {
+		_, cost := bestC(nbPoints, nbBits)
+		_, costGLV := bestC(2*nbPoints, digitsBits(glvScalarBits))
+		if costGLV+glvSplitCostG1*float64(nbPoints) < cost {
+			points, scalars = msmGLVG1Affine(points, scalars, config.ScalarsMont, config.NbTasks)
+			nbPoints = len(points)
+			config.ScalarsMont = false
+			scalarsBits = scalarsBitLen(scalars, false, config.NbTasks)
+			nbBits = digitsBits(scalarsBits)
+		}
+	}
+
+	// number of c-bit windows with non-zero digits
+	nbWindows := func(c uint64) int {
+		return (nbBits + int(c) - 1) / int(c)
 	}
 
 	var C uint64
 	nbSplits := 1
 	nbChunks := 0
 	for nbChunks < config.NbTasks {
-		C = bestC(nbPoints)
-		nbChunks = int(fr.Limbs * 64 / C) // number of c-bit radixes in a scalar
-		if (fr.Limbs*64)%C != 0 {
-			nbChunks++
-		}
-		nbChunks *= nbSplits
+		C, _ = bestC(nbPoints, nbBits)
+		nbChunks = nbWindows(C) * nbSplits
 		if nbChunks < config.NbTasks {
+			if nbPoints < 2 {
+				// no more points to split
+				break
+			}
 			nbSplits <<= 1
 			nbPoints >>= 1
 		}
@@ -267,7 +362,20 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 
 	// if we have more than 10% of small values, we split the processing of the first chunk in 2
 	// we may want to do that in msmInnerG1Jac , but that would incur a cost of looping through all scalars one more time
-	splitFirstChunk := (float64(smallValues) / float64(len(scalars))) >= 0.1
+	opt := msmOptions{nbChunks: nbWindows(C)}
+	opt.splitFirstChunk = (float64(smallValues) / float64(len(scalars))) >= 0.1
+
+	// batch affine buckets are worth it when there are enough of them to fill batches without conflicts
+	switch config.Buckets {
+	case ecc.BucketsAuto:
+		opt.batchAffine = C >= batchAffineMinC
+	case ecc.BucketsBatchAffine:
+		opt.batchAffine = true
+	case ecc.BucketsExtendedJacobian:
+		opt.batchAffine = false
+	default:
+		return nil, errors.New("invalid config: unknown config.Buckets")
+	}
 
 	// we have nbSplits intermediate results that we must sum together.
 	_p := make([]G1Jac, nbSplits-1)
@@ -276,12 +384,12 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 		start := i * nbPoints
 		end := start + nbPoints
 		go func(start, end, i int) {
-			msmInnerG1Jac(&_p[i], int(C), points[start:end], scalars[start:end], splitFirstChunk)
+			msmInnerG1Jac(&_p[i], int(C), points[start:end], scalars[start:end], opt)
 			chDone <- i
 		}(start, end, i)
 	}
 
-	msmInnerG1Jac(p, int(C), points[(nbSplits-1)*nbPoints:], scalars[(nbSplits-1)*nbPoints:], splitFirstChunk)
+	msmInnerG1Jac(p, int(C), points[(nbSplits-1)*nbPoints:], scalars[(nbSplits-1)*nbPoints:], opt)
 	for i := 0; i < nbSplits-1; i++ {
 		done := <-chDone
 		p.AddAssign(&_p[done])
@@ -290,54 +398,121 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 	return p, nil
 }
 
-func msmInnerG1Jac(p *G1Jac, c int, points []G1Affine, scalars []fr.Element, splitFirstChunk bool) {
+// msmBinary sets p = ∑ scalars[i] * points[i], for scalars in {0, 1}
+func (p *G1Jac) msmBinary(points []G1Affine, scalars []fr.Element, scalarsMont bool, nbTasks int) *G1Jac {
+	one := fr.Element{1}
+	if scalarsMont {
+		one = fr.One()
+	}
+
+	chSums := make(chan g1JacExtended, nbTasks)
+	parallel.Execute(len(points), func(start, end int) {
+		var sum g1JacExtended
+		sum.setInfinity()
+		for i := start; i < end; i++ {
+			if scalars[i] == one {
+				sum.addMixed(&points[i])
+			}
+		}
+		chSums <- sum
+	}, nbTasks)
+	close(chSums)
+
+	var total g1JacExtended
+	total.setInfinity()
+	for sum := range chSums {
+		total.add(&sum)
+	}
+	return p.unsafeFromJacExtended(&total)
+}
+
+// msmGLVG1Affine returns the points and scalars of the GLV decomposition of the multiExp:
+// with sᵢ = s1ᵢ + λ s2ᵢ (ecc.SplitScalar), ∑ sᵢ Pᵢ = ∑ |s1ᵢ| (±Pᵢ) + |s2ᵢ| (±ϕ(Pᵢ))
+//
+// The scalars are returned in regular form, the points ±Pᵢ and ±ϕ(Pᵢ) being interleaved.
+func msmGLVG1Affine(points []G1Affine, scalars []fr.Element, scalarsMont bool, nbTasks int) ([]G1Affine, []fr.Element) {
+	glvPoints := make([]G1Affine, 2*len(points))
+	glvScalars := make([]fr.Element, 2*len(scalars))
+
+	parallel.Execute(len(points), func(start, end int) {
+		var s big.Int
+		for i := start; i < end; i++ {
+			if scalarsMont {
+				scalars[i].ToBigIntRegular(&s)
+			} else {
+				scalars[i].ToBigInt(&s)
+			}
+			k := ecc.SplitScalar(&s, &glvBasis)
+
+			p, phiP := &glvPoints[2*i], &glvPoints[2*i+1]
+			p.Set(&points[i])
+			phiP.Y.Set(&points[i].Y)
+			phiP.X.Mul(&points[i].X, &thirdRootOneG1)
+
+			if k[0].Sign() == -1 {
+				k[0].Neg(&k[0])
+				p.Neg(p)
+			}
+			if k[1].Sign() == -1 {
+				k[1].Neg(&k[1])
+				phiP.Neg(phiP)
+			}
+			glvScalars[2*i].SetBigInt(&k[0]).FromMont()
+			glvScalars[2*i+1].SetBigInt(&k[1]).FromMont()
+		}
+	}, nbTasks)
+
+	return glvPoints, glvScalars
+}
+
+func msmInnerG1Jac(p *G1Jac, c int, points []G1Affine, scalars []fr.Element, opt msmOptions) {
 
 	switch c {
 
 	case 4:
-		p.msmC4(points, scalars, splitFirstChunk)
+		p.msmC4(points, scalars, opt)
 
 	case 5:
-		p.msmC5(points, scalars, splitFirstChunk)
+		p.msmC5(points, scalars, opt)
 
 	case 6:
-		p.msmC6(points, scalars, splitFirstChunk)
+		p.msmC6(points, scalars, opt)
 
 	case 7:
-		p.msmC7(points, scalars, splitFirstChunk)
+		p.msmC7(points, scalars, opt)
 
 	case 8:
-		p.msmC8(points, scalars, splitFirstChunk)
+		p.msmC8(points, scalars, opt)
 
 	case 9:
-		p.msmC9(points, scalars, splitFirstChunk)
+		p.msmC9(points, scalars, opt)
 
 	case 10:
-		p.msmC10(points, scalars, splitFirstChunk)
+		p.msmC10(points, scalars, opt)
 
 	case 11:
-		p.msmC11(points, scalars, splitFirstChunk)
+		p.msmC11(points, scalars, opt)
 
 	case 12:
-		p.msmC12(points, scalars, splitFirstChunk)
+		p.msmC12(points, scalars, opt)
 
 	case 13:
-		p.msmC13(points, scalars, splitFirstChunk)
+		p.msmC13(points, scalars, opt)
 
 	case 14:
-		p.msmC14(points, scalars, splitFirstChunk)
+		p.msmC14(points, scalars, opt)
 
 	case 15:
-		p.msmC15(points, scalars, splitFirstChunk)
+		p.msmC15(points, scalars, opt)
 
 	case 16:
-		p.msmC16(points, scalars, splitFirstChunk)
+		p.msmC16(points, scalars, opt)
 
 	case 20:
-		p.msmC20(points, scalars, splitFirstChunk)
+		p.msmC20(points, scalars, opt)
 
 	case 21:
-		p.msmC21(points, scalars, splitFirstChunk)
+		p.msmC21(points, scalars, opt)
 
 	default:
 		panic("not implemented")
@@ -424,7 +599,7 @@ func msmProcessChunkG1Affine(chunk uint64,
 
 }
 
-func (p *G1Jac) msmC4(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC4(points []G1Affine, scalars []fr.Element, opt msmOptions) *G1Jac {
 	const (
 		c        = 4                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -441,16 +616,32 @@ func (p *G1Jac) msmC4(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g1JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG1AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g1JacExtended
 		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g1JacExtended, 2)
@@ -469,7 +660,7 @@ func (p *G1Jac) msmC4(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC5(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC5(points []G1Affine, scalars []fr.Element, opt msmOptions) *G1Jac {
 	const (
 		c        = 5                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -486,23 +677,41 @@ func (p *G1Jac) msmC5(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g1JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G1Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG1AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g1JacExtended
 		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g1JacExtended, 2)
@@ -521,7 +730,7 @@ func (p *G1Jac) msmC5(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC6(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC6(points []G1Affine, scalars []fr.Element, opt msmOptions) *G1Jac {
 	const (
 		c        = 6                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -538,23 +747,41 @@ func (p *G1Jac) msmC6(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g1JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G1Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG1AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g1JacExtended
 		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g1JacExtended, 2)
@@ -573,7 +800,7 @@ func (p *G1Jac) msmC6(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC7(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC7(points []G1Affine, scalars []fr.Element, opt msmOptions) *G1Jac {
 	const (
 		c        = 7                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -590,23 +817,41 @@ func (p *G1Jac) msmC7(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g1JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G1Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG1AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g1JacExtended
 		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g1JacExtended, 2)
@@ -625,7 +870,7 @@ func (p *G1Jac) msmC7(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC8(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC8(points []G1Affine, scalars []fr.Element, opt msmOptions) *G1Jac {
 	const (
 		c        = 8                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -642,16 +887,32 @@ func (p *G1Jac) msmC8(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g1JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG1AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g1JacExtended
 		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g1JacExtended, 2)
@@ -670,7 +931,7 @@ func (p *G1Jac) msmC8(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC9(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC9(points []G1Affine, scalars []fr.Element, opt msmOptions) *G1Jac {
 	const (
 		c        = 9                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -687,23 +948,41 @@ func (p *G1Jac) msmC9(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g1JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G1Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG1AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g1JacExtended
 		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g1JacExtended, 2)
@@ -722,7 +1001,7 @@ func (p *G1Jac) msmC9(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC10(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC10(points []G1Affine, scalars []fr.Element, opt msmOptions) *G1Jac {
 	const (
 		c        = 10                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -739,23 +1018,41 @@ func (p *G1Jac) msmC10(points []G1Affine, scalars []fr.Element, splitFirstChunk
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g1JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G1Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG1AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g1JacExtended
 		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g1JacExtended, 2)
@@ -774,7 +1071,7 @@ func (p *G1Jac) msmC10(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC11(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC11(points []G1Affine, scalars []fr.Element, opt msmOptions) *G1Jac {
 	const (
 		c        = 11                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -791,23 +1088,41 @@ func (p *G1Jac) msmC11(points []G1Affine, scalars []fr.Element, splitFirstChunk
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g1JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G1Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG1AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g1JacExtended
 		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g1JacExtended, 2)
@@ -826,7 +1141,7 @@ func (p *G1Jac) msmC11(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC12(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC12(points []G1Affine, scalars []fr.Element, opt msmOptions) *G1Jac {
 	const (
 		c        = 12                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -843,23 +1158,41 @@ func (p *G1Jac) msmC12(points []G1Affine, scalars []fr.Element, splitFirstChunk
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g1JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G1Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG1AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g1JacExtended
 		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g1JacExtended, 2)
@@ -878,7 +1211,7 @@ func (p *G1Jac) msmC12(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC13(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC13(points []G1Affine, scalars []fr.Element, opt msmOptions) *G1Jac {
 	const (
 		c        = 13                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -895,23 +1228,41 @@ func (p *G1Jac) msmC13(points []G1Affine, scalars []fr.Element, splitFirstChunk
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g1JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G1Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG1AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g1JacExtended
 		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g1JacExtended, 2)
@@ -930,7 +1281,7 @@ func (p *G1Jac) msmC13(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC14(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC14(points []G1Affine, scalars []fr.Element, opt msmOptions) *G1Jac {
 	const (
 		c        = 14                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -947,23 +1298,41 @@ func (p *G1Jac) msmC14(points []G1Affine, scalars []fr.Element, splitFirstChunk
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g1JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G1Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG1AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g1JacExtended
 		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g1JacExtended, 2)
@@ -982,7 +1351,7 @@ func (p *G1Jac) msmC14(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC15(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC15(points []G1Affine, scalars []fr.Element, opt msmOptions) *G1Jac {
 	const (
 		c        = 15                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -999,23 +1368,41 @@ func (p *G1Jac) msmC15(points []G1Affine, scalars []fr.Element, splitFirstChunk
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g1JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G1Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG1AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g1JacExtended
 		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g1JacExtended, 2)
@@ -1034,7 +1421,7 @@ func (p *G1Jac) msmC15(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC16(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC16(points []G1Affine, scalars []fr.Element, opt msmOptions) *G1Jac {
 	const (
 		c        = 16                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1051,16 +1438,32 @@ func (p *G1Jac) msmC16(points []G1Affine, scalars []fr.Element, splitFirstChunk
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g1JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG1AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g1JacExtended
 		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g1JacExtended, 2)
@@ -1079,7 +1482,7 @@ func (p *G1Jac) msmC16(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC20(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC20(points []G1Affine, scalars []fr.Element, opt msmOptions) *G1Jac {
 	const (
 		c        = 20                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1096,23 +1499,41 @@ func (p *G1Jac) msmC20(points []G1Affine, scalars []fr.Element, splitFirstChunk
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g1JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G1Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG1AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g1JacExtended
 		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g1JacExtended, 2)
@@ -1131,7 +1552,7 @@ func (p *G1Jac) msmC20(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC21(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC21(points []G1Affine, scalars []fr.Element, opt msmOptions) *G1Jac {
 	const (
 		c        = 21                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1148,23 +1569,41 @@ func (p *G1Jac) msmC21(points []G1Affine, scalars []fr.Element, splitFirstChunk
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g1JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G1Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG1AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g1JacExtended
 		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g1JacExtended, 2)
@@ -1224,6 +1663,8 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 	// msmProcessChunk places points into buckets base on their selector and return the weighted bucket sum in given channel
 	// step 3
 	// reduce the buckets weigthed sums into our result (msmReduceChunk)
+	// for large c, the buckets can instead be in affine coordinates, the points being added by batches
+	// that share one inversion (msmProcessChunkG2AffineBatchAffine); see config.Buckets
 
 	// ensure len(points) == len(scalars)
 	nbPoints := len(points)
@@ -1238,9 +1679,36 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 		return nil, errors.New("invalid config: config.NbTasks > 1024")
 	}
 
+	// the bit length of the scalars bounds the number of windows with non-zero digits
+	scalarsBits := config.MaxScalarBits
+	if scalarsBits <= 0 || scalarsBits > fr.Bits {
+		scalarsBits = scalarsBitLen(scalars, config.ScalarsMont, config.NbTasks)
+	}
+	switch scalarsBits {
+	case 0:
+		// all the scalars are 0
+		p.X.SetOne()
+		p.Y.SetOne()
+		p.Z.SetZero()
+		return p, nil
+	case 1:
+		// 0/1 scalars: no buckets, we sum the points with a scalar 1
+		return p.msmBinary(points, scalars, config.ScalarsMont, config.NbTasks), nil
+	}
+
+	// number of bits of the digits: partitionScalars carries a digit >= 2^{c-1} to the next window,
+	// so the top window needs 2 bits above the scalars to absorb the carry
+	digitsBits := func(scalarsBits int) int {
+		if scalarsBits+2 < fr.Limbs*64 {
+			return scalarsBits + 2
+		}
+		return fr.Limbs * 64
+	}
+	nbBits := digitsBits(scalarsBits)
+
 	// here, we compute the best C for nbPoints
 	// we split recursively until nbChunks(c) >= nbTasks,
-	bestC := func(nbPoints int) uint64 {
+	bestC := func(nbPoints, nbBits int) (uint64, float64) {
 		// implemented msmC methods (the c we use must be in this slice)
 		implementedCs := []uint64{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 20, 21}
 		var C uint64
@@ -1250,7 +1718,7 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 		// for example, on a MBP 2016, for G2 MultiExp > 8M points, hand picking c gives better results
 		min := math.MaxFloat64
 		for _, c := range implementedCs {
-			cc := fr.Limbs * 64 * (nbPoints + (1 << (c)))
+			cc := nbBits * (nbPoints + (1 << (c)))
 			cost := float64(cc) / float64(c)
 			if cost < min {
 				min = cost
@@ -1261,20 +1729,40 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 		// if C > 16 && nbPoints < 1 << 23 {
 		// 	C = 16
 		// }
-		return C
+		return C, min
+	}
+
+	// GLV: with ϕ(P) = λP, sᵢ Pᵢ = s1ᵢ Pᵢ + s2ᵢ ϕ(Pᵢ), where s1ᵢ and s2ᵢ have about half the bits of r.
+	// The multiExp of the 2n points has as many additions in the buckets, but half as many windows to reduce:
+	// it's worth the decomposition of the scalars when the reduction of the buckets isn't negligible.
+	if config.EnableGLV && scalarsBits > glvScalarBits {
+		_, cost := bestC(nbPoints, nbBits)
+		_, costGLV := bestC(2*nbPoints, digitsBits(glvScalarBits))
+		if costGLV+glvSplitCostG2*float64(nbPoints) < cost {
+			points, scalars = msmGLVG2Affine(points, scalars, config.ScalarsMont, config.NbTasks)
+			nbPoints = len(points)
+			config.ScalarsMont = false
+			scalarsBits = scalarsBitLen(scalars, false, config.NbTasks)
+			nbBits = digitsBits(scalarsBits)
+		}
+	}
+
+	// number of c-bit windows with non-zero digits
+	nbWindows := func(c uint64) int {
+		return (nbBits + int(c) - 1) / int(c)
 	}
 
 	var C uint64
 	nbSplits := 1
 	nbChunks := 0
 	for nbChunks < config.NbTasks {
-		C = bestC(nbPoints)
-		nbChunks = int(fr.Limbs * 64 / C) // number of c-bit radixes in a scalar
-		if (fr.Limbs*64)%C != 0 {
-			nbChunks++
-		}
-		nbChunks *= nbSplits
+		C, _ = bestC(nbPoints, nbBits)
+		nbChunks = nbWindows(C) * nbSplits
 		if nbChunks < config.NbTasks {
+			if nbPoints < 2 {
+				// no more points to split
+				break
+			}
 			nbSplits <<= 1
 			nbPoints >>= 1
 		}
@@ -1288,7 +1776,20 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 
 	// if we have more than 10% of small values, we split the processing of the first chunk in 2
 	// we may want to do that in msmInnerG2Jac , but that would incur a cost of looping through all scalars one more time
-	splitFirstChunk := (float64(smallValues) / float64(len(scalars))) >= 0.1
+	opt := msmOptions{nbChunks: nbWindows(C)}
+	opt.splitFirstChunk = (float64(smallValues) / float64(len(scalars))) >= 0.1
+
+	// batch affine buckets are worth it when there are enough of them to fill batches without conflicts
+	switch config.Buckets {
+	case ecc.BucketsAuto:
+		opt.batchAffine = C >= batchAffineMinC
+	case ecc.BucketsBatchAffine:
+		opt.batchAffine = true
+	case ecc.BucketsExtendedJacobian:
+		opt.batchAffine = false
+	default:
+		return nil, errors.New("invalid config: unknown config.Buckets")
+	}
 
 	// we have nbSplits intermediate results that we must sum together.
 	_p := make([]G2Jac, nbSplits-1)
@@ -1297,12 +1798,12 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 		start := i * nbPoints
 		end := start + nbPoints
 		go func(start, end, i int) {
-			msmInnerG2Jac(&_p[i], int(C), points[start:end], scalars[start:end], splitFirstChunk)
+			msmInnerG2Jac(&_p[i], int(C), points[start:end], scalars[start:end], opt)
 			chDone <- i
 		}(start, end, i)
 	}
 
-	msmInnerG2Jac(p, int(C), points[(nbSplits-1)*nbPoints:], scalars[(nbSplits-1)*nbPoints:], splitFirstChunk)
+	msmInnerG2Jac(p, int(C), points[(nbSplits-1)*nbPoints:], scalars[(nbSplits-1)*nbPoints:], opt)
 	for i := 0; i < nbSplits-1; i++ {
 		done := <-chDone
 		p.AddAssign(&_p[done])
@@ -1311,54 +1812,121 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 	return p, nil
 }
 
-func msmInnerG2Jac(p *G2Jac, c int, points []G2Affine, scalars []fr.Element, splitFirstChunk bool) {
+// msmBinary sets p = ∑ scalars[i] * points[i], for scalars in {0, 1}
+func (p *G2Jac) msmBinary(points []G2Affine, scalars []fr.Element, scalarsMont bool, nbTasks int) *G2Jac {
+	one := fr.Element{1}
+	if scalarsMont {
+		one = fr.One()
+	}
+
+	chSums := make(chan g2JacExtended, nbTasks)
+	parallel.Execute(len(points), func(start, end int) {
+		var sum g2JacExtended
+		sum.setInfinity()
+		for i := start; i < end; i++ {
+			if scalars[i] == one {
+				sum.addMixed(&points[i])
+			}
+		}
+		chSums <- sum
+	}, nbTasks)
+	close(chSums)
+
+	var total g2JacExtended
+	total.setInfinity()
+	for sum := range chSums {
+		total.add(&sum)
+	}
+	return p.unsafeFromJacExtended(&total)
+}
+
+// msmGLVG2Affine returns the points and scalars of the GLV decomposition of the multiExp:
+// with sᵢ = s1ᵢ + λ s2ᵢ (ecc.SplitScalar), ∑ sᵢ Pᵢ = ∑ |s1ᵢ| (±Pᵢ) + |s2ᵢ| (±ϕ(Pᵢ))
+//
+// The scalars are returned in regular form, the points ±Pᵢ and ±ϕ(Pᵢ) being interleaved.
+func msmGLVG2Affine(points []G2Affine, scalars []fr.Element, scalarsMont bool, nbTasks int) ([]G2Affine, []fr.Element) {
+	glvPoints := make([]G2Affine, 2*len(points))
+	glvScalars := make([]fr.Element, 2*len(scalars))
+
+	parallel.Execute(len(points), func(start, end int) {
+		var s big.Int
+		for i := start; i < end; i++ {
+			if scalarsMont {
+				scalars[i].ToBigIntRegular(&s)
+			} else {
+				scalars[i].ToBigInt(&s)
+			}
+			k := ecc.SplitScalar(&s, &glvBasis)
+
+			p, phiP := &glvPoints[2*i], &glvPoints[2*i+1]
+			p.Set(&points[i])
+			phiP.Y.Set(&points[i].Y)
+			phiP.X.MulByElement(&points[i].X, &thirdRootOneG2)
+
+			if k[0].Sign() == -1 {
+				k[0].Neg(&k[0])
+				p.Neg(p)
+			}
+			if k[1].Sign() == -1 {
+				k[1].Neg(&k[1])
+				phiP.Neg(phiP)
+			}
+			glvScalars[2*i].SetBigInt(&k[0]).FromMont()
+			glvScalars[2*i+1].SetBigInt(&k[1]).FromMont()
+		}
+	}, nbTasks)
+
+	return glvPoints, glvScalars
+}
+
+func msmInnerG2Jac(p *G2Jac, c int, points []G2Affine, scalars []fr.Element, opt msmOptions) {
 
 	switch c {
 
 	case 4:
-		p.msmC4(points, scalars, splitFirstChunk)
+		p.msmC4(points, scalars, opt)
 
 	case 5:
-		p.msmC5(points, scalars, splitFirstChunk)
+		p.msmC5(points, scalars, opt)
 
 	case 6:
-		p.msmC6(points, scalars, splitFirstChunk)
+		p.msmC6(points, scalars, opt)
 
 	case 7:
-		p.msmC7(points, scalars, splitFirstChunk)
+		p.msmC7(points, scalars, opt)
 
 	case 8:
-		p.msmC8(points, scalars, splitFirstChunk)
+		p.msmC8(points, scalars, opt)
 
 	case 9:
-		p.msmC9(points, scalars, splitFirstChunk)
+		p.msmC9(points, scalars, opt)
 
 	case 10:
-		p.msmC10(points, scalars, splitFirstChunk)
+		p.msmC10(points, scalars, opt)
 
 	case 11:
-		p.msmC11(points, scalars, splitFirstChunk)
+		p.msmC11(points, scalars, opt)
 
 	case 12:
-		p.msmC12(points, scalars, splitFirstChunk)
+		p.msmC12(points, scalars, opt)
 
 	case 13:
-		p.msmC13(points, scalars, splitFirstChunk)
+		p.msmC13(points, scalars, opt)
 
 	case 14:
-		p.msmC14(points, scalars, splitFirstChunk)
+		p.msmC14(points, scalars, opt)
 
 	case 15:
-		p.msmC15(points, scalars, splitFirstChunk)
+		p.msmC15(points, scalars, opt)
 
 	case 16:
-		p.msmC16(points, scalars, splitFirstChunk)
+		p.msmC16(points, scalars, opt)
 
 	case 20:
-		p.msmC20(points, scalars, splitFirstChunk)
+		p.msmC20(points, scalars, opt)
 
 	case 21:
-		p.msmC21(points, scalars, splitFirstChunk)
+		p.msmC21(points, scalars, opt)
 
 	default:
 		panic("not implemented")
@@ -1445,7 +2013,7 @@ func msmProcessChunkG2Affine(chunk uint64,
 
 }
 
-func (p *G2Jac) msmC4(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC4(points []G2Affine, scalars []fr.Element, opt msmOptions) *G2Jac {
 	const (
 		c        = 4                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1462,16 +2030,32 @@ func (p *G2Jac) msmC4(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g2JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG2AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g2JacExtended
 		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g2JacExtended, 2)
@@ -1490,7 +2074,7 @@ func (p *G2Jac) msmC4(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC5(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC5(points []G2Affine, scalars []fr.Element, opt msmOptions) *G2Jac {
 	const (
 		c        = 5                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1507,23 +2091,41 @@ func (p *G2Jac) msmC5(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g2JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G2Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG2AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g2JacExtended
 		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g2JacExtended, 2)
@@ -1542,7 +2144,7 @@ func (p *G2Jac) msmC5(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC6(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC6(points []G2Affine, scalars []fr.Element, opt msmOptions) *G2Jac {
 	const (
 		c        = 6                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1559,23 +2161,41 @@ func (p *G2Jac) msmC6(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g2JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G2Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG2AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g2JacExtended
 		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g2JacExtended, 2)
@@ -1594,7 +2214,7 @@ func (p *G2Jac) msmC6(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC7(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC7(points []G2Affine, scalars []fr.Element, opt msmOptions) *G2Jac {
 	const (
 		c        = 7                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1611,23 +2231,41 @@ func (p *G2Jac) msmC7(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g2JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G2Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG2AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g2JacExtended
 		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g2JacExtended, 2)
@@ -1646,7 +2284,7 @@ func (p *G2Jac) msmC7(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC8(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC8(points []G2Affine, scalars []fr.Element, opt msmOptions) *G2Jac {
 	const (
 		c        = 8                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1663,16 +2301,32 @@ func (p *G2Jac) msmC8(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g2JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG2AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g2JacExtended
 		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g2JacExtended, 2)
@@ -1691,7 +2345,7 @@ func (p *G2Jac) msmC8(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC9(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC9(points []G2Affine, scalars []fr.Element, opt msmOptions) *G2Jac {
 	const (
 		c        = 9                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1708,23 +2362,41 @@ func (p *G2Jac) msmC9(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g2JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G2Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG2AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g2JacExtended
 		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g2JacExtended, 2)
@@ -1743,7 +2415,7 @@ func (p *G2Jac) msmC9(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC10(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC10(points []G2Affine, scalars []fr.Element, opt msmOptions) *G2Jac {
 	const (
 		c        = 10                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1760,23 +2432,41 @@ func (p *G2Jac) msmC10(points []G2Affine, scalars []fr.Element, splitFirstChunk
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g2JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G2Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG2AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g2JacExtended
 		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g2JacExtended, 2)
@@ -1795,7 +2485,7 @@ func (p *G2Jac) msmC10(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC11(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC11(points []G2Affine, scalars []fr.Element, opt msmOptions) *G2Jac {
 	const (
 		c        = 11                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1812,23 +2502,41 @@ func (p *G2Jac) msmC11(points []G2Affine, scalars []fr.Element, splitFirstChunk
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g2JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G2Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG2AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g2JacExtended
 		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g2JacExtended, 2)
@@ -1847,7 +2555,7 @@ func (p *G2Jac) msmC11(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC12(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC12(points []G2Affine, scalars []fr.Element, opt msmOptions) *G2Jac {
 	const (
 		c        = 12                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1864,23 +2572,41 @@ func (p *G2Jac) msmC12(points []G2Affine, scalars []fr.Element, splitFirstChunk
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g2JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G2Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG2AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g2JacExtended
 		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g2JacExtended, 2)
@@ -1899,7 +2625,7 @@ func (p *G2Jac) msmC12(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC13(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC13(points []G2Affine, scalars []fr.Element, opt msmOptions) *G2Jac {
 	const (
 		c        = 13                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1916,23 +2642,41 @@ func (p *G2Jac) msmC13(points []G2Affine, scalars []fr.Element, splitFirstChunk
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g2JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G2Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG2AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g2JacExtended
 		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g2JacExtended, 2)
@@ -1951,7 +2695,7 @@ func (p *G2Jac) msmC13(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC14(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC14(points []G2Affine, scalars []fr.Element, opt msmOptions) *G2Jac {
 	const (
 		c        = 14                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1968,23 +2712,41 @@ func (p *G2Jac) msmC14(points []G2Affine, scalars []fr.Element, splitFirstChunk
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g2JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G2Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG2AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g2JacExtended
 		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g2JacExtended, 2)
@@ -2003,7 +2765,7 @@ func (p *G2Jac) msmC14(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC15(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC15(points []G2Affine, scalars []fr.Element, opt msmOptions) *G2Jac {
 	const (
 		c        = 15                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -2020,23 +2782,41 @@ func (p *G2Jac) msmC15(points []G2Affine, scalars []fr.Element, splitFirstChunk
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g2JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G2Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG2AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g2JacExtended
 		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g2JacExtended, 2)
@@ -2055,7 +2835,7 @@ func (p *G2Jac) msmC15(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC16(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC16(points []G2Affine, scalars []fr.Element, opt msmOptions) *G2Jac {
 	const (
 		c        = 16                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -2072,16 +2852,32 @@ func (p *G2Jac) msmC16(points []G2Affine, scalars []fr.Element, splitFirstChunk
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g2JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG2AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g2JacExtended
 		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g2JacExtended, 2)
@@ -2100,7 +2896,7 @@ func (p *G2Jac) msmC16(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC20(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC20(points []G2Affine, scalars []fr.Element, opt msmOptions) *G2Jac {
 	const (
 		c        = 20                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -2117,23 +2913,41 @@ func (p *G2Jac) msmC20(points []G2Affine, scalars []fr.Element, splitFirstChunk
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g2JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G2Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG2AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g2JacExtended
 		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g2JacExtended, 2)
@@ -2152,7 +2966,7 @@ func (p *G2Jac) msmC20(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC21(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC21(points []G2Affine, scalars []fr.Element, opt msmOptions) *G2Jac {
 	const (
 		c        = 21                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -2169,23 +2983,41 @@ func (p *G2Jac) msmC21(points []G2Affine, scalars []fr.Element, splitFirstChunk
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
+	// the chunks from nbUsedChunks on only have zero digits: their sum is the infinity
+	nbUsedChunks := len(chChunks)
+	if opt.nbChunks > 0 && opt.nbChunks < nbUsedChunks {
+		nbUsedChunks = opt.nbChunks
+	}
+	for j := nbUsedChunks; j < len(chChunks); j++ {
+		var infinity g2JacExtended
+		chChunks[j] <- *infinity.setInfinity()
+	}
+
 	// c doesn't divide 256, last window is smaller we can allocate less buckets
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
+	if nbChunks < nbUsedChunks {
+		go func(j uint64, points []G2Affine, scalars []fr.Element) {
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		}(uint64(nbChunks), points, scalars)
+	}
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
+		if opt.batchAffine {
+			msmProcessChunkG2AffineBatchAffine(uint64(j), chChunk, c, points, scalars)
+			return
+		}
 		var buckets [1 << (c - 1)]g2JacExtended
 		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
-		go processChunk(j, points, scalars, chChunks[j])
+		if j < nbUsedChunks {
+			go processChunk(j, points, scalars, chChunks[j])
+		}
 	}
 
-	if !splitFirstChunk {
+	if !opt.splitFirstChunk {
 		go processChunk(0, points, scalars, chChunks[0])
 	} else {
 		chSplit := make(chan g2JacExtended, 2)
@@ -0,0 +1,360 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12378
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestMultiExpPrecomputedG1(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 2
+	} else {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	// size of the multiExps
+	const nbSamples = 73
+
+	// multi exp points
+	samplePoints := make([]G1Affine, nbSamples)
+	var g G1Jac
+	g.Set(&g1Gen)
+	for i := 1; i <= nbSamples; i++ {
+		samplePoints[i-1].FromJacobian(&g)
+		g.AddAssign(&g1Gen)
+	}
+	// an infinity point
+	samplePoints[nbSamples/2].X.SetZero()
+	samplePoints[nbSamples/2].Y.SetZero()
+
+	// the default window size, and all the valid ones (only 3 and 16 in short mode)
+	precomputed := []*G1PrecomputedPoints{NewG1PrecomputedPoints(samplePoints)}
+	for c := uint64(2); c <= precomputedMaxC; c++ {
+		if testing.Short() && c != 3 && c != 16 {
+			continue
+		}
+		if precomputedValidC(c) {
+			precomputed = append(precomputed, NewG1PrecomputedPoints(samplePoints, c))
+		}
+	}
+
+	properties.Property("[BLS12-378] MultiExpPrecomputed should be consistent with MultiExp", prop.ForAll(
+		func(mixer fr.Element) bool {
+			// mixer ensures that all the words of a fpElement are set
+			sampleScalars := make([]fr.Element, nbSamples)
+			for i := 1; i <= nbSamples; i++ {
+				sampleScalars[i-1].SetUint64(uint64(i)).
+					Mul(&sampleScalars[i-1], &mixer)
+			}
+			// zero and small scalars
+			sampleScalars[0].SetZero()
+			sampleScalars[1].SetUint64(1)
+
+			var expected, result G1Jac
+			expected.MultiExp(samplePoints, sampleScalars, ecc.MultiExpConfig{ScalarsMont: true})
+
+			for _, pre := range precomputed {
+				for _, nbTasks := range []int{1, 3, 0} {
+					if _, err := result.MultiExpPrecomputed(pre, sampleScalars, ecc.MultiExpConfig{ScalarsMont: true, NbTasks: nbTasks}); err != nil {
+						return false
+					}
+					if !result.Equal(&expected) {
+						return false
+					}
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// the number of scalars must match the number of points
+	var result G1Jac
+	if _, err := result.MultiExpPrecomputed(precomputed[0], make([]fr.Element, nbSamples-1), ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("MultiExpPrecomputed should fail if len(scalars) != len(points)")
+	}
+}
+
+func TestG1PrecomputedPointsSerialization(t *testing.T) {
+	t.Parallel()
+	samplePoints := make([]G1Affine, 10)
+	var g G1Jac
+	g.Set(&g1Gen)
+	for i := range samplePoints {
+		samplePoints[i].FromJacobian(&g)
+		g.AddAssign(&g1Gen)
+	}
+	pre := NewG1PrecomputedPoints(samplePoints, 11)
+
+	var buf bytes.Buffer
+	written, err := pre.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded G1PrecomputedPoints
+	read, err := decoded.ReadFrom(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read != written {
+		t.Fatal("the number of bytes read and written should match")
+	}
+	if decoded.C != pre.C || len(decoded.Points) != len(pre.Points) {
+		t.Fatal("decoded points don't match the original ones")
+	}
+	for k := range pre.Points {
+		for i := range pre.Points[k] {
+			if !decoded.Points[k][i].Equal(&pre.Points[k][i]) {
+				t.Fatal("decoded points don't match the original ones")
+			}
+		}
+	}
+
+	// a point of a window that isn't 2^{C*k} times the base point
+	tampered := &G1PrecomputedPoints{C: pre.C, Points: make([][]G1Affine, len(pre.Points))}
+	for k := range pre.Points {
+		tampered.Points[k] = append([]G1Affine(nil), pre.Points[k]...)
+	}
+	tampered.Points[2][3], tampered.Points[2][4] = tampered.Points[2][4], tampered.Points[2][3]
+	buf.Reset()
+	if _, err := tampered.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decoded.ReadFrom(&buf); err == nil {
+		t.Fatal("decoding precomputed points that don't match the base points should fail")
+	}
+
+	// the windows of other base points
+	tampered.Points[2][3], tampered.Points[2][4] = tampered.Points[2][4], tampered.Points[2][3]
+	tampered.Points[0][0] = samplePoints[1]
+	buf.Reset()
+	if _, err := tampered.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decoded.ReadFrom(&buf); err == nil {
+		t.Fatal("decoding precomputed points that don't match the base points should fail")
+	}
+}
+
+func BenchmarkMultiExpPrecomputedG1(b *testing.B) {
+	const nbSamples = 1 << 16
+
+	var (
+		samplePoints  [nbSamples]G1Affine
+		sampleScalars [nbSamples]fr.Element
+	)
+
+	fillBenchScalars(sampleScalars[:])
+	fillBenchBasesG1(samplePoints[:])
+
+	var testPoint G1Affine
+
+	for i := 8; i <= 16; i += 4 {
+		using := 1 << i
+		pre := NewG1PrecomputedPoints(samplePoints[:using])
+
+		b.Run(fmt.Sprintf("%d points", using), func(b *testing.B) {
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				testPoint.MultiExpPrecomputed(pre, sampleScalars[:using], ecc.MultiExpConfig{})
+			}
+		})
+	}
+}
+
+func TestMultiExpPrecomputedG2(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 2
+	} else {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	// size of the multiExps
+	const nbSamples = 73
+
+	// multi exp points
+	samplePoints := make([]G2Affine, nbSamples)
+	var g G2Jac
+	g.Set(&g2Gen)
+	for i := 1; i <= nbSamples; i++ {
+		samplePoints[i-1].FromJacobian(&g)
+		g.AddAssign(&g2Gen)
+	}
+	// an infinity point
+	samplePoints[nbSamples/2].X.SetZero()
+	samplePoints[nbSamples/2].Y.SetZero()
+
+	// the default window size, and all the valid ones (only 3 and 16 in short mode)
+	precomputed := []*G2PrecomputedPoints{NewG2PrecomputedPoints(samplePoints)}
+	for c := uint64(2); c <= precomputedMaxC; c++ {
+		if testing.Short() && c != 3 && c != 16 {
+			continue
+		}
+		if precomputedValidC(c) {
+			precomputed = append(precomputed, NewG2PrecomputedPoints(samplePoints, c))
+		}
+	}
+
+	properties.Property("[BLS12-378] MultiExpPrecomputed should be consistent with MultiExp", prop.ForAll(
+		func(mixer fr.Element) bool {
+			// mixer ensures that all the words of a fpElement are set
+			sampleScalars := make([]fr.Element, nbSamples)
+			for i := 1; i <= nbSamples; i++ {
+				sampleScalars[i-1].SetUint64(uint64(i)).
+					Mul(&sampleScalars[i-1], &mixer)
+			}
+			// zero and small scalars
+			sampleScalars[0].SetZero()
+			sampleScalars[1].SetUint64(1)
+
+			var expected, result G2Jac
+			expected.MultiExp(samplePoints, sampleScalars, ecc.MultiExpConfig{ScalarsMont: true})
+
+			for _, pre := range precomputed {
+				for _, nbTasks := range []int{1, 3, 0} {
+					if _, err := result.MultiExpPrecomputed(pre, sampleScalars, ecc.MultiExpConfig{ScalarsMont: true, NbTasks: nbTasks}); err != nil {
+						return false
+					}
+					if !result.Equal(&expected) {
+						return false
+					}
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// the number of scalars must match the number of points
+	var result G2Jac
+	if _, err := result.MultiExpPrecomputed(precomputed[0], make([]fr.Element, nbSamples-1), ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("MultiExpPrecomputed should fail if len(scalars) != len(points)")
+	}
+}
+
+func TestG2PrecomputedPointsSerialization(t *testing.T) {
+	t.Parallel()
+	samplePoints := make([]G2Affine, 10)
+	var g G2Jac
+	g.Set(&g2Gen)
+	for i := range samplePoints {
+		samplePoints[i].FromJacobian(&g)
+		g.AddAssign(&g2Gen)
+	}
+	pre := NewG2PrecomputedPoints(samplePoints, 11)
+
+	var buf bytes.Buffer
+	written, err := pre.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded G2PrecomputedPoints
+	read, err := decoded.ReadFrom(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read != written {
+		t.Fatal("the number of bytes read and written should match")
+	}
+	if decoded.C != pre.C || len(decoded.Points) != len(pre.Points) {
+		t.Fatal("decoded points don't match the original ones")
+	}
+	for k := range pre.Points {
+		for i := range pre.Points[k] {
+			if !decoded.Points[k][i].Equal(&pre.Points[k][i]) {
+				t.Fatal("decoded points don't match the original ones")
+			}
+		}
+	}
+
+	// a point of a window that isn't 2^{C*k} times the base point
+	tampered := &G2PrecomputedPoints{C: pre.C, Points: make([][]G2Affine, len(pre.Points))}
+	for k := range pre.Points {
+		tampered.Points[k] = append([]G2Affine(nil), pre.Points[k]...)
+	}
+	tampered.Points[2][3], tampered.Points[2][4] = tampered.Points[2][4], tampered.Points[2][3]
+	buf.Reset()
+	if _, err := tampered.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decoded.ReadFrom(&buf); err == nil {
+		t.Fatal("decoding precomputed points that don't match the base points should fail")
+	}
+
+	// the windows of other base points
+	tampered.Points[2][3], tampered.Points[2][4] = tampered.Points[2][4], tampered.Points[2][3]
+	tampered.Points[0][0] = samplePoints[1]
+	buf.Reset()
+	if _, err := tampered.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decoded.ReadFrom(&buf); err == nil {
+		t.Fatal("decoding precomputed points that don't match the base points should fail")
+	}
+}
+
+func BenchmarkMultiExpPrecomputedG2(b *testing.B) {
+	const nbSamples = 1 << 16
+
+	var (
+		samplePoints  [nbSamples]G2Affine
+		sampleScalars [nbSamples]fr.Element
+	)
+
+	fillBenchScalars(sampleScalars[:])
+	fillBenchBasesG2(samplePoints[:])
+
+	var testPoint G2Affine
+
+	for i := 8; i <= 16; i += 4 {
+		using := 1 << i
+		pre := NewG2PrecomputedPoints(samplePoints[:using])
+
+		b.Run(fmt.Sprintf("%d points", using), func(b *testing.B) {
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				testPoint.MultiExpPrecomputed(pre, sampleScalars[:using], ecc.MultiExpConfig{})
+			}
+		})
+	}
+}
@@ -0,0 +1,337 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12378
+
+import (
+	"errors"
+	"io"
+	"runtime"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// MultiExpReader computes ∑ scalars[i] * points[i], the points being decoded from r, which holds a []G1Affine
+// encoded by an Encoder (raw or compressed); opts are the options of the Decoder (see NoSubgroupChecks and
+// BatchSubgroupChecks, which checks each chunk at once)
+//
+// The points are read by chunks of nbPointsPerChunk points, and only the first len(scalars) ones are read:
+// r can hold a larger slice, for instance the points of a SRS.
+// The memory use is bounded by nbPointsPerChunk: a chunk of points and of digits is held at a time, with the
+// buckets of all the windows, which are kept from a chunk to the next and reduced once. The window size is
+// picked for nbPointsPerChunk points.
+// The buckets are in extended Jacobian coordinates, and the scalars aren't decomposed with GLV:
+// config.Buckets and config.EnableGLV are ignored.
+//
+// This call return an error if r holds less than len(scalars) points, if it can't decode them, or if provided
+// config is invalid.
+func (p *G1Jac) MultiExpReader(r io.Reader, scalars []fr.Element, nbPointsPerChunk int, config ecc.MultiExpConfig, opts ...func(*Decoder)) (*G1Jac, error) {
+	if nbPointsPerChunk <= 0 {
+		return nil, errors.New("invalid number of points per chunk")
+	}
+
+	// if nbTasks is not set, use all available CPUs
+	if config.NbTasks <= 0 {
+		config.NbTasks = runtime.NumCPU()
+	} else if config.NbTasks > 1024 {
+		return nil, errors.New("invalid config: config.NbTasks > 1024")
+	}
+
+	dec := NewDecoder(r, opts...)
+	sliceLen, err := dec.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	if int(sliceLen) < len(scalars) {
+		return nil, errors.New("len(points) < len(scalars)")
+	}
+
+	// the bit length of the scalars bounds the number of windows with non-zero digits
+	scalarsBits := config.MaxScalarBits
+	if scalarsBits <= 0 || scalarsBits > fr.Bits {
+		scalarsBits = scalarsBitLen(scalars, config.ScalarsMont, config.NbTasks)
+	}
+	if scalarsBits == 0 {
+		p.X.SetOne()
+		p.Y.SetOne()
+		p.Z.SetZero()
+		return p, nil
+	}
+
+	// number of bits of the digits, with 2 bits above the scalars to absorb the carry of partitionScalars
+	nbBits := fr.Limbs * 64
+	if scalarsBits+2 < nbBits {
+		nbBits = scalarsBits + 2
+	}
+	if nbPointsPerChunk > len(scalars) {
+		nbPointsPerChunk = len(scalars)
+	}
+	c := msmBucketsBestC(nbPointsPerChunk, nbBits, msmBucketsMaxC)
+	nbWindows := (nbBits + int(c) - 1) / int(c)
+
+	buckets := make([][]g1JacExtended, nbWindows)
+	for j := range buckets {
+		buckets[j] = make([]g1JacExtended, 1<<(c-1))
+		for k := range buckets[j] {
+			buckets[j][k].setInfinity()
+		}
+	}
+
+	points := make([]G1Affine, nbPointsPerChunk)
+	for start := 0; start < len(scalars); start += nbPointsPerChunk {
+		end := start + nbPointsPerChunk
+		if end > len(scalars) {
+			end = len(scalars)
+		}
+		points = points[:end-start]
+		if err := dec.decodePointsG1(points); err != nil {
+			return nil, err
+		}
+
+		digits, _ := partitionScalars(scalars[start:end], c, config.ScalarsMont, config.NbTasks)
+		parallel.Execute(nbWindows, func(wStart, wEnd int) {
+			for j := wStart; j < wEnd; j++ {
+				msmAccumulateG1Affine(uint64(j), c, points, digits, buckets[j])
+			}
+		}, config.NbTasks)
+	}
+
+	// reduce the buckets of each window, and the windows into p
+	totals := make([]g1JacExtended, nbWindows)
+	parallel.Execute(nbWindows, func(start, end int) {
+		var runningSum g1JacExtended
+		for j := start; j < end; j++ {
+			// total =  bucket[0] + 2*bucket[1] + 3*bucket[2] ... + n*bucket[n-1]
+			runningSum.setInfinity()
+			totals[j].setInfinity()
+			for k := len(buckets[j]) - 1; k >= 0; k-- {
+				if !buckets[j][k].ZZ.IsZero() {
+					runningSum.add(&buckets[j][k])
+				}
+				totals[j].add(&runningSum)
+			}
+		}
+	}, config.NbTasks)
+
+	var _p g1JacExtended
+	_p.Set(&totals[nbWindows-1])
+	for j := nbWindows - 2; j >= 0; j-- {
+		for l := uint64(0); l < c; l++ {
+			_p.double(&_p)
+		}
+		_p.add(&totals[j])
+	}
+
+	return p.unsafeFromJacExtended(&_p), nil
+}
+
+// msmAccumulateG1Affine adds the points to the buckets, according to the digits of the chunk
+//
+// Unlike msmProcessChunkG1Affine, the buckets aren't reset nor reduced: they accumulate the points of
+// several calls.
+func msmAccumulateG1Affine(chunk uint64, c uint64, points []G1Affine, digits []fr.Element, buckets []g1JacExtended) {
+	mask := uint64((1 << c) - 1) // low c bits are 1
+	msbWindow := uint64(1 << (c - 1))
+
+	jc := uint64(chunk * c)
+	s := selector{}
+	s.index = jc / 64
+	s.shift = jc - (s.index * 64)
+	s.mask = mask << s.shift
+	s.multiWordSelect = (64%c) != 0 && s.shift > (64-c) && s.index < (fr.Limbs-1)
+	if s.multiWordSelect {
+		nbBitsHigh := s.shift - uint64(64-c)
+		s.maskHigh = (1 << nbBitsHigh) - 1
+		s.shiftHigh = (c - nbBitsHigh)
+	}
+
+	for i := range points {
+		bits := (digits[i][s.index] & s.mask) >> s.shift
+		if s.multiWordSelect {
+			bits += (digits[i][s.index+1] & s.maskHigh) << s.shiftHigh
+		}
+
+		if bits == 0 {
+			continue
+		}
+
+		// if msbWindow bit is set, we need to substract
+		if bits&msbWindow == 0 {
+			// add
+			buckets[bits-1].addMixed(&points[i])
+		} else {
+			// sub
+			buckets[bits & ^msbWindow].subMixed(&points[i])
+		}
+	}
+}
+
+// MultiExpReader computes ∑ scalars[i] * points[i], the points being decoded from r, which holds a []G2Affine
+// encoded by an Encoder (raw or compressed); opts are the options of the Decoder (see NoSubgroupChecks and
+// BatchSubgroupChecks, which checks each chunk at once)
+//
+// The points are read by chunks of nbPointsPerChunk points, and only the first len(scalars) ones are read:
+// r can hold a larger slice, for instance the points of a SRS.
+// The memory use is bounded by nbPointsPerChunk: a chunk of points and of digits is held at a time, with the
+// buckets of all the windows, which are kept from a chunk to the next and reduced once. The window size is
+// picked for nbPointsPerChunk points.
+// The buckets are in extended Jacobian coordinates, and the scalars aren't decomposed with GLV:
+// config.Buckets and config.EnableGLV are ignored.
+//
+// This call return an error if r holds less than len(scalars) points, if it can't decode them, or if provided
+// config is invalid.
+func (p *G2Jac) MultiExpReader(r io.Reader, scalars []fr.Element, nbPointsPerChunk int, config ecc.MultiExpConfig, opts ...func(*Decoder)) (*G2Jac, error) {
+	if nbPointsPerChunk <= 0 {
+		return nil, errors.New("invalid number of points per chunk")
+	}
+
+	// if nbTasks is not set, use all available CPUs
+	if config.NbTasks <= 0 {
+		config.NbTasks = runtime.NumCPU()
+	} else if config.NbTasks > 1024 {
+		return nil, errors.New("invalid config: config.NbTasks > 1024")
+	}
+
+	dec := NewDecoder(r, opts...)
+	sliceLen, err := dec.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	if int(sliceLen) < len(scalars) {
+		return nil, errors.New("len(points) < len(scalars)")
+	}
+
+	// the bit length of the scalars bounds the number of windows with non-zero digits
+	scalarsBits := config.MaxScalarBits
+	if scalarsBits <= 0 || scalarsBits > fr.Bits {
+		scalarsBits = scalarsBitLen(scalars, config.ScalarsMont, config.NbTasks)
+	}
+	if scalarsBits == 0 {
+		p.X.SetOne()
+		p.Y.SetOne()
+		p.Z.SetZero()
+		return p, nil
+	}
+
+	// number of bits of the digits, with 2 bits above the scalars to absorb the carry of partitionScalars
+	nbBits := fr.Limbs * 64
+	if scalarsBits+2 < nbBits {
+		nbBits = scalarsBits + 2
+	}
+	if nbPointsPerChunk > len(scalars) {
+		nbPointsPerChunk = len(scalars)
+	}
+	c := msmBucketsBestC(nbPointsPerChunk, nbBits, msmBucketsMaxC)
+	nbWindows := (nbBits + int(c) - 1) / int(c)
+
+	buckets := make([][]g2JacExtended, nbWindows)
+	for j := range buckets {
+		buckets[j] = make([]g2JacExtended, 1<<(c-1))
+		for k := range buckets[j] {
+			buckets[j][k].setInfinity()
+		}
+	}
+
+	points := make([]G2Affine, nbPointsPerChunk)
+	for start := 0; start < len(scalars); start += nbPointsPerChunk {
+		end := start + nbPointsPerChunk
+		if end > len(scalars) {
+			end = len(scalars)
+		}
+		points = points[:end-start]
+		if err := dec.decodePointsG2(points); err != nil {
+			return nil, err
+		}
+
+		digits, _ := partitionScalars(scalars[start:end], c, config.ScalarsMont, config.NbTasks)
+		parallel.Execute(nbWindows, func(wStart, wEnd int) {
+			for j := wStart; j < wEnd; j++ {
+				msmAccumulateG2Affine(uint64(j), c, points, digits, buckets[j])
+			}
+		}, config.NbTasks)
+	}
+
+	// reduce the buckets of each window, and the windows into p
+	totals := make([]g2JacExtended, nbWindows)
+	parallel.Execute(nbWindows, func(start, end int) {
+		var runningSum g2JacExtended
+		for j := start; j < end; j++ {
+			// total =  bucket[0] + 2*bucket[1] + 3*bucket[2] ... + n*bucket[n-1]
+			runningSum.setInfinity()
+			totals[j].setInfinity()
+			for k := len(buckets[j]) - 1; k >= 0; k-- {
+				if !buckets[j][k].ZZ.IsZero() {
+					runningSum.add(&buckets[j][k])
+				}
+				totals[j].add(&runningSum)
+			}
+		}
+	}, config.NbTasks)
+
+	var _p g2JacExtended
+	_p.Set(&totals[nbWindows-1])
+	for j := nbWindows - 2; j >= 0; j-- {
+		for l := uint64(0); l < c; l++ {
+			_p.double(&_p)
+		}
+		_p.add(&totals[j])
+	}
+
+	return p.unsafeFromJacExtended(&_p), nil
+}
+
+// msmAccumulateG2Affine adds the points to the buckets, according to the digits of the chunk
+//
+// Unlike msmProcessChunkG2Affine, the buckets aren't reset nor reduced: they accumulate the points of
+// several calls.
+func msmAccumulateG2Affine(chunk uint64, c uint64, points []G2Affine, digits []fr.Element, buckets []g2JacExtended) {
+	mask := uint64((1 << c) - 1) // low c bits are 1
+	msbWindow := uint64(1 << (c - 1))
+
+	jc := uint64(chunk * c)
+	s := selector{}
+	s.index = jc / 64
+	s.shift = jc - (s.index * 64)
+	s.mask = mask << s.shift
+	s.multiWordSelect = (64%c) != 0 && s.shift > (64-c) && s.index < (fr.Limbs-1)
+	if s.multiWordSelect {
+		nbBitsHigh := s.shift - uint64(64-c)
+		s.maskHigh = (1 << nbBitsHigh) - 1
+		s.shiftHigh = (c - nbBitsHigh)
+	}
+
+	for i := range points {
+		bits := (digits[i][s.index] & s.mask) >> s.shift
+		if s.multiWordSelect {
+			bits += (digits[i][s.index+1] & s.maskHigh) << s.shiftHigh
+		}
+
+		if bits == 0 {
+			continue
+		}
+
+		// if msbWindow bit is set, we need to substract
+		if bits&msbWindow == 0 {
+			// add
+			buckets[bits-1].addMixed(&points[i])
+		} else {
+			// sub
+			buckets[bits & ^msbWindow].subMixed(&points[i])
+		}
+	}
+}
@@ -83,10 +83,10 @@ func TestMultiExpG1(t *testing.T) {
 			}
 
 			scalars16, _ := partitionScalars(sampleScalars[:], 16, false, runtime.NumCPU())
-			r16.msmC16(samplePoints[:], scalars16, true)
+			r16.msmC16(samplePoints[:], scalars16, msmOptions{splitFirstChunk: true})
 
 			splitted1.MultiExp(samplePointsLarge[:], sampleScalars[:], ecc.MultiExpConfig{NbTasks: 128})
-			splitted2.MultiExp(samplePointsLarge[:], sampleScalars[:], ecc.MultiExpConfig{NbTasks: 51})
+			splitted2.MultiExp(samplePointsLarge[:], sampleScalars[:], ecc.MultiExpConfig{NbTasks: 51, Buckets: ecc.BucketsBatchAffine})
 			return r16.Equal(&splitted1) && r16.Equal(&splitted2)
 		},
 		genScalar,
@@ -118,13 +118,21 @@ func TestMultiExpG1(t *testing.T) {
 					FromMont()
 			}
 
-			results := make([]G1Jac, len(cRange)+1)
-			for i, c := range cRange {
+			// with extended Jacobian buckets, and batch affine ones for c <= 16 (larger c allocate a lot of memory)
+			var results []G1Jac
+			for _, c := range cRange {
 				scalars, _ := partitionScalars(sampleScalars[:], c, false, runtime.NumCPU())
-				msmInnerG1Jac(&results[i], int(c), samplePoints[:], scalars, false)
+				var r G1Jac
+				msmInnerG1Jac(&r, int(c), samplePoints[:], scalars, msmOptions{})
+				results = append(results, r)
+				if c <= 16 {
+					msmInnerG1Jac(&r, int(c), samplePoints[:], scalars, msmOptions{batchAffine: true})
+					results = append(results, r)
+				}
 				if c == 16 {
 					// split the first chunk
-					msmInnerG1Jac(&results[len(results)-1], 16, samplePoints[:], scalars, true)
+					msmInnerG1Jac(&r, 16, samplePoints[:], scalars, msmOptions{splitFirstChunk: true, batchAffine: true})
+					results = append(results, r)
 				}
 			}
 			for i := 1; i < len(results); i++ {
@@ -137,6 +145,81 @@ func TestMultiExpG1(t *testing.T) {
 		genScalar,
 	))
 
+	properties.Property("[G1] Multi exponentation with small scalars should skip the empty windows", prop.ForAll(
+		func(mixer fr.Element) bool {
+			var sampleScalars [nbSamples]fr.Element
+			var expected, result G1Jac
+
+			// boolean, 8-bit, 31-bit, 64-bit and 0 scalars
+			for _, nbBits := range []int{1, 8, 31, 64, 0} {
+				for i := 0; i < nbSamples; i++ {
+					sampleScalars[i].SetZero()
+					if nbBits > 0 {
+						sampleScalars[i][0] = (mixer[i%fr.Limbs] >> (i % 7)) & (^uint64(0) >> (64 - nbBits))
+					}
+				}
+				// all ones: the carries propagate up to the top window
+				if nbBits > 0 {
+					sampleScalars[0][0] = ^uint64(0) >> (64 - nbBits)
+				}
+
+				// reference: all the windows, without the bit length of the scalars
+				scalars, _ := partitionScalars(sampleScalars[:], 16, false, runtime.NumCPU())
+				msmInnerG1Jac(&expected, 16, samplePoints[:], scalars, msmOptions{})
+
+				// bit length detected, and provided
+				result.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{})
+				if !result.Equal(&expected) {
+					return false
+				}
+				result.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{MaxScalarBits: nbBits})
+				if !result.Equal(&expected) {
+					return false
+				}
+
+				// in Montgomery form
+				for i := 0; i < nbSamples; i++ {
+					sampleScalars[i].ToMont()
+				}
+				result.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{ScalarsMont: true, EnableGLV: true})
+				if !result.Equal(&expected) {
+					return false
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.Property("[G1] Multi exponentation with the GLV decomposition should be consistent with the one without", prop.ForAll(
+		func(mixer fr.Element) bool {
+			var sampleScalars [nbSamples]fr.Element
+			for i := 1; i <= nbSamples; i++ {
+				sampleScalars[i-1].SetUint64(uint64(i)).
+					Mul(&sampleScalars[i-1], &mixer)
+			}
+			sampleScalars[0].SetOne().Neg(&sampleScalars[0])
+
+			// the decomposed scalars have half the bits of r
+			glvPoints, glvScalars := msmGLVG1Affine(samplePoints[:], sampleScalars[:], true, runtime.NumCPU())
+			for i := range glvScalars {
+				if glvScalars[i].BitLen() > glvScalarBits {
+					return false
+				}
+			}
+
+			var expected, glv, result G1Jac
+			expected.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{ScalarsMont: true})
+			glv.MultiExp(glvPoints, glvScalars, ecc.MultiExpConfig{})
+
+			// the estimated cost of a small multiExp is lower with GLV
+			result.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{ScalarsMont: true, EnableGLV: true})
+
+			return glv.Equal(&expected) && result.Equal(&expected)
+		},
+		genScalar,
+	))
+
 	// note : this test is here as we expect to have a different multiExp than the above bucket method
 	// for small number of points
 	properties.Property("[G1] Multi exponentation (<50points) should be consistent with sum of square", prop.ForAll(
@@ -328,10 +411,10 @@ func TestMultiExpG2(t *testing.T) {
 			}
 
 			scalars16, _ := partitionScalars(sampleScalars[:], 16, false, runtime.NumCPU())
-			r16.msmC16(samplePoints[:], scalars16, true)
+			r16.msmC16(samplePoints[:], scalars16, msmOptions{splitFirstChunk: true})
 
 			splitted1.MultiExp(samplePointsLarge[:], sampleScalars[:], ecc.MultiExpConfig{NbTasks: 128})
-			splitted2.MultiExp(samplePointsLarge[:], sampleScalars[:], ecc.MultiExpConfig{NbTasks: 51})
+			splitted2.MultiExp(samplePointsLarge[:], sampleScalars[:], ecc.MultiExpConfig{NbTasks: 51, Buckets: ecc.BucketsBatchAffine})
 			return r16.Equal(&splitted1) && r16.Equal(&splitted2)
 		},
 		genScalar,
@@ -361,13 +444,21 @@ func TestMultiExpG2(t *testing.T) {
 					FromMont()
 			}
 
-			results := make([]G2Jac, len(cRange)+1)
-			for i, c := range cRange {
+			// with extended Jacobian buckets, and batch affine ones for c <= 16 (larger c allocate a lot of memory)
+			var results []G2Jac
+			for _, c := range cRange {
 				scalars, _ := partitionScalars(sampleScalars[:], c, false, runtime.NumCPU())
-				msmInnerG2Jac(&results[i], int(c), samplePoints[:], scalars, false)
+				var r G2Jac
+				msmInnerG2Jac(&r, int(c), samplePoints[:], scalars, msmOptions{})
+				results = append(results, r)
+				if c <= 16 {
+					msmInnerG2Jac(&r, int(c), samplePoints[:], scalars, msmOptions{batchAffine: true})
+					results = append(results, r)
+				}
 				if c == 16 {
 					// split the first chunk
-					msmInnerG2Jac(&results[len(results)-1], 16, samplePoints[:], scalars, true)
+					msmInnerG2Jac(&r, 16, samplePoints[:], scalars, msmOptions{splitFirstChunk: true, batchAffine: true})
+					results = append(results, r)
 				}
 			}
 			for i := 1; i < len(results); i++ {
@@ -380,6 +471,81 @@ func TestMultiExpG2(t *testing.T) {
 		genScalar,
 	))
 
+	properties.Property("[G2] Multi exponentation with small scalars should skip the empty windows", prop.ForAll(
+		func(mixer fr.Element) bool {
+			var sampleScalars [nbSamples]fr.Element
+			var expected, result G2Jac
+
+			// boolean, 8-bit, 31-bit, 64-bit and 0 scalars
+			for _, nbBits := range []int{1, 8, 31, 64, 0} {
+				for i := 0; i < nbSamples; i++ {
+					sampleScalars[i].SetZero()
+					if nbBits > 0 {
+						sampleScalars[i][0] = (mixer[i%fr.Limbs] >> (i % 7)) & (^uint64(0) >> (64 - nbBits))
+					}
+				}
+				// all ones: the carries propagate up to the top window
+				if nbBits > 0 {
+					sampleScalars[0][0] = ^uint64(0) >> (64 - nbBits)
+				}
+
+				// reference: all the windows, without the bit length of the scalars
+				scalars, _ := partitionScalars(sampleScalars[:], 16, false, runtime.NumCPU())
+				msmInnerG2Jac(&expected, 16, samplePoints[:], scalars, msmOptions{})
+
+				// bit length detected, and provided
+				result.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{})
+				if !result.Equal(&expected) {
+					return false
+				}
+				result.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{MaxScalarBits: nbBits})
+				if !result.Equal(&expected) {
+					return false
+				}
+
+				// in Montgomery form
+				for i := 0; i < nbSamples; i++ {
+					sampleScalars[i].ToMont()
+				}
+				result.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{ScalarsMont: true, EnableGLV: true})
+				if !result.Equal(&expected) {
+					return false
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.Property("[G2] Multi exponentation with the GLV decomposition should be consistent with the one without", prop.ForAll(
+		func(mixer fr.Element) bool {
+			var sampleScalars [nbSamples]fr.Element
+			for i := 1; i <= nbSamples; i++ {
+				sampleScalars[i-1].SetUint64(uint64(i)).
+					Mul(&sampleScalars[i-1], &mixer)
+			}
+			sampleScalars[0].SetOne().Neg(&sampleScalars[0])
+
+			// the decomposed scalars have half the bits of r
+			glvPoints, glvScalars := msmGLVG2Affine(samplePoints[:], sampleScalars[:], true, runtime.NumCPU())
+			for i := range glvScalars {
+				if glvScalars[i].BitLen() > glvScalarBits {
+					return false
+				}
+			}
+
+			var expected, glv, result G2Jac
+			expected.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{ScalarsMont: true})
+			glv.MultiExp(glvPoints, glvScalars, ecc.MultiExpConfig{})
+
+			// the estimated cost of a small multiExp is lower with GLV
+			result.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{ScalarsMont: true, EnableGLV: true})
+
+			return glv.Equal(&expected) && result.Equal(&expected)
+		},
+		genScalar,
+	))
+
 	// note : this test is here as we expect to have a different multiExp than the above bucket method
 	// for small number of points
 	properties.Property("[G2] Multi exponentation (<50points) should be consistent with sum of square", prop.ForAll(
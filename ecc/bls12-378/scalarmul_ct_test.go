@@ -0,0 +1,276 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12378
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestScalarMultiplicationCTG1(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	properties.Property("[BLS12-378] ScalarMultiplicationCT should be consistent with ScalarMultiplication", prop.ForAll(
+		func(s, m fr.Element) bool {
+			var a, expected, result G1Jac
+			var b big.Int
+			a.ScalarMultiplication(&g1Gen, m.ToBigIntRegular(&b))
+			expected.ScalarMultiplication(&a, s.ToBigIntRegular(&b))
+			if !result.ScalarMultiplicationCT(&a, &s).Equal(&expected) {
+				return false
+			}
+
+			var aAff, expectedAff, resultAff G1Affine
+			aAff.FromJacobian(&a)
+			expectedAff.FromJacobian(&expected)
+			return resultAff.ScalarMultiplicationCT(&aAff, &s).Equal(&expectedAff)
+		},
+		genScalar,
+		genScalar,
+	))
+
+	properties.Property("[BLS12-378] the complete formulas should be consistent with the Jacobian ones", prop.ForAll(
+		func(m1, m2 fr.Element) bool {
+			var a, b, inf, expected, result G1Jac
+			var bi big.Int
+			a.ScalarMultiplication(&g1Gen, m1.ToBigIntRegular(&bi))
+			b.ScalarMultiplication(&g1Gen, m2.ToBigIntRegular(&bi))
+			inf.Z.SetZero()
+
+			var pa, pb, pNegA, pInf, p g1Proj
+			pa.fromJacobian(&a)
+			pb.fromJacobian(&b)
+			pNegA.Neg(&pa)
+			pInf.fromJacobian(&inf)
+
+			// a + b
+			expected.Set(&a).AddAssign(&b)
+			if !result.fromProj(p.addComplete(&pa, &pb)).Equal(&expected) {
+				return false
+			}
+			// a + a, 2a
+			expected.Double(&a)
+			if !result.fromProj(p.addComplete(&pa, &pa)).Equal(&expected) {
+				return false
+			}
+			if !result.fromProj(p.doubleComplete(&pa)).Equal(&expected) {
+				return false
+			}
+			// a - a, a + ∞, ∞ + a, 2∞
+			if !p.addComplete(&pa, &pNegA).z.IsZero() {
+				return false
+			}
+			if !result.fromProj(p.addComplete(&pa, &pInf)).Equal(&a) {
+				return false
+			}
+			if !result.fromProj(p.addComplete(&pInf, &pa)).Equal(&a) {
+				return false
+			}
+			return p.doubleComplete(&pInf).z.IsZero()
+		},
+		genScalar,
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// edge cases: 0, 1, r - 1, and the infinity
+	var minusOne fr.Element
+	minusOne.SetOne().Neg(&minusOne)
+	one := fr.One()
+	var inf, minusGen, p G1Jac
+	inf.Z.SetZero()
+	minusGen.Neg(&g1Gen)
+	if !p.ScalarMultiplicationCT(&g1Gen, &fr.Element{}).Equal(&inf) {
+		t.Fatal("0 * a should be infinity")
+	}
+	if !p.ScalarMultiplicationCT(&g1Gen, &one).Equal(&g1Gen) {
+		t.Fatal("1 * a should be a")
+	}
+	if !p.ScalarMultiplicationCT(&g1Gen, &minusOne).Equal(&minusGen) {
+		t.Fatal("(r - 1) * a should be -a")
+	}
+	if !p.ScalarMultiplicationCT(&inf, &minusOne).Equal(&inf) {
+		t.Fatal("s * infinity should be infinity")
+	}
+
+	var infAff, pAff G1Affine
+	if !pAff.ScalarMultiplicationCT(&g1GenAff, &fr.Element{}).IsInfinity() {
+		t.Fatal("0 * a should be infinity")
+	}
+	if !pAff.ScalarMultiplicationCT(&infAff, &one).IsInfinity() {
+		t.Fatal("s * infinity should be infinity")
+	}
+}
+
+func BenchmarkScalarMultiplicationCTG1(b *testing.B) {
+	var s fr.Element
+	s.SetRandom()
+	var p G1Jac
+	b.Run("ScalarMultiplication", func(b *testing.B) {
+		var bs big.Int
+		s.ToBigIntRegular(&bs)
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			p.ScalarMultiplication(&g1Gen, &bs)
+		}
+	})
+	b.Run("ScalarMultiplicationCT", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			p.ScalarMultiplicationCT(&g1Gen, &s)
+		}
+	})
+}
+
+func TestScalarMultiplicationCTG2(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	properties.Property("[BLS12-378] ScalarMultiplicationCT should be consistent with ScalarMultiplication", prop.ForAll(
+		func(s, m fr.Element) bool {
+			var a, expected, result G2Jac
+			var b big.Int
+			a.ScalarMultiplication(&g2Gen, m.ToBigIntRegular(&b))
+			expected.ScalarMultiplication(&a, s.ToBigIntRegular(&b))
+			if !result.ScalarMultiplicationCT(&a, &s).Equal(&expected) {
+				return false
+			}
+
+			var aAff, expectedAff, resultAff G2Affine
+			aAff.FromJacobian(&a)
+			expectedAff.FromJacobian(&expected)
+			return resultAff.ScalarMultiplicationCT(&aAff, &s).Equal(&expectedAff)
+		},
+		genScalar,
+		genScalar,
+	))
+
+	properties.Property("[BLS12-378] the complete formulas should be consistent with the Jacobian ones", prop.ForAll(
+		func(m1, m2 fr.Element) bool {
+			var a, b, inf, expected, result G2Jac
+			var bi big.Int
+			a.ScalarMultiplication(&g2Gen, m1.ToBigIntRegular(&bi))
+			b.ScalarMultiplication(&g2Gen, m2.ToBigIntRegular(&bi))
+			inf.Z.SetZero()
+
+			var pa, pb, pNegA, pInf, p g2Proj
+			pa.fromJacobian(&a)
+			pb.fromJacobian(&b)
+			pNegA.Neg(&pa)
+			pInf.fromJacobian(&inf)
+
+			// a + b
+			expected.Set(&a).AddAssign(&b)
+			if !result.fromProj(p.addComplete(&pa, &pb)).Equal(&expected) {
+				return false
+			}
+			// a + a, 2a
+			expected.Double(&a)
+			if !result.fromProj(p.addComplete(&pa, &pa)).Equal(&expected) {
+				return false
+			}
+			if !result.fromProj(p.doubleComplete(&pa)).Equal(&expected) {
+				return false
+			}
+			// a - a, a + ∞, ∞ + a, 2∞
+			if !p.addComplete(&pa, &pNegA).z.IsZero() {
+				return false
+			}
+			if !result.fromProj(p.addComplete(&pa, &pInf)).Equal(&a) {
+				return false
+			}
+			if !result.fromProj(p.addComplete(&pInf, &pa)).Equal(&a) {
+				return false
+			}
+			return p.doubleComplete(&pInf).z.IsZero()
+		},
+		genScalar,
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// edge cases: 0, 1, r - 1, and the infinity
+	var minusOne fr.Element
+	minusOne.SetOne().Neg(&minusOne)
+	one := fr.One()
+	var inf, minusGen, p G2Jac
+	inf.Z.SetZero()
+	minusGen.Neg(&g2Gen)
+	if !p.ScalarMultiplicationCT(&g2Gen, &fr.Element{}).Equal(&inf) {
+		t.Fatal("0 * a should be infinity")
+	}
+	if !p.ScalarMultiplicationCT(&g2Gen, &one).Equal(&g2Gen) {
+		t.Fatal("1 * a should be a")
+	}
+	if !p.ScalarMultiplicationCT(&g2Gen, &minusOne).Equal(&minusGen) {
+		t.Fatal("(r - 1) * a should be -a")
+	}
+	if !p.ScalarMultiplicationCT(&inf, &minusOne).Equal(&inf) {
+		t.Fatal("s * infinity should be infinity")
+	}
+
+	var infAff, pAff G2Affine
+	if !pAff.ScalarMultiplicationCT(&g2GenAff, &fr.Element{}).IsInfinity() {
+		t.Fatal("0 * a should be infinity")
+	}
+	if !pAff.ScalarMultiplicationCT(&infAff, &one).IsInfinity() {
+		t.Fatal("s * infinity should be infinity")
+	}
+}
+
+func BenchmarkScalarMultiplicationCTG2(b *testing.B) {
+	var s fr.Element
+	s.SetRandom()
+	var p G2Jac
+	b.Run("ScalarMultiplication", func(b *testing.B) {
+		var bs big.Int
+		s.ToBigIntRegular(&bs)
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			p.ScalarMultiplication(&g2Gen, &bs)
+		}
+	})
+	b.Run("ScalarMultiplicationCT", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			p.ScalarMultiplicationCT(&g2Gen, &s)
+		}
+	})
+}
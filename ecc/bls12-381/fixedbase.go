@@ -0,0 +1,468 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12381
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// fixedBaseDefaultWindow is the default window size of the fixed-base tables
+const fixedBaseDefaultWindow = 8
+
+// fixedBaseMaxWindow bounds the window size of the fixed-base tables (2^(w-1) points per window)
+const fixedBaseMaxWindow = 16
+
+// frModulusWords are the words of r, the order of fr
+var frModulusWords = func() [fr.Limbs]uint64 {
+	// r - 1 is even
+	var m fr.Element
+	m.SetOne().Neg(&m)
+	m = m.ToRegular()
+	m[0]++
+	return [fr.Limbs]uint64(m)
+}()
+
+// fixedBaseNbWindows returns the number of windows of the fixed-base tables of window size w
+func fixedBaseNbWindows(w uint64) int {
+	// the recoded scalar (< 2r) has at most fr.Bits + 1 bits, and its last digit is 1 (see fixedBaseDigits)
+	return int((fr.Bits+w)/w) + 1
+}
+
+// fixedBaseMaxNbWindows is the number of windows of the fixed-base tables of window size 1, the largest one
+const fixedBaseMaxNbWindows = fr.Bits + 2
+
+// fixedBaseDigits recodes s in base 2^w with odd signed digits, in [-2^w + 1, 2^w - 1]:
+// k = ∑ digits[i] 2^(w*i), with k = s if s is odd, and k = s + r otherwise (so that k is odd)
+//
+// It doesn't branch on s: with k odd, k = d + 2^w k' where d = (k mod 2^(w+1)) - 2^w and
+// k' = (k >> w) | 1 is odd.
+func fixedBaseDigits(digits []int, s *fr.Element, w uint64) {
+	var k [fr.Limbs + 1]uint64
+
+	// s out of the Montgomery form, s * 1 * R⁻¹, with the branch-free multiplication
+	var sr fr.Element
+	sr.MulCT(s, &fr.Element{1})
+
+	mask := (sr[0] & 1) - 1 // all ones iff s is even
+	var carry uint64
+	for i := 0; i < fr.Limbs; i++ {
+		k[i], carry = bits.Add64(sr[i], frModulusWords[i]&mask, carry)
+	}
+	k[fr.Limbs] = carry
+
+	wMask := uint64(1)<<(w+1) - 1
+	for i := 0; i < len(digits)-1; i++ {
+		digits[i] = int(k[0]&wMask) - (1 << w)
+		// k = (k >> w) | 1
+		for j := 0; j < fr.Limbs; j++ {
+			k[j] = k[j]>>w | k[j+1]<<(64-w)
+		}
+		k[fr.Limbs] >>= w
+		k[0] |= 1
+	}
+	digits[len(digits)-1] = int(k[0])
+}
+
+// G1FixedBaseTable is a table of precomputed multiples of a fixed base of G1, for scalar multiplications
+// without doublings
+//
+// Table[i][j] = (2j + 1) * 2^(W*i) * Base, for j < 2^(W-1); the last window only holds 2^(W*i) * Base.
+// A scalar is recoded with odd signed digits (see fixedBaseDigits), and its multiple of Base is
+// the sum of one (possibly negated) point of each window.
+//
+// The table is not modified after its creation: it can be used concurrently.
+type G1FixedBaseTable struct {
+	Base  G1Affine
+	W     uint64
+	Table [][]G1Affine
+}
+
+// NewG1FixedBaseTable returns the table of multiples of base, with windows of w[0] bits if set,
+// fixedBaseDefaultWindow bits otherwise
+func NewG1FixedBaseTable(base *G1Affine, w ...uint64) *G1FixedBaseTable {
+	t := &G1FixedBaseTable{W: fixedBaseDefaultWindow}
+	t.Base.Set(base)
+	if len(w) > 0 {
+		t.W = w[0]
+	}
+	if t.W < 1 || t.W > fixedBaseMaxWindow {
+		panic("invalid window size")
+	}
+	t.precompute()
+	return t
+}
+
+func (t *G1FixedBaseTable) precompute() {
+	nbWindows := fixedBaseNbWindows(t.W)
+	t.Table = make([][]G1Affine, nbWindows)
+
+	// bases[i] = 2^(W*i) * Base
+	bases := make([]G1Jac, nbWindows)
+	bases[0].FromAffine(&t.Base)
+	for i := 1; i < nbWindows; i++ {
+		bases[i].Set(&bases[i-1])
+		for j := uint64(0); j < t.W; j++ {
+			bases[i].DoubleAssign()
+		}
+	}
+	t.Table[nbWindows-1] = make([]G1Affine, 1)
+	t.Table[nbWindows-1][0].FromJacobian(&bases[nbWindows-1])
+
+	parallel.Execute(nbWindows-1, func(start, end int) {
+		window := make([]G1Jac, 1<<(t.W-1))
+		var double G1Jac
+		for i := start; i < end; i++ {
+			// odd multiples of bases[i]
+			double.Double(&bases[i])
+			window[0].Set(&bases[i])
+			for j := 1; j < len(window); j++ {
+				window[j].Set(&window[j-1]).AddAssign(&double)
+			}
+			t.Table[i] = BatchJacobianToAffineG1(window)
+		}
+	})
+}
+
+// Mul sets p = s * Base and returns p
+//
+// It runs in variable time: use MulCT if s is secret.
+func (t *G1FixedBaseTable) Mul(p *G1Jac, s *fr.Element) *G1Jac {
+	var digits [fixedBaseMaxNbWindows]int
+	fixedBaseDigits(digits[:len(t.Table)], s, t.W)
+
+	var res G1Jac
+	var q G1Affine
+	res.FromAffine(&t.Table[len(t.Table)-1][0])
+	for i := 0; i < len(t.Table)-1; i++ {
+		if d := digits[i]; d > 0 {
+			res.AddMixed(&t.Table[i][d>>1])
+		} else {
+			q.Neg(&t.Table[i][(-d)>>1])
+			res.AddMixed(&q)
+		}
+	}
+	return p.Set(&res)
+}
+
+// MulCT sets p = s * Base and returns p
+//
+// The sequence of operations and the memory accesses don't depend on s: the recoding is branch-free,
+// every digit is non-zero, and each window is scanned entirely to select its point. The mixed addition
+// still branches on its exceptional cases (equal or opposite points), which happen with a negligible
+// probability for a scalar that isn't chosen to that end.
+func (t *G1FixedBaseTable) MulCT(p *G1Jac, s *fr.Element) *G1Jac {
+	var digits [fixedBaseMaxNbWindows]int
+	fixedBaseDigits(digits[:len(t.Table)], s, t.W)
+
+	var res G1Jac
+	var q, negQ G1Affine
+	res.FromAffine(&t.Table[len(t.Table)-1][0])
+	for i := 0; i < len(t.Table)-1; i++ {
+		d := digits[i]
+		// sign = 1 iff d < 0, idx = |d| >> 1
+		sign := int(uint(d) >> (bits.UintSize - 1))
+		idx := int32(((d ^ -sign) + sign) >> 1)
+		for j := range t.Table[i] {
+			eq := subtle.ConstantTimeEq(int32(j), idx)
+			q.X.Select(eq, &q.X, &t.Table[i][j].X)
+			q.Y.Select(eq, &q.Y, &t.Table[i][j].Y)
+		}
+		negQ.Neg(&q)
+		q.Y.Select(sign, &q.Y, &negQ.Y)
+		res.AddMixed(&q)
+	}
+	return p.Set(&res)
+}
+
+// MulMany returns the affine points scalars[i] * Base
+//
+// It runs in variable time, in parallel.
+func (t *G1FixedBaseTable) MulMany(scalars []fr.Element) []G1Affine {
+	res := make([]G1Jac, len(scalars))
+	parallel.Execute(len(scalars), func(start, end int) {
+		for i := start; i < end; i++ {
+			t.Mul(&res[i], &scalars[i])
+		}
+	})
+	return BatchJacobianToAffineG1(res)
+}
+
+// WriteTo writes the binary encoding of the table (Base, W and the points of Table) to w
+func (t *G1FixedBaseTable) WriteTo(w io.Writer) (int64, error) {
+	enc := NewEncoder(w)
+
+	toEncode := []interface{}{
+		&t.Base,
+		t.W,
+	}
+	for i := range t.Table {
+		toEncode = append(toEncode, t.Table[i])
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes a table written by WriteTo from r
+func (t *G1FixedBaseTable) ReadFrom(r io.Reader) (int64, error) {
+	dec := NewDecoder(r)
+
+	if err := dec.Decode(&t.Base); err != nil {
+		return dec.BytesRead(), err
+	}
+	if err := dec.Decode(&t.W); err != nil {
+		return dec.BytesRead(), err
+	}
+	if t.W < 1 || t.W > fixedBaseMaxWindow {
+		return dec.BytesRead(), errors.New("invalid fixed-base table window size")
+	}
+
+	t.Table = make([][]G1Affine, fixedBaseNbWindows(t.W))
+	for i := range t.Table {
+		if err := dec.Decode(&t.Table[i]); err != nil {
+			return dec.BytesRead(), err
+		}
+		expected := 1 << (t.W - 1)
+		if i == len(t.Table)-1 {
+			expected = 1
+		}
+		if len(t.Table[i]) != expected {
+			return dec.BytesRead(), errors.New("invalid fixed-base table window length")
+		}
+	}
+
+	// the first point of the window i is 2^(W*i) * Base: the windows match Base and W
+	var expected, first G1Jac
+	expected.FromAffine(&t.Base)
+	for i := range t.Table {
+		if i != 0 {
+			for j := uint64(0); j < t.W; j++ {
+				expected.DoubleAssign()
+			}
+		}
+		if !first.FromAffine(&t.Table[i][0]).Equal(&expected) {
+			return dec.BytesRead(), errors.New("invalid fixed-base table: the windows don't match Base and W")
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
+
+// G2FixedBaseTable is a table of precomputed multiples of a fixed base of G2, for scalar multiplications
+// without doublings
+//
+// Table[i][j] = (2j + 1) * 2^(W*i) * Base, for j < 2^(W-1); the last window only holds 2^(W*i) * Base.
+// A scalar is recoded with odd signed digits (see fixedBaseDigits), and its multiple of Base is
+// the sum of one (possibly negated) point of each window.
+//
+// The table is not modified after its creation: it can be used concurrently.
+type G2FixedBaseTable struct {
+	Base  G2Affine
+	W     uint64
+	Table [][]G2Affine
+}
+
+// NewG2FixedBaseTable returns the table of multiples of base, with windows of w[0] bits if set,
+// fixedBaseDefaultWindow bits otherwise
+func NewG2FixedBaseTable(base *G2Affine, w ...uint64) *G2FixedBaseTable {
+	t := &G2FixedBaseTable{W: fixedBaseDefaultWindow}
+	t.Base.Set(base)
+	if len(w) > 0 {
+		t.W = w[0]
+	}
+	if t.W < 1 || t.W > fixedBaseMaxWindow {
+		panic("invalid window size")
+	}
+	t.precompute()
+	return t
+}
+
+func (t *G2FixedBaseTable) precompute() {
+	nbWindows := fixedBaseNbWindows(t.W)
+	t.Table = make([][]G2Affine, nbWindows)
+
+	// bases[i] = 2^(W*i) * Base
+	bases := make([]G2Jac, nbWindows)
+	bases[0].FromAffine(&t.Base)
+	for i := 1; i < nbWindows; i++ {
+		bases[i].Set(&bases[i-1])
+		for j := uint64(0); j < t.W; j++ {
+			bases[i].DoubleAssign()
+		}
+	}
+	t.Table[nbWindows-1] = make([]G2Affine, 1)
+	t.Table[nbWindows-1][0].FromJacobian(&bases[nbWindows-1])
+
+	parallel.Execute(nbWindows-1, func(start, end int) {
+		window := make([]G2Jac, 1<<(t.W-1))
+		var double G2Jac
+		for i := start; i < end; i++ {
+			// odd multiples of bases[i]
+			double.Double(&bases[i])
+			window[0].Set(&bases[i])
+			for j := 1; j < len(window); j++ {
+				window[j].Set(&window[j-1]).AddAssign(&double)
+			}
+			t.Table[i] = make([]G2Affine, len(window))
+			for j := range window {
+				t.Table[i][j].FromJacobian(&window[j])
+			}
+		}
+	})
+}
+
+// Mul sets p = s * Base and returns p
+//
+// It runs in variable time: use MulCT if s is secret.
+func (t *G2FixedBaseTable) Mul(p *G2Jac, s *fr.Element) *G2Jac {
+	var digits [fixedBaseMaxNbWindows]int
+	fixedBaseDigits(digits[:len(t.Table)], s, t.W)
+
+	var res G2Jac
+	var q G2Affine
+	res.FromAffine(&t.Table[len(t.Table)-1][0])
+	for i := 0; i < len(t.Table)-1; i++ {
+		if d := digits[i]; d > 0 {
+			res.AddMixed(&t.Table[i][d>>1])
+		} else {
+			q.Neg(&t.Table[i][(-d)>>1])
+			res.AddMixed(&q)
+		}
+	}
+	return p.Set(&res)
+}
+
+// MulCT sets p = s * Base and returns p
+//
+// The sequence of operations and the memory accesses don't depend on s: the recoding is branch-free,
+// every digit is non-zero, and each window is scanned entirely to select its point. The mixed addition
+// still branches on its exceptional cases (equal or opposite points), which happen with a negligible
+// probability for a scalar that isn't chosen to that end.
+func (t *G2FixedBaseTable) MulCT(p *G2Jac, s *fr.Element) *G2Jac {
+	var digits [fixedBaseMaxNbWindows]int
+	fixedBaseDigits(digits[:len(t.Table)], s, t.W)
+
+	var res G2Jac
+	var q, negQ G2Affine
+	res.FromAffine(&t.Table[len(t.Table)-1][0])
+	for i := 0; i < len(t.Table)-1; i++ {
+		d := digits[i]
+		// sign = 1 iff d < 0, idx = |d| >> 1
+		sign := int(uint(d) >> (bits.UintSize - 1))
+		idx := int32(((d ^ -sign) + sign) >> 1)
+		for j := range t.Table[i] {
+			eq := subtle.ConstantTimeEq(int32(j), idx)
+			q.X.Select(eq, &q.X, &t.Table[i][j].X)
+			q.Y.Select(eq, &q.Y, &t.Table[i][j].Y)
+		}
+		negQ.Neg(&q)
+		q.Y.Select(sign, &q.Y, &negQ.Y)
+		res.AddMixed(&q)
+	}
+	return p.Set(&res)
+}
+
+// MulMany returns the affine points scalars[i] * Base
+//
+// It runs in variable time, in parallel.
+func (t *G2FixedBaseTable) MulMany(scalars []fr.Element) []G2Affine {
+	res := make([]G2Affine, len(scalars))
+	parallel.Execute(len(scalars), func(start, end int) {
+		var p G2Jac
+		for i := start; i < end; i++ {
+			res[i].FromJacobian(t.Mul(&p, &scalars[i]))
+		}
+	})
+	return res
+}
+
+// WriteTo writes the binary encoding of the table (Base, W and the points of Table) to w
+func (t *G2FixedBaseTable) WriteTo(w io.Writer) (int64, error) {
+	enc := NewEncoder(w)
+
+	toEncode := []interface{}{
+		&t.Base,
+		t.W,
+	}
+	for i := range t.Table {
+		toEncode = append(toEncode, t.Table[i])
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes a table written by WriteTo from r
+func (t *G2FixedBaseTable) ReadFrom(r io.Reader) (int64, error) {
+	dec := NewDecoder(r)
+
+	if err := dec.Decode(&t.Base); err != nil {
+		return dec.BytesRead(), err
+	}
+	if err := dec.Decode(&t.W); err != nil {
+		return dec.BytesRead(), err
+	}
+	if t.W < 1 || t.W > fixedBaseMaxWindow {
+		return dec.BytesRead(), errors.New("invalid fixed-base table window size")
+	}
+
+	t.Table = make([][]G2Affine, fixedBaseNbWindows(t.W))
+	for i := range t.Table {
+		if err := dec.Decode(&t.Table[i]); err != nil {
+			return dec.BytesRead(), err
+		}
+		expected := 1 << (t.W - 1)
+		if i == len(t.Table)-1 {
+			expected = 1
+		}
+		if len(t.Table[i]) != expected {
+			return dec.BytesRead(), errors.New("invalid fixed-base table window length")
+		}
+	}
+
+	// the first point of the window i is 2^(W*i) * Base: the windows match Base and W
+	var expected, first G2Jac
+	expected.FromAffine(&t.Base)
+	for i := range t.Table {
+		if i != 0 {
+			for j := uint64(0); j < t.W; j++ {
+				expected.DoubleAssign()
+			}
+		}
+		if !first.FromAffine(&t.Table[i][0]).Equal(&expected) {
+			return dec.BytesRead(), errors.New("invalid fixed-base table: the windows don't match Base and W")
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
@@ -0,0 +1,354 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12381
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestFixedBaseDigits(t *testing.T) {
+	var minusOne fr.Element
+	minusOne.SetOne().Neg(&minusOne)
+	r := fr.Modulus()
+
+	for _, w := range []uint64{1, 3, 8, 13, 16} {
+		digits := make([]int, fixedBaseNbWindows(w))
+		for _, s := range []fr.Element{{}, {1}, minusOne} {
+			fixedBaseDigits(digits, &s, w)
+
+			// k = ∑ digits[i] 2^(w*i) is s or s + r, and odd
+			var k, d, sb big.Int
+			for i := len(digits) - 1; i >= 0; i-- {
+				if i < len(digits)-1 && (digits[i]&1 == 0 || digits[i] >= 1<<w || digits[i] <= -(1<<w)) {
+					t.Fatalf("w=%d: invalid digit %d", w, digits[i])
+				}
+				k.Lsh(&k, uint(w)).Add(&k, d.SetInt64(int64(digits[i])))
+			}
+			if digits[len(digits)-1] != 1 {
+				t.Fatalf("w=%d: the last digit should be 1", w)
+			}
+			s.ToBigIntRegular(&sb)
+			if k.Bit(0) != 1 || (k.Cmp(&sb) != 0 && k.Cmp(sb.Add(&sb, r)) != 0) {
+				t.Fatalf("w=%d: wrong recoding of %s", w, s.String())
+			}
+		}
+	}
+}
+
+func TestG1FixedBaseTable(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = nbFuzzShort
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	tables := []*G1FixedBaseTable{
+		NewG1FixedBaseTable(&g1GenAff),
+		NewG1FixedBaseTable(&g1GenAff, 1),
+		NewG1FixedBaseTable(&g1GenAff, 5),
+	}
+
+	properties.Property("[BLS12-381] Mul and MulCT should be consistent with ScalarMultiplication", prop.ForAll(
+		func(s fr.Element) bool {
+			var expected, p G1Jac
+			var b big.Int
+			expected.ScalarMultiplication(&g1Gen, s.ToBigIntRegular(&b))
+			for _, table := range tables {
+				if !table.Mul(&p, &s).Equal(&expected) {
+					return false
+				}
+				if !table.MulCT(&p, &s).Equal(&expected) {
+					return false
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.Property("[BLS12-381] MulMany should be consistent with Mul", prop.ForAll(
+		func(mixer fr.Element) bool {
+			scalars := make([]fr.Element, 5)
+			for i := range scalars {
+				scalars[i].SetUint64(uint64(i)).Mul(&scalars[i], &mixer)
+			}
+			res := tables[0].MulMany(scalars)
+			for i := range scalars {
+				var p G1Jac
+				var expected G1Affine
+				expected.FromJacobian(tables[0].Mul(&p, &scalars[i]))
+				if !res[i].Equal(&expected) {
+					return false
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// edge cases: 0, 1, r - 1
+	var minusOne fr.Element
+	minusOne.SetOne().Neg(&minusOne)
+	var inf, minusGen, p G1Jac
+	minusGen.Neg(&g1Gen)
+	for _, table := range tables {
+		if !table.MulCT(&p, &fr.Element{}).Equal(&inf) || !table.Mul(&p, &fr.Element{}).Equal(&inf) {
+			t.Fatal("0 * Base should be infinity")
+		}
+		one := fr.One()
+		if !table.MulCT(&p, &one).Equal(&g1Gen) {
+			t.Fatal("1 * Base should be Base")
+		}
+		if !table.MulCT(&p, &minusOne).Equal(&minusGen) {
+			t.Fatal("(r - 1) * Base should be -Base")
+		}
+	}
+}
+
+func TestG1FixedBaseTableSerialization(t *testing.T) {
+	t.Parallel()
+	table := NewG1FixedBaseTable(&g1GenAff, 4)
+
+	var buf bytes.Buffer
+	written, err := table.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded G1FixedBaseTable
+	read, err := decoded.ReadFrom(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read != written {
+		t.Fatal("the number of bytes read and written should match")
+	}
+	if !decoded.Base.Equal(&table.Base) || decoded.W != table.W || len(decoded.Table) != len(table.Table) {
+		t.Fatal("decoded table doesn't match the original one")
+	}
+	for i := range table.Table {
+		for j := range table.Table[i] {
+			if !decoded.Table[i][j].Equal(&table.Table[i][j]) {
+				t.Fatal("decoded table doesn't match the original one")
+			}
+		}
+	}
+
+	// a table whose Base or W doesn't match its windows is rejected
+	other := NewG1FixedBaseTable(&g1GenAff, 4)
+	other.Base.Neg(&other.Base)
+	buf.Reset()
+	if _, err := other.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decoded.ReadFrom(&buf); err == nil {
+		t.Fatal("a table with another Base should be rejected")
+	}
+	other = NewG1FixedBaseTable(&g1GenAff, 4)
+	other.Table[1], other.Table[2] = other.Table[2], other.Table[1]
+	buf.Reset()
+	if _, err := other.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decoded.ReadFrom(&buf); err == nil {
+		t.Fatal("a table with swapped windows should be rejected")
+	}
+}
+
+func BenchmarkG1FixedBaseTable(b *testing.B) {
+	table := NewG1FixedBaseTable(&g1GenAff)
+	var s fr.Element
+	s.SetRandom()
+	var p G1Jac
+
+	b.Run("Mul", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			table.Mul(&p, &s)
+		}
+	})
+	b.Run("MulCT", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			table.MulCT(&p, &s)
+		}
+	})
+	b.Run("ScalarMultiplication", func(b *testing.B) {
+		var bi big.Int
+		s.ToBigIntRegular(&bi)
+		for i := 0; i < b.N; i++ {
+			p.ScalarMultiplication(&g1Gen, &bi)
+		}
+	})
+}
+
+func TestG2FixedBaseTable(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = nbFuzzShort
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	tables := []*G2FixedBaseTable{
+		NewG2FixedBaseTable(&g2GenAff),
+		NewG2FixedBaseTable(&g2GenAff, 1),
+		NewG2FixedBaseTable(&g2GenAff, 5),
+	}
+
+	properties.Property("[BLS12-381] Mul and MulCT should be consistent with ScalarMultiplication", prop.ForAll(
+		func(s fr.Element) bool {
+			var expected, p G2Jac
+			var b big.Int
+			expected.ScalarMultiplication(&g2Gen, s.ToBigIntRegular(&b))
+			for _, table := range tables {
+				if !table.Mul(&p, &s).Equal(&expected) {
+					return false
+				}
+				if !table.MulCT(&p, &s).Equal(&expected) {
+					return false
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.Property("[BLS12-381] MulMany should be consistent with Mul", prop.ForAll(
+		func(mixer fr.Element) bool {
+			scalars := make([]fr.Element, 5)
+			for i := range scalars {
+				scalars[i].SetUint64(uint64(i)).Mul(&scalars[i], &mixer)
+			}
+			res := tables[0].MulMany(scalars)
+			for i := range scalars {
+				var p G2Jac
+				var expected G2Affine
+				expected.FromJacobian(tables[0].Mul(&p, &scalars[i]))
+				if !res[i].Equal(&expected) {
+					return false
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// edge cases: 0, 1, r - 1
+	var minusOne fr.Element
+	minusOne.SetOne().Neg(&minusOne)
+	var inf, minusGen, p G2Jac
+	minusGen.Neg(&g2Gen)
+	for _, table := range tables {
+		if !table.MulCT(&p, &fr.Element{}).Equal(&inf) || !table.Mul(&p, &fr.Element{}).Equal(&inf) {
+			t.Fatal("0 * Base should be infinity")
+		}
+		one := fr.One()
+		if !table.MulCT(&p, &one).Equal(&g2Gen) {
+			t.Fatal("1 * Base should be Base")
+		}
+		if !table.MulCT(&p, &minusOne).Equal(&minusGen) {
+			t.Fatal("(r - 1) * Base should be -Base")
+		}
+	}
+}
+
+func TestG2FixedBaseTableSerialization(t *testing.T) {
+	t.Parallel()
+	table := NewG2FixedBaseTable(&g2GenAff, 4)
+
+	var buf bytes.Buffer
+	written, err := table.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded G2FixedBaseTable
+	read, err := decoded.ReadFrom(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read != written {
+		t.Fatal("the number of bytes read and written should match")
+	}
+	if !decoded.Base.Equal(&table.Base) || decoded.W != table.W || len(decoded.Table) != len(table.Table) {
+		t.Fatal("decoded table doesn't match the original one")
+	}
+	for i := range table.Table {
+		for j := range table.Table[i] {
+			if !decoded.Table[i][j].Equal(&table.Table[i][j]) {
+				t.Fatal("decoded table doesn't match the original one")
+			}
+		}
+	}
+
+	// a table whose Base or W doesn't match its windows is rejected
+	other := NewG2FixedBaseTable(&g2GenAff, 4)
+	other.Base.Neg(&other.Base)
+	buf.Reset()
+	if _, err := other.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decoded.ReadFrom(&buf); err == nil {
+		t.Fatal("a table with another Base should be rejected")
+	}
+	other = NewG2FixedBaseTable(&g2GenAff, 4)
+	other.Table[1], other.Table[2] = other.Table[2], other.Table[1]
+	buf.Reset()
+	if _, err := other.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decoded.ReadFrom(&buf); err == nil {
+		t.Fatal("a table with swapped windows should be rejected")
+	}
+}
+
+func BenchmarkG2FixedBaseTable(b *testing.B) {
+	table := NewG2FixedBaseTable(&g2GenAff)
+	var s fr.Element
+	s.SetRandom()
+	var p G2Jac
+
+	b.Run("Mul", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			table.Mul(&p, &s)
+		}
+	})
+	b.Run("MulCT", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			table.MulCT(&p, &s)
+		}
+	})
+	b.Run("ScalarMultiplication", func(b *testing.B) {
+		var bi big.Int
+		s.ToBigIntRegular(&bi)
+		for i := 0; i < b.N; i++ {
+			p.ScalarMultiplication(&g2Gen, &bi)
+		}
+	})
+}
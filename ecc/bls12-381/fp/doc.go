@@ -39,6 +39,11 @@
 // 	 .Inv(a)
 // 	b.Exp(b, new(big.Int).SetUint64(42))
 //
+// Slices of field elements can be manipulated in batch through the Vector type:
+// 	var a, b, c Vector
+// 	c.Mul(a, b)
+// 	s := c.InnerProduct(a)
+//
 // Modulus q =
 //
 // 	q[base10] = 4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559787
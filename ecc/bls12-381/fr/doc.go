@@ -39,6 +39,11 @@
 // 	 .Inv(a)
 // 	b.Exp(b, new(big.Int).SetUint64(42))
 //
+// Slices of field elements can be manipulated in batch through the Vector type:
+// 	var a, b, c Vector
+// 	c.Mul(a, b)
+// 	s := c.InnerProduct(a)
+//
 // Modulus q =
 //
 // 	q[base10] = 52435875175126190479447740508185965837690552500527637822603658699938581184513
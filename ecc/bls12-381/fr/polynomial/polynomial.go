@@ -33,14 +33,41 @@ func (p *Polynomial) Degree() uint64 {
 	return uint64(len(*p) - 1)
 }
 
+// evalBlockSize is the number of coefficients evaluated in a block by Eval
+const evalBlockSize = 32
+
 // Eval evaluates p at v
 // returns a fr.Element
 func (p *Polynomial) Eval(v *fr.Element) fr.Element {
-
-	res := (*p)[len(*p)-1]
-	for i := len(*p) - 2; i >= 0; i-- {
-		res.Mul(&res, v)
-		res.Add(&res, &(*p)[i])
+	n := len(*p)
+	if n == 0 {
+		return fr.Element{}
+	}
+
+	// Horner's method on blocks of k coefficients:
+	// p(v) = ∑ⱼ (∑ₗ p[jk+l]vˡ)(vᵏ)ʲ
+	// the products of a block don't depend on each other, and are accumulated without
+	// modular reduction (see fr.Accumulator).
+	k := evalBlockSize
+	if n < k {
+		k = n
+	}
+	var powers [evalBlockSize + 1]fr.Element
+	powers[0].SetOne()
+	for i := 1; i <= k; i++ {
+		powers[i].Mul(&powers[i-1], v)
+	}
+
+	var res fr.Element
+	var acc fr.Accumulator
+	for s := (n - 1) / k * k; s >= 0; s -= k {
+		acc.Reset()
+		acc.MulAdd(&res, &powers[k])
+		acc.Add(&(*p)[s])
+		for l := 1; l < k && s+l < n; l++ {
+			acc.MulAdd(&(*p)[s+l], &powers[l])
+		}
+		res = acc.Reduce()
 	}
 
 	return res
@@ -18,6 +18,7 @@ package bls12381
 
 import (
 	"bytes"
+	"encoding/binary"
 	"io"
 	"math/big"
 	"math/rand"
@@ -27,8 +28,8 @@ import (
 	"github.com/leanovate/gopter/prop"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fptower"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
-	"github.com/consensys/gnark-crypto/ecc/bls12-381/internal/fptower"
 )
 
 const (
@@ -301,6 +302,141 @@ func TestG1AffineSerialization(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestG1AffineDecodePoints(t *testing.T) {
+	t.Parallel()
+	// more than decodeBlockSize bytes, with raw and compressed points and the infinity
+	const nbPoints = 1000
+	points := make([]G1Affine, nbPoints)
+	var p G1Jac
+	p.Set(&g1Gen)
+	for i := 1; i < nbPoints; i++ {
+		points[i].FromJacobian(&p)
+		p.AddAssign(&g1Gen)
+	}
+
+	var buf, compressed bytes.Buffer
+	var lenBuf [4]byte
+	binary.BigEndian.PutUint32(lenBuf[:], nbPoints)
+	buf.Write(lenBuf[:])
+	for i := range points {
+		pBytes := points[i].Bytes()
+		compressed.Write(pBytes[:])
+		if i%3 == 0 {
+			pRawBytes := points[i].RawBytes()
+			buf.Write(pRawBytes[:])
+		} else {
+			buf.Write(pBytes[:])
+		}
+	}
+	// a value after the slice must be left in the stream
+	trailing := fr.NewElement(42)
+	tBytes := trailing.Bytes()
+	buf.Write(tBytes[:])
+
+	var decoded []G1Affine
+	var decodedTrailing fr.Element
+	dec := NewDecoder(bytes.NewReader(buf.Bytes()))
+	if err := dec.Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if err := dec.Decode(&decodedTrailing); err != nil {
+		t.Fatal(err)
+	}
+	if !decodedTrailing.Equal(&trailing) {
+		t.Fatal("decoding a slice shouldn't read past its end")
+	}
+	if dec.BytesRead() != int64(buf.Len()) {
+		t.Fatal("invalid number of bytes read")
+	}
+	if len(decoded) != nbPoints {
+		t.Fatal("decoded points don't match the original ones")
+	}
+	for i := range points {
+		if !decoded[i].Equal(&points[i]) {
+			t.Fatal("decoded points don't match the original ones")
+		}
+	}
+
+	// a truncated stream
+	if err := NewDecoder(bytes.NewReader(buf.Bytes()[:buf.Len()-fr.Bytes-1])).Decode(&decoded); err == nil {
+		t.Fatal("decoding a truncated slice should fail")
+	}
+
+	// the subgroup checks of the points of a slice, batched or skipped
+	for _, options := range [][]func(*Decoder){{BatchSubgroupChecks()}, {NoSubgroupChecks()}} {
+		if err := NewDecoder(bytes.NewReader(buf.Bytes()), options...).Decode(&decoded); err != nil {
+			t.Fatal(err)
+		}
+		if len(decoded) != nbPoints {
+			t.Fatal("decoded points don't match the original ones")
+		}
+		for i := range points {
+			if !decoded[i].Equal(&points[i]) {
+				t.Fatal("decoded points don't match the original ones")
+			}
+		}
+	}
+
+	// an X coordinate off the curve
+	var bad G1Affine
+	var ySquared fp.Element
+	for {
+		bad.X.SetRandom()
+		ySquared.Square(&bad.X).Mul(&ySquared, &bad.X).Add(&ySquared, &bCurveCoeff)
+		if ySquared.Legendre() == -1 {
+			break
+		}
+	}
+	bad.Y.SetOne()
+	badBytes := bad.Bytes()
+	var withBad bytes.Buffer
+	binary.BigEndian.PutUint32(lenBuf[:], nbPoints+1)
+	withBad.Write(lenBuf[:])
+	withBad.Write(compressed.Bytes())
+	withBad.Write(badBytes[:])
+	if err := NewDecoder(bytes.NewReader(withBad.Bytes())).Decode(&decoded); err == nil {
+		t.Fatal("decompressing a point off the curve should fail")
+	}
+	if _, err := new(G1Affine).SetBytes(badBytes[:]); err == nil {
+		t.Fatal("decompressing a point off the curve should fail")
+	}
+}
+
+func BenchmarkG1AffineDecompress(b *testing.B) {
+	const nbPoints = 1 << 10
+	var compressed bytes.Buffer
+	var p G1Jac
+	var pAff G1Affine
+	p.Set(&g1Gen)
+	for i := 0; i < nbPoints; i++ {
+		pBytes := pAff.FromJacobian(&p).Bytes()
+		compressed.Write(pBytes[:])
+		p.AddAssign(&g1Gen)
+	}
+
+	b.Run("SetBytes", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			buf := compressed.Bytes()
+			for i := 0; i < nbPoints; i++ {
+				if _, err := pAff.SetBytes(buf[i*SizeOfG1AffineCompressed:]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		var lenBuf [4]byte
+		binary.BigEndian.PutUint32(lenBuf[:], nbPoints)
+		stream := append(lenBuf[:], compressed.Bytes()...)
+		var decoded []G1Affine
+		for j := 0; j < b.N; j++ {
+			if err := NewDecoder(bytes.NewReader(stream)).Decode(&decoded); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
 func TestG2AffineSerialization(t *testing.T) {
 	t.Parallel()
 	// test round trip serialization of infinity
@@ -394,6 +530,141 @@ func TestG2AffineSerialization(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestG2AffineDecodePoints(t *testing.T) {
+	t.Parallel()
+	// more than decodeBlockSize bytes, with raw and compressed points and the infinity
+	const nbPoints = 1000
+	points := make([]G2Affine, nbPoints)
+	var p G2Jac
+	p.Set(&g2Gen)
+	for i := 1; i < nbPoints; i++ {
+		points[i].FromJacobian(&p)
+		p.AddAssign(&g2Gen)
+	}
+
+	var buf, compressed bytes.Buffer
+	var lenBuf [4]byte
+	binary.BigEndian.PutUint32(lenBuf[:], nbPoints)
+	buf.Write(lenBuf[:])
+	for i := range points {
+		pBytes := points[i].Bytes()
+		compressed.Write(pBytes[:])
+		if i%3 == 0 {
+			pRawBytes := points[i].RawBytes()
+			buf.Write(pRawBytes[:])
+		} else {
+			buf.Write(pBytes[:])
+		}
+	}
+	// a value after the slice must be left in the stream
+	trailing := fr.NewElement(42)
+	tBytes := trailing.Bytes()
+	buf.Write(tBytes[:])
+
+	var decoded []G2Affine
+	var decodedTrailing fr.Element
+	dec := NewDecoder(bytes.NewReader(buf.Bytes()))
+	if err := dec.Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if err := dec.Decode(&decodedTrailing); err != nil {
+		t.Fatal(err)
+	}
+	if !decodedTrailing.Equal(&trailing) {
+		t.Fatal("decoding a slice shouldn't read past its end")
+	}
+	if dec.BytesRead() != int64(buf.Len()) {
+		t.Fatal("invalid number of bytes read")
+	}
+	if len(decoded) != nbPoints {
+		t.Fatal("decoded points don't match the original ones")
+	}
+	for i := range points {
+		if !decoded[i].Equal(&points[i]) {
+			t.Fatal("decoded points don't match the original ones")
+		}
+	}
+
+	// a truncated stream
+	if err := NewDecoder(bytes.NewReader(buf.Bytes()[:buf.Len()-fr.Bytes-1])).Decode(&decoded); err == nil {
+		t.Fatal("decoding a truncated slice should fail")
+	}
+
+	// the subgroup checks of the points of a slice, batched or skipped
+	for _, options := range [][]func(*Decoder){{BatchSubgroupChecks()}, {NoSubgroupChecks()}} {
+		if err := NewDecoder(bytes.NewReader(buf.Bytes()), options...).Decode(&decoded); err != nil {
+			t.Fatal(err)
+		}
+		if len(decoded) != nbPoints {
+			t.Fatal("decoded points don't match the original ones")
+		}
+		for i := range points {
+			if !decoded[i].Equal(&points[i]) {
+				t.Fatal("decoded points don't match the original ones")
+			}
+		}
+	}
+
+	// an X coordinate off the curve
+	var bad G2Affine
+	var ySquared fptower.E2
+	for {
+		bad.X.SetRandom()
+		ySquared.Square(&bad.X).Mul(&ySquared, &bad.X).Add(&ySquared, &bTwistCurveCoeff)
+		if ySquared.Legendre() == -1 {
+			break
+		}
+	}
+	bad.Y.SetOne()
+	badBytes := bad.Bytes()
+	var withBad bytes.Buffer
+	binary.BigEndian.PutUint32(lenBuf[:], nbPoints+1)
+	withBad.Write(lenBuf[:])
+	withBad.Write(compressed.Bytes())
+	withBad.Write(badBytes[:])
+	if err := NewDecoder(bytes.NewReader(withBad.Bytes())).Decode(&decoded); err == nil {
+		t.Fatal("decompressing a point off the curve should fail")
+	}
+	if _, err := new(G2Affine).SetBytes(badBytes[:]); err == nil {
+		t.Fatal("decompressing a point off the curve should fail")
+	}
+}
+
+func BenchmarkG2AffineDecompress(b *testing.B) {
+	const nbPoints = 1 << 10
+	var compressed bytes.Buffer
+	var p G2Jac
+	var pAff G2Affine
+	p.Set(&g2Gen)
+	for i := 0; i < nbPoints; i++ {
+		pBytes := pAff.FromJacobian(&p).Bytes()
+		compressed.Write(pBytes[:])
+		p.AddAssign(&g2Gen)
+	}
+
+	b.Run("SetBytes", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			buf := compressed.Bytes()
+			for i := 0; i < nbPoints; i++ {
+				if _, err := pAff.SetBytes(buf[i*SizeOfG2AffineCompressed:]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		var lenBuf [4]byte
+		binary.BigEndian.PutUint32(lenBuf[:], nbPoints)
+		stream := append(lenBuf[:], compressed.Bytes()...)
+		var decoded []G2Affine
+		for j := 0; j < b.N; j++ {
+			if err := NewDecoder(bytes.NewReader(stream)).Decode(&decoded); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
 // define Gopters generators
 
 // GenFr generates an Fr element
@@ -0,0 +1,251 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12381
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestMultiExpBatchG1(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 2
+	} else {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	// size of the multiExps
+	const nbSamples = 73
+
+	// multi exp points
+	samplePoints := make([]G1Affine, nbSamples)
+	var g G1Jac
+	g.Set(&g1Gen)
+	for i := 1; i <= nbSamples; i++ {
+		samplePoints[i-1].FromJacobian(&g)
+		g.AddAssign(&g1Gen)
+	}
+
+	properties.Property("[BLS12-381] MultiExpBatch should be consistent with MultiExp", prop.ForAll(
+		func(mixer fr.Element) bool {
+			// full width, 8-bit, 0/1 and 0 scalars
+			scalars := make([][]fr.Element, 4)
+			for i := range scalars {
+				scalars[i] = make([]fr.Element, nbSamples)
+			}
+			for j := 1; j <= nbSamples; j++ {
+				scalars[0][j-1].SetUint64(uint64(j)).
+					Mul(&scalars[0][j-1], &mixer)
+				scalars[1][j-1].SetUint64((mixer[j%fr.Limbs] >> (j % 7)) & 0xff)
+				scalars[2][j-1].SetUint64(uint64(j) & 1)
+			}
+
+			for _, config := range []ecc.MultiExpConfig{
+				{ScalarsMont: true},
+				{ScalarsMont: true, NbTasks: 3},
+				{ScalarsMont: true, NbTasks: 1024}, // caps the window size
+				{ScalarsMont: true, MaxScalarBits: fr.Bits},
+			} {
+				results, err := MultiExpBatchG1(samplePoints, scalars, config)
+				if err != nil || len(results) != len(scalars) {
+					return false
+				}
+				for i := range scalars {
+					var expected G1Jac
+					expected.MultiExp(samplePoints, scalars[i], config)
+					if !results[i].Equal(&expected) {
+						return false
+					}
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// the scalar vectors must have the length of points
+	if _, err := MultiExpBatchG1(samplePoints, [][]fr.Element{make([]fr.Element, nbSamples), make([]fr.Element, nbSamples-1)}, ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("MultiExpBatch should fail if len(scalars[i]) != len(points)")
+	}
+}
+
+func BenchmarkMultiExpBatchG1(b *testing.B) {
+	const (
+		nbSamples = 1 << 14
+		nbVectors = 4
+	)
+
+	var samplePoints [nbSamples]G1Affine
+	fillBenchBasesG1(samplePoints[:])
+
+	scalars := make([][]fr.Element, nbVectors)
+	for i := range scalars {
+		scalars[i] = make([]fr.Element, nbSamples)
+		fillBenchScalars(scalars[i])
+		scalars[i][0].SetUint64(uint64(i))
+	}
+
+	for i := 8; i <= 14; i += 3 {
+		using := 1 << i
+		vectors := make([][]fr.Element, nbVectors)
+		for j := range vectors {
+			vectors[j] = scalars[j][:using]
+		}
+
+		b.Run(fmt.Sprintf("%d points, MultiExpBatch", using), func(b *testing.B) {
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				MultiExpBatchG1(samplePoints[:using], vectors, ecc.MultiExpConfig{})
+			}
+		})
+
+		b.Run(fmt.Sprintf("%d points, MultiExp", using), func(b *testing.B) {
+			var testPoint G1Jac
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				for k := range vectors {
+					testPoint.MultiExp(samplePoints[:using], vectors[k], ecc.MultiExpConfig{})
+				}
+			}
+		})
+	}
+}
+
+func TestMultiExpBatchG2(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 2
+	} else {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	// size of the multiExps
+	const nbSamples = 73
+
+	// multi exp points
+	samplePoints := make([]G2Affine, nbSamples)
+	var g G2Jac
+	g.Set(&g2Gen)
+	for i := 1; i <= nbSamples; i++ {
+		samplePoints[i-1].FromJacobian(&g)
+		g.AddAssign(&g2Gen)
+	}
+
+	properties.Property("[BLS12-381] MultiExpBatch should be consistent with MultiExp", prop.ForAll(
+		func(mixer fr.Element) bool {
+			// full width, 8-bit, 0/1 and 0 scalars
+			scalars := make([][]fr.Element, 4)
+			for i := range scalars {
+				scalars[i] = make([]fr.Element, nbSamples)
+			}
+			for j := 1; j <= nbSamples; j++ {
+				scalars[0][j-1].SetUint64(uint64(j)).
+					Mul(&scalars[0][j-1], &mixer)
+				scalars[1][j-1].SetUint64((mixer[j%fr.Limbs] >> (j % 7)) & 0xff)
+				scalars[2][j-1].SetUint64(uint64(j) & 1)
+			}
+
+			for _, config := range []ecc.MultiExpConfig{
+				{ScalarsMont: true},
+				{ScalarsMont: true, NbTasks: 3},
+				{ScalarsMont: true, NbTasks: 1024}, // caps the window size
+				{ScalarsMont: true, MaxScalarBits: fr.Bits},
+			} {
+				results, err := MultiExpBatchG2(samplePoints, scalars, config)
+				if err != nil || len(results) != len(scalars) {
+					return false
+				}
+				for i := range scalars {
+					var expected G2Jac
+					expected.MultiExp(samplePoints, scalars[i], config)
+					if !results[i].Equal(&expected) {
+						return false
+					}
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// the scalar vectors must have the length of points
+	if _, err := MultiExpBatchG2(samplePoints, [][]fr.Element{make([]fr.Element, nbSamples), make([]fr.Element, nbSamples-1)}, ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("MultiExpBatch should fail if len(scalars[i]) != len(points)")
+	}
+}
+
+func BenchmarkMultiExpBatchG2(b *testing.B) {
+	const (
+		nbSamples = 1 << 14
+		nbVectors = 4
+	)
+
+	var samplePoints [nbSamples]G2Affine
+	fillBenchBasesG2(samplePoints[:])
+
+	scalars := make([][]fr.Element, nbVectors)
+	for i := range scalars {
+		scalars[i] = make([]fr.Element, nbSamples)
+		fillBenchScalars(scalars[i])
+		scalars[i][0].SetUint64(uint64(i))
+	}
+
+	for i := 8; i <= 14; i += 3 {
+		using := 1 << i
+		vectors := make([][]fr.Element, nbVectors)
+		for j := range vectors {
+			vectors[j] = scalars[j][:using]
+		}
+
+		b.Run(fmt.Sprintf("%d points, MultiExpBatch", using), func(b *testing.B) {
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				MultiExpBatchG2(samplePoints[:using], vectors, ecc.MultiExpConfig{})
+			}
+		})
+
+		b.Run(fmt.Sprintf("%d points, MultiExp", using), func(b *testing.B) {
+			var testPoint G2Jac
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				for k := range vectors {
+					testPoint.MultiExp(samplePoints[:using], vectors[k], ecc.MultiExpConfig{})
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12381
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"math"
+	"math/bits"
+	"sync/atomic"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// batchSubGroupSecurity is the statistical security of the batched subgroup checks, in bits: a slice with a point
+// outside the subgroup passes BatchIsInSubGroup with probability at most 2^-batchSubGroupSecurity
+const batchSubGroupSecurity = 64
+
+// batchSubGroupRounds returns the number of random linear combinations checked by BatchIsInSubGroup, and the bit
+// size of their coefficients, for a cofactor with no prime factor below l
+//
+// A point on the curve is the sum of a point of the subgroup and of a point T of order m | cofactor, m ≥ l if T
+// isn't the infinity. A combination with uniform nbBits-bit coefficients cancels T with probability at most
+// ⌈2^nbBits / l⌉ / 2^nbBits, whatever the other points are. Coefficients in {0, 1} only give 1/2, but add half of
+// the points; larger ones tend to 1/l for about one addition per point.
+func batchSubGroupRounds(l uint64) (nbRounds, nbBits int) {
+	rounds := func(nbBits int) int {
+		e := math.Ldexp(1, nbBits)
+		p := math.Ceil(e/float64(l)) / e
+		return int(math.Ceil(batchSubGroupSecurity / -math.Log2(p)))
+	}
+	nbRounds, nbBits = rounds(1), 1
+	wide := bits.Len64(l) + 8
+	if wide > 64 {
+		wide = 64
+	}
+	if r := rounds(wide); 2*r < nbRounds {
+		nbRounds, nbBits = r, wide
+	}
+	return
+}
+
+// batchSubGroupCoefficients sets the scalars to random nbBits-bit coefficients, in regular form
+func batchSubGroupCoefficients(scalars []fr.Element, nbBits int) error {
+	buf := make([]byte, (len(scalars)*nbBits+63)/64*8+8)
+	if _, err := rand.Read(buf); err != nil {
+		return err
+	}
+	mask := uint64(1)<<nbBits - 1
+	if nbBits == 64 {
+		mask = math.MaxUint64
+	}
+	for i := range scalars {
+		pos := i * nbBits
+		w := binary.LittleEndian.Uint64(buf[pos/64*8:]) >> (pos % 64)
+		if pos%64+nbBits > 64 {
+			w |= binary.LittleEndian.Uint64(buf[pos/64*8+8:]) << (64 - pos%64)
+		}
+		scalars[i] = fr.Element{w & mask}
+	}
+	return nil
+}
+
+// g1CofactorPrime is the smallest prime factor of the cofactor of G1, or a lower bound on it
+const g1CofactorPrime = 3
+
+// BatchIsInSubGroupG1 returns true if all the points are on the curve and in the subgroup
+// of order r
+//
+// Instead of a subgroup check per point, it checks that random linear combinations of the points are in the
+// subgroup, each with a multiExp and one subgroup check. This test is probabilistic: a slice with a point outside
+// the subgroup passes it with probability at most 2^-64, over the randomness drawn from crypto/rand. The number
+// of combinations depends on the smallest prime factor of the cofactor (see batchSubGroupRounds).
+//
+// It returns false if the randomness can't be read.
+func BatchIsInSubGroupG1(points []G1Affine) bool {
+	// the linear combinations only reveal the components of small order of points on the curve
+	var nbErrs uint64
+	parallel.Execute(len(points), func(start, end int) {
+		for i := start; i < end; i++ {
+			if !points[i].IsOnCurve() {
+				atomic.AddUint64(&nbErrs, 1)
+				return
+			}
+		}
+	})
+	if nbErrs != 0 {
+		return false
+	}
+
+	nbRounds, nbBits := batchSubGroupRounds(g1CofactorPrime)
+	scalars := make([]fr.Element, len(points))
+	// GLV stays disabled: its endomorphism is a multiplication by λ on the subgroup only
+	config := ecc.MultiExpConfig{MaxScalarBits: nbBits}
+	var q G1Jac
+	for i := 0; i < nbRounds; i++ {
+		if err := batchSubGroupCoefficients(scalars, nbBits); err != nil {
+			return false
+		}
+		if _, err := q.MultiExp(points, scalars, config); err != nil {
+			return false
+		}
+		if !q.IsInSubGroup() {
+			return false
+		}
+	}
+	return true
+}
+
+// g2CofactorPrime is the smallest prime factor of the cofactor of G2, or a lower bound on it
+const g2CofactorPrime = 13
+
+// BatchIsInSubGroupG2 returns true if all the points are on the curve and in the subgroup
+// of order r
+//
+// Instead of a subgroup check per point, it checks that random linear combinations of the points are in the
+// subgroup, each with a multiExp and one subgroup check. This test is probabilistic: a slice with a point outside
+// the subgroup passes it with probability at most 2^-64, over the randomness drawn from crypto/rand. The number
+// of combinations depends on the smallest prime factor of the cofactor (see batchSubGroupRounds).
+//
+// It returns false if the randomness can't be read.
+func BatchIsInSubGroupG2(points []G2Affine) bool {
+	// the linear combinations only reveal the components of small order of points on the curve
+	var nbErrs uint64
+	parallel.Execute(len(points), func(start, end int) {
+		for i := start; i < end; i++ {
+			if !points[i].IsOnCurve() {
+				atomic.AddUint64(&nbErrs, 1)
+				return
+			}
+		}
+	})
+	if nbErrs != 0 {
+		return false
+	}
+
+	nbRounds, nbBits := batchSubGroupRounds(g2CofactorPrime)
+	scalars := make([]fr.Element, len(points))
+	// GLV stays disabled: its endomorphism is a multiplication by λ on the subgroup only
+	config := ecc.MultiExpConfig{MaxScalarBits: nbBits}
+	var q G2Jac
+	for i := 0; i < nbRounds; i++ {
+		if err := batchSubGroupCoefficients(scalars, nbBits); err != nil {
+			return false
+		}
+		if _, err := q.MultiExp(points, scalars, config); err != nil {
+			return false
+		}
+		if !q.IsInSubGroup() {
+			return false
+		}
+	}
+	return true
+}
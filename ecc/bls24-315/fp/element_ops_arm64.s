@@ -0,0 +1,440 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+#include "textflag.h"
+#include "funcdata.h"
+
+// modulus q
+DATA q<>+0(SB)/8, $0x6fe802ff40300001
+DATA q<>+8(SB)/8, $0x421ee5da52bde502
+DATA q<>+16(SB)/8, $0xdec1d01aa27a1ae0
+DATA q<>+24(SB)/8, $0xd3f7498be97c5eaf
+DATA q<>+32(SB)/8, $0x04c23a02b586d650
+GLOBL q<>(SB), (RODATA+NOPTR), $40
+
+// qInv0 q'[0]
+DATA qInv0<>(SB)/8, $0x702ff9ff402fffff
+GLOBL qInv0<>(SB), (RODATA+NOPTR), $8
+
+// add(res, x, y *Element)
+TEXT ·add(SB), NOSPLIT, $0-24
+	MOVD x+8(FP), R0
+	LDP  0(R0), (R1, R2)
+	LDP  16(R0), (R3, R4)
+	MOVD 32(R0), R5
+	MOVD y+16(FP), R0
+	LDP  0(R0), (R6, R7)
+	LDP  16(R0), (R8, R9)
+	MOVD 32(R0), R10
+	ADDS R1, R6, R1
+	ADCS R2, R7, R2
+	ADCS R3, R8, R3
+	ADCS R4, R9, R4
+	ADC  R5, R10, R5
+	LDP  q<>+0(SB), (R6, R7)
+	LDP  q<>+16(SB), (R8, R9)
+	MOVD q<>+32(SB), R10
+	MOVD res+0(FP), R0
+
+	// q = t - q
+	SUBS R6, R1, R6
+	SBCS R7, R2, R7
+	SBCS R8, R3, R8
+	SBCS R9, R4, R9
+	SBCS R10, R5, R10
+
+	// if no borrow, return q, else return t
+	CSEL CS, R6, R1, R1
+	CSEL CS, R7, R2, R2
+	CSEL CS, R8, R3, R3
+	CSEL CS, R9, R4, R4
+	CSEL CS, R10, R5, R5
+	STP  (R1, R2), 0(R0)
+	STP  (R3, R4), 16(R0)
+	MOVD R5, 32(R0)
+	RET
+
+// sub(res, x, y *Element)
+TEXT ·sub(SB), NOSPLIT, $0-24
+	MOVD x+8(FP), R0
+	LDP  0(R0), (R1, R2)
+	LDP  16(R0), (R3, R4)
+	MOVD 32(R0), R5
+	MOVD y+16(FP), R0
+	LDP  0(R0), (R6, R7)
+	LDP  16(R0), (R8, R9)
+	MOVD 32(R0), R10
+	SUBS R6, R1, R1
+	SBCS R7, R2, R2
+	SBCS R8, R3, R3
+	SBCS R9, R4, R4
+	SBCS R10, R5, R5
+	LDP  q<>+0(SB), (R6, R7)
+	LDP  q<>+16(SB), (R8, R9)
+	MOVD q<>+32(SB), R10
+
+	// add q if underflow, 0 if not
+	CSEL CS, ZR, R6, R6
+	CSEL CS, ZR, R7, R7
+	CSEL CS, ZR, R8, R8
+	CSEL CS, ZR, R9, R9
+	CSEL CS, ZR, R10, R10
+	ADDS R6, R1, R1
+	ADCS R7, R2, R2
+	ADCS R8, R3, R3
+	ADCS R9, R4, R4
+	ADC  R10, R5, R5
+	MOVD res+0(FP), R0
+	STP  (R1, R2), 0(R0)
+	STP  (R3, R4), 16(R0)
+	MOVD R5, 32(R0)
+	RET
+
+// mul(res, x, y *Element)
+TEXT ·mul(SB), NOSPLIT, $0-24
+	MOVD x+8(FP), R0
+	LDP  0(R0), (R5, R6)
+	LDP  16(R0), (R7, R8)
+	MOVD 32(R0), R9
+	MOVD y+16(FP), R1
+	LDP  q<>+0(SB), (R10, R11)
+	LDP  q<>+16(SB), (R12, R13)
+	MOVD q<>+32(SB), R14
+	MOVD qInv0<>(SB), R3
+	MOVD 0(R1), R2
+
+	// (C,t[j])  := t[j] + x[j]*y[i] + C
+	MUL   R5, R2, R15
+	MUL   R6, R2, R16
+	MUL   R7, R2, R17
+	MUL   R8, R2, R19
+	MUL   R9, R2, R20
+	UMULH R5, R2, R0
+	ADDS  R0, R16, R16
+	UMULH R6, R2, R0
+	ADCS  R0, R17, R17
+	UMULH R7, R2, R0
+	ADCS  R0, R19, R19
+	UMULH R8, R2, R0
+	ADCS  R0, R20, R20
+	UMULH R9, R2, R0
+	ADC   R0, ZR, R21
+
+	// m := t[0]*q'[0] mod W
+	MUL R15, R3, R4
+
+	// (C,t[j-1]) := t[j] + m*q[j] + C
+	MUL   R10, R4, R0
+	ADDS  R0, R15, R15
+	MUL   R11, R4, R0
+	ADCS  R0, R16, R16
+	MUL   R12, R4, R0
+	ADCS  R0, R17, R17
+	MUL   R13, R4, R0
+	ADCS  R0, R19, R19
+	MUL   R14, R4, R0
+	ADCS  R0, R20, R20
+	ADC   ZR, R21, R21
+	UMULH R10, R4, R0
+	ADDS  R0, R16, R15
+	UMULH R11, R4, R0
+	ADCS  R0, R17, R16
+	UMULH R12, R4, R0
+	ADCS  R0, R19, R17
+	UMULH R13, R4, R0
+	ADCS  R0, R20, R19
+	UMULH R14, R4, R0
+	ADC   R0, R21, R20
+	MOVD  8(R1), R2
+
+	// (C,t[j])  := t[j] + x[j]*y[i] + C
+	MUL   R5, R2, R0
+	ADDS  R0, R15, R15
+	MUL   R6, R2, R0
+	ADCS  R0, R16, R16
+	MUL   R7, R2, R0
+	ADCS  R0, R17, R17
+	MUL   R8, R2, R0
+	ADCS  R0, R19, R19
+	MUL   R9, R2, R0
+	ADCS  R0, R20, R20
+	ADC   ZR, ZR, R21
+	UMULH R5, R2, R0
+	ADDS  R0, R16, R16
+	UMULH R6, R2, R0
+	ADCS  R0, R17, R17
+	UMULH R7, R2, R0
+	ADCS  R0, R19, R19
+	UMULH R8, R2, R0
+	ADCS  R0, R20, R20
+	UMULH R9, R2, R0
+	ADC   R0, R21, R21
+
+	// m := t[0]*q'[0] mod W
+	MUL R15, R3, R4
+
+	// (C,t[j-1]) := t[j] + m*q[j] + C
+	MUL   R10, R4, R0
+	ADDS  R0, R15, R15
+	MUL   R11, R4, R0
+	ADCS  R0, R16, R16
+	MUL   R12, R4, R0
+	ADCS  R0, R17, R17
+	MUL   R13, R4, R0
+	ADCS  R0, R19, R19
+	MUL   R14, R4, R0
+	ADCS  R0, R20, R20
+	ADC   ZR, R21, R21
+	UMULH R10, R4, R0
+	ADDS  R0, R16, R15
+	UMULH R11, R4, R0
+	ADCS  R0, R17, R16
+	UMULH R12, R4, R0
+	ADCS  R0, R19, R17
+	UMULH R13, R4, R0
+	ADCS  R0, R20, R19
+	UMULH R14, R4, R0
+	ADC   R0, R21, R20
+	MOVD  16(R1), R2
+
+	// (C,t[j])  := t[j] + x[j]*y[i] + C
+	MUL   R5, R2, R0
+	ADDS  R0, R15, R15
+	MUL   R6, R2, R0
+	ADCS  R0, R16, R16
+	MUL   R7, R2, R0
+	ADCS  R0, R17, R17
+	MUL   R8, R2, R0
+	ADCS  R0, R19, R19
+	MUL   R9, R2, R0
+	ADCS  R0, R20, R20
+	ADC   ZR, ZR, R21
+	UMULH R5, R2, R0
+	ADDS  R0, R16, R16
+	UMULH R6, R2, R0
+	ADCS  R0, R17, R17
+	UMULH R7, R2, R0
+	ADCS  R0, R19, R19
+	UMULH R8, R2, R0
+	ADCS  R0, R20, R20
+	UMULH R9, R2, R0
+	ADC   R0, R21, R21
+
+	// m := t[0]*q'[0] mod W
+	MUL R15, R3, R4
+
+	// (C,t[j-1]) := t[j] + m*q[j] + C
+	MUL   R10, R4, R0
+	ADDS  R0, R15, R15
+	MUL   R11, R4, R0
+	ADCS  R0, R16, R16
+	MUL   R12, R4, R0
+	ADCS  R0, R17, R17
+	MUL   R13, R4, R0
+	ADCS  R0, R19, R19
+	MUL   R14, R4, R0
+	ADCS  R0, R20, R20
+	ADC   ZR, R21, R21
+	UMULH R10, R4, R0
+	ADDS  R0, R16, R15
+	UMULH R11, R4, R0
+	ADCS  R0, R17, R16
+	UMULH R12, R4, R0
+	ADCS  R0, R19, R17
+	UMULH R13, R4, R0
+	ADCS  R0, R20, R19
+	UMULH R14, R4, R0
+	ADC   R0, R21, R20
+	MOVD  24(R1), R2
+
+	// (C,t[j])  := t[j] + x[j]*y[i] + C
+	MUL   R5, R2, R0
+	ADDS  R0, R15, R15
+	MUL   R6, R2, R0
+	ADCS  R0, R16, R16
+	MUL   R7, R2, R0
+	ADCS  R0, R17, R17
+	MUL   R8, R2, R0
+	ADCS  R0, R19, R19
+	MUL   R9, R2, R0
+	ADCS  R0, R20, R20
+	ADC   ZR, ZR, R21
+	UMULH R5, R2, R0
+	ADDS  R0, R16, R16
+	UMULH R6, R2, R0
+	ADCS  R0, R17, R17
+	UMULH R7, R2, R0
+	ADCS  R0, R19, R19
+	UMULH R8, R2, R0
+	ADCS  R0, R20, R20
+	UMULH R9, R2, R0
+	ADC   R0, R21, R21
+
+	// m := t[0]*q'[0] mod W
+	MUL R15, R3, R4
+
+	// (C,t[j-1]) := t[j] + m*q[j] + C
+	MUL   R10, R4, R0
+	ADDS  R0, R15, R15
+	MUL   R11, R4, R0
+	ADCS  R0, R16, R16
+	MUL   R12, R4, R0
+	ADCS  R0, R17, R17
+	MUL   R13, R4, R0
+	ADCS  R0, R19, R19
+	MUL   R14, R4, R0
+	ADCS  R0, R20, R20
+	ADC   ZR, R21, R21
+	UMULH R10, R4, R0
+	ADDS  R0, R16, R15
+	UMULH R11, R4, R0
+	ADCS  R0, R17, R16
+	UMULH R12, R4, R0
+	ADCS  R0, R19, R17
+	UMULH R13, R4, R0
+	ADCS  R0, R20, R19
+	UMULH R14, R4, R0
+	ADC   R0, R21, R20
+	MOVD  32(R1), R2
+
+	// (C,t[j])  := t[j] + x[j]*y[i] + C
+	MUL   R5, R2, R0
+	ADDS  R0, R15, R15
+	MUL   R6, R2, R0
+	ADCS  R0, R16, R16
+	MUL   R7, R2, R0
+	ADCS  R0, R17, R17
+	MUL   R8, R2, R0
+	ADCS  R0, R19, R19
+	MUL   R9, R2, R0
+	ADCS  R0, R20, R20
+	ADC   ZR, ZR, R21
+	UMULH R5, R2, R0
+	ADDS  R0, R16, R16
+	UMULH R6, R2, R0
+	ADCS  R0, R17, R17
+	UMULH R7, R2, R0
+	ADCS  R0, R19, R19
+	UMULH R8, R2, R0
+	ADCS  R0, R20, R20
+	UMULH R9, R2, R0
+	ADC   R0, R21, R21
+
+	// m := t[0]*q'[0] mod W
+	MUL R15, R3, R4
+
+	// (C,t[j-1]) := t[j] + m*q[j] + C
+	MUL   R10, R4, R0
+	ADDS  R0, R15, R15
+	MUL   R11, R4, R0
+	ADCS  R0, R16, R16
+	MUL   R12, R4, R0
+	ADCS  R0, R17, R17
+	MUL   R13, R4, R0
+	ADCS  R0, R19, R19
+	MUL   R14, R4, R0
+	ADCS  R0, R20, R20
+	ADC   ZR, R21, R21
+	UMULH R10, R4, R0
+	ADDS  R0, R16, R15
+	UMULH R11, R4, R0
+	ADCS  R0, R17, R16
+	UMULH R12, R4, R0
+	ADCS  R0, R19, R17
+	UMULH R13, R4, R0
+	ADCS  R0, R20, R19
+	UMULH R14, R4, R0
+	ADC   R0, R21, R20
+
+	// reduce if necessary
+	MOVD res+0(FP), R0
+
+	// q = t - q
+	SUBS R10, R15, R10
+	SBCS R11, R16, R11
+	SBCS R12, R17, R12
+	SBCS R13, R19, R13
+	SBCS R14, R20, R14
+
+	// if no borrow, return q, else return t
+	CSEL CS, R10, R15, R15
+	CSEL CS, R11, R16, R16
+	CSEL CS, R12, R17, R17
+	CSEL CS, R13, R19, R19
+	CSEL CS, R14, R20, R20
+	STP  (R15, R16), 0(R0)
+	STP  (R17, R19), 16(R0)
+	MOVD R20, 32(R0)
+	RET
+
+// Butterfly(a, b *Element) sets a = a + b; b = a - b
+TEXT ·Butterfly(SB), NOSPLIT, $0-16
+	MOVD a+0(FP), R0
+	LDP  0(R0), (R2, R3)
+	LDP  16(R0), (R4, R5)
+	MOVD 32(R0), R6
+	MOVD b+8(FP), R1
+	LDP  0(R1), (R7, R8)
+	LDP  16(R1), (R9, R10)
+	MOVD 32(R1), R11
+	ADDS R2, R7, R12
+	ADCS R3, R8, R13
+	ADCS R4, R9, R14
+	ADCS R5, R10, R15
+	ADC  R6, R11, R16
+	SUBS R7, R2, R7
+	SBCS R8, R3, R8
+	SBCS R9, R4, R9
+	SBCS R10, R5, R10
+	SBCS R11, R6, R11
+	LDP  q<>+0(SB), (R2, R3)
+	LDP  q<>+16(SB), (R4, R5)
+	MOVD q<>+32(SB), R6
+
+	// add q if underflow, 0 if not
+	CSEL CS, ZR, R2, R2
+	CSEL CS, ZR, R3, R3
+	CSEL CS, ZR, R4, R4
+	CSEL CS, ZR, R5, R5
+	CSEL CS, ZR, R6, R6
+	ADDS R2, R7, R7
+	ADCS R3, R8, R8
+	ADCS R4, R9, R9
+	ADCS R5, R10, R10
+	ADC  R6, R11, R11
+	STP  (R7, R8), 0(R1)
+	STP  (R9, R10), 16(R1)
+	MOVD R11, 32(R1)
+	LDP  q<>+0(SB), (R2, R3)
+	LDP  q<>+16(SB), (R4, R5)
+	MOVD q<>+32(SB), R6
+
+	// q = t - q
+	SUBS R2, R12, R2
+	SBCS R3, R13, R3
+	SBCS R4, R14, R4
+	SBCS R5, R15, R5
+	SBCS R6, R16, R6
+
+	// if no borrow, return q, else return t
+	CSEL CS, R2, R12, R12
+	CSEL CS, R3, R13, R13
+	CSEL CS, R4, R14, R14
+	CSEL CS, R5, R15, R15
+	CSEL CS, R6, R16, R16
+	STP  (R12, R13), 0(R0)
+	STP  (R14, R15), 16(R0)
+	MOVD R16, 32(R0)
+	RET
+
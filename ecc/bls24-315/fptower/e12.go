@@ -15,6 +15,9 @@
 package fptower
 
 import (
+	"crypto/rand"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fp"
+	"io"
 	"math/big"
 )
 
@@ -56,15 +59,22 @@ func (z *E12) SetOne() *E12 {
 	return z
 }
 
-// SetRandom set z to a random elmt
+// SetRandom sets z to a uniform random value, reading the randomness from crypto/rand.Reader
 func (z *E12) SetRandom() (*E12, error) {
-	if _, err := z.C0.SetRandom(); err != nil {
+	return z.SetRandomFrom(rand.Reader)
+}
+
+// SetRandomFrom sets z to a uniform random value, reading the randomness from r
+//
+// Each coordinate is sampled uniformly with SetRandomFrom, in order.
+func (z *E12) SetRandomFrom(r io.Reader) (*E12, error) {
+	if _, err := z.C0.SetRandomFrom(r); err != nil {
 		return nil, err
 	}
-	if _, err := z.C1.SetRandom(); err != nil {
+	if _, err := z.C1.SetRandomFrom(r); err != nil {
 		return nil, err
 	}
-	if _, err := z.C2.SetRandom(); err != nil {
+	if _, err := z.C2.SetRandomFrom(r); err != nil {
 		return nil, err
 	}
 	return z, nil
@@ -208,6 +218,35 @@ func (z *E12) Inverse(x *E12) *E12 {
 	return z
 }
 
+// InverseCT an element in E12, in constant time
+//
+// if x == 0, sets and returns z = x
+func (z *E12) InverseCT(x *E12) *E12 {
+	// Algorithm 17 from https://eprint.iacr.org/2010/354.pdf
+	// step 9 is wrong in the paper it's t1-t4
+	var t0, t1, t2, t3, t4, t5, t6, c0, c1, c2, d1, d2 E4
+	t0.Square(&x.C0)
+	t1.Square(&x.C1)
+	t2.Square(&x.C2)
+	t3.Mul(&x.C0, &x.C1)
+	t4.Mul(&x.C0, &x.C2)
+	t5.Mul(&x.C1, &x.C2)
+	c0.MulByNonResidue(&t5).Sub(&t0, &c0)
+	c1.MulByNonResidue(&t2).Sub(&c1, &t3)
+	c2.Sub(&t1, &t4)
+	t6.Mul(&x.C0, &c0)
+	d1.Mul(&x.C2, &c1)
+	d2.Mul(&x.C1, &c2)
+	d1.Add(&d1, &d2).MulByNonResidue(&d1)
+	t6.Add(&t6, &d1)
+	t6.InverseCT(&t6)
+	z.C0.Mul(&c0, &t6)
+	z.C1.Mul(&c1, &t6)
+	z.C2.Mul(&c2, &t6)
+
+	return z
+}
+
 // BatchInvertE12 returns a new slice with every element inverted.
 // Uses Montgomery batch inversion trick
 //
@@ -344,3 +383,56 @@ func (z *E12) MulByE2(x *E12, y *E4) *E12 {
 	z.C2.Mul(&x.C2, &yCopy)
 	return z
 }
+
+func (z *E12) Select(cond int, caseZ *E12, caseNz *E12) *E12 {
+	//Might be able to save a nanosecond or two by an aggregate implementation
+
+	z.C0.Select(cond, &caseZ.C0, &caseNz.C0)
+	z.C1.Select(cond, &caseZ.C1, &caseNz.C1)
+	z.C2.Select(cond, &caseZ.C2, &caseNz.C2)
+
+	return z
+}
+
+// ExpCT sets z=xᵏ and returns it
+//
+// Unlike Exp, ExpCT uses a Montgomery ladder over max(12*fp.Bits, k.BitLen()) bits with
+// constant-time selections, so that its sequence of operations doesn't depend on x and k (only the sign
+// of k and the bit length of exponents larger than 12*fp.Bits are leaked).
+// It is as constant-time as the E4 arithmetic it relies on.
+func (z *E12) ExpCT(x E12, k *big.Int) *E12 {
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ == (x⁻¹)ᵏ
+		x.InverseCT(&x)
+
+		// we negate k in a temp big.Int since
+		// Int.Bit(_) of k and -k is different
+		e = bigIntPool.Get().(*big.Int)
+		defer bigIntPool.Put(e)
+		e.Neg(k)
+	}
+
+	nbBits := 12 * fp.Bits
+	if e.BitLen() > nbBits {
+		nbBits = e.BitLen()
+	}
+
+	// invariant: r1 = r0 * x
+	var r0, r1, a, b E12
+	r0.SetOne()
+	r1.Set(&x)
+	for i := nbBits - 1; i >= 0; i-- {
+		bit := int(e.Bit(i))
+		// (a, b) = (r0, r1) if bit == 0, (r1, r0) otherwise
+		a.Select(bit, &r0, &r1)
+		b.Select(bit, &r1, &r0)
+		b.Mul(&a, &b)
+		a.Square(&a)
+		r0.Select(bit, &a, &b)
+		r1.Select(bit, &b, &a)
+	}
+
+	return z.Set(&r0)
+}
@@ -35,6 +35,38 @@ func mulGenericE2(z, x, y *E2) {
 	z.A0.Add(&c, &b)
 }
 
+// MulCT sets z to the E2-product of x,y, returns z
+//
+// Unlike Mul, MulCT only uses the branch-free fp.Element operations (AddCT, SubCT, MulCT).
+func (z *E2) MulCT(x, y *E2) *E2 {
+	var a, b, c fp.Element
+	a.AddCT(&x.A0, &x.A1)
+	b.AddCT(&y.A0, &y.A1)
+	a.MulCT(&a, &b)
+	b.MulCT(&x.A0, &y.A0)
+	c.MulCT(&x.A1, &y.A1)
+	z.A1.SubCT(&a, &b).SubCT(&z.A1, &c)
+	// 13c = 8c + 4c + c
+	a.DoubleCT(&c).DoubleCT(&a)
+	c.AddCT(&c, &a)
+	a.DoubleCT(&a)
+	c.AddCT(&c, &a)
+	z.A0.AddCT(&c, &b)
+	return z
+}
+
+// mulByNonResidueCT multiplies a E2 by (0,1), without branching
+func (z *E2) mulByNonResidueCT(x *E2) *E2 {
+	// 13a = 8a + 4a + a
+	var a, b fp.Element
+	a.DoubleCT(&x.A1).DoubleCT(&a)
+	b.AddCT(&x.A1, &a)
+	a.DoubleCT(&a)
+	z.A1 = x.A0
+	z.A0.AddCT(&b, &a)
+	return z
+}
+
 // Square sets z to the E2-product of x,x returns z
 func (z *E2) Square(x *E2) *E2 {
 	//algo 22 https://eprint.iacr.org/2010/354.pdf
@@ -86,6 +118,25 @@ func (z *E2) Inverse(x *E2) *E2 {
 	return z
 }
 
+// InverseCT sets z to the inverse of x and returns z
+//
+// Unlike Inverse, it inverts the norm of x with fp.InverseCT, and only uses the branch-free
+// operations (MulCT, NegCT), in constant time.
+//
+// if x == 0, sets and returns z = x
+func (z *E2) InverseCT(x *E2) *E2 {
+	// x⁻¹ = x̄ / N(x), with N(x) = x * x̄ in fp
+	var n E2
+	n.A0 = x.A0
+	n.A1.NegCT(&x.A1)
+	n.MulCT(x, &n)
+	n.A0.InverseCT(&n.A0)
+	z.A0.MulCT(&x.A0, &n.A0)
+	z.A1.MulCT(&x.A1, &n.A0).NegCT(&z.A1)
+
+	return z
+}
+
 // norm sets x to the norm of z
 func (z *E2) norm(x *fp.Element) {
 	var tmp0, tmp1 fp.Element
@@ -16,6 +16,7 @@ package fptower
 
 import (
 	"crypto/rand"
+	"math/big"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fp"
@@ -251,6 +252,16 @@ func TestE2Ops(t *testing.T) {
 		genA,
 	))
 
+	properties.Property("[BLS24-315] InverseCT must match Inverse", prop.ForAll(
+		func(a *E2) bool {
+			var b, c E2
+			b.InverseCT(a)
+			c.Inverse(a)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
 	properties.Property("[BLS24-315] neg twice should leave an element invariant", prop.ForAll(
 		func(a *E2) bool {
 			var b E2
@@ -511,3 +522,93 @@ func TestE2Div(t *testing.T) {
 
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
+
+func TestE2ConstantTime(t *testing.T) {
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 100
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := GenE2()
+	genB := GenE2()
+	genExp := GenFp()
+
+	properties.Property("[BLS24-315] InverseCT must match Inverse", prop.ForAll(
+		func(a *E2) bool {
+			var b, c E2
+			b.InverseCT(a)
+			c.Inverse(a)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[BLS24-315] ExpCT must match square and multiply", prop.ForAll(
+		func(a *E2, e fp.Element) bool {
+			var k big.Int
+			e.ToBigIntRegular(&k)
+			// square and multiply reference
+			var b, c E2
+			c.SetOne()
+			for i := k.BitLen() - 1; i >= 0; i-- {
+				c.Square(&c)
+				if k.Bit(i) == 1 {
+					c.Mul(&c, a)
+				}
+			}
+			b.ExpCT(*a, &k)
+			if !b.Equal(&c) {
+				return false
+			}
+			// xᵏ = (x⁻¹)⁻ᵏ
+			k.Neg(&k)
+			c.Inverse(&c)
+			b.ExpCT(*a, &k)
+			return b.Equal(&c)
+		},
+		genA,
+		genExp,
+	))
+
+	var nonSquare E2
+	for nonSquare.Legendre() != -1 {
+		_, _ = nonSquare.SetRandom()
+	}
+
+	properties.Property("[BLS24-315] SqrtCT must return a square root of a square", prop.ForAll(
+		func(a *E2) bool {
+			var b, c E2
+			b.Square(a)
+			if c.SqrtCT(&b) == nil {
+				return false
+			}
+			c.Square(&c)
+			if !c.Equal(&b) {
+				return false
+			}
+			// a non square has no square root
+			b.Mul(&b, &nonSquare)
+			return b.SqrtCT(&b) == nil
+		},
+		genA,
+	))
+
+	properties.Property("[BLS24-315] the CT operations must match their variable-time counterparts", prop.ForAll(
+		func(a, b *E2) bool {
+			var c, d E2
+			return c.AddCT(a, b).Equal(d.Add(a, b)) &&
+				c.SubCT(a, b).Equal(d.Sub(a, b)) &&
+				c.DoubleCT(a).Equal(d.Double(a)) &&
+				c.NegCT(a).Equal(d.Neg(a)) &&
+				c.MulCT(a, b).Equal(d.Mul(a, b)) &&
+				c.SquareCT(a).Equal(d.Square(a)) &&
+				(a.NotEqual(b) == 0) == a.Equal(b) &&
+				a.NotEqual(a) == 0
+		},
+		genA,
+		genB,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
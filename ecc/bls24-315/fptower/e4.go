@@ -15,6 +15,8 @@
 package fptower
 
 import (
+	"crypto/rand"
+	"io"
 	"math/big"
 
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fp"
@@ -137,12 +139,72 @@ func (z *E4) Neg(x *E4) *E4 {
 	return z
 }
 
-// SetRandom used only in tests
+// AddCT set z=x+y in E4 and return z, without branching (see fp.Element.AddCT)
+func (z *E4) AddCT(x, y *E4) *E4 {
+	z.B0.AddCT(&x.B0, &y.B0)
+	z.B1.AddCT(&x.B1, &y.B1)
+	return z
+}
+
+// SubCT sets z to x sub y and return z, without branching (see fp.Element.SubCT)
+func (z *E4) SubCT(x, y *E4) *E4 {
+	z.B0.SubCT(&x.B0, &y.B0)
+	z.B1.SubCT(&x.B1, &y.B1)
+	return z
+}
+
+// DoubleCT sets z=2*x and returns z, without branching (see fp.Element.DoubleCT)
+func (z *E4) DoubleCT(x *E4) *E4 {
+	z.B0.DoubleCT(&x.B0)
+	z.B1.DoubleCT(&x.B1)
+	return z
+}
+
+// NegCT negates an E4 element, without branching (see fp.Element.NegCT)
+func (z *E4) NegCT(x *E4) *E4 {
+	z.B0.NegCT(&x.B0)
+	z.B1.NegCT(&x.B1)
+	return z
+}
+
+// MulCT set z=x*y in E4 and return z
+//
+// Unlike Mul, MulCT only uses the branch-free E2 operations (AddCT, SubCT, MulCT).
+func (z *E4) MulCT(x, y *E4) *E4 {
+	var a, b, c E2
+	a.AddCT(&x.B0, &x.B1)
+	b.AddCT(&y.B0, &y.B1)
+	a.MulCT(&a, &b)
+	b.MulCT(&x.B0, &y.B0)
+	c.MulCT(&x.B1, &y.B1)
+	z.B1.SubCT(&a, &b).SubCT(&z.B1, &c)
+	z.B0.mulByNonResidueCT(&c).AddCT(&z.B0, &b)
+	return z
+}
+
+// SquareCT set z=x*x in E4 and return z, without branching (see MulCT)
+func (z *E4) SquareCT(x *E4) *E4 {
+	return z.MulCT(x, x)
+}
+
+// NotEqual returns 0 if and only if z == x; constant-time
+func (z *E4) NotEqual(x *E4) uint64 {
+	return z.B0.NotEqual(&x.B0) | z.B1.NotEqual(&x.B1)
+}
+
+// SetRandom sets z to a uniform random value, reading the randomness from crypto/rand.Reader
 func (z *E4) SetRandom() (*E4, error) {
-	if _, err := z.B0.SetRandom(); err != nil {
+	return z.SetRandomFrom(rand.Reader)
+}
+
+// SetRandomFrom sets z to a uniform random value, reading the randomness from r
+//
+// Each coordinate is sampled uniformly with SetRandomFrom, in order.
+func (z *E4) SetRandomFrom(r io.Reader) (*E4, error) {
+	if _, err := z.B0.SetRandomFrom(r); err != nil {
 		return nil, err
 	}
-	if _, err := z.B1.SetRandom(); err != nil {
+	if _, err := z.B1.SetRandomFrom(r); err != nil {
 		return nil, err
 	}
 	return z, nil
@@ -153,6 +215,14 @@ func (z *E4) IsZero() bool {
 	return z.B0.IsZero() && z.B1.IsZero()
 }
 
+// Select is a constant-time conditional move.
+// If cond=0, z = caseZ. Else z = caseNz
+func (z *E4) Select(cond int, caseZ *E4, caseNz *E4) *E4 {
+	z.B0.Select(cond, &caseZ.B0, &caseNz.B0)
+	z.B1.Select(cond, &caseZ.B1, &caseNz.B1)
+	return z
+}
+
 // MulByNonResidue mul x by (0,1)
 func (z *E4) MulByNonResidue(x *E4) *E4 {
 	z.B1, z.B0 = x.B0, x.B1
@@ -217,6 +287,25 @@ func (z *E4) Inverse(x *E4) *E4 {
 	return z
 }
 
+// InverseCT sets z to the inverse of x and returns z
+//
+// Unlike Inverse, it inverts the E2 element with E2.InverseCT, and only uses the branch-free
+// operations (MulCT, SubCT, NegCT), in constant time.
+//
+// if x == 0, sets and returns z = x
+func (z *E4) InverseCT(x *E4) *E4 {
+	var t0, t1, tmp E2
+	t0.SquareCT(&x.B0)
+	t1.SquareCT(&x.B1)
+	tmp.mulByNonResidueCT(&t1)
+	t0.SubCT(&t0, &tmp)
+	t1.InverseCT(&t0)
+	z.B0.MulCT(&x.B0, &t1)
+	z.B1.MulCT(&x.B1, &t1).NegCT(&z.B1)
+
+	return z
+}
+
 // Exp sets z=xᵏ (mod q⁴) and returns it
 func (z *E4) Exp(x E4, k *big.Int) *E4 {
 	if k.IsUint64() && k.Uint64() == 0 {
@@ -322,6 +411,57 @@ func (z *E4) Sqrt(x *E4) *E4 {
 	return z
 }
 
+// SqrtCT sets z to the square root of x and returns z
+// if the square root doesn't exist (x is not a square)
+// SqrtCT leaves z unchanged and returns nil
+//
+// Unlike Sqrt, SqrtCT computes both branches of the algorithm with constant-time
+// exponentiations and square roots in E2, and selects the result; only whether x is a square or not is leaked.
+// cf https://eprint.iacr.org/2012/685.pdf (algo 10)
+func (z *E4) SqrtCT(x *E4) *E4 {
+
+	// precomputation
+	var b, c, d, e, f, x0, y, y1, _g E4
+	var _b, o E2
+
+	// c must be a non square (works for p=1 mod 12 hence 1 mod 4, only bls377 has such a p currently)
+	c.B1.SetOne()
+
+	q := fp.Modulus()
+	var exp, one big.Int
+	one.SetUint64(1)
+	exp.Mul(q, q).Sub(&exp, &one).Rsh(&exp, 1)
+	d.Exp(c, &exp)
+	e.Mul(&d, &c).Inverse(&e)
+	f.Mul(&d, &c).Square(&f)
+
+	// computation
+	exp.Rsh(&exp, 1)
+	b.ExpCT(*x, &exp)
+	b.norm(&_b)
+	o.SetOne()
+	notOne := int(_b.A0.NotEqual(&o.A0) | _b.A1.NotEqual(&o.A1))
+
+	// if N(b) = 1, √x = b̄ * √(b²x)
+	x0.Square(&b).Mul(&x0, x)
+	_g.B0.SqrtCT(&x0.B0)
+	y.Conjugate(&b).Mul(&y, &_g)
+
+	// else √x = b̄ * √(b²xf) * e
+	x0.Mul(&x0, &f)
+	_g.B0.SetZero()
+	_g.B0.SqrtCT(&x0.B0)
+	y1.Conjugate(&b).Mul(&y1, &_g).Mul(&y1, &e)
+	y.Select(notOne, &y, &y1)
+
+	// ensure y * y = x
+	b.Square(&y)
+	if !b.Equal(x) {
+		return nil
+	}
+	return z.Set(&y)
+}
+
 // BatchInvertE4 returns a new slice with every element inverted.
 // Uses Montgomery batch inversion trick
 //
@@ -363,3 +503,46 @@ func (z *E4) Div(x *E4, y *E4) *E4 {
 	r.Inverse(y).Mul(x, &r)
 	return z.Set(&r)
 }
+
+// ExpCT sets z=xᵏ and returns it
+//
+// Unlike Exp, ExpCT uses a Montgomery ladder over max(4*fp.Bits, k.BitLen()) bits with
+// constant-time selections, so that its sequence of operations doesn't depend on x and k (only the sign
+// of k and the bit length of exponents larger than 4*fp.Bits are leaked).
+// It is as constant-time as the E2 arithmetic it relies on.
+func (z *E4) ExpCT(x E4, k *big.Int) *E4 {
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ == (x⁻¹)ᵏ
+		x.InverseCT(&x)
+
+		// we negate k in a temp big.Int since
+		// Int.Bit(_) of k and -k is different
+		e = bigIntPool.Get().(*big.Int)
+		defer bigIntPool.Put(e)
+		e.Neg(k)
+	}
+
+	nbBits := 4 * fp.Bits
+	if e.BitLen() > nbBits {
+		nbBits = e.BitLen()
+	}
+
+	// invariant: r1 = r0 * x
+	var r0, r1, a, b E4
+	r0.SetOne()
+	r1.Set(&x)
+	for i := nbBits - 1; i >= 0; i-- {
+		bit := int(e.Bit(i))
+		// (a, b) = (r0, r1) if bit == 0, (r1, r0) otherwise
+		a.Select(bit, &r0, &r1)
+		b.Select(bit, &r1, &r0)
+		b.Mul(&a, &b)
+		a.Square(&a)
+		r0.Select(bit, &a, &b)
+		r1.Select(bit, &b, &a)
+	}
+
+	return z.Set(&r0)
+}
@@ -15,6 +15,7 @@
 package fptower
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fp"
@@ -213,6 +214,16 @@ func TestE4Ops(t *testing.T) {
 		genA,
 	))
 
+	properties.Property("[BLS24-315] InverseCT must match Inverse", prop.ForAll(
+		func(a *E4) bool {
+			var b, c E4
+			b.InverseCT(a)
+			c.Inverse(a)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
 	properties.Property("[BLS24-315] square and mul should output the same result", prop.ForAll(
 		func(a *E4) bool {
 			var b, c E4
@@ -353,3 +364,93 @@ func BenchmarkE4Conjugate(b *testing.B) {
 		a.Conjugate(&a)
 	}
 }
+
+func TestE4ConstantTime(t *testing.T) {
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 100
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := GenE4()
+	genB := GenE4()
+	genExp := GenFp()
+
+	properties.Property("[BLS24-315] InverseCT must match Inverse", prop.ForAll(
+		func(a *E4) bool {
+			var b, c E4
+			b.InverseCT(a)
+			c.Inverse(a)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[BLS24-315] ExpCT must match square and multiply", prop.ForAll(
+		func(a *E4, e fp.Element) bool {
+			var k big.Int
+			e.ToBigIntRegular(&k)
+			// square and multiply reference
+			var b, c E4
+			c.SetOne()
+			for i := k.BitLen() - 1; i >= 0; i-- {
+				c.Square(&c)
+				if k.Bit(i) == 1 {
+					c.Mul(&c, a)
+				}
+			}
+			b.ExpCT(*a, &k)
+			if !b.Equal(&c) {
+				return false
+			}
+			// xᵏ = (x⁻¹)⁻ᵏ
+			k.Neg(&k)
+			c.Inverse(&c)
+			b.ExpCT(*a, &k)
+			return b.Equal(&c)
+		},
+		genA,
+		genExp,
+	))
+
+	var nonSquare E4
+	for nonSquare.Legendre() != -1 {
+		_, _ = nonSquare.SetRandom()
+	}
+
+	properties.Property("[BLS24-315] SqrtCT must return a square root of a square", prop.ForAll(
+		func(a *E4) bool {
+			var b, c E4
+			b.Square(a)
+			if c.SqrtCT(&b) == nil {
+				return false
+			}
+			c.Square(&c)
+			if !c.Equal(&b) {
+				return false
+			}
+			// a non square has no square root
+			b.Mul(&b, &nonSquare)
+			return b.SqrtCT(&b) == nil
+		},
+		genA,
+	))
+
+	properties.Property("[BLS24-315] the CT operations must match their variable-time counterparts", prop.ForAll(
+		func(a, b *E4) bool {
+			var c, d E4
+			return c.AddCT(a, b).Equal(d.Add(a, b)) &&
+				c.SubCT(a, b).Equal(d.Sub(a, b)) &&
+				c.DoubleCT(a).Equal(d.Double(a)) &&
+				c.NegCT(a).Equal(d.Neg(a)) &&
+				c.MulCT(a, b).Equal(d.Mul(a, b)) &&
+				c.SquareCT(a).Equal(d.Square(a)) &&
+				(a.NotEqual(b) == 0) == a.Equal(b) &&
+				a.NotEqual(a) == 0
+		},
+		genA,
+		genB,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
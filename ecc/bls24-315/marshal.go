@@ -24,8 +24,8 @@ import (
 	"sync/atomic"
 
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fptower"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
-	"github.com/consensys/gnark-crypto/ecc/bls24-315/internal/fptower"
 	"github.com/consensys/gnark-crypto/internal/parallel"
 )
 
@@ -47,6 +47,9 @@ const (
 	mCompressedInfinity   byte = 0b110 << 5
 )
 
+// decodeBlockSize is the maximum number of bytes read at once by the Decoder, when it decodes a slice of points
+const decodeBlockSize = 1 << 14
+
 // SizeOfGT represents the size in bytes that a GT element need in binary form
 const SizeOfGT = fptower.SizeOfGT
 
@@ -59,9 +62,10 @@ type Encoder struct {
 
 // Decoder reads bls24-315 object values from an inbound stream
 type Decoder struct {
-	r             io.Reader
-	n             int64 // read bytes
-	subGroupCheck bool  // default to true
+	r                  io.Reader
+	n                  int64 // read bytes
+	subGroupCheck      bool  // default to true
+	batchSubGroupCheck bool  // batch the subgroup checks of the points of a slice
 }
 
 // NewDecoder returns a binary decoder supporting curve bls24-315 objects in both
@@ -197,52 +201,7 @@ func (dec *Decoder) Decode(v interface{}) (err error) {
 		if len(*t) != int(sliceLen) {
 			*t = make([]G1Affine, sliceLen)
 		}
-		compressed := make([]bool, sliceLen)
-		for i := 0; i < len(*t); i++ {
-
-			// we start by reading compressed point size, if metadata tells us it is uncompressed, we read more.
-			read, err = io.ReadFull(dec.r, buf[:SizeOfG1AffineCompressed])
-			dec.n += int64(read)
-			if err != nil {
-				return
-			}
-			nbBytes := SizeOfG1AffineCompressed
-			// most significant byte contains metadata
-			if !isCompressed(buf[0]) {
-				nbBytes = SizeOfG1AffineUncompressed
-				// we read more.
-				read, err = io.ReadFull(dec.r, buf[SizeOfG1AffineCompressed:SizeOfG1AffineUncompressed])
-				dec.n += int64(read)
-				if err != nil {
-					return
-				}
-				_, err = (*t)[i].setBytes(buf[:nbBytes], false)
-				if err != nil {
-					return
-				}
-			} else {
-				compressed[i] = !((*t)[i].unsafeSetCompressedBytes(buf[:nbBytes]))
-			}
-		}
-		var nbErrs uint64
-		parallel.Execute(len(compressed), func(start, end int) {
-			for i := start; i < end; i++ {
-				if compressed[i] {
-					if err := (*t)[i].unsafeComputeY(dec.subGroupCheck); err != nil {
-						atomic.AddUint64(&nbErrs, 1)
-					}
-				} else if dec.subGroupCheck {
-					if !(*t)[i].IsInSubGroup() {
-						atomic.AddUint64(&nbErrs, 1)
-					}
-				}
-			}
-		})
-		if nbErrs != 0 {
-			return errors.New("point decompression failed")
-		}
-
-		return nil
+		return dec.decodePointsG1(*t)
 	case *[]G2Affine:
 		var sliceLen uint32
 		sliceLen, err = dec.readUint32()
@@ -252,52 +211,7 @@ func (dec *Decoder) Decode(v interface{}) (err error) {
 		if len(*t) != int(sliceLen) {
 			*t = make([]G2Affine, sliceLen)
 		}
-		compressed := make([]bool, sliceLen)
-		for i := 0; i < len(*t); i++ {
-
-			// we start by reading compressed point size, if metadata tells us it is uncompressed, we read more.
-			read, err = io.ReadFull(dec.r, buf[:SizeOfG2AffineCompressed])
-			dec.n += int64(read)
-			if err != nil {
-				return
-			}
-			nbBytes := SizeOfG2AffineCompressed
-			// most significant byte contains metadata
-			if !isCompressed(buf[0]) {
-				nbBytes = SizeOfG2AffineUncompressed
-				// we read more.
-				read, err = io.ReadFull(dec.r, buf[SizeOfG2AffineCompressed:SizeOfG2AffineUncompressed])
-				dec.n += int64(read)
-				if err != nil {
-					return
-				}
-				_, err = (*t)[i].setBytes(buf[:nbBytes], false)
-				if err != nil {
-					return
-				}
-			} else {
-				compressed[i] = !((*t)[i].unsafeSetCompressedBytes(buf[:nbBytes]))
-			}
-		}
-		var nbErrs uint64
-		parallel.Execute(len(compressed), func(start, end int) {
-			for i := start; i < end; i++ {
-				if compressed[i] {
-					if err := (*t)[i].unsafeComputeY(dec.subGroupCheck); err != nil {
-						atomic.AddUint64(&nbErrs, 1)
-					}
-				} else if dec.subGroupCheck {
-					if !(*t)[i].IsInSubGroup() {
-						atomic.AddUint64(&nbErrs, 1)
-					}
-				}
-			}
-		})
-		if nbErrs != 0 {
-			return errors.New("point decompression failed")
-		}
-
-		return nil
+		return dec.decodePointsG2(*t)
 	default:
 		n := binary.Size(t)
 		if n == -1 {
@@ -380,6 +294,16 @@ func NoSubgroupChecks() func(*Decoder) {
 	}
 }
 
+// BatchSubgroupChecks returns an option to use in NewDecoder(...) which batches the subgroup checks of the
+// points of the slices the decoder will read: instead of one check per point, a slice is checked at once with
+// random linear combinations of its points (see BatchIsInSubGroupG1), which is probabilistic.
+// It has no effect on single points, nor with NoSubgroupChecks.
+func BatchSubgroupChecks() func(*Decoder) {
+	return func(dec *Decoder) {
+		dec.batchSubGroupCheck = true
+	}
+}
+
 func (enc *Encoder) encode(v interface{}) (err error) {
 	rv := reflect.ValueOf(v)
 	if v == nil || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
@@ -871,6 +795,90 @@ func (p *G1Affine) unsafeSetCompressedBytes(buf []byte) (isInfinity bool) {
 	return
 }
 
+// decodePointsG1 decodes len(points) points, encoded as the elements of a []G1Affine
+// (without the length of the slice)
+//
+// The points are read by blocks of up to decodeBlockSize bytes, without reading past the last point: each
+// point left takes at least SizeOfG1AffineCompressed bytes. Their X coordinates are set as they're read,
+// then the Y coordinates of the compressed points (and the subgroup checks) are computed in parallel.
+func (dec *Decoder) decodePointsG1(points []G1Affine) error {
+	var block [decodeBlockSize + SizeOfG1AffineUncompressed]byte
+	var buf []byte // bytes read, not decoded yet
+
+	// fill reads bytes until buf holds at least n of them, and reads ahead up to max bytes
+	fill := func(n, max int) error {
+		if len(buf) >= n {
+			return nil
+		}
+		if max > decodeBlockSize {
+			max = decodeBlockSize
+		}
+		if max < n {
+			max = n
+		}
+		k := copy(block[:], buf)
+		read, err := io.ReadFull(dec.r, block[k:max])
+		dec.n += int64(read)
+		buf = block[:k+read]
+		return err
+	}
+
+	compressed := make([]bool, len(points))
+	for i := range points {
+		minLeft := (len(points) - i) * SizeOfG1AffineCompressed
+		if err := fill(SizeOfG1AffineCompressed, minLeft); err != nil {
+			return err
+		}
+		// most significant byte contains metadata
+		if isCompressed(buf[0]) {
+			compressed[i] = !(points[i].unsafeSetCompressedBytes(buf[:SizeOfG1AffineCompressed]))
+			buf = buf[SizeOfG1AffineCompressed:]
+			continue
+		}
+		if err := fill(SizeOfG1AffineUncompressed, minLeft+SizeOfG1AffineUncompressed-SizeOfG1AffineCompressed); err != nil {
+			return err
+		}
+		if _, err := points[i].setBytes(buf[:SizeOfG1AffineUncompressed], false); err != nil {
+			return err
+		}
+		buf = buf[SizeOfG1AffineUncompressed:]
+	}
+
+	return dec.computeYG1(points, compressed)
+}
+
+// computeYG1 computes in parallel the Y coordinates of the compressed points, which X coordinates
+// are set by unsafeSetCompressedBytes, and checks the points according to the options of the decoder
+//
+// Each compressed point costs a square root (see unsafeComputeY): the parallelism is on the points, the square
+// roots aren't batched.
+func (dec *Decoder) computeYG1(points []G1Affine, compressed []bool) error {
+	// with batched subgroup checks, the points are checked once decoded
+	checkPoint := dec.subGroupCheck && !dec.batchSubGroupCheck
+	var nbErrs uint64
+	parallel.Execute(len(compressed), func(start, end int) {
+		for i := start; i < end; i++ {
+			if compressed[i] {
+				if err := points[i].unsafeComputeY(checkPoint); err != nil {
+					atomic.AddUint64(&nbErrs, 1)
+				}
+			} else if checkPoint {
+				if !points[i].IsInSubGroup() {
+					atomic.AddUint64(&nbErrs, 1)
+				}
+			}
+		}
+	})
+	if nbErrs != 0 {
+		return errors.New("point decompression failed")
+	}
+	if dec.subGroupCheck && dec.batchSubGroupCheck && !BatchIsInSubGroupG1(points) {
+		return errors.New("invalid point: subgroup check failed")
+	}
+
+	return nil
+}
+
 // SizeOfG2AffineCompressed represents the size in bytes that a G2Affine need in binary form, compressed
 const SizeOfG2AffineCompressed = 40 * 4
 
@@ -1127,10 +1135,11 @@ func (p *G2Affine) setBytes(buf []byte, subGroupCheck bool) (int, error) {
 
 	YSquared.Square(&p.X).Mul(&YSquared, &p.X)
 	YSquared.Add(&YSquared, &bTwistCurveCoeff)
-	if YSquared.Legendre() == -1 {
+	// Sqrt doesn't check that YSquared is a square: we check the result, cheaper than a Legendre symbol
+	var check fptower.E4
+	if !check.Square(Y.Sqrt(&YSquared)).Equal(&YSquared) {
 		return 0, errors.New("invalid compressed coordinate: square root doesn't exist")
 	}
-	Y.Sqrt(&YSquared)
 
 	if Y.LexicographicallyLargest() {
 		// Y ">" -Y
@@ -1166,10 +1175,11 @@ func (p *G2Affine) unsafeComputeY(subGroupCheck bool) error {
 
 	YSquared.Square(&p.X).Mul(&YSquared, &p.X)
 	YSquared.Add(&YSquared, &bTwistCurveCoeff)
-	if YSquared.Legendre() == -1 {
+	// Sqrt doesn't check that YSquared is a square: we check the result, cheaper than a Legendre symbol
+	var check fptower.E4
+	if !check.Square(Y.Sqrt(&YSquared)).Equal(&YSquared) {
 		return errors.New("invalid compressed coordinate: square root doesn't exist")
 	}
-	Y.Sqrt(&YSquared)
 
 	if Y.LexicographicallyLargest() {
 		// Y ">" -Y
@@ -1227,3 +1237,87 @@ func (p *G2Affine) unsafeSetCompressedBytes(buf []byte) (isInfinity bool) {
 	// recomputing Y will be done asynchronously
 	return
 }
+
+// decodePointsG2 decodes len(points) points, encoded as the elements of a []G2Affine
+// (without the length of the slice)
+//
+// The points are read by blocks of up to decodeBlockSize bytes, without reading past the last point: each
+// point left takes at least SizeOfG2AffineCompressed bytes. Their X coordinates are set as they're read,
+// then the Y coordinates of the compressed points (and the subgroup checks) are computed in parallel.
+func (dec *Decoder) decodePointsG2(points []G2Affine) error {
+	var block [decodeBlockSize + SizeOfG2AffineUncompressed]byte
+	var buf []byte // bytes read, not decoded yet
+
+	// fill reads bytes until buf holds at least n of them, and reads ahead up to max bytes
+	fill := func(n, max int) error {
+		if len(buf) >= n {
+			return nil
+		}
+		if max > decodeBlockSize {
+			max = decodeBlockSize
+		}
+		if max < n {
+			max = n
+		}
+		k := copy(block[:], buf)
+		read, err := io.ReadFull(dec.r, block[k:max])
+		dec.n += int64(read)
+		buf = block[:k+read]
+		return err
+	}
+
+	compressed := make([]bool, len(points))
+	for i := range points {
+		minLeft := (len(points) - i) * SizeOfG2AffineCompressed
+		if err := fill(SizeOfG2AffineCompressed, minLeft); err != nil {
+			return err
+		}
+		// most significant byte contains metadata
+		if isCompressed(buf[0]) {
+			compressed[i] = !(points[i].unsafeSetCompressedBytes(buf[:SizeOfG2AffineCompressed]))
+			buf = buf[SizeOfG2AffineCompressed:]
+			continue
+		}
+		if err := fill(SizeOfG2AffineUncompressed, minLeft+SizeOfG2AffineUncompressed-SizeOfG2AffineCompressed); err != nil {
+			return err
+		}
+		if _, err := points[i].setBytes(buf[:SizeOfG2AffineUncompressed], false); err != nil {
+			return err
+		}
+		buf = buf[SizeOfG2AffineUncompressed:]
+	}
+
+	return dec.computeYG2(points, compressed)
+}
+
+// computeYG2 computes in parallel the Y coordinates of the compressed points, which X coordinates
+// are set by unsafeSetCompressedBytes, and checks the points according to the options of the decoder
+//
+// Each compressed point costs a square root (see unsafeComputeY): the parallelism is on the points, the square
+// roots aren't batched.
+func (dec *Decoder) computeYG2(points []G2Affine, compressed []bool) error {
+	// with batched subgroup checks, the points are checked once decoded
+	checkPoint := dec.subGroupCheck && !dec.batchSubGroupCheck
+	var nbErrs uint64
+	parallel.Execute(len(compressed), func(start, end int) {
+		for i := start; i < end; i++ {
+			if compressed[i] {
+				if err := points[i].unsafeComputeY(checkPoint); err != nil {
+					atomic.AddUint64(&nbErrs, 1)
+				}
+			} else if checkPoint {
+				if !points[i].IsInSubGroup() {
+					atomic.AddUint64(&nbErrs, 1)
+				}
+			}
+		}
+	})
+	if nbErrs != 0 {
+		return errors.New("point decompression failed")
+	}
+	if dec.subGroupCheck && dec.batchSubGroupCheck && !BatchIsInSubGroupG2(points) {
+		return errors.New("invalid point: subgroup check failed")
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls24315
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestMultiExpBatchAffineG1(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 2
+	} else {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	const nbSamples = 500
+
+	// a few distinct points, their opposites and the infinity, so that the buckets hit the
+	// exceptional cases of the affine addition
+	var g G1Jac
+	g.Set(&g1Gen)
+	samplePoints := make([]G1Affine, nbSamples)
+	for i := 0; i < nbSamples; i++ {
+		switch i % 5 {
+		case 0:
+			samplePoints[i].FromJacobian(&g)
+		case 1:
+			samplePoints[i].Neg(&samplePoints[i-1])
+		case 4:
+			// infinity
+		default:
+			samplePoints[i].Set(&samplePoints[i-1])
+		}
+		if i%50 == 49 {
+			g.AddAssign(&g1Gen)
+		}
+	}
+
+	properties.Property("[BLS24-315] MultiExp with batch affine buckets should be consistent with extended Jacobian ones", prop.ForAll(
+		func(mixer fr.Element) bool {
+			// few distinct scalars, so that the points conflict in the batches
+			sampleScalars := make([]fr.Element, nbSamples)
+			for i := 0; i < nbSamples; i++ {
+				sampleScalars[i].SetUint64(uint64(i%7)).Mul(&sampleScalars[i], &mixer)
+			}
+
+			var expected, result G1Jac
+			expected.MultiExp(samplePoints, sampleScalars, ecc.MultiExpConfig{ScalarsMont: true, Buckets: ecc.BucketsExtendedJacobian})
+			for _, nbTasks := range []int{1, 5} {
+				result.MultiExp(samplePoints, sampleScalars, ecc.MultiExpConfig{ScalarsMont: true, Buckets: ecc.BucketsBatchAffine, NbTasks: nbTasks})
+				if !result.Equal(&expected) {
+					return false
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestMultiExpBatchAffineG2(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 2
+	} else {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	const nbSamples = 500
+
+	// a few distinct points, their opposites and the infinity, so that the buckets hit the
+	// exceptional cases of the affine addition
+	var g G2Jac
+	g.Set(&g2Gen)
+	samplePoints := make([]G2Affine, nbSamples)
+	for i := 0; i < nbSamples; i++ {
+		switch i % 5 {
+		case 0:
+			samplePoints[i].FromJacobian(&g)
+		case 1:
+			samplePoints[i].Neg(&samplePoints[i-1])
+		case 4:
+			// infinity
+		default:
+			samplePoints[i].Set(&samplePoints[i-1])
+		}
+		if i%50 == 49 {
+			g.AddAssign(&g2Gen)
+		}
+	}
+
+	properties.Property("[BLS24-315] MultiExp with batch affine buckets should be consistent with extended Jacobian ones", prop.ForAll(
+		func(mixer fr.Element) bool {
+			// few distinct scalars, so that the points conflict in the batches
+			sampleScalars := make([]fr.Element, nbSamples)
+			for i := 0; i < nbSamples; i++ {
+				sampleScalars[i].SetUint64(uint64(i%7)).Mul(&sampleScalars[i], &mixer)
+			}
+
+			var expected, result G2Jac
+			expected.MultiExp(samplePoints, sampleScalars, ecc.MultiExpConfig{ScalarsMont: true, Buckets: ecc.BucketsExtendedJacobian})
+			for _, nbTasks := range []int{1, 5} {
+				result.MultiExp(samplePoints, sampleScalars, ecc.MultiExpConfig{ScalarsMont: true, Buckets: ecc.BucketsBatchAffine, NbTasks: nbTasks})
+				if !result.Equal(&expected) {
+					return false
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
@@ -0,0 +1,387 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls24315
+
+import (
+	"errors"
+	"runtime"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// msmBucketsMaxC bounds the window size of MultiExpBatch and MultiExpReader, which hold several sets of
+// buckets at once (2^{c-1} buckets per scalar vector or per window)
+const msmBucketsMaxC = 16
+
+// msmBatchMaxBuckets is the number of buckets MultiExpBatch aims to hold at once, for all the scalar vectors
+// and all the tasks: the window size is lowered until len(scalars) * nbTasks * 2^{c-1} fits
+const msmBatchMaxBuckets = 1 << 20
+
+// msmBucketsBestC returns the window size minimizing the approximate cost of a multiExp of nbPoints points,
+// with digits of nbBits bits, among the window sizes up to maxC (or the smallest valid one above it)
+func msmBucketsBestC(nbPoints, nbBits int, maxC uint64) uint64 {
+	// cost = bits/c * (nbPoints + 2^{c}), as in MultiExp
+	var C uint64
+	min := -1.0
+	for c := uint64(2); c <= msmBucketsMaxC; c++ {
+		if c > maxC && C != 0 {
+			break
+		}
+		// partitionScalars drops the carry of the last window (see precomputedValidC)
+		if nbBits == fr.Limbs*64 && !precomputedValidC(c) {
+			continue
+		}
+		cost := float64(nbBits*(nbPoints+(1<<c))) / float64(c)
+		if min < 0 || cost < min {
+			min = cost
+			C = c
+		}
+	}
+	return C
+}
+
+// MultiExpBatchG1 computes the multiExps ∑ⱼ scalars[i][j] * points[j], for each scalar vector scalars[i]
+//
+// The multiExps share the reads of the points and the setup of the buckets: each c-bit window is processed
+// for all the scalar vectors at once, a point being added to the buckets of each vector.
+// The buckets are in extended Jacobian coordinates, and the scalars aren't decomposed with GLV:
+// config.Buckets and config.EnableGLV are ignored.
+//
+// Memory: each task holds 2^{c-1} buckets per scalar vector, and the digits of all the vectors are computed
+// upfront (len(scalars) * len(points) elements). The window size c is capped so that the buckets of all the
+// tasks stay around msmBatchMaxBuckets points, at the cost of more windows: for a large number of vectors,
+// calling MultiExpBatch on groups of vectors bounds the memory use further.
+//
+// This call return an error if a scalar vector doesn't have len(points) elements or if provided config is invalid.
+func MultiExpBatchG1(points []G1Affine, scalars [][]fr.Element, config ecc.MultiExpConfig) ([]G1Jac, error) {
+	nbPoints := len(points)
+	for i := range scalars {
+		if len(scalars[i]) != nbPoints {
+			return nil, errors.New("len(points) != len(scalars)")
+		}
+	}
+
+	// if nbTasks is not set, use all available CPUs
+	if config.NbTasks <= 0 {
+		config.NbTasks = runtime.NumCPU()
+	} else if config.NbTasks > 1024 {
+		return nil, errors.New("invalid config: config.NbTasks > 1024")
+	}
+
+	res := make([]G1Jac, len(scalars))
+	for i := range res {
+		res[i].X.SetOne()
+		res[i].Y.SetOne()
+	}
+
+	// the bit length of the scalars bounds the number of windows with non-zero digits
+	scalarsBits := config.MaxScalarBits
+	if scalarsBits <= 0 || scalarsBits > fr.Bits {
+		scalarsBits = 0
+		for i := range scalars {
+			if l := scalarsBitLen(scalars[i], config.ScalarsMont, config.NbTasks); l > scalarsBits {
+				scalarsBits = l
+			}
+		}
+	}
+	if scalarsBits == 0 || nbPoints == 0 {
+		return res, nil
+	}
+
+	// number of bits of the digits, with 2 bits above the scalars to absorb the carry of partitionScalars
+	nbBits := fr.Limbs * 64
+	if scalarsBits+2 < nbBits {
+		nbBits = scalarsBits + 2
+	}
+	// the tasks hold 2^{c-1} buckets per scalar vector each
+	maxC := uint64(msmBucketsMaxC)
+	for maxC > 2 && len(scalars)*config.NbTasks<<(maxC-1) > msmBatchMaxBuckets {
+		maxC--
+	}
+	c := msmBucketsBestC(nbPoints, nbBits, maxC)
+	nbChunks := (nbBits + int(c) - 1) / int(c)
+
+	digits := make([][]fr.Element, len(scalars))
+	for i := range scalars {
+		digits[i], _ = partitionScalars(scalars[i], c, config.ScalarsMont, config.NbTasks)
+	}
+
+	// totals[j][i] is the sum of the buckets of the chunk j of the vector i
+	totals := make([][]g1JacExtended, nbChunks)
+	parallel.Execute(nbChunks, func(start, end int) {
+		buckets := make([][]g1JacExtended, len(scalars))
+		for i := range buckets {
+			buckets[i] = make([]g1JacExtended, 1<<(c-1))
+		}
+		for j := start; j < end; j++ {
+			totals[j] = make([]g1JacExtended, len(scalars))
+			msmBatchProcessChunkG1Affine(uint64(j), c, points, digits, buckets, totals[j])
+		}
+	}, config.NbTasks)
+
+	// res[i] = ∑ⱼ 2^{c*j} totals[j][i]
+	parallel.Execute(len(scalars), func(start, end int) {
+		var p g1JacExtended
+		for i := start; i < end; i++ {
+			p.Set(&totals[nbChunks-1][i])
+			for j := nbChunks - 2; j >= 0; j-- {
+				for l := uint64(0); l < c; l++ {
+					p.double(&p)
+				}
+				p.add(&totals[j][i])
+			}
+			res[i].unsafeFromJacExtended(&p)
+		}
+	}, config.NbTasks)
+
+	return res, nil
+}
+
+// msmBatchProcessChunkG1Affine places the points into the buckets of each scalar vector, according to
+// the digits of the chunk, and sets totals[i] to the weighted sum of the buckets of the vector i
+func msmBatchProcessChunkG1Affine(chunk uint64,
+	c uint64,
+	points []G1Affine,
+	digits [][]fr.Element,
+	buckets [][]g1JacExtended,
+	totals []g1JacExtended) {
+
+	mask := uint64((1 << c) - 1) // low c bits are 1
+	msbWindow := uint64(1 << (c - 1))
+
+	for i := range buckets {
+		for k := range buckets[i] {
+			buckets[i][k].setInfinity()
+		}
+	}
+
+	jc := uint64(chunk * c)
+	s := selector{}
+	s.index = jc / 64
+	s.shift = jc - (s.index * 64)
+	s.mask = mask << s.shift
+	s.multiWordSelect = (64%c) != 0 && s.shift > (64-c) && s.index < (fr.Limbs-1)
+	if s.multiWordSelect {
+		nbBitsHigh := s.shift - uint64(64-c)
+		s.maskHigh = (1 << nbBitsHigh) - 1
+		s.shiftHigh = (c - nbBitsHigh)
+	}
+
+	// each point is read once, and added to the buckets of all the vectors
+	for j := range points {
+		for i := range digits {
+			bits := (digits[i][j][s.index] & s.mask) >> s.shift
+			if s.multiWordSelect {
+				bits += (digits[i][j][s.index+1] & s.maskHigh) << s.shiftHigh
+			}
+
+			if bits == 0 {
+				continue
+			}
+
+			// if msbWindow bit is set, we need to substract
+			if bits&msbWindow == 0 {
+				// add
+				buckets[i][bits-1].addMixed(&points[j])
+			} else {
+				// sub
+				buckets[i][bits & ^msbWindow].subMixed(&points[j])
+			}
+		}
+	}
+
+	// reduce buckets into totals
+	// total =  bucket[0] + 2*bucket[1] + 3*bucket[2] ... + n*bucket[n-1]
+	var runningSum g1JacExtended
+	for i := range buckets {
+		runningSum.setInfinity()
+		totals[i].setInfinity()
+		for k := len(buckets[i]) - 1; k >= 0; k-- {
+			if !buckets[i][k].ZZ.IsZero() {
+				runningSum.add(&buckets[i][k])
+			}
+			totals[i].add(&runningSum)
+		}
+	}
+}
+
+// MultiExpBatchG2 computes the multiExps ∑ⱼ scalars[i][j] * points[j], for each scalar vector scalars[i]
+//
+// The multiExps share the reads of the points and the setup of the buckets: each c-bit window is processed
+// for all the scalar vectors at once, a point being added to the buckets of each vector.
+// The buckets are in extended Jacobian coordinates, and the scalars aren't decomposed with GLV:
+// config.Buckets and config.EnableGLV are ignored.
+//
+// Memory: each task holds 2^{c-1} buckets per scalar vector, and the digits of all the vectors are computed
+// upfront (len(scalars) * len(points) elements). The window size c is capped so that the buckets of all the
+// tasks stay around msmBatchMaxBuckets points, at the cost of more windows: for a large number of vectors,
+// calling MultiExpBatch on groups of vectors bounds the memory use further.
+//
+// This call return an error if a scalar vector doesn't have len(points) elements or if provided config is invalid.
+func MultiExpBatchG2(points []G2Affine, scalars [][]fr.Element, config ecc.MultiExpConfig) ([]G2Jac, error) {
+	nbPoints := len(points)
+	for i := range scalars {
+		if len(scalars[i]) != nbPoints {
+			return nil, errors.New("len(points) != len(scalars)")
+		}
+	}
+
+	// if nbTasks is not set, use all available CPUs
+	if config.NbTasks <= 0 {
+		config.NbTasks = runtime.NumCPU()
+	} else if config.NbTasks > 1024 {
+		return nil, errors.New("invalid config: config.NbTasks > 1024")
+	}
+
+	res := make([]G2Jac, len(scalars))
+	for i := range res {
+		res[i].X.SetOne()
+		res[i].Y.SetOne()
+	}
+
+	// the bit length of the scalars bounds the number of windows with non-zero digits
+	scalarsBits := config.MaxScalarBits
+	if scalarsBits <= 0 || scalarsBits > fr.Bits {
+		scalarsBits = 0
+		for i := range scalars {
+			if l := scalarsBitLen(scalars[i], config.ScalarsMont, config.NbTasks); l > scalarsBits {
+				scalarsBits = l
+			}
+		}
+	}
+	if scalarsBits == 0 || nbPoints == 0 {
+		return res, nil
+	}
+
+	// number of bits of the digits, with 2 bits above the scalars to absorb the carry of partitionScalars
+	nbBits := fr.Limbs * 64
+	if scalarsBits+2 < nbBits {
+		nbBits = scalarsBits + 2
+	}
+	// the tasks hold 2^{c-1} buckets per scalar vector each
+	maxC := uint64(msmBucketsMaxC)
+	for maxC > 2 && len(scalars)*config.NbTasks<<(maxC-1) > msmBatchMaxBuckets {
+		maxC--
+	}
+	c := msmBucketsBestC(nbPoints, nbBits, maxC)
+	nbChunks := (nbBits + int(c) - 1) / int(c)
+
+	digits := make([][]fr.Element, len(scalars))
+	for i := range scalars {
+		digits[i], _ = partitionScalars(scalars[i], c, config.ScalarsMont, config.NbTasks)
+	}
+
+	// totals[j][i] is the sum of the buckets of the chunk j of the vector i
+	totals := make([][]g2JacExtended, nbChunks)
+	parallel.Execute(nbChunks, func(start, end int) {
+		buckets := make([][]g2JacExtended, len(scalars))
+		for i := range buckets {
+			buckets[i] = make([]g2JacExtended, 1<<(c-1))
+		}
+		for j := start; j < end; j++ {
+			totals[j] = make([]g2JacExtended, len(scalars))
+			msmBatchProcessChunkG2Affine(uint64(j), c, points, digits, buckets, totals[j])
+		}
+	}, config.NbTasks)
+
+	// res[i] = ∑ⱼ 2^{c*j} totals[j][i]
+	parallel.Execute(len(scalars), func(start, end int) {
+		var p g2JacExtended
+		for i := start; i < end; i++ {
+			p.Set(&totals[nbChunks-1][i])
+			for j := nbChunks - 2; j >= 0; j-- {
+				for l := uint64(0); l < c; l++ {
+					p.double(&p)
+				}
+				p.add(&totals[j][i])
+			}
+			res[i].unsafeFromJacExtended(&p)
+		}
+	}, config.NbTasks)
+
+	return res, nil
+}
+
+// msmBatchProcessChunkG2Affine places the points into the buckets of each scalar vector, according to
+// the digits of the chunk, and sets totals[i] to the weighted sum of the buckets of the vector i
+func msmBatchProcessChunkG2Affine(chunk uint64,
+	c uint64,
+	points []G2Affine,
+	digits [][]fr.Element,
+	buckets [][]g2JacExtended,
+	totals []g2JacExtended) {
+
+	mask := uint64((1 << c) - 1) // low c bits are 1
+	msbWindow := uint64(1 << (c - 1))
+
+	for i := range buckets {
+		for k := range buckets[i] {
+			buckets[i][k].setInfinity()
+		}
+	}
+
+	jc := uint64(chunk * c)
+	s := selector{}
+	s.index = jc / 64
+	s.shift = jc - (s.index * 64)
+	s.mask = mask << s.shift
+	s.multiWordSelect = (64%c) != 0 && s.shift > (64-c) && s.index < (fr.Limbs-1)
+	if s.multiWordSelect {
+		nbBitsHigh := s.shift - uint64(64-c)
+		s.maskHigh = (1 << nbBitsHigh) - 1
+		s.shiftHigh = (c - nbBitsHigh)
+	}
+
+	// each point is read once, and added to the buckets of all the vectors
+	for j := range points {
+		for i := range digits {
+			bits := (digits[i][j][s.index] & s.mask) >> s.shift
+			if s.multiWordSelect {
+				bits += (digits[i][j][s.index+1] & s.maskHigh) << s.shiftHigh
+			}
+
+			if bits == 0 {
+				continue
+			}
+
+			// if msbWindow bit is set, we need to substract
+			if bits&msbWindow == 0 {
+				// add
+				buckets[i][bits-1].addMixed(&points[j])
+			} else {
+				// sub
+				buckets[i][bits & ^msbWindow].subMixed(&points[j])
+			}
+		}
+	}
+
+	// reduce buckets into totals
+	// total =  bucket[0] + 2*bucket[1] + 3*bucket[2] ... + n*bucket[n-1]
+	var runningSum g2JacExtended
+	for i := range buckets {
+		runningSum.setInfinity()
+		totals[i].setInfinity()
+		for k := len(buckets[i]) - 1; k >= 0; k-- {
+			if !buckets[i][k].ZZ.IsZero() {
+				runningSum.add(&buckets[i][k])
+			}
+			totals[i].add(&runningSum)
+		}
+	}
+}
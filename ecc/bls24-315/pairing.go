@@ -16,8 +16,10 @@ package bls24315
 
 import (
 	"errors"
+	"io"
 
-	"github.com/consensys/gnark-crypto/ecc/bls24-315/internal/fptower"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fptower"
 )
 
 // GT target group of the pairing
@@ -266,3 +268,173 @@ func (p *g2Proj) AddMixedStep(evaluations *lineEvaluation, a *G2Affine) {
 	evaluations.r1.Neg(&O)
 	evaluations.r2.Set(&J)
 }
+
+// LineEvaluations are the lines of the Miller loop of a point Q of G2, which don't depend on the point of G1
+// they're evaluated at (see PrecomputeLines)
+type LineEvaluations struct {
+	lines    []lineEvaluation
+	infinity bool // Q is the infinity
+}
+
+// nbLineEvaluations returns the number of lines of the Miller loop of a point of G2
+func nbLineEvaluations() int {
+	// doubling at i == len(loopCounter) - 2
+	n := 1
+	for i := len(loopCounter) - 3; i >= 0; i-- {
+		n++
+		if loopCounter[i] != 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// PrecomputeLines computes the lines of the Miller loop of Q, to compute the Miller loops of several points of
+// G1 with Q without doubling and adding Q each time (see MillerLoopFixedQ)
+func PrecomputeLines(Q G2Affine) LineEvaluations {
+	if Q.IsInfinity() {
+		return LineEvaluations{infinity: true}
+	}
+	lines := make([]lineEvaluation, 0, nbLineEvaluations())
+
+	var qProj g2Proj
+	var qNeg G2Affine
+	qProj.FromAffine(&Q)
+	qNeg.Neg(&Q)
+
+	var l lineEvaluation
+
+	// i == len(loopCounter) - 2
+	qProj.DoubleStep(&l)
+	lines = append(lines, l)
+
+	for i := len(loopCounter) - 3; i >= 0; i-- {
+		qProj.DoubleStep(&l)
+		lines = append(lines, l)
+
+		if loopCounter[i] == 1 {
+			qProj.AddMixedStep(&l, &Q)
+			lines = append(lines, l)
+		} else if loopCounter[i] == -1 {
+			qProj.AddMixedStep(&l, &qNeg)
+			lines = append(lines, l)
+		}
+	}
+
+	return LineEvaluations{lines: lines}
+}
+
+// MillerLoopFixedQ computes the multi-Miller loop
+// ∏ᵢ MillerLoop(Pᵢ, Qᵢ)
+// from the lines of the Qᵢ computed by PrecomputeLines
+func MillerLoopFixedQ(P []G1Affine, lines []LineEvaluations) (GT, error) {
+	// check input size match
+	n := len(P)
+	if n == 0 || n != len(lines) {
+		return GT{}, errors.New("invalid inputs sizes")
+	}
+
+	// filter infinity points
+	nbLines := nbLineEvaluations()
+	p := make([]G1Affine, 0, n)
+	q := make([][]lineEvaluation, 0, n)
+
+	for k := 0; k < n; k++ {
+		if !lines[k].infinity && len(lines[k].lines) != nbLines {
+			return GT{}, errors.New("invalid line evaluations")
+		}
+		if P[k].IsInfinity() || lines[k].infinity {
+			continue
+		}
+		p = append(p, P[k])
+		q = append(q, lines[k].lines)
+	}
+	n = len(p)
+
+	var result GT
+	result.SetOne()
+
+	var l lineEvaluation
+
+	// i == len(loopCounter) - 2
+	for k := 0; k < n; k++ {
+		l.evaluate(&q[k][0], &p[k])
+		result.MulBy034(&l.r0, &l.r1, &l.r2)
+	}
+
+	j := 1
+	for i := len(loopCounter) - 3; i >= 0; i-- {
+		// (∏ᵢfᵢ)²
+		result.Square(&result)
+
+		for k := 0; k < n; k++ {
+			l.evaluate(&q[k][j], &p[k])
+			result.MulBy034(&l.r0, &l.r1, &l.r2)
+
+			if loopCounter[i] != 0 {
+				l.evaluate(&q[k][j+1], &p[k])
+				result.MulBy034(&l.r0, &l.r1, &l.r2)
+			}
+		}
+
+		j++
+		if loopCounter[i] != 0 {
+			j++
+		}
+	}
+
+	result.Conjugate(&result)
+
+	return result, nil
+}
+
+// evaluate sets l to the line evaluated at p
+func (l *lineEvaluation) evaluate(line *lineEvaluation, p *G1Affine) {
+	l.r0.MulByElement(&line.r0, &p.Y)
+	l.r1.MulByElement(&line.r1, &p.X)
+	l.r2.Set(&line.r2)
+}
+
+// WriteTo writes the binary encoding of the lines: the coordinates of their coefficients, as a []fp.Element
+// (empty if Q is the infinity)
+func (lines *LineEvaluations) WriteTo(w io.Writer) (int64, error) {
+	if !lines.infinity && len(lines.lines) == 0 {
+		return 0, errors.New("line evaluations not initialized, see PrecomputeLines")
+	}
+	coeffs := make([]fp.Element, 0, len(lines.lines)*12)
+	for i := range lines.lines {
+		l := &lines.lines[i]
+		coeffs = append(coeffs, l.r0.B0.A0, l.r0.B0.A1, l.r0.B1.A0, l.r0.B1.A1, l.r1.B0.A0, l.r1.B0.A1, l.r1.B1.A0, l.r1.B1.A1, l.r2.B0.A0, l.r2.B0.A1, l.r2.B1.A0, l.r2.B1.A1)
+	}
+
+	enc := NewEncoder(w)
+	err := enc.Encode(coeffs)
+	return enc.BytesWritten(), err
+}
+
+// ReadFrom decodes lines written by WriteTo
+//
+// The lines are only checked to be as many as those of PrecomputeLines: they must come from a trusted source.
+func (lines *LineEvaluations) ReadFrom(r io.Reader) (int64, error) {
+	dec := NewDecoder(r)
+	var coeffs []fp.Element
+	if err := dec.Decode(&coeffs); err != nil {
+		return dec.BytesRead(), err
+	}
+	if len(coeffs) == 0 {
+		*lines = LineEvaluations{infinity: true}
+		return dec.BytesRead(), nil
+	}
+	if len(coeffs) != nbLineEvaluations()*12 {
+		return dec.BytesRead(), errors.New("invalid number of line evaluations")
+	}
+
+	lines.infinity = false
+	lines.lines = make([]lineEvaluation, len(coeffs)/12)
+	for i := range lines.lines {
+		c := coeffs[i*12 : (i+1)*12]
+		l := &lines.lines[i]
+		l.r0.B0.A0, l.r0.B0.A1, l.r0.B1.A0, l.r0.B1.A1, l.r1.B0.A0, l.r1.B0.A1, l.r1.B1.A0, l.r1.B1.A1, l.r2.B0.A0, l.r2.B0.A1, l.r2.B1.A0, l.r2.B1.A1 = c[0], c[1], c[2], c[3], c[4], c[5], c[6], c[7], c[8], c[9], c[10], c[11]
+	}
+	return dec.BytesRead(), nil
+}
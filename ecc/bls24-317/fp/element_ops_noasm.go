@@ -1,5 +1,5 @@
-//go:build !amd64
-// +build !amd64
+//go:build !amd64 && !arm64
+// +build !amd64,!arm64
 
 // Copyright 2020 ConsenSys Software Inc.
 //
@@ -49,6 +49,14 @@ func MulBy13(x *Element) {
 func Butterfly(a, b *Element) {
 	_butterflyGeneric(a, b)
 }
+
+func add(z, x, y *Element) {
+	_addGeneric(z, x, y)
+}
+
+func sub(z, x, y *Element) {
+	_subGeneric(z, x, y)
+}
 func mul(z, x, y *Element) {
 	_mulGeneric(z, x, y)
 }
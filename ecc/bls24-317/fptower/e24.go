@@ -17,9 +17,12 @@
 package fptower
 
 import (
+	"crypto/rand"
 	"errors"
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fp"
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"io"
 	"math/big"
 	"sync"
 )
@@ -101,12 +104,19 @@ func (z *E24) Double(x *E24) *E24 {
 	return z
 }
 
-// SetRandom used only in tests
+// SetRandom sets z to a uniform random value, reading the randomness from crypto/rand.Reader
 func (z *E24) SetRandom() (*E24, error) {
-	if _, err := z.D0.SetRandom(); err != nil {
+	return z.SetRandomFrom(rand.Reader)
+}
+
+// SetRandomFrom sets z to a uniform random value, reading the randomness from r
+//
+// Each coordinate is sampled uniformly with SetRandomFrom, in order.
+func (z *E24) SetRandomFrom(r io.Reader) (*E24, error) {
+	if _, err := z.D0.SetRandomFrom(r); err != nil {
 		return nil, err
 	}
-	if _, err := z.D1.SetRandom(); err != nil {
+	if _, err := z.D1.SetRandomFrom(r); err != nil {
 		return nil, err
 	}
 	return z, nil
@@ -417,6 +427,24 @@ func (z *E24) Inverse(x *E24) *E24 {
 	return z
 }
 
+// InverseCT set z to the inverse of x in E24 and return z, in constant time
+//
+// if x == 0, sets and returns z = x
+func (z *E24) InverseCT(x *E24) *E24 {
+	// Algorithm 23 from https://eprint.iacr.org/2010/354.pdf
+
+	var t0, t1, tmp E12
+	t0.Square(&x.D0)
+	t1.Square(&x.D1)
+	tmp.MulByNonResidue(&t1)
+	t0.Sub(&t0, &tmp)
+	t1.InverseCT(&t0)
+	z.D0.Mul(&x.D0, &t1)
+	z.D1.Mul(&x.D1, &t1).Neg(&z.D1)
+
+	return z
+}
+
 // BatchInvertE24 returns a new slice with every element inverted.
 // Uses Montgomery batch inversion trick
 //
@@ -927,3 +955,55 @@ func BatchDecompressTorus(x []E12) ([]E24, error) {
 
 	return res, nil
 }
+
+func (z *E24) Select(cond int, caseZ *E24, caseNz *E24) *E24 {
+	//Might be able to save a nanosecond or two by an aggregate implementation
+
+	z.D0.Select(cond, &caseZ.D0, &caseNz.D0)
+	z.D1.Select(cond, &caseZ.D1, &caseNz.D1)
+
+	return z
+}
+
+// ExpCT sets z=xᵏ and returns it
+//
+// Unlike Exp, ExpCT uses a Montgomery ladder over max(24*fp.Bits, k.BitLen()) bits with
+// constant-time selections, so that its sequence of operations doesn't depend on x and k (only the sign
+// of k and the bit length of exponents larger than 24*fp.Bits are leaked).
+// It is as constant-time as the E12 arithmetic it relies on.
+func (z *E24) ExpCT(x E24, k *big.Int) *E24 {
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ == (x⁻¹)ᵏ
+		x.InverseCT(&x)
+
+		// we negate k in a temp big.Int since
+		// Int.Bit(_) of k and -k is different
+		e = bigIntPool.Get().(*big.Int)
+		defer bigIntPool.Put(e)
+		e.Neg(k)
+	}
+
+	nbBits := 24 * fp.Bits
+	if e.BitLen() > nbBits {
+		nbBits = e.BitLen()
+	}
+
+	// invariant: r1 = r0 * x
+	var r0, r1, a, b E24
+	r0.SetOne()
+	r1.Set(&x)
+	for i := nbBits - 1; i >= 0; i-- {
+		bit := int(e.Bit(i))
+		// (a, b) = (r0, r1) if bit == 0, (r1, r0) otherwise
+		a.Select(bit, &r0, &r1)
+		b.Select(bit, &r1, &r0)
+		b.Mul(&a, &b)
+		a.Square(&a)
+		r0.Select(bit, &a, &b)
+		r1.Select(bit, &b, &a)
+	}
+
+	return z.Set(&r0)
+}
@@ -29,6 +29,30 @@ func (z *E2) Mul(x, y *E2) *E2 {
 	return z
 }
 
+// MulCT sets z to the E2-product of x,y, returns z
+//
+// Unlike Mul, MulCT only uses the branch-free fp.Element operations (AddCT, SubCT, MulCT).
+func (z *E2) MulCT(x, y *E2) *E2 {
+	var a, b, c fp.Element
+	a.AddCT(&x.A0, &x.A1)
+	b.AddCT(&y.A0, &y.A1)
+	a.MulCT(&a, &b)
+	b.MulCT(&x.A0, &y.A0)
+	c.MulCT(&x.A1, &y.A1)
+	z.A1.SubCT(&a, &b).SubCT(&z.A1, &c)
+	z.A0.SubCT(&b, &c)
+	return z
+}
+
+// mulByNonResidueCT multiplies a E2 by (1,1), without branching
+func (z *E2) mulByNonResidueCT(x *E2) *E2 {
+	var a fp.Element
+	a.SubCT(&x.A0, &x.A1)
+	z.A1.AddCT(&x.A0, &x.A1)
+	z.A0 = a
+	return z
+}
+
 // Square sets z to the E2-product of x,x returns z
 func (z *E2) Square(x *E2) *E2 {
 	// algo 22 https://eprint.iacr.org/2010/354.pdf
@@ -80,6 +104,25 @@ func (z *E2) Inverse(x *E2) *E2 {
 	return z
 }
 
+// InverseCT sets z to the inverse of x and returns z
+//
+// Unlike Inverse, it inverts the norm of x with fp.InverseCT, and only uses the branch-free
+// operations (MulCT, NegCT), in constant time.
+//
+// if x == 0, sets and returns z = x
+func (z *E2) InverseCT(x *E2) *E2 {
+	// x⁻¹ = x̄ / N(x), with N(x) = x * x̄ in fp
+	var n E2
+	n.A0 = x.A0
+	n.A1.NegCT(&x.A1)
+	n.MulCT(x, &n)
+	n.A0.InverseCT(&n.A0)
+	z.A0.MulCT(&x.A0, &n.A0)
+	z.A1.MulCT(&x.A1, &n.A0).NegCT(&z.A1)
+
+	return z
+}
+
 // norm sets x to the norm of z
 func (z *E2) norm(x *fp.Element) {
 	var tmp fp.Element
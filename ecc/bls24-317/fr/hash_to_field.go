@@ -0,0 +1,46 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fr
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+// Hash msg to count prime field elements.
+// https://datatracker.ietf.org/doc/html/rfc9380#section-5.2
+//
+// The pseudo random bytes are expanded from msg and dst with ecc.ExpandMsgXmd, and each
+// element is obtained from L = ceil((ceil(log2(q)) + k) / 8) of them with SetBytesWide,
+// where k = 128 is the security parameter.
+func Hash(msg, dst []byte, count int) ([]Element, error) {
+	// 128 bits of security
+	// L = ceil((ceil(log2(q)) + k) / 8), where k is the security parameter = 128
+	const nbBytes = 1 + (Bits-1)/8
+	const L = 16 + nbBytes
+
+	lenInBytes := count * L
+	pseudoRandomBytes, err := ecc.ExpandMsgXmd(msg, dst, lenInBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Element, count)
+	for i := 0; i < count; i++ {
+		res[i].SetBytesWide(pseudoRandomBytes[i*L : (i+1)*L])
+	}
+	return res, nil
+}
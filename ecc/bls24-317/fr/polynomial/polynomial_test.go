@@ -54,6 +54,51 @@ func TestPolynomialEval(t *testing.T) {
 	}
 }
 
+func TestPolynomialEvalNoAllocations(t *testing.T) {
+	f := make(Polynomial, 3*evalBlockSize+5)
+	for i := range f {
+		f[i].SetRandom()
+	}
+	var point fr.Element
+	point.SetRandom()
+
+	allocs := testing.AllocsPerRun(10, func() {
+		_ = f.Eval(&point)
+	})
+	if allocs != 0 {
+		t.Fatalf("Eval allocated %v times", allocs)
+	}
+}
+
+func TestPolynomialEvalHorner(t *testing.T) {
+
+	// Eval works on blocks of coefficients, check it against Horner's method
+	// for sizes around the block size
+	var point fr.Element
+	point.SetRandom()
+	for _, n := range []int{1, 2, evalBlockSize - 1, evalBlockSize, evalBlockSize + 1, 3*evalBlockSize + 5} {
+		f := make(Polynomial, n)
+		for i := 0; i < n; i++ {
+			f[i].SetRandom()
+		}
+
+		expectedEval := f[n-1]
+		for i := n - 2; i >= 0; i-- {
+			expectedEval.Mul(&expectedEval, &point).Add(&expectedEval, &f[i])
+		}
+
+		purportedEval := f.Eval(&point)
+		if !purportedEval.Equal(&expectedEval) {
+			t.Fatal("polynomial evaluation failed", n)
+		}
+	}
+
+	var zero Polynomial
+	if e := zero.Eval(&point); !e.IsZero() {
+		t.Fatal("the zero polynomial should evaluate to 0")
+	}
+}
+
 func TestPolynomialAddConstantInPlace(t *testing.T) {
 
 	// build polynomial
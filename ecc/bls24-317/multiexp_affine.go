@@ -0,0 +1,481 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls24317
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fptower"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+)
+
+// batchAffineMinC is the smallest window size for which MultiExp uses batch affine buckets when
+// config.Buckets is ecc.BucketsAuto: below, there are too few buckets to fill batches without conflicts
+const batchAffineMinC = 10
+
+// batchAffineSize returns the number of additions of a batch, for windows of c bits
+//
+// With 2^{c-1} buckets and uniformly distributed digits, a batch of b additions has about b^2 / 2^c
+// conflicting digits (two points for the same bucket), which have to be queued for the next batch.
+func batchAffineSize(c uint64) int {
+	return 1 << ((c + 3) / 2)
+}
+
+// batchOpG1Affine is a point waiting in the queue of msmProcessChunkG1AffineBatchAffine, with its bucket
+type batchOpG1Affine struct {
+	bucketID uint64
+	point    G1Affine
+}
+
+// msmProcessChunkG1AffineBatchAffine is msmProcessChunkG1Affine with the buckets in affine coordinates:
+// the points are added to the buckets by batches, sharing one field inversion (see batchAddG1Affine)
+//
+// The additions of a batch must be independent: a point whose bucket is already in the current batch is
+// pushed to a queue, whose points are added to the next batches. If the queue fills up (the digits aren't
+// uniformly distributed), it is flushed in a second set of buckets, in extended Jacobian coordinates.
+// The exceptional cases of the affine addition (infinity, doubling, opposite points) are processed
+// outside of the batches.
+func msmProcessChunkG1AffineBatchAffine(chunk uint64,
+	chRes chan<- g1JacExtended,
+	c uint64,
+	points []G1Affine,
+	scalars []fr.Element) {
+
+	mask := uint64((1 << c) - 1) // low c bits are 1
+	msbWindow := uint64(1 << (c - 1))
+	nbBuckets := 1 << (c - 1)
+	batchSize := batchAffineSize(c)
+
+	// buckets in affine coordinates, the infinity being (0,0)
+	buckets := make([]G1Affine, nbBuckets)
+	bucketsJE := make([]g1JacExtended, nbBuckets)
+	for i := 0; i < len(bucketsJE); i++ {
+		bucketsJE[i].setInfinity()
+	}
+
+	// current batch: R[i] += P[i], R[i] being the bucket bucketIDs[i], marked in inBatch
+	var (
+		R         = make([]*G1Affine, batchSize)
+		P         = make([]G1Affine, batchSize)
+		bucketIDs = make([]uint64, batchSize)
+		inBatch   = make([]bool, nbBuckets)
+		cptAdd    int
+		queue     = make([]batchOpG1Affine, batchSize)
+		qID       int
+		scratch   = make([]fp.Element, 2*batchSize)
+	)
+
+	executeAndReset := func() {
+		batchAddG1Affine(R[:cptAdd], P[:cptAdd], scratch)
+		for i := 0; i < cptAdd; i++ {
+			inBatch[bucketIDs[i]] = false
+		}
+		cptAdd = 0
+	}
+
+	// add adds p to the bucket bucketID, which must not be in the current batch
+	add := func(bucketID uint64, p *G1Affine) {
+		bucket := &buckets[bucketID]
+		if bucket.IsInfinity() {
+			bucket.Set(p)
+			return
+		}
+		if bucket.X.Equal(&p.X) {
+			if bucket.Y.Equal(&p.Y) {
+				// doubling, with a negligible probability for random inputs
+				bucketsJE[bucketID].addMixed(p)
+			} else {
+				// p = -bucket
+				bucket.X.SetZero()
+				bucket.Y.SetZero()
+			}
+			return
+		}
+		inBatch[bucketID] = true
+		bucketIDs[cptAdd] = bucketID
+		R[cptAdd] = bucket
+		P[cptAdd].Set(p)
+		cptAdd++
+	}
+
+	flushQueue := func() {
+		for i := 0; i < qID; i++ {
+			bucketsJE[queue[i].bucketID].addMixed(&queue[i].point)
+		}
+		qID = 0
+	}
+
+	// processQueue moves the points of the queue, from its top, to the current batch
+	processQueue := func() {
+		for qID > 0 && !inBatch[queue[qID-1].bucketID] {
+			add(queue[qID-1].bucketID, &queue[qID-1].point)
+			qID--
+		}
+	}
+
+	jc := uint64(chunk * c)
+	s := selector{}
+	s.index = jc / 64
+	s.shift = jc - (s.index * 64)
+	s.mask = mask << s.shift
+	s.multiWordSelect = (64%c) != 0 && s.shift > (64-c) && s.index < (fr.Limbs-1)
+	if s.multiWordSelect {
+		nbBitsHigh := s.shift - uint64(64-c)
+		s.maskHigh = (1 << nbBitsHigh) - 1
+		s.shiftHigh = (c - nbBitsHigh)
+	}
+
+	var p G1Affine
+	// for each scalars, get the digit corresponding to the chunk we're processing.
+	for i := 0; i < len(scalars); i++ {
+		bits := (scalars[i][s.index] & s.mask) >> s.shift
+		if s.multiWordSelect {
+			bits += (scalars[i][s.index+1] & s.maskHigh) << s.shiftHigh
+		}
+
+		if bits == 0 || points[i].IsInfinity() {
+			continue
+		}
+
+		// if msbWindow bit is set, we need to substract
+		var bucketID uint64
+		if bits&msbWindow == 0 {
+			bucketID = bits - 1
+			p.Set(&points[i])
+		} else {
+			bucketID = bits & ^msbWindow
+			p.Neg(&points[i])
+		}
+
+		if inBatch[bucketID] {
+			// conflict with the current batch
+			queue[qID].bucketID = bucketID
+			queue[qID].point.Set(&p)
+			qID++
+			if qID == len(queue) {
+				flushQueue()
+			}
+			continue
+		}
+
+		add(bucketID, &p)
+		if cptAdd == batchSize {
+			executeAndReset()
+			processQueue()
+		}
+	}
+
+	// process the last batches and the queue
+	for cptAdd > 0 {
+		executeAndReset()
+		processQueue()
+	}
+	flushQueue()
+
+	// reduce buckets into total
+	// total =  bucket[0] + 2*bucket[1] + 3*bucket[2] ... + n*bucket[n-1]
+
+	var runningSum, total g1JacExtended
+	runningSum.setInfinity()
+	total.setInfinity()
+	for k := len(buckets) - 1; k >= 0; k-- {
+		runningSum.addMixed(&buckets[k])
+		if !bucketsJE[k].ZZ.IsZero() {
+			runningSum.add(&bucketsJE[k])
+		}
+		total.add(&runningSum)
+	}
+
+	chRes <- total
+}
+
+// batchAddG1Affine sets R[i] = R[i] + P[i], with one field inversion (Montgomery's trick)
+//
+// The exceptional cases (R[i] or P[i] infinity, R[i] = ±P[i]) must be filtered out by the caller.
+// scratch must hold at least 2 * len(R) elements.
+func batchAddG1Affine(R []*G1Affine, P []G1Affine, scratch []fp.Element) {
+	batchSize := len(R)
+	if batchSize == 0 {
+		return
+	}
+	lambda, lambdain := scratch[:batchSize], scratch[batchSize:2*batchSize]
+
+	// the denominators Px - Rx
+	for j := 0; j < batchSize; j++ {
+		lambdain[j].Sub(&P[j].X, &R[j].X)
+	}
+
+	// invert denominator using montgomery batch invert technique
+	{
+		var accumulator fp.Element
+		lambda[0].SetOne()
+		accumulator.Set(&lambdain[0])
+
+		for i := 1; i < batchSize; i++ {
+			lambda[i] = accumulator
+			accumulator.Mul(&accumulator, &lambdain[i])
+		}
+
+		accumulator.Inverse(&accumulator)
+
+		for i := batchSize - 1; i > 0; i-- {
+			lambda[i].Mul(&lambda[i], &accumulator)
+			accumulator.Mul(&accumulator, &lambdain[i])
+		}
+		lambda[0].Set(&accumulator)
+	}
+
+	var d fp.Element
+	var rr G1Affine
+
+	for j := 0; j < batchSize; j++ {
+		// lambda = (Py - Ry) / (Px - Rx)
+		d.Sub(&P[j].Y, &R[j].Y)
+		lambda[j].Mul(&lambda[j], &d)
+
+		// X = lambda^2 - Rx - Px, Y = lambda(Rx - X) - Ry
+		rr.X.Square(&lambda[j])
+		rr.X.Sub(&rr.X, &R[j].X)
+		rr.X.Sub(&rr.X, &P[j].X)
+		d.Sub(&R[j].X, &rr.X)
+		rr.Y.Mul(&lambda[j], &d)
+		rr.Y.Sub(&rr.Y, &R[j].Y)
+		R[j].Set(&rr)
+	}
+}
+
+// batchOpG2Affine is a point waiting in the queue of msmProcessChunkG2AffineBatchAffine, with its bucket
+type batchOpG2Affine struct {
+	bucketID uint64
+	point    G2Affine
+}
+
+// msmProcessChunkG2AffineBatchAffine is msmProcessChunkG2Affine with the buckets in affine coordinates:
+// the points are added to the buckets by batches, sharing one field inversion (see batchAddG2Affine)
+//
+// The additions of a batch must be independent: a point whose bucket is already in the current batch is
+// pushed to a queue, whose points are added to the next batches. If the queue fills up (the digits aren't
+// uniformly distributed), it is flushed in a second set of buckets, in extended Jacobian coordinates.
+// The exceptional cases of the affine addition (infinity, doubling, opposite points) are processed
+// outside of the batches.
+func msmProcessChunkG2AffineBatchAffine(chunk uint64,
+	chRes chan<- g2JacExtended,
+	c uint64,
+	points []G2Affine,
+	scalars []fr.Element) {
+
+	mask := uint64((1 << c) - 1) // low c bits are 1
+	msbWindow := uint64(1 << (c - 1))
+	nbBuckets := 1 << (c - 1)
+	batchSize := batchAffineSize(c)
+
+	// buckets in affine coordinates, the infinity being (0,0)
+	buckets := make([]G2Affine, nbBuckets)
+	bucketsJE := make([]g2JacExtended, nbBuckets)
+	for i := 0; i < len(bucketsJE); i++ {
+		bucketsJE[i].setInfinity()
+	}
+
+	// current batch: R[i] += P[i], R[i] being the bucket bucketIDs[i], marked in inBatch
+	var (
+		R         = make([]*G2Affine, batchSize)
+		P         = make([]G2Affine, batchSize)
+		bucketIDs = make([]uint64, batchSize)
+		inBatch   = make([]bool, nbBuckets)
+		cptAdd    int
+		queue     = make([]batchOpG2Affine, batchSize)
+		qID       int
+		scratch   = make([]fptower.E4, 2*batchSize)
+	)
+
+	executeAndReset := func() {
+		batchAddG2Affine(R[:cptAdd], P[:cptAdd], scratch)
+		for i := 0; i < cptAdd; i++ {
+			inBatch[bucketIDs[i]] = false
+		}
+		cptAdd = 0
+	}
+
+	// add adds p to the bucket bucketID, which must not be in the current batch
+	add := func(bucketID uint64, p *G2Affine) {
+		bucket := &buckets[bucketID]
+		if bucket.IsInfinity() {
+			bucket.Set(p)
+			return
+		}
+		if bucket.X.Equal(&p.X) {
+			if bucket.Y.Equal(&p.Y) {
+				// doubling, with a negligible probability for random inputs
+				bucketsJE[bucketID].addMixed(p)
+			} else {
+				// p = -bucket
+				bucket.X.SetZero()
+				bucket.Y.SetZero()
+			}
+			return
+		}
+		inBatch[bucketID] = true
+		bucketIDs[cptAdd] = bucketID
+		R[cptAdd] = bucket
+		P[cptAdd].Set(p)
+		cptAdd++
+	}
+
+	flushQueue := func() {
+		for i := 0; i < qID; i++ {
+			bucketsJE[queue[i].bucketID].addMixed(&queue[i].point)
+		}
+		qID = 0
+	}
+
+	// processQueue moves the points of the queue, from its top, to the current batch
+	processQueue := func() {
+		for qID > 0 && !inBatch[queue[qID-1].bucketID] {
+			add(queue[qID-1].bucketID, &queue[qID-1].point)
+			qID--
+		}
+	}
+
+	jc := uint64(chunk * c)
+	s := selector{}
+	s.index = jc / 64
+	s.shift = jc - (s.index * 64)
+	s.mask = mask << s.shift
+	s.multiWordSelect = (64%c) != 0 && s.shift > (64-c) && s.index < (fr.Limbs-1)
+	if s.multiWordSelect {
+		nbBitsHigh := s.shift - uint64(64-c)
+		s.maskHigh = (1 << nbBitsHigh) - 1
+		s.shiftHigh = (c - nbBitsHigh)
+	}
+
+	var p G2Affine
+	// for each scalars, get the digit corresponding to the chunk we're processing.
+	for i := 0; i < len(scalars); i++ {
+		bits := (scalars[i][s.index] & s.mask) >> s.shift
+		if s.multiWordSelect {
+			bits += (scalars[i][s.index+1] & s.maskHigh) << s.shiftHigh
+		}
+
+		if bits == 0 || points[i].IsInfinity() {
+			continue
+		}
+
+		// if msbWindow bit is set, we need to substract
+		var bucketID uint64
+		if bits&msbWindow == 0 {
+			bucketID = bits - 1
+			p.Set(&points[i])
+		} else {
+			bucketID = bits & ^msbWindow
+			p.Neg(&points[i])
+		}
+
+		if inBatch[bucketID] {
+			// conflict with the current batch
+			queue[qID].bucketID = bucketID
+			queue[qID].point.Set(&p)
+			qID++
+			if qID == len(queue) {
+				flushQueue()
+			}
+			continue
+		}
+
+		add(bucketID, &p)
+		if cptAdd == batchSize {
+			executeAndReset()
+			processQueue()
+		}
+	}
+
+	// process the last batches and the queue
+	for cptAdd > 0 {
+		executeAndReset()
+		processQueue()
+	}
+	flushQueue()
+
+	// reduce buckets into total
+	// total =  bucket[0] + 2*bucket[1] + 3*bucket[2] ... + n*bucket[n-1]
+
+	var runningSum, total g2JacExtended
+	runningSum.setInfinity()
+	total.setInfinity()
+	for k := len(buckets) - 1; k >= 0; k-- {
+		runningSum.addMixed(&buckets[k])
+		if !bucketsJE[k].ZZ.IsZero() {
+			runningSum.add(&bucketsJE[k])
+		}
+		total.add(&runningSum)
+	}
+
+	chRes <- total
+}
+
+// batchAddG2Affine sets R[i] = R[i] + P[i], with one field inversion (Montgomery's trick)
+//
+// The exceptional cases (R[i] or P[i] infinity, R[i] = ±P[i]) must be filtered out by the caller.
+// scratch must hold at least 2 * len(R) elements.
+func batchAddG2Affine(R []*G2Affine, P []G2Affine, scratch []fptower.E4) {
+	batchSize := len(R)
+	if batchSize == 0 {
+		return
+	}
+	lambda, lambdain := scratch[:batchSize], scratch[batchSize:2*batchSize]
+
+	// the denominators Px - Rx
+	for j := 0; j < batchSize; j++ {
+		lambdain[j].Sub(&P[j].X, &R[j].X)
+	}
+
+	// invert denominator using montgomery batch invert technique
+	{
+		var accumulator fptower.E4
+		lambda[0].SetOne()
+		accumulator.Set(&lambdain[0])
+
+		for i := 1; i < batchSize; i++ {
+			lambda[i] = accumulator
+			accumulator.Mul(&accumulator, &lambdain[i])
+		}
+
+		accumulator.Inverse(&accumulator)
+
+		for i := batchSize - 1; i > 0; i-- {
+			lambda[i].Mul(&lambda[i], &accumulator)
+			accumulator.Mul(&accumulator, &lambdain[i])
+		}
+		lambda[0].Set(&accumulator)
+	}
+
+	var d fptower.E4
+	var rr G2Affine
+
+	for j := 0; j < batchSize; j++ {
+		// lambda = (Py - Ry) / (Px - Rx)
+		d.Sub(&P[j].Y, &R[j].Y)
+		lambda[j].Mul(&lambda[j], &d)
+
+		// X = lambda^2 - Rx - Px, Y = lambda(Rx - X) - Ry
+		rr.X.Square(&lambda[j])
+		rr.X.Sub(&rr.X, &R[j].X)
+		rr.X.Sub(&rr.X, &P[j].X)
+		d.Sub(&R[j].X, &rr.X)
+		rr.Y.Mul(&lambda[j], &d)
+		rr.Y.Sub(&rr.Y, &R[j].Y)
+		R[j].Set(&rr)
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls24317
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestMultiExpReaderG1(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 2
+	} else {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	// size of the multiExps, and number of encoded points
+	const (
+		nbSamples = 73
+		nbPoints  = nbSamples + 10
+	)
+
+	// multi exp points
+	samplePoints := make([]G1Affine, nbPoints)
+	var g G1Jac
+	g.Set(&g1Gen)
+	for i := 1; i <= nbPoints; i++ {
+		samplePoints[i-1].FromJacobian(&g)
+		g.AddAssign(&g1Gen)
+	}
+	// an infinity point
+	samplePoints[nbSamples/2].X.SetZero()
+	samplePoints[nbSamples/2].Y.SetZero()
+
+	// compressed and raw encodings
+	var compressed, raw bytes.Buffer
+	if err := NewEncoder(&compressed).Encode(samplePoints); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewEncoder(&raw, RawEncoding()).Encode(samplePoints); err != nil {
+		t.Fatal(err)
+	}
+
+	properties.Property("[BLS24-317] MultiExpReader should be consistent with MultiExp", prop.ForAll(
+		func(mixer fr.Element) bool {
+			// mixer ensures that all the words of a fpElement are set
+			sampleScalars := make([]fr.Element, nbSamples)
+			for i := 1; i <= nbSamples; i++ {
+				sampleScalars[i-1].SetUint64(uint64(i)).
+					Mul(&sampleScalars[i-1], &mixer)
+			}
+
+			var expected, result G1Jac
+			expected.MultiExp(samplePoints[:nbSamples], sampleScalars, ecc.MultiExpConfig{ScalarsMont: true})
+
+			for _, encoded := range [][]byte{compressed.Bytes(), raw.Bytes()} {
+				for _, nbPointsPerChunk := range []int{1, 10, nbSamples, nbPoints} {
+					r := bytes.NewReader(encoded)
+					if _, err := result.MultiExpReader(r, sampleScalars, nbPointsPerChunk, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+						return false
+					}
+					if !result.Equal(&expected) {
+						return false
+					}
+				}
+			}
+
+			// without subgroup checks
+			r := bytes.NewReader(compressed.Bytes())
+			if _, err := result.MultiExpReader(r, sampleScalars, 16, ecc.MultiExpConfig{ScalarsMont: true}, NoSubgroupChecks()); err != nil {
+				return false
+			}
+			return result.Equal(&expected)
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// the reader must hold at least len(scalars) points
+	var result G1Jac
+	scalars := make([]fr.Element, nbPoints+1)
+	for i := range scalars {
+		scalars[i].SetOne()
+	}
+	if _, err := result.MultiExpReader(bytes.NewReader(raw.Bytes()), scalars, 16, ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("MultiExpReader should fail if the reader holds less than len(scalars) points")
+	}
+	if _, err := result.MultiExpReader(bytes.NewReader(raw.Bytes()[:raw.Len()/2]), scalars[:nbPoints], 16, ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("MultiExpReader should fail on a truncated reader")
+	}
+}
+
+func BenchmarkMultiExpReaderG1(b *testing.B) {
+	const nbSamples = 1 << 16
+
+	var (
+		samplePoints  [nbSamples]G1Affine
+		sampleScalars [nbSamples]fr.Element
+	)
+
+	fillBenchScalars(sampleScalars[:])
+	fillBenchBasesG1(samplePoints[:])
+
+	// the points of fillBenchBases aren't on the curve: they are read without any check
+	var buf bytes.Buffer
+	NewEncoder(&buf, RawEncoding()).Encode(samplePoints[:])
+
+	for _, nbPointsPerChunk := range []int{1 << 10, 1 << 13, 1 << 16} {
+		b.Run(fmt.Sprintf("%d points per chunk", nbPointsPerChunk), func(b *testing.B) {
+			var testPoint G1Jac
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				testPoint.MultiExpReader(bytes.NewReader(buf.Bytes()), sampleScalars[:], nbPointsPerChunk, ecc.MultiExpConfig{}, NoSubgroupChecks())
+			}
+		})
+	}
+}
+
+func TestMultiExpReaderG2(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 2
+	} else {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	// size of the multiExps, and number of encoded points
+	const (
+		nbSamples = 73
+		nbPoints  = nbSamples + 10
+	)
+
+	// multi exp points
+	samplePoints := make([]G2Affine, nbPoints)
+	var g G2Jac
+	g.Set(&g2Gen)
+	for i := 1; i <= nbPoints; i++ {
+		samplePoints[i-1].FromJacobian(&g)
+		g.AddAssign(&g2Gen)
+	}
+	// an infinity point
+	samplePoints[nbSamples/2].X.SetZero()
+	samplePoints[nbSamples/2].Y.SetZero()
+
+	// compressed and raw encodings
+	var compressed, raw bytes.Buffer
+	if err := NewEncoder(&compressed).Encode(samplePoints); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewEncoder(&raw, RawEncoding()).Encode(samplePoints); err != nil {
+		t.Fatal(err)
+	}
+
+	properties.Property("[BLS24-317] MultiExpReader should be consistent with MultiExp", prop.ForAll(
+		func(mixer fr.Element) bool {
+			// mixer ensures that all the words of a fpElement are set
+			sampleScalars := make([]fr.Element, nbSamples)
+			for i := 1; i <= nbSamples; i++ {
+				sampleScalars[i-1].SetUint64(uint64(i)).
+					Mul(&sampleScalars[i-1], &mixer)
+			}
+
+			var expected, result G2Jac
+			expected.MultiExp(samplePoints[:nbSamples], sampleScalars, ecc.MultiExpConfig{ScalarsMont: true})
+
+			for _, encoded := range [][]byte{compressed.Bytes(), raw.Bytes()} {
+				for _, nbPointsPerChunk := range []int{1, 10, nbSamples, nbPoints} {
+					r := bytes.NewReader(encoded)
+					if _, err := result.MultiExpReader(r, sampleScalars, nbPointsPerChunk, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+						return false
+					}
+					if !result.Equal(&expected) {
+						return false
+					}
+				}
+			}
+
+			// without subgroup checks
+			r := bytes.NewReader(compressed.Bytes())
+			if _, err := result.MultiExpReader(r, sampleScalars, 16, ecc.MultiExpConfig{ScalarsMont: true}, NoSubgroupChecks()); err != nil {
+				return false
+			}
+			return result.Equal(&expected)
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// the reader must hold at least len(scalars) points
+	var result G2Jac
+	scalars := make([]fr.Element, nbPoints+1)
+	for i := range scalars {
+		scalars[i].SetOne()
+	}
+	if _, err := result.MultiExpReader(bytes.NewReader(raw.Bytes()), scalars, 16, ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("MultiExpReader should fail if the reader holds less than len(scalars) points")
+	}
+	if _, err := result.MultiExpReader(bytes.NewReader(raw.Bytes()[:raw.Len()/2]), scalars[:nbPoints], 16, ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("MultiExpReader should fail on a truncated reader")
+	}
+}
+
+func BenchmarkMultiExpReaderG2(b *testing.B) {
+	const nbSamples = 1 << 16
+
+	var (
+		samplePoints  [nbSamples]G2Affine
+		sampleScalars [nbSamples]fr.Element
+	)
+
+	fillBenchScalars(sampleScalars[:])
+	fillBenchBasesG2(samplePoints[:])
+
+	// the points of fillBenchBases aren't on the curve: they are read without any check
+	var buf bytes.Buffer
+	NewEncoder(&buf, RawEncoding()).Encode(samplePoints[:])
+
+	for _, nbPointsPerChunk := range []int{1 << 10, 1 << 13, 1 << 16} {
+		b.Run(fmt.Sprintf("%d points per chunk", nbPointsPerChunk), func(b *testing.B) {
+			var testPoint G2Jac
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				testPoint.MultiExpReader(bytes.NewReader(buf.Bytes()), sampleScalars[:], nbPointsPerChunk, ecc.MultiExpConfig{}, NoSubgroupChecks())
+			}
+		})
+	}
+}
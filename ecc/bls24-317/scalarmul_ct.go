@@ -0,0 +1,448 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls24317
+
+import (
+	"crypto/subtle"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fptower"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+)
+
+// scalarMulCTWindow is the window size of the constant-time scalar multiplication (2^(w-1) precomputed points)
+const scalarMulCTWindow = 4
+
+// scalarMulCTNbWindows is the number of digits of a scalar recoded on scalarMulCTWindow bits (see fixedBaseNbWindows)
+const scalarMulCTNbWindows = (fr.Bits+scalarMulCTWindow)/scalarMulCTWindow + 1
+
+// ScalarMultiplicationCT computes and returns p = a ⋅ s, in constant time
+//
+// a must be in the subgroup of order r: s is recoded as s or s + r (see G1Jac.ScalarMultiplicationCT).
+func (p *G1Affine) ScalarMultiplicationCT(a *G1Affine, s *fr.Element) *G1Affine {
+	var _p g1Proj
+	_p.fromAffineCT(a)
+	_p.mulCT(&_p, s)
+	p.fromProjCT(&_p)
+	return p
+}
+
+// ScalarMultiplicationCT computes and returns p = a ⋅ s, in constant time
+//
+// The sequence of operations and the memory accesses don't depend on s nor on the coordinates of a: s is
+// recoded with odd signed digits without branches (see fixedBaseDigits), the multiples of a are selected by
+// scanning the whole table, the points are added with the complete formulas of Renes, Costello and
+// Batina, which have no exceptional cases, and the coordinates are computed with the branch-free field
+// operations (AddCT, SubCT, MulCT, ...), including in the conversions from and to Jacobian coordinates.
+//
+// The recoding replaces an even s by s + r: a must be in the subgroup of order r.
+func (p *G1Jac) ScalarMultiplicationCT(a *G1Jac, s *fr.Element) *G1Jac {
+	var _p g1Proj
+	_p.fromJacobian(a)
+	_p.mulCT(&_p, s)
+	return p.fromProj(&_p)
+}
+
+// mulCT sets p = a ⋅ s, with a fixed-window ladder on the odd signed digits of s
+func (p *g1Proj) mulCT(a *g1Proj, s *fr.Element) *g1Proj {
+	var digits [scalarMulCTNbWindows]int
+	fixedBaseDigits(digits[:], s, scalarMulCTWindow)
+
+	// table[j] = (2j+1) ⋅ a
+	var table [1 << (scalarMulCTWindow - 1)]g1Proj
+	var a2 g1Proj
+	table[0].Set(a)
+	a2.doubleComplete(a)
+	for j := 1; j < len(table); j++ {
+		table[j].addComplete(&table[j-1], &a2)
+	}
+
+	// the last digit is 1
+	var res, q g1Proj
+	var negY fp.Element
+	res.Set(&table[0])
+	for i := len(digits) - 2; i >= 0; i-- {
+		for j := 0; j < scalarMulCTWindow; j++ {
+			res.doubleComplete(&res)
+		}
+		d := digits[i]
+		// sign = 1 iff d < 0, idx = |d| >> 1
+		sign := int(uint(d) >> (bits.UintSize - 1))
+		idx := int32(((d ^ -sign) + sign) >> 1)
+		for j := range table {
+			eq := subtle.ConstantTimeEq(int32(j), idx)
+			q.x.Select(eq, &q.x, &table[j].x)
+			q.y.Select(eq, &q.y, &table[j].y)
+			q.z.Select(eq, &q.z, &table[j].z)
+		}
+		negY.NegCT(&q.y)
+		q.y.Select(sign, &q.y, &negY)
+		res.addComplete(&res, &q)
+	}
+
+	return p.Set(&res)
+}
+
+// fromAffineCT sets p = a, p in homogenous projective, a in affine, without branching
+//
+// The infinity (0,0) is mapped to (0:1:0).
+func (p *g1Proj) fromAffineCT(a *G1Affine) *g1Proj {
+	var zero, one fp.Element
+	one.SetOne()
+	// notInf == 0 iff a is the infinity
+	notInf := int(a.X.NotEqual(&zero) | a.Y.NotEqual(&zero))
+	p.x.Set(&a.X)
+	p.y.Select(notInf, &one, &a.Y)
+	p.z.Select(notInf, &zero, &one)
+	return p
+}
+
+// fromJacobian sets p = a, p in homogenous projective, a in Jacobian, without branching
+//
+// The infinity (X:Y:0) is mapped to (0:1:0).
+func (p *g1Proj) fromJacobian(a *G1Jac) *g1Proj {
+	var zero, one, zz fp.Element
+	one.SetOne()
+	// notInf == 0 iff a is the infinity
+	notInf := int(a.Z.NotEqual(&zero))
+
+	// (X:Y:Z) in Jacobian is (XZ:Y:Z³) in projective
+	zz.SquareCT(&a.Z)
+	p.x.MulCT(&a.X, &a.Z)
+	p.y.Select(notInf, &one, &a.Y)
+	p.z.MulCT(&zz, &a.Z)
+	return p
+}
+
+// fromProj sets p = a, p in Jacobian, a in homogenous projective, without branching
+//
+// The infinity (0:Y:0) is mapped to (1:1:0).
+func (p *G1Jac) fromProj(a *g1Proj) *G1Jac {
+	var zero, one, zz fp.Element
+	one.SetOne()
+	// notInf == 0 iff a is the infinity
+	notInf := int(a.z.NotEqual(&zero))
+
+	// (X:Y:Z) in projective is (XZ:YZ²:Z) in Jacobian
+	zz.SquareCT(&a.z)
+	p.X.MulCT(&a.x, &a.z)
+	p.Y.MulCT(&a.y, &zz)
+	p.Z.Set(&a.z)
+	p.X.Select(notInf, &one, &p.X)
+	p.Y.Select(notInf, &one, &p.Y)
+	return p
+}
+
+// fromProjCT sets p = a, p in affine, a in homogenous projective, with a constant-time inversion
+//
+// The infinity (0:1:0) is mapped to (0,0) without branching: the inverse of 0 is 0.
+func (p *G1Affine) fromProjCT(a *g1Proj) *G1Affine {
+	var zInv fp.Element
+	zInv.InverseCT(&a.z)
+	p.X.MulCT(&a.x, &zInv)
+	p.Y.MulCT(&a.y, &zInv)
+	return p
+}
+
+// mulBy3bG1 sets z = 3b ⋅ z, b being the constant term of the curve equation
+func mulBy3bG1(z *fp.Element) *fp.Element {
+	var t fp.Element
+	z.MulCT(z, &bCurveCoeff)
+	t.DoubleCT(z)
+	return z.AddCT(z, &t)
+}
+
+// addComplete sets p = a + b, with the complete addition formula for a = 0 curves
+//
+// It is correct for all the points of the curve, including a = ±b and the infinity (0:1:0), without
+// branching (https://eprint.iacr.org/2015/1060.pdf, algorithm 7).
+func (p *g1Proj) addComplete(a, b *g1Proj) *g1Proj {
+	var t0, t1, t2, t3, t4, X3, Y3, Z3 fp.Element
+	t0.MulCT(&a.x, &b.x)
+	t1.MulCT(&a.y, &b.y)
+	t2.MulCT(&a.z, &b.z)
+	t3.AddCT(&a.x, &a.y)
+	t4.AddCT(&b.x, &b.y)
+	t3.MulCT(&t3, &t4)
+	t4.AddCT(&t0, &t1)
+	t3.SubCT(&t3, &t4)
+	t4.AddCT(&a.y, &a.z)
+	X3.AddCT(&b.y, &b.z)
+	t4.MulCT(&t4, &X3)
+	X3.AddCT(&t1, &t2)
+	t4.SubCT(&t4, &X3)
+	X3.AddCT(&a.x, &a.z)
+	Y3.AddCT(&b.x, &b.z)
+	X3.MulCT(&X3, &Y3)
+	Y3.AddCT(&t0, &t2)
+	Y3.SubCT(&X3, &Y3)
+	X3.DoubleCT(&t0)
+	t0.AddCT(&X3, &t0)
+	mulBy3bG1(&t2)
+	Z3.AddCT(&t1, &t2)
+	t1.SubCT(&t1, &t2)
+	mulBy3bG1(&Y3)
+	X3.MulCT(&t4, &Y3)
+	t2.MulCT(&t3, &t1)
+	X3.SubCT(&t2, &X3)
+	Y3.MulCT(&Y3, &t0)
+	t1.MulCT(&t1, &Z3)
+	Y3.AddCT(&t1, &Y3)
+	t0.MulCT(&t0, &t3)
+	Z3.MulCT(&Z3, &t4)
+	Z3.AddCT(&Z3, &t0)
+
+	p.x, p.y, p.z = X3, Y3, Z3
+	return p
+}
+
+// doubleComplete sets p = 2 ⋅ a, with the complete doubling formula for a = 0 curves
+//
+// https://eprint.iacr.org/2015/1060.pdf, algorithm 9
+func (p *g1Proj) doubleComplete(a *g1Proj) *g1Proj {
+	var t0, t1, t2, X3, Y3, Z3 fp.Element
+	t0.SquareCT(&a.y)
+	Z3.DoubleCT(&t0)
+	Z3.DoubleCT(&Z3)
+	Z3.DoubleCT(&Z3)
+	t1.MulCT(&a.y, &a.z)
+	t2.SquareCT(&a.z)
+	mulBy3bG1(&t2)
+	X3.MulCT(&t2, &Z3)
+	Y3.AddCT(&t0, &t2)
+	Z3.MulCT(&t1, &Z3)
+	t1.DoubleCT(&t2)
+	t2.AddCT(&t1, &t2)
+	t0.SubCT(&t0, &t2)
+	Y3.MulCT(&t0, &Y3)
+	Y3.AddCT(&X3, &Y3)
+	t1.MulCT(&a.x, &a.y)
+	X3.MulCT(&t0, &t1)
+	X3.DoubleCT(&X3)
+
+	p.x, p.y, p.z = X3, Y3, Z3
+	return p
+}
+
+// ScalarMultiplicationCT computes and returns p = a ⋅ s, in constant time
+//
+// a must be in the subgroup of order r: s is recoded as s or s + r (see G2Jac.ScalarMultiplicationCT).
+func (p *G2Affine) ScalarMultiplicationCT(a *G2Affine, s *fr.Element) *G2Affine {
+	var _p g2Proj
+	_p.fromAffineCT(a)
+	_p.mulCT(&_p, s)
+	p.fromProjCT(&_p)
+	return p
+}
+
+// ScalarMultiplicationCT computes and returns p = a ⋅ s, in constant time
+//
+// The sequence of operations and the memory accesses don't depend on s nor on the coordinates of a: s is
+// recoded with odd signed digits without branches (see fixedBaseDigits), the multiples of a are selected by
+// scanning the whole table, the points are added with the complete formulas of Renes, Costello and
+// Batina, which have no exceptional cases, and the coordinates are computed with the branch-free field
+// operations (AddCT, SubCT, MulCT, ...), including in the conversions from and to Jacobian coordinates.
+//
+// The recoding replaces an even s by s + r: a must be in the subgroup of order r.
+func (p *G2Jac) ScalarMultiplicationCT(a *G2Jac, s *fr.Element) *G2Jac {
+	var _p g2Proj
+	_p.fromJacobian(a)
+	_p.mulCT(&_p, s)
+	return p.fromProj(&_p)
+}
+
+// mulCT sets p = a ⋅ s, with a fixed-window ladder on the odd signed digits of s
+func (p *g2Proj) mulCT(a *g2Proj, s *fr.Element) *g2Proj {
+	var digits [scalarMulCTNbWindows]int
+	fixedBaseDigits(digits[:], s, scalarMulCTWindow)
+
+	// table[j] = (2j+1) ⋅ a
+	var table [1 << (scalarMulCTWindow - 1)]g2Proj
+	var a2 g2Proj
+	table[0].Set(a)
+	a2.doubleComplete(a)
+	for j := 1; j < len(table); j++ {
+		table[j].addComplete(&table[j-1], &a2)
+	}
+
+	// the last digit is 1
+	var res, q g2Proj
+	var negY fptower.E4
+	res.Set(&table[0])
+	for i := len(digits) - 2; i >= 0; i-- {
+		for j := 0; j < scalarMulCTWindow; j++ {
+			res.doubleComplete(&res)
+		}
+		d := digits[i]
+		// sign = 1 iff d < 0, idx = |d| >> 1
+		sign := int(uint(d) >> (bits.UintSize - 1))
+		idx := int32(((d ^ -sign) + sign) >> 1)
+		for j := range table {
+			eq := subtle.ConstantTimeEq(int32(j), idx)
+			q.x.Select(eq, &q.x, &table[j].x)
+			q.y.Select(eq, &q.y, &table[j].y)
+			q.z.Select(eq, &q.z, &table[j].z)
+		}
+		negY.NegCT(&q.y)
+		q.y.Select(sign, &q.y, &negY)
+		res.addComplete(&res, &q)
+	}
+
+	return p.Set(&res)
+}
+
+// fromAffineCT sets p = a, p in homogenous projective, a in affine, without branching
+//
+// The infinity (0,0) is mapped to (0:1:0).
+func (p *g2Proj) fromAffineCT(a *G2Affine) *g2Proj {
+	var zero, one fptower.E4
+	one.SetOne()
+	// notInf == 0 iff a is the infinity
+	notInf := int(a.X.NotEqual(&zero) | a.Y.NotEqual(&zero))
+	p.x.Set(&a.X)
+	p.y.Select(notInf, &one, &a.Y)
+	p.z.Select(notInf, &zero, &one)
+	return p
+}
+
+// fromJacobian sets p = a, p in homogenous projective, a in Jacobian, without branching
+//
+// The infinity (X:Y:0) is mapped to (0:1:0).
+func (p *g2Proj) fromJacobian(a *G2Jac) *g2Proj {
+	var zero, one, zz fptower.E4
+	one.SetOne()
+	// notInf == 0 iff a is the infinity
+	notInf := int(a.Z.NotEqual(&zero))
+
+	// (X:Y:Z) in Jacobian is (XZ:Y:Z³) in projective
+	zz.SquareCT(&a.Z)
+	p.x.MulCT(&a.X, &a.Z)
+	p.y.Select(notInf, &one, &a.Y)
+	p.z.MulCT(&zz, &a.Z)
+	return p
+}
+
+// fromProj sets p = a, p in Jacobian, a in homogenous projective, without branching
+//
+// The infinity (0:Y:0) is mapped to (1:1:0).
+func (p *G2Jac) fromProj(a *g2Proj) *G2Jac {
+	var zero, one, zz fptower.E4
+	one.SetOne()
+	// notInf == 0 iff a is the infinity
+	notInf := int(a.z.NotEqual(&zero))
+
+	// (X:Y:Z) in projective is (XZ:YZ²:Z) in Jacobian
+	zz.SquareCT(&a.z)
+	p.X.MulCT(&a.x, &a.z)
+	p.Y.MulCT(&a.y, &zz)
+	p.Z.Set(&a.z)
+	p.X.Select(notInf, &one, &p.X)
+	p.Y.Select(notInf, &one, &p.Y)
+	return p
+}
+
+// fromProjCT sets p = a, p in affine, a in homogenous projective, with a constant-time inversion
+//
+// The infinity (0:1:0) is mapped to (0,0) without branching: the inverse of 0 is 0.
+func (p *G2Affine) fromProjCT(a *g2Proj) *G2Affine {
+	var zInv fptower.E4
+	zInv.InverseCT(&a.z)
+	p.X.MulCT(&a.x, &zInv)
+	p.Y.MulCT(&a.y, &zInv)
+	return p
+}
+
+// mulBy3bG2 sets z = 3b ⋅ z, b being the constant term of the curve equation
+func mulBy3bG2(z *fptower.E4) *fptower.E4 {
+	var t fptower.E4
+	z.MulCT(z, &bTwistCurveCoeff)
+	t.DoubleCT(z)
+	return z.AddCT(z, &t)
+}
+
+// addComplete sets p = a + b, with the complete addition formula for a = 0 curves
+//
+// It is correct for all the points of the curve, including a = ±b and the infinity (0:1:0), without
+// branching (https://eprint.iacr.org/2015/1060.pdf, algorithm 7).
+func (p *g2Proj) addComplete(a, b *g2Proj) *g2Proj {
+	var t0, t1, t2, t3, t4, X3, Y3, Z3 fptower.E4
+	t0.MulCT(&a.x, &b.x)
+	t1.MulCT(&a.y, &b.y)
+	t2.MulCT(&a.z, &b.z)
+	t3.AddCT(&a.x, &a.y)
+	t4.AddCT(&b.x, &b.y)
+	t3.MulCT(&t3, &t4)
+	t4.AddCT(&t0, &t1)
+	t3.SubCT(&t3, &t4)
+	t4.AddCT(&a.y, &a.z)
+	X3.AddCT(&b.y, &b.z)
+	t4.MulCT(&t4, &X3)
+	X3.AddCT(&t1, &t2)
+	t4.SubCT(&t4, &X3)
+	X3.AddCT(&a.x, &a.z)
+	Y3.AddCT(&b.x, &b.z)
+	X3.MulCT(&X3, &Y3)
+	Y3.AddCT(&t0, &t2)
+	Y3.SubCT(&X3, &Y3)
+	X3.DoubleCT(&t0)
+	t0.AddCT(&X3, &t0)
+	mulBy3bG2(&t2)
+	Z3.AddCT(&t1, &t2)
+	t1.SubCT(&t1, &t2)
+	mulBy3bG2(&Y3)
+	X3.MulCT(&t4, &Y3)
+	t2.MulCT(&t3, &t1)
+	X3.SubCT(&t2, &X3)
+	Y3.MulCT(&Y3, &t0)
+	t1.MulCT(&t1, &Z3)
+	Y3.AddCT(&t1, &Y3)
+	t0.MulCT(&t0, &t3)
+	Z3.MulCT(&Z3, &t4)
+	Z3.AddCT(&Z3, &t0)
+
+	p.x, p.y, p.z = X3, Y3, Z3
+	return p
+}
+
+// doubleComplete sets p = 2 ⋅ a, with the complete doubling formula for a = 0 curves
+//
+// https://eprint.iacr.org/2015/1060.pdf, algorithm 9
+func (p *g2Proj) doubleComplete(a *g2Proj) *g2Proj {
+	var t0, t1, t2, X3, Y3, Z3 fptower.E4
+	t0.SquareCT(&a.y)
+	Z3.DoubleCT(&t0)
+	Z3.DoubleCT(&Z3)
+	Z3.DoubleCT(&Z3)
+	t1.MulCT(&a.y, &a.z)
+	t2.SquareCT(&a.z)
+	mulBy3bG2(&t2)
+	X3.MulCT(&t2, &Z3)
+	Y3.AddCT(&t0, &t2)
+	Z3.MulCT(&t1, &Z3)
+	t1.DoubleCT(&t2)
+	t2.AddCT(&t1, &t2)
+	t0.SubCT(&t0, &t2)
+	Y3.MulCT(&t0, &Y3)
+	Y3.AddCT(&X3, &Y3)
+	t1.MulCT(&a.x, &a.y)
+	X3.MulCT(&t0, &t1)
+	X3.DoubleCT(&X3)
+
+	p.x, p.y, p.z = X3, Y3, Z3
+	return p
+}
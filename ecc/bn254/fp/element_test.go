@@ -19,14 +19,16 @@ package fp
 import (
 	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"math/bits"
+	mrand "math/rand"
 
 	"github.com/consensys/gnark-crypto/internal/field"
-	mrand "math/rand"
 
 	"testing"
+	"testing/iotest"
 
 	"github.com/leanovate/gopter"
 	ggen "github.com/leanovate/gopter/gen"
@@ -474,6 +476,83 @@ func TestElementBytes(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestElementSetRandomFrom(t *testing.T) {
+	t.Parallel()
+
+	// two readers with the same seed must yield the same values
+	r1 := mrand.New(mrand.NewSource(42)) //#nosec G404 -- deterministic source on purpose
+	r2 := mrand.New(mrand.NewSource(42)) //#nosec G404 -- deterministic source on purpose
+
+	var a, b Element
+	for i := 0; i < 100; i++ {
+		if _, err := a.SetRandomFrom(r1); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := b.SetRandomFrom(r2); err != nil {
+			t.Fatal(err)
+		}
+		if !a.Equal(&b) {
+			t.Fatal("SetRandomFrom with the same seed should output the same values")
+		}
+		if !a.smallerThanModulus() {
+			t.Fatal("SetRandomFrom should output a value smaller than the modulus")
+		}
+	}
+
+	// reader errors are returned
+	if _, err := a.SetRandomFrom(iotest.ErrReader(errors.New("test"))); err == nil {
+		t.Fatal("SetRandomFrom should return the error of the reader")
+	}
+}
+
+func TestElementSetBytesWide(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	// inputs of all sizes up to 2*Bytes + 1, to go through the SetBytes fallback
+	genE := ggen.SliceOfN(2*Bytes+1, ggen.UInt8())
+	genL := ggen.IntRange(0, 2*Bytes+1)
+
+	properties.Property("SetBytesWide must match big.Int reduction", prop.ForAll(
+		func(e []uint8, l int) bool {
+			var a Element
+			a.SetBytesWide(e[:l])
+
+			var b big.Int
+			b.SetBytes(e[:l]).Mod(&b, Modulus())
+
+			var c big.Int
+			a.ToBigIntRegular(&c)
+
+			return b.Cmp(&c) == 0
+		},
+		genE,
+		genL,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// all ones is the largest input of the fast path
+	var e [2 * Bytes]byte
+	for i := range e {
+		e[i] = 0xff
+	}
+	var a Element
+	a.SetBytesWide(e[:])
+	var b, c big.Int
+	b.SetBytes(e[:]).Mod(&b, Modulus())
+	if a.ToBigIntRegular(&c).Cmp(&b) != 0 {
+		t.Fatal("SetBytesWide failed on 2*Bytes 0xff bytes")
+	}
+}
+
 func TestElementInverseExp(t *testing.T) {
 	// inverse must be equal to exp^-2
 	exp := Modulus()
@@ -772,6 +851,14 @@ func TestElementAdd(t *testing.T) {
 				c.Add(&a.element, &r)
 				d.Add(&a.bigint, &rb).Mod(&d, Modulus())
 
+				// checking generic impl against asm path
+				var cGeneric Element
+				_addGeneric(&cGeneric, &a.element, &r)
+				if !cGeneric.Equal(&c) {
+					// need to give context to failing error.
+					return false
+				}
+
 				if c.FromMont().ToBigInt(&e).Cmp(&d) != 0 {
 					return false
 				}
@@ -794,6 +881,17 @@ func TestElementAdd(t *testing.T) {
 		genB,
 	))
 
+	properties.Property("Add: assembly implementation must be consistent with generic one", prop.ForAll(
+		func(a, b testPairElement) bool {
+			var c, d Element
+			c.Add(&a.element, &b.element)
+			_addGeneric(&d, &a.element, &b.element)
+			return c.Equal(&d)
+		},
+		genA,
+		genB,
+	))
+
 	specialValueTest := func() {
 		// test special values against special values
 		testValues := make([]Element, len(staticTestValues))
@@ -811,6 +909,13 @@ func TestElementAdd(t *testing.T) {
 				c.Add(&a, &b)
 				d.Add(&aBig, &bBig).Mod(&d, Modulus())
 
+				// checking asm against generic impl
+				var cGeneric Element
+				_addGeneric(&cGeneric, &a, &b)
+				if !cGeneric.Equal(&c) {
+					t.Fatal("Add failed special test values: asm and generic impl don't match")
+				}
+
 				if c.FromMont().ToBigInt(&e).Cmp(&d) != 0 {
 					t.Fatal("Add failed special test values")
 				}
@@ -881,6 +986,14 @@ func TestElementSub(t *testing.T) {
 				c.Sub(&a.element, &r)
 				d.Sub(&a.bigint, &rb).Mod(&d, Modulus())
 
+				// checking generic impl against asm path
+				var cGeneric Element
+				_subGeneric(&cGeneric, &a.element, &r)
+				if !cGeneric.Equal(&c) {
+					// need to give context to failing error.
+					return false
+				}
+
 				if c.FromMont().ToBigInt(&e).Cmp(&d) != 0 {
 					return false
 				}
@@ -903,6 +1016,17 @@ func TestElementSub(t *testing.T) {
 		genB,
 	))
 
+	properties.Property("Sub: assembly implementation must be consistent with generic one", prop.ForAll(
+		func(a, b testPairElement) bool {
+			var c, d Element
+			c.Sub(&a.element, &b.element)
+			_subGeneric(&d, &a.element, &b.element)
+			return c.Equal(&d)
+		},
+		genA,
+		genB,
+	))
+
 	specialValueTest := func() {
 		// test special values against special values
 		testValues := make([]Element, len(staticTestValues))
@@ -920,6 +1044,13 @@ func TestElementSub(t *testing.T) {
 				c.Sub(&a, &b)
 				d.Sub(&aBig, &bBig).Mod(&d, Modulus())
 
+				// checking asm against generic impl
+				var cGeneric Element
+				_subGeneric(&cGeneric, &a, &b)
+				if !cGeneric.Equal(&c) {
+					t.Fatal("Sub failed special test values: asm and generic impl don't match")
+				}
+
 				if c.FromMont().ToBigInt(&e).Cmp(&d) != 0 {
 					t.Fatal("Sub failed special test values")
 				}
@@ -2840,3 +2971,286 @@ func approximateRef(x *Element) uint64 {
 	hi.Add(&hi, &lo)
 	return hi.Uint64()
 }
+
+func TestElementInverseCT(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+
+	properties.Property("InverseCT must match Inverse", prop.ForAll(
+		func(a testPairElement) bool {
+			var b, c Element
+			b.InverseCT(&a.element)
+			c.Inverse(&a.element)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	for _, a := range staticTestValues {
+		var b, c Element
+		b.InverseCT(&a)
+		c.Inverse(&a)
+		if !b.Equal(&c) {
+			t.Fatal("InverseCT failed special test values")
+		}
+	}
+}
+
+func TestElementExpCT(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+	genB := gen()
+
+	properties.Property("ExpCT must match Exp", prop.ForAll(
+		func(a, b testPairElement) bool {
+			var c, d Element
+			c.ExpCT(a.element, &b.bigint)
+			d.Exp(a.element, &b.bigint)
+			return c.Equal(&d)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("ExpCT must match Exp with negative and large exponents", prop.ForAll(
+		func(a, b testPairElement) bool {
+			var k big.Int
+			k.Lsh(&b.bigint, Bits+3).Neg(&k)
+			var c, d Element
+			c.ExpCT(a.element, &k)
+			d.Exp(a.element, &k)
+			return c.Equal(&d)
+		},
+		genA,
+		genB,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	for _, a := range staticTestValues {
+		for _, b := range staticTestValues {
+			var bBig big.Int
+			b.ToBigIntRegular(&bBig)
+			var c, d Element
+			c.ExpCT(a, &bBig)
+			d.Exp(a, &bBig)
+			if !c.Equal(&d) {
+				t.Fatal("ExpCT failed special test values")
+			}
+		}
+	}
+}
+
+func TestElementSqrtCT(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+
+	properties.Property("SqrtCT must match Sqrt", prop.ForAll(
+		func(a testPairElement) bool {
+			return checkSqrtCTElement(&a.element)
+		},
+		genA,
+	))
+
+	properties.Property("SqrtCT of a square must be a square root", prop.ForAll(
+		func(a testPairElement) bool {
+			var s Element
+			s.Square(&a.element)
+			return checkSqrtCTElement(&s)
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	for _, a := range staticTestValues {
+		if !checkSqrtCTElement(&a) {
+			t.Fatal("SqrtCT failed special test values")
+		}
+	}
+}
+
+func TestElementArithmeticCT(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+	genB := gen()
+
+	properties.Property("the CT operations must match their variable-time counterparts", prop.ForAll(
+		func(a, b testPairElement) bool {
+			return checkArithmeticCTElement(&a.element, &b.element)
+		},
+		genA,
+		genB,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	for _, a := range staticTestValues {
+		for _, b := range staticTestValues {
+			if !checkArithmeticCTElement(&a, &b) {
+				t.Fatal("CT operations failed special test values")
+			}
+		}
+	}
+}
+
+func checkArithmeticCTElement(a, b *Element) bool {
+	var c, d Element
+	if !c.AddCT(a, b).Equal(d.Add(a, b)) {
+		return false
+	}
+	if !c.SubCT(a, b).Equal(d.Sub(a, b)) {
+		return false
+	}
+	if !c.DoubleCT(a).Equal(d.Double(a)) {
+		return false
+	}
+	if !c.NegCT(a).Equal(d.Neg(a)) {
+		return false
+	}
+	if !c.MulCT(a, b).Equal(d.Mul(a, b)) {
+		return false
+	}
+	return c.SquareCT(a).Equal(d.Square(a))
+}
+
+func checkSqrtCTElement(a *Element) bool {
+	var b, c Element
+	rb := b.SqrtCT(a)
+	rc := c.Sqrt(a)
+	if rb == nil || rc == nil {
+		// both must agree on the existence of a square root
+		return rb == nil && rc == nil && b.IsZero()
+	}
+	b.Square(&b)
+	return b.Equal(a)
+}
+
+func TestElementAccumulator(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+	genB := gen()
+
+	properties.Property("Accumulator: MulAdd then Reduce must match Mul then Add", prop.ForAll(
+		func(a, b testPairElement) bool {
+			var acc Accumulator
+			var expected, tmp Element
+			x, y := a.element, b.element
+			for i := 0; i < 10; i++ {
+				acc.MulAdd(&x, &y)
+				tmp.Mul(&x, &y)
+				expected.Add(&expected, &tmp)
+				x.Add(&x, &y)
+				y.Square(&y)
+			}
+			acc.Add(&a.element)
+			expected.Add(&expected, &a.element)
+			r := acc.Reduce()
+			return r.Equal(&expected)
+		},
+		genA,
+		genB,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// long sums of the special values, such as (q-1)²
+	var acc Accumulator
+	var expected, tmp Element
+	for _, a := range staticTestValues {
+		for _, b := range staticTestValues {
+			for i := 0; i < 100; i++ {
+				acc.MulAdd(&a, &b)
+				tmp.Mul(&a, &b)
+				expected.Add(&expected, &tmp)
+			}
+			r := acc.Reduce()
+			if !r.Equal(&expected) {
+				t.Fatal("Accumulator failed special test values")
+			}
+		}
+	}
+
+	acc.Reset()
+	if r := acc.Reduce(); !r.IsZero() {
+		t.Fatal("the empty sum should be 0")
+	}
+}
+
+func BenchmarkElementAccumulator(b *testing.B) {
+	var x, y [64]Element
+	for i := range x {
+		x[i].SetRandom()
+		y[i].SetRandom()
+	}
+
+	b.Run("MulAdd+Reduce", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var acc Accumulator
+			for j := range x {
+				acc.MulAdd(&x[j], &y[j])
+			}
+			benchResElement = acc.Reduce()
+		}
+	})
+
+	b.Run("Mul+Add", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var res, tmp Element
+			for j := range x {
+				tmp.Mul(&x[j], &y[j])
+				res.Add(&res, &tmp)
+			}
+			benchResElement = res
+		}
+	})
+}
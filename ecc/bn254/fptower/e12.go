@@ -17,11 +17,13 @@
 package fptower
 
 import (
+	"crypto/rand"
 	"encoding/binary"
 	"errors"
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"io"
 	"math/big"
 	"sync"
 )
@@ -103,12 +105,19 @@ func (z *E12) Double(x *E12) *E12 {
 	return z
 }
 
-// SetRandom used only in tests
+// SetRandom sets z to a uniform random value, reading the randomness from crypto/rand.Reader
 func (z *E12) SetRandom() (*E12, error) {
-	if _, err := z.C0.SetRandom(); err != nil {
+	return z.SetRandomFrom(rand.Reader)
+}
+
+// SetRandomFrom sets z to a uniform random value, reading the randomness from r
+//
+// Each coordinate is sampled uniformly with SetRandomFrom, in order.
+func (z *E12) SetRandomFrom(r io.Reader) (*E12, error) {
+	if _, err := z.C0.SetRandomFrom(r); err != nil {
 		return nil, err
 	}
-	if _, err := z.C1.SetRandom(); err != nil {
+	if _, err := z.C1.SetRandomFrom(r); err != nil {
 		return nil, err
 	}
 	return z, nil
@@ -419,6 +428,24 @@ func (z *E12) Inverse(x *E12) *E12 {
 	return z
 }
 
+// InverseCT set z to the inverse of x in E12 and return z, in constant time
+//
+// if x == 0, sets and returns z = x
+func (z *E12) InverseCT(x *E12) *E12 {
+	// Algorithm 23 from https://eprint.iacr.org/2010/354.pdf
+
+	var t0, t1, tmp E6
+	t0.Square(&x.C0)
+	t1.Square(&x.C1)
+	tmp.MulByNonResidue(&t1)
+	t0.Sub(&t0, &tmp)
+	t1.InverseCT(&t0)
+	z.C0.Mul(&x.C0, &t1)
+	z.C1.Mul(&x.C1, &t1).Neg(&z.C1)
+
+	return z
+}
+
 // BatchInvertE12 returns a new slice with every element inverted.
 // Uses Montgomery batch inversion trick
 //
@@ -880,3 +907,46 @@ func (z *E12) Div(x *E12, y *E12) *E12 {
 	r.Inverse(y).Mul(x, &r)
 	return z.Set(&r)
 }
+
+// ExpCT sets z=xᵏ and returns it
+//
+// Unlike Exp, ExpCT uses a Montgomery ladder over max(12*fp.Bits, k.BitLen()) bits with
+// constant-time selections, so that its sequence of operations doesn't depend on x and k (only the sign
+// of k and the bit length of exponents larger than 12*fp.Bits are leaked).
+// It is as constant-time as the E6 arithmetic it relies on.
+func (z *E12) ExpCT(x E12, k *big.Int) *E12 {
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ == (x⁻¹)ᵏ
+		x.InverseCT(&x)
+
+		// we negate k in a temp big.Int since
+		// Int.Bit(_) of k and -k is different
+		e = bigIntPool.Get().(*big.Int)
+		defer bigIntPool.Put(e)
+		e.Neg(k)
+	}
+
+	nbBits := 12 * fp.Bits
+	if e.BitLen() > nbBits {
+		nbBits = e.BitLen()
+	}
+
+	// invariant: r1 = r0 * x
+	var r0, r1, a, b E12
+	r0.SetOne()
+	r1.Set(&x)
+	for i := nbBits - 1; i >= 0; i-- {
+		bit := int(e.Bit(i))
+		// (a, b) = (r0, r1) if bit == 0, (r1, r0) otherwise
+		a.Select(bit, &r0, &r1)
+		b.Select(bit, &r1, &r0)
+		b.Mul(&a, &b)
+		a.Square(&a)
+		r0.Select(bit, &a, &b)
+		r1.Select(bit, &b, &a)
+	}
+
+	return z.Set(&r0)
+}
@@ -47,6 +47,21 @@ func mulGenericE2(z, x, y *E2) {
 	z.A0.Sub(&b, &c) // z.A0.MulByNonResidue(&c).Add(&z.A0, &b)
 }
 
+// MulCT sets z to the E2-product of x,y, returns z
+//
+// Unlike Mul, MulCT only uses the branch-free fp.Element operations (AddCT, SubCT, MulCT).
+func (z *E2) MulCT(x, y *E2) *E2 {
+	var a, b, c fp.Element
+	a.AddCT(&x.A0, &x.A1)
+	b.AddCT(&y.A0, &y.A1)
+	a.MulCT(&a, &b)
+	b.MulCT(&x.A0, &y.A0)
+	c.MulCT(&x.A1, &y.A1)
+	z.A1.SubCT(&a, &b).SubCT(&z.A1, &c)
+	z.A0.SubCT(&b, &c)
+	return z
+}
+
 // squareGenericE2 sets z to the E2-product of x,x returns z
 // note: do not rename, this is referenced in the x86 assembly impl
 func squareGenericE2(z, x *E2) {
@@ -226,3 +226,951 @@ TEXT ·Butterfly(SB), NOSPLIT, $0-16
 	MOVQ SI, 16(AX)
 	MOVQ DI, 24(AX)
 	RET
+
+// addVec(res, a, b *Element, n uint64) res[0...n] = a[0...n] + b[0...n]
+TEXT ·addVec(SB), NOSPLIT, $0-32
+	MOVQ res+0(FP), CX
+	MOVQ a+8(FP), AX
+	MOVQ b+16(FP), DX
+	MOVQ n+24(FP), BX
+
+l1:
+	TESTQ BX, BX
+	JEQ   l2
+	MOVQ  0(AX), SI
+	MOVQ  8(AX), DI
+	MOVQ  16(AX), R8
+	MOVQ  24(AX), R9
+	ADDQ  0(DX), SI
+	ADCQ  8(DX), DI
+	ADCQ  16(DX), R8
+	ADCQ  24(DX), R9
+
+	// reduce element(SI,DI,R8,R9) using temp registers (R10,R11,R12,R13)
+	REDUCE(SI,DI,R8,R9,R10,R11,R12,R13)
+
+	MOVQ SI, 0(CX)
+	MOVQ DI, 8(CX)
+	MOVQ R8, 16(CX)
+	MOVQ R9, 24(CX)
+
+	// increment pointers to visit next element
+	ADDQ $32, AX
+	ADDQ $32, DX
+	ADDQ $32, CX
+	DECQ BX
+	JMP  l1
+
+l2:
+	RET
+
+// subVec(res, a, b *Element, n uint64) res[0...n] = a[0...n] - b[0...n]
+TEXT ·subVec(SB), NOSPLIT, $0-32
+	MOVQ res+0(FP), CX
+	MOVQ a+8(FP), AX
+	MOVQ b+16(FP), DX
+	MOVQ n+24(FP), BX
+
+l3:
+	TESTQ BX, BX
+	JEQ   l4
+	MOVQ  0(AX), SI
+	MOVQ  8(AX), DI
+	MOVQ  16(AX), R8
+	MOVQ  24(AX), R9
+	SUBQ  0(DX), SI
+	SBBQ  8(DX), DI
+	SBBQ  16(DX), R8
+	SBBQ  24(DX), R9
+	JCC   l5
+	ADDQ  q<>+0(SB), SI
+	ADCQ  q<>+8(SB), DI
+	ADCQ  q<>+16(SB), R8
+	ADCQ  q<>+24(SB), R9
+
+l5:
+	MOVQ SI, 0(CX)
+	MOVQ DI, 8(CX)
+	MOVQ R8, 16(CX)
+	MOVQ R9, 24(CX)
+
+	// increment pointers to visit next element
+	ADDQ $32, AX
+	ADDQ $32, DX
+	ADDQ $32, CX
+	DECQ BX
+	JMP  l3
+
+l4:
+	RET
+
+// sumVec(res, a *Element, n uint64) res = a[0] + ... + a[n-1]
+TEXT ·sumVec(SB), NOSPLIT, $0-24
+	MOVQ a+8(FP), AX
+	MOVQ n+16(FP), DX
+
+	// acc = 0
+	XORQ CX, CX
+	XORQ BX, BX
+	XORQ SI, SI
+	XORQ DI, DI
+
+l6:
+	TESTQ DX, DX
+	JEQ   l7
+	ADDQ  0(AX), CX
+	ADCQ  8(AX), BX
+	ADCQ  16(AX), SI
+	ADCQ  24(AX), DI
+
+	// reduce element(CX,BX,SI,DI) using temp registers (R8,R9,R10,R11)
+	REDUCE(CX,BX,SI,DI,R8,R9,R10,R11)
+
+	// increment pointer to visit next element
+	ADDQ $32, AX
+	DECQ DX
+	JMP  l6
+
+l7:
+	MOVQ res+0(FP), AX
+	MOVQ CX, 0(AX)
+	MOVQ BX, 8(AX)
+	MOVQ SI, 16(AX)
+	MOVQ DI, 24(AX)
+	RET
+
+// mulVec(res, a, b *Element, n uint64) res[0...n] = a[0...n] * b[0...n]
+TEXT ·mulVec(SB), $8-32
+	MOVQ res+0(FP), CX
+	MOVQ a+8(FP), R14
+	MOVQ b+16(FP), R13
+	MOVQ n+24(FP), BX
+
+l8:
+	TESTQ BX, BX
+	JEQ   l9
+
+	// A -> BP
+	// t[0] -> SI
+	// t[1] -> DI
+	// t[2] -> R8
+	// t[3] -> R9
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 0(R13), DX
+
+	// (A,t[0])  := x[0]*y[0] + A
+	MULXQ 0(R14), SI, DI
+
+	// (A,t[1])  := x[1]*y[0] + A
+	MULXQ 8(R14), AX, R8
+	ADOXQ AX, DI
+
+	// (A,t[2])  := x[2]*y[0] + A
+	MULXQ 16(R14), AX, R9
+	ADOXQ AX, R8
+
+	// (A,t[3])  := x[3]*y[0] + A
+	MULXQ 24(R14), AX, BP
+	ADOXQ AX, R9
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ SI, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, R10
+	ADCXQ SI, AX
+	MOVQ  R10, SI
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ DI, SI
+	MULXQ q<>+8(SB), AX, DI
+	ADOXQ AX, SI
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ R8, DI
+	MULXQ q<>+16(SB), AX, R8
+	ADOXQ AX, DI
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ R9, R8
+	MULXQ q<>+24(SB), AX, R9
+	ADOXQ AX, R8
+
+	// t[3] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, R9
+	ADOXQ BP, R9
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 8(R13), DX
+
+	// (A,t[0])  := t[0] + x[0]*y[1] + A
+	MULXQ 0(R14), AX, BP
+	ADOXQ AX, SI
+
+	// (A,t[1])  := t[1] + x[1]*y[1] + A
+	ADCXQ BP, DI
+	MULXQ 8(R14), AX, BP
+	ADOXQ AX, DI
+
+	// (A,t[2])  := t[2] + x[2]*y[1] + A
+	ADCXQ BP, R8
+	MULXQ 16(R14), AX, BP
+	ADOXQ AX, R8
+
+	// (A,t[3])  := t[3] + x[3]*y[1] + A
+	ADCXQ BP, R9
+	MULXQ 24(R14), AX, BP
+	ADOXQ AX, R9
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ SI, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, R10
+	ADCXQ SI, AX
+	MOVQ  R10, SI
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ DI, SI
+	MULXQ q<>+8(SB), AX, DI
+	ADOXQ AX, SI
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ R8, DI
+	MULXQ q<>+16(SB), AX, R8
+	ADOXQ AX, DI
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ R9, R8
+	MULXQ q<>+24(SB), AX, R9
+	ADOXQ AX, R8
+
+	// t[3] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, R9
+	ADOXQ BP, R9
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 16(R13), DX
+
+	// (A,t[0])  := t[0] + x[0]*y[2] + A
+	MULXQ 0(R14), AX, BP
+	ADOXQ AX, SI
+
+	// (A,t[1])  := t[1] + x[1]*y[2] + A
+	ADCXQ BP, DI
+	MULXQ 8(R14), AX, BP
+	ADOXQ AX, DI
+
+	// (A,t[2])  := t[2] + x[2]*y[2] + A
+	ADCXQ BP, R8
+	MULXQ 16(R14), AX, BP
+	ADOXQ AX, R8
+
+	// (A,t[3])  := t[3] + x[3]*y[2] + A
+	ADCXQ BP, R9
+	MULXQ 24(R14), AX, BP
+	ADOXQ AX, R9
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ SI, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, R10
+	ADCXQ SI, AX
+	MOVQ  R10, SI
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ DI, SI
+	MULXQ q<>+8(SB), AX, DI
+	ADOXQ AX, SI
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ R8, DI
+	MULXQ q<>+16(SB), AX, R8
+	ADOXQ AX, DI
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ R9, R8
+	MULXQ q<>+24(SB), AX, R9
+	ADOXQ AX, R8
+
+	// t[3] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, R9
+	ADOXQ BP, R9
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 24(R13), DX
+
+	// (A,t[0])  := t[0] + x[0]*y[3] + A
+	MULXQ 0(R14), AX, BP
+	ADOXQ AX, SI
+
+	// (A,t[1])  := t[1] + x[1]*y[3] + A
+	ADCXQ BP, DI
+	MULXQ 8(R14), AX, BP
+	ADOXQ AX, DI
+
+	// (A,t[2])  := t[2] + x[2]*y[3] + A
+	ADCXQ BP, R8
+	MULXQ 16(R14), AX, BP
+	ADOXQ AX, R8
+
+	// (A,t[3])  := t[3] + x[3]*y[3] + A
+	ADCXQ BP, R9
+	MULXQ 24(R14), AX, BP
+	ADOXQ AX, R9
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ SI, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, R10
+	ADCXQ SI, AX
+	MOVQ  R10, SI
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ DI, SI
+	MULXQ q<>+8(SB), AX, DI
+	ADOXQ AX, SI
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ R8, DI
+	MULXQ q<>+16(SB), AX, R8
+	ADOXQ AX, DI
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ R9, R8
+	MULXQ q<>+24(SB), AX, R9
+	ADOXQ AX, R8
+
+	// t[3] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, R9
+	ADOXQ BP, R9
+
+	// reduce element(SI,DI,R8,R9) using temp registers (R11,R12,R10,s0-8(SP))
+	REDUCE(SI,DI,R8,R9,R11,R12,R10,s0-8(SP))
+
+	MOVQ SI, 0(CX)
+	MOVQ DI, 8(CX)
+	MOVQ R8, 16(CX)
+	MOVQ R9, 24(CX)
+
+	// increment pointers to visit next element
+	ADDQ $32, R14
+	ADDQ $32, R13
+	ADDQ $32, CX
+	DECQ BX
+	JMP  l8
+
+l9:
+	RET
+
+// scalarMulVec(res, a, b *Element, n uint64) res[0...n] = a[0...n] * b
+TEXT ·scalarMulVec(SB), $8-32
+	MOVQ res+0(FP), CX
+	MOVQ a+8(FP), R14
+	MOVQ b+16(FP), R13
+	MOVQ n+24(FP), BX
+
+l10:
+	TESTQ BX, BX
+	JEQ   l11
+
+	// A -> BP
+	// t[0] -> SI
+	// t[1] -> DI
+	// t[2] -> R8
+	// t[3] -> R9
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 0(R13), DX
+
+	// (A,t[0])  := x[0]*y[0] + A
+	MULXQ 0(R14), SI, DI
+
+	// (A,t[1])  := x[1]*y[0] + A
+	MULXQ 8(R14), AX, R8
+	ADOXQ AX, DI
+
+	// (A,t[2])  := x[2]*y[0] + A
+	MULXQ 16(R14), AX, R9
+	ADOXQ AX, R8
+
+	// (A,t[3])  := x[3]*y[0] + A
+	MULXQ 24(R14), AX, BP
+	ADOXQ AX, R9
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ SI, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, R10
+	ADCXQ SI, AX
+	MOVQ  R10, SI
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ DI, SI
+	MULXQ q<>+8(SB), AX, DI
+	ADOXQ AX, SI
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ R8, DI
+	MULXQ q<>+16(SB), AX, R8
+	ADOXQ AX, DI
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ R9, R8
+	MULXQ q<>+24(SB), AX, R9
+	ADOXQ AX, R8
+
+	// t[3] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, R9
+	ADOXQ BP, R9
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 8(R13), DX
+
+	// (A,t[0])  := t[0] + x[0]*y[1] + A
+	MULXQ 0(R14), AX, BP
+	ADOXQ AX, SI
+
+	// (A,t[1])  := t[1] + x[1]*y[1] + A
+	ADCXQ BP, DI
+	MULXQ 8(R14), AX, BP
+	ADOXQ AX, DI
+
+	// (A,t[2])  := t[2] + x[2]*y[1] + A
+	ADCXQ BP, R8
+	MULXQ 16(R14), AX, BP
+	ADOXQ AX, R8
+
+	// (A,t[3])  := t[3] + x[3]*y[1] + A
+	ADCXQ BP, R9
+	MULXQ 24(R14), AX, BP
+	ADOXQ AX, R9
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ SI, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, R10
+	ADCXQ SI, AX
+	MOVQ  R10, SI
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ DI, SI
+	MULXQ q<>+8(SB), AX, DI
+	ADOXQ AX, SI
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ R8, DI
+	MULXQ q<>+16(SB), AX, R8
+	ADOXQ AX, DI
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ R9, R8
+	MULXQ q<>+24(SB), AX, R9
+	ADOXQ AX, R8
+
+	// t[3] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, R9
+	ADOXQ BP, R9
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 16(R13), DX
+
+	// (A,t[0])  := t[0] + x[0]*y[2] + A
+	MULXQ 0(R14), AX, BP
+	ADOXQ AX, SI
+
+	// (A,t[1])  := t[1] + x[1]*y[2] + A
+	ADCXQ BP, DI
+	MULXQ 8(R14), AX, BP
+	ADOXQ AX, DI
+
+	// (A,t[2])  := t[2] + x[2]*y[2] + A
+	ADCXQ BP, R8
+	MULXQ 16(R14), AX, BP
+	ADOXQ AX, R8
+
+	// (A,t[3])  := t[3] + x[3]*y[2] + A
+	ADCXQ BP, R9
+	MULXQ 24(R14), AX, BP
+	ADOXQ AX, R9
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ SI, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, R10
+	ADCXQ SI, AX
+	MOVQ  R10, SI
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ DI, SI
+	MULXQ q<>+8(SB), AX, DI
+	ADOXQ AX, SI
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ R8, DI
+	MULXQ q<>+16(SB), AX, R8
+	ADOXQ AX, DI
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ R9, R8
+	MULXQ q<>+24(SB), AX, R9
+	ADOXQ AX, R8
+
+	// t[3] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, R9
+	ADOXQ BP, R9
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 24(R13), DX
+
+	// (A,t[0])  := t[0] + x[0]*y[3] + A
+	MULXQ 0(R14), AX, BP
+	ADOXQ AX, SI
+
+	// (A,t[1])  := t[1] + x[1]*y[3] + A
+	ADCXQ BP, DI
+	MULXQ 8(R14), AX, BP
+	ADOXQ AX, DI
+
+	// (A,t[2])  := t[2] + x[2]*y[3] + A
+	ADCXQ BP, R8
+	MULXQ 16(R14), AX, BP
+	ADOXQ AX, R8
+
+	// (A,t[3])  := t[3] + x[3]*y[3] + A
+	ADCXQ BP, R9
+	MULXQ 24(R14), AX, BP
+	ADOXQ AX, R9
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ SI, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, R10
+	ADCXQ SI, AX
+	MOVQ  R10, SI
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ DI, SI
+	MULXQ q<>+8(SB), AX, DI
+	ADOXQ AX, SI
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ R8, DI
+	MULXQ q<>+16(SB), AX, R8
+	ADOXQ AX, DI
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ R9, R8
+	MULXQ q<>+24(SB), AX, R9
+	ADOXQ AX, R8
+
+	// t[3] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, R9
+	ADOXQ BP, R9
+
+	// reduce element(SI,DI,R8,R9) using temp registers (R11,R12,R10,s0-8(SP))
+	REDUCE(SI,DI,R8,R9,R11,R12,R10,s0-8(SP))
+
+	MOVQ SI, 0(CX)
+	MOVQ DI, 8(CX)
+	MOVQ R8, 16(CX)
+	MOVQ R9, 24(CX)
+
+	// increment pointers to visit next element
+	ADDQ $32, R14
+	ADDQ $32, CX
+	DECQ BX
+	JMP  l10
+
+l11:
+	RET
+
+// innerProdVec(res, a, b *Element, n uint64) res = a[0] * b[0] + ... + a[n-1] * b[n-1]
+TEXT ·innerProdVec(SB), $32-32
+	MOVQ a+8(FP), R14
+	MOVQ b+16(FP), R13
+	MOVQ n+24(FP), CX
+
+	// acc = 0
+	XORQ AX, AX
+	MOVQ AX, R9
+	MOVQ AX, R10
+	MOVQ AX, R11
+	MOVQ AX, R12
+
+l12:
+	TESTQ CX, CX
+	JEQ   l13
+
+	// A -> BP
+	// t[0] -> BX
+	// t[1] -> SI
+	// t[2] -> DI
+	// t[3] -> R8
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 0(R13), DX
+
+	// (A,t[0])  := x[0]*y[0] + A
+	MULXQ 0(R14), BX, SI
+
+	// (A,t[1])  := x[1]*y[0] + A
+	MULXQ 8(R14), AX, DI
+	ADOXQ AX, SI
+
+	// (A,t[2])  := x[2]*y[0] + A
+	MULXQ 16(R14), AX, R8
+	ADOXQ AX, DI
+
+	// (A,t[3])  := x[3]*y[0] + A
+	MULXQ 24(R14), AX, BP
+	ADOXQ AX, R8
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADOXQ AX, BP
+	PUSHQ BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ BX, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, BP
+	ADCXQ BX, AX
+	MOVQ  BP, BX
+	POPQ  BP
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ SI, BX
+	MULXQ q<>+8(SB), AX, SI
+	ADOXQ AX, BX
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ DI, SI
+	MULXQ q<>+16(SB), AX, DI
+	ADOXQ AX, SI
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ R8, DI
+	MULXQ q<>+24(SB), AX, R8
+	ADOXQ AX, DI
+
+	// t[3] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, R8
+	ADOXQ BP, R8
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 8(R13), DX
+
+	// (A,t[0])  := t[0] + x[0]*y[1] + A
+	MULXQ 0(R14), AX, BP
+	ADOXQ AX, BX
+
+	// (A,t[1])  := t[1] + x[1]*y[1] + A
+	ADCXQ BP, SI
+	MULXQ 8(R14), AX, BP
+	ADOXQ AX, SI
+
+	// (A,t[2])  := t[2] + x[2]*y[1] + A
+	ADCXQ BP, DI
+	MULXQ 16(R14), AX, BP
+	ADOXQ AX, DI
+
+	// (A,t[3])  := t[3] + x[3]*y[1] + A
+	ADCXQ BP, R8
+	MULXQ 24(R14), AX, BP
+	ADOXQ AX, R8
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+	PUSHQ BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ BX, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, BP
+	ADCXQ BX, AX
+	MOVQ  BP, BX
+	POPQ  BP
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ SI, BX
+	MULXQ q<>+8(SB), AX, SI
+	ADOXQ AX, BX
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ DI, SI
+	MULXQ q<>+16(SB), AX, DI
+	ADOXQ AX, SI
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ R8, DI
+	MULXQ q<>+24(SB), AX, R8
+	ADOXQ AX, DI
+
+	// t[3] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, R8
+	ADOXQ BP, R8
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 16(R13), DX
+
+	// (A,t[0])  := t[0] + x[0]*y[2] + A
+	MULXQ 0(R14), AX, BP
+	ADOXQ AX, BX
+
+	// (A,t[1])  := t[1] + x[1]*y[2] + A
+	ADCXQ BP, SI
+	MULXQ 8(R14), AX, BP
+	ADOXQ AX, SI
+
+	// (A,t[2])  := t[2] + x[2]*y[2] + A
+	ADCXQ BP, DI
+	MULXQ 16(R14), AX, BP
+	ADOXQ AX, DI
+
+	// (A,t[3])  := t[3] + x[3]*y[2] + A
+	ADCXQ BP, R8
+	MULXQ 24(R14), AX, BP
+	ADOXQ AX, R8
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+	PUSHQ BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ BX, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, BP
+	ADCXQ BX, AX
+	MOVQ  BP, BX
+	POPQ  BP
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ SI, BX
+	MULXQ q<>+8(SB), AX, SI
+	ADOXQ AX, BX
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ DI, SI
+	MULXQ q<>+16(SB), AX, DI
+	ADOXQ AX, SI
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ R8, DI
+	MULXQ q<>+24(SB), AX, R8
+	ADOXQ AX, DI
+
+	// t[3] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, R8
+	ADOXQ BP, R8
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 24(R13), DX
+
+	// (A,t[0])  := t[0] + x[0]*y[3] + A
+	MULXQ 0(R14), AX, BP
+	ADOXQ AX, BX
+
+	// (A,t[1])  := t[1] + x[1]*y[3] + A
+	ADCXQ BP, SI
+	MULXQ 8(R14), AX, BP
+	ADOXQ AX, SI
+
+	// (A,t[2])  := t[2] + x[2]*y[3] + A
+	ADCXQ BP, DI
+	MULXQ 16(R14), AX, BP
+	ADOXQ AX, DI
+
+	// (A,t[3])  := t[3] + x[3]*y[3] + A
+	ADCXQ BP, R8
+	MULXQ 24(R14), AX, BP
+	ADOXQ AX, R8
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+	PUSHQ BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ BX, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, BP
+	ADCXQ BX, AX
+	MOVQ  BP, BX
+	POPQ  BP
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ SI, BX
+	MULXQ q<>+8(SB), AX, SI
+	ADOXQ AX, BX
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ DI, SI
+	MULXQ q<>+16(SB), AX, DI
+	ADOXQ AX, SI
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ R8, DI
+	MULXQ q<>+24(SB), AX, R8
+	ADOXQ AX, DI
+
+	// t[3] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, R8
+	ADOXQ BP, R8
+
+	// reduce element(BX,SI,DI,R8) using temp registers (s0-8(SP),s1-16(SP),s2-24(SP),s3-32(SP))
+	REDUCE(BX,SI,DI,R8,s0-8(SP),s1-16(SP),s2-24(SP),s3-32(SP))
+
+	ADDQ R9, BX
+	ADCQ R10, SI
+	ADCQ R11, DI
+	ADCQ R12, R8
+
+	// reduce element(BX,SI,DI,R8) using temp registers (s0-8(SP),s1-16(SP),s2-24(SP),s3-32(SP))
+	REDUCE(BX,SI,DI,R8,s0-8(SP),s1-16(SP),s2-24(SP),s3-32(SP))
+
+	MOVQ BX, R9
+	MOVQ SI, R10
+	MOVQ DI, R11
+	MOVQ R8, R12
+
+	// increment pointers to visit next element
+	ADDQ $32, R14
+	ADDQ $32, R13
+	DECQ CX
+	JMP  l12
+
+l13:
+	MOVQ R9, BX
+	MOVQ R10, SI
+	MOVQ R11, DI
+	MOVQ R12, R8
+	MOVQ res+0(FP), R14
+	MOVQ BX, 0(R14)
+	MOVQ SI, 8(R14)
+	MOVQ DI, 16(R14)
+	MOVQ R8, 24(R14)
+	RET
@@ -1,6 +1,7 @@
 package fptower
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc/bw6-633/fp"
@@ -260,6 +261,54 @@ func TestE3Ops(t *testing.T) {
 		genA,
 	))
 
+	properties.Property("[BW6-633] Exp(a, k) should be a multiplied k times by itself", prop.ForAll(
+		func(a *E3) bool {
+			var b, c E3
+			b.Exp(*a, big.NewInt(5))
+			c.Square(a).Square(&c).Mul(&c, a)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[BW6-633] Legendre of a square should be 1", prop.ForAll(
+		func(a *E3) bool {
+			var b E3
+			b.Square(a)
+			return a.IsZero() || b.Legendre() == 1
+		},
+		genA,
+	))
+
+	properties.Property("[BW6-633] Sqrt(a²) should be ±a", prop.ForAll(
+		func(a *E3) bool {
+			var b, c, d E3
+			b.Square(a)
+			c.Sqrt(&b)
+			d.Neg(&c)
+			return c.Equal(a) || d.Equal(a)
+		},
+		genA,
+	))
+
+	properties.Property("[BW6-633] a non-square should have Legendre -1", prop.ForAll(
+		func(a *E3) bool {
+			// a² times a non-square of fp
+			var b E3
+			b.Square(a)
+			var nr fp.Element
+			for i := uint64(2); ; i++ {
+				nr.SetUint64(i)
+				if nr.Legendre() == -1 {
+					break
+				}
+			}
+			b.MulByElement(&b, &nr)
+			return a.IsZero() || b.Legendre() == -1
+		},
+		genA,
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
@@ -342,3 +391,76 @@ func BenchmarkE3Conjugate(b *testing.B) {
 		a.Conjugate(&a)
 	}
 }
+
+func TestE3ConstantTime(t *testing.T) {
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 100
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := GenE3()
+	genExp := GenFp()
+
+	properties.Property("[BW6-633] InverseCT must match Inverse", prop.ForAll(
+		func(a *E3) bool {
+			var b, c E3
+			b.InverseCT(a)
+			c.Inverse(a)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[BW6-633] ExpCT must match square and multiply", prop.ForAll(
+		func(a *E3, e fp.Element) bool {
+			var k big.Int
+			e.ToBigIntRegular(&k)
+			// square and multiply reference
+			var b, c E3
+			c.SetOne()
+			for i := k.BitLen() - 1; i >= 0; i-- {
+				c.Square(&c)
+				if k.Bit(i) == 1 {
+					c.Mul(&c, a)
+				}
+			}
+			b.ExpCT(*a, &k)
+			if !b.Equal(&c) {
+				return false
+			}
+			// xᵏ = (x⁻¹)⁻ᵏ
+			k.Neg(&k)
+			c.Inverse(&c)
+			b.ExpCT(*a, &k)
+			return b.Equal(&c)
+		},
+		genA,
+		genExp,
+	))
+
+	var nonSquare E3
+	for nonSquare.Legendre() != -1 {
+		_, _ = nonSquare.SetRandom()
+	}
+
+	properties.Property("[BW6-633] SqrtCT must return a square root of a square", prop.ForAll(
+		func(a *E3) bool {
+			var b, c E3
+			b.Square(a)
+			if c.SqrtCT(&b) == nil {
+				return false
+			}
+			c.Square(&c)
+			if !c.Equal(&b) {
+				return false
+			}
+			// a non square has no square root
+			b.Mul(&b, &nonSquare)
+			return b.SqrtCT(&b) == nil
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
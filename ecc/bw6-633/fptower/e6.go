@@ -17,7 +17,9 @@
 package fptower
 
 import (
+	"crypto/rand"
 	"errors"
+	"io"
 	"math/big"
 	"sync"
 
@@ -103,12 +105,19 @@ func (z *E6) Double(x *E6) *E6 {
 	return z
 }
 
-// SetRandom used only in tests
+// SetRandom sets z to a uniform random value, reading the randomness from crypto/rand.Reader
 func (z *E6) SetRandom() (*E6, error) {
-	if _, err := z.B0.SetRandom(); err != nil {
+	return z.SetRandomFrom(rand.Reader)
+}
+
+// SetRandomFrom sets z to a uniform random value, reading the randomness from r
+//
+// Each coordinate is sampled uniformly with SetRandomFrom, in order.
+func (z *E6) SetRandomFrom(r io.Reader) (*E6, error) {
+	if _, err := z.B0.SetRandomFrom(r); err != nil {
 		return nil, err
 	}
-	if _, err := z.B1.SetRandom(); err != nil {
+	if _, err := z.B1.SetRandomFrom(r); err != nil {
 		return nil, err
 	}
 	return z, nil
@@ -418,6 +427,24 @@ func (z *E6) Inverse(x *E6) *E6 {
 	return z
 }
 
+// InverseCT set z to the inverse of x in E6 and return z, in constant time
+//
+// if x == 0, sets and returns z = x
+func (z *E6) InverseCT(x *E6) *E6 {
+	// Algorithm 23 from https://eprint.iacr.org/2010/354.pdf
+
+	var t0, t1, tmp E3
+	t0.Square(&x.B0)
+	t1.Square(&x.B1)
+	tmp.MulByNonResidue(&t1)
+	t0.Sub(&t0, &tmp)
+	t1.InverseCT(&t0)
+	z.B0.Mul(&x.B0, &t1)
+	z.B1.Mul(&x.B1, &t1).Neg(&z.B1)
+
+	return z
+}
+
 // BatchInvertE6 returns a new slice with every element inverted.
 // Uses Montgomery batch inversion trick
 //
@@ -883,3 +910,55 @@ func BatchDecompressTorus(x []E3) ([]E6, error) {
 
 	return res, nil
 }
+
+func (z *E6) Select(cond int, caseZ *E6, caseNz *E6) *E6 {
+	//Might be able to save a nanosecond or two by an aggregate implementation
+
+	z.B0.Select(cond, &caseZ.B0, &caseNz.B0)
+	z.B1.Select(cond, &caseZ.B1, &caseNz.B1)
+
+	return z
+}
+
+// ExpCT sets z=xᵏ and returns it
+//
+// Unlike Exp, ExpCT uses a Montgomery ladder over max(6*fp.Bits, k.BitLen()) bits with
+// constant-time selections, so that its sequence of operations doesn't depend on x and k (only the sign
+// of k and the bit length of exponents larger than 6*fp.Bits are leaked).
+// It is as constant-time as the E3 arithmetic it relies on.
+func (z *E6) ExpCT(x E6, k *big.Int) *E6 {
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ == (x⁻¹)ᵏ
+		x.InverseCT(&x)
+
+		// we negate k in a temp big.Int since
+		// Int.Bit(_) of k and -k is different
+		e = bigIntPool.Get().(*big.Int)
+		defer bigIntPool.Put(e)
+		e.Neg(k)
+	}
+
+	nbBits := 6 * fp.Bits
+	if e.BitLen() > nbBits {
+		nbBits = e.BitLen()
+	}
+
+	// invariant: r1 = r0 * x
+	var r0, r1, a, b E6
+	r0.SetOne()
+	r1.Set(&x)
+	for i := nbBits - 1; i >= 0; i-- {
+		bit := int(e.Bit(i))
+		// (a, b) = (r0, r1) if bit == 0, (r1, r0) otherwise
+		a.Select(bit, &r0, &r1)
+		b.Select(bit, &r1, &r0)
+		b.Mul(&a, &b)
+		a.Square(&a)
+		r0.Select(bit, &a, &b)
+		r1.Select(bit, &b, &a)
+	}
+
+	return z.Set(&r0)
+}
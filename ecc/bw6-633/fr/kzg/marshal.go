@@ -57,6 +57,7 @@ func (srs *SRS) ReadFrom(r io.Reader) (int64, error) {
 			return dec.BytesRead(), err
 		}
 	}
+	srs.precomputeLines()
 
 	return dec.BytesRead(), nil
 }
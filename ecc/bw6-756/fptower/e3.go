@@ -13,6 +13,10 @@
 package fptower
 
 import (
+	"crypto/rand"
+	"io"
+	"math/big"
+
 	"github.com/consensys/gnark-crypto/ecc/bw6-756/fp"
 )
 
@@ -64,15 +68,22 @@ func (z *E3) SetOne() *E3 {
 	return z
 }
 
-// SetRandom set z to a random elmt
+// SetRandom sets z to a uniform random value, reading the randomness from crypto/rand.Reader
 func (z *E3) SetRandom() (*E3, error) {
-	if _, err := z.A0.SetRandom(); err != nil {
+	return z.SetRandomFrom(rand.Reader)
+}
+
+// SetRandomFrom sets z to a uniform random value, reading the randomness from r
+//
+// Each coordinate is sampled uniformly with SetRandomFrom, in order.
+func (z *E3) SetRandomFrom(r io.Reader) (*E3, error) {
+	if _, err := z.A0.SetRandomFrom(r); err != nil {
 		return nil, err
 	}
-	if _, err := z.A1.SetRandom(); err != nil {
+	if _, err := z.A1.SetRandomFrom(r); err != nil {
 		return nil, err
 	}
-	if _, err := z.A2.SetRandom(); err != nil {
+	if _, err := z.A2.SetRandomFrom(r); err != nil {
 		return nil, err
 	}
 	return z, nil
@@ -300,6 +311,221 @@ func (z *E3) Inverse(x *E3) *E3 {
 	return z
 }
 
+// InverseCT an element in E3, in constant time
+//
+// if x == 0, sets and returns z = x
+func (z *E3) InverseCT(x *E3) *E3 {
+	// Algorithm 17 from https://eprint.iacr.org/2010/354.pdf
+	// step 9 is wrong in the paper it's t1-t4
+	var t0, t1, t2, t3, t4, t5, t6, c0, c1, c2, d1, d2 fp.Element
+	t0.Square(&x.A0)
+	t1.Square(&x.A1)
+	t2.Square(&x.A2)
+	t3.Mul(&x.A0, &x.A1)
+	t4.Mul(&x.A0, &x.A2)
+	t5.Mul(&x.A1, &x.A2)
+	c0.MulByNonResidue(&t5).Neg(&c0).Add(&c0, &t0)
+	c1.MulByNonResidue(&t2).Sub(&c1, &t3)
+	c2.Sub(&t1, &t4)
+	t6.Mul(&x.A0, &c0)
+	d1.Mul(&x.A2, &c1)
+	d2.Mul(&x.A1, &c2)
+	d1.Add(&d1, &d2).MulByNonResidue(&d1)
+	t6.Add(&t6, &d1)
+	t6.InverseCT(&t6)
+	z.A0.Mul(&c0, &t6)
+	z.A1.Mul(&c1, &t6)
+	z.A2.Mul(&c2, &t6)
+
+	return z
+}
+
+// IsOne returns true if z is equal to one
+func (z *E3) IsOne() bool {
+	return z.A0.IsOne() && z.A1.IsZero() && z.A2.IsZero()
+}
+
+// norm sets x to the norm of z, the product of its conjugates (in fp)
+func (z *E3) norm(x *fp.Element) {
+	// same as in Inverse: z * (c0 + c1*u + c2*u²) = norm
+	var t0, t1, t2, t3, t4, t5, c0, c1, c2, d1, d2 fp.Element
+	t0.Square(&z.A0)
+	t1.Square(&z.A1)
+	t2.Square(&z.A2)
+	t3.Mul(&z.A0, &z.A1)
+	t4.Mul(&z.A0, &z.A2)
+	t5.Mul(&z.A1, &z.A2)
+	c0.MulByNonResidue(&t5).Neg(&c0).Add(&c0, &t0)
+	c1.MulByNonResidue(&t2).Sub(&c1, &t3)
+	c2.Sub(&t1, &t4)
+	x.Mul(&z.A0, &c0)
+	d1.Mul(&z.A2, &c1)
+	d2.Mul(&z.A1, &c2)
+	d1.Add(&d1, &d2).MulByNonResidue(&d1)
+	x.Add(x, &d1)
+}
+
+// Legendre returns the Legendre symbol of z
+//
+// E3 has odd degree over fp, so z is a square iff its norm is a square in fp
+func (z *E3) Legendre() int {
+	var n fp.Element
+	z.norm(&n)
+	return n.Legendre()
+}
+
+// Exp sets z=xᵏ (mod q³) and returns it
+func (z *E3) Exp(x E3, k *big.Int) *E3 {
+	if k.IsUint64() && k.Uint64() == 0 {
+		return z.SetOne()
+	}
+
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ (mod q³) == (x⁻¹)ᵏ (mod q³)
+		x.Inverse(&x)
+
+		// we negate k in a temp big.Int since
+		// Int.Bit(_) of k and -k is different
+		e = bigIntPool.Get().(*big.Int)
+		defer bigIntPool.Put(e)
+		e.Neg(k)
+	}
+
+	z.SetOne()
+	b := e.Bytes()
+	for i := 0; i < len(b); i++ {
+		w := b[i]
+		for j := 0; j < 8; j++ {
+			z.Square(z)
+			if (w & (0b10000000 >> j)) != 0 {
+				z.Mul(z, &x)
+			}
+		}
+	}
+
+	return z
+}
+
+// Sqrt sets z to the square root of and returns z
+// The function does not test whether the square root
+// exists or not, it's up to the caller to call
+// Legendre beforehand.
+//
+// It uses Tonelli-Shanks, with q³-1 = 2ˢt (t odd): since q²+q+1 is odd, s is the 2-adicity of q-1.
+func (z *E3) Sqrt(x *E3) *E3 {
+	if x.IsZero() {
+		return z.SetZero()
+	}
+
+	// precomputation
+	var t, one big.Int
+	one.SetUint64(1)
+	q := fp.Modulus()
+	t.Mul(q, q).Mul(&t, q).Sub(&t, &one)
+	s := t.TrailingZeroBits()
+	t.Rsh(&t, s)
+
+	// a non-square of fp is a non-square of E3 (its norm is its cube)
+	var c E3
+	for i := uint64(2); ; i++ {
+		c.A0.SetUint64(i)
+		if c.A0.Legendre() == -1 {
+			break
+		}
+	}
+
+	// computation
+	var y, b, g, tmp E3
+	g.Exp(c, &t)
+	b.Exp(*x, &t)
+	t.Add(&t, &one).Rsh(&t, 1)
+	y.Exp(*x, &t)
+
+	// invariant: y² = xb, b^(2ʳ⁻¹) = 1 and g has order 2ʳ
+	r := s
+	for !b.IsOne() {
+		// m < r is the smallest such that b^(2ᵐ) = 1
+		m := uint(0)
+		tmp.Set(&b)
+		for !tmp.IsOne() {
+			tmp.Square(&tmp)
+			m++
+		}
+		tmp.Set(&g)
+		for i := m + 1; i < r; i++ {
+			tmp.Square(&tmp)
+		}
+		y.Mul(&y, &tmp)
+		g.Square(&tmp)
+		b.Mul(&b, &g)
+		r = m
+	}
+
+	return z.Set(&y)
+}
+
+// SqrtCT sets z to the square root of x and returns z
+// if the square root doesn't exist (x is not a square)
+// SqrtCT leaves z unchanged and returns nil
+//
+// Unlike Sqrt, SqrtCT uses the constant-time variant of Tonelli-Shanks described in
+// RFC 9380 (Appendix I.4), with constant-time exponentiations and selections;
+// only whether x is a square or not is leaked.
+func (z *E3) SqrtCT(x *E3) *E3 {
+
+	// precomputation, q³-1 = 2ˢt (t odd)
+	var t, one big.Int
+	one.SetUint64(1)
+	q := fp.Modulus()
+	t.Mul(q, q).Mul(&t, q).Sub(&t, &one)
+	s := int(t.TrailingZeroBits())
+	t.Rsh(&t, uint(s))
+
+	// a non-square of fp is a non-square of E3 (its norm is its cube)
+	var c E3
+	for i := uint64(2); ; i++ {
+		c.A0.SetUint64(i)
+		if c.A0.Legendre() == -1 {
+			break
+		}
+	}
+	c.Exp(c, &t)
+
+	// computation
+	var y, b, tt, tmp, o E3
+	o.SetOne()
+
+	// y = x^((t-1)/2), tt = x^t, y = x^((t+1)/2)
+	t.Rsh(&t, 1)
+	y.ExpCT(*x, &t)
+	tt.Square(&y).Mul(&tt, x)
+	y.Mul(&y, x)
+	b.Set(&tt)
+
+	for i := s; i >= 2; i-- {
+		for j := 1; j <= i-2; j++ {
+			b.Square(&b)
+		}
+		// if b ≠ 1, y = y * c and tt = tt * c²
+		notOne := int(b.A0.NotEqual(&o.A0) | b.A1.NotEqual(&o.A1) | b.A2.NotEqual(&o.A2))
+		tmp.Mul(&y, &c)
+		y.Select(notOne, &y, &tmp)
+		c.Square(&c)
+		tmp.Mul(&tt, &c)
+		tt.Select(notOne, &tt, &tmp)
+		b.Set(&tt)
+	}
+
+	// ensure y * y = x
+	b.Square(&y)
+	if !b.Equal(x) {
+		return nil
+	}
+	return z.Set(&y)
+}
+
 // BatchInvertE3 returns a new slice with every element inverted.
 // Uses Montgomery batch inversion trick
 //
@@ -335,3 +561,56 @@ func BatchInvertE3(a []E3) []E3 {
 
 	return res
 }
+
+func (z *E3) Select(cond int, caseZ *E3, caseNz *E3) *E3 {
+	//Might be able to save a nanosecond or two by an aggregate implementation
+
+	z.A0.Select(cond, &caseZ.A0, &caseNz.A0)
+	z.A1.Select(cond, &caseZ.A1, &caseNz.A1)
+	z.A2.Select(cond, &caseZ.A2, &caseNz.A2)
+
+	return z
+}
+
+// ExpCT sets z=xᵏ and returns it
+//
+// Unlike Exp, ExpCT uses a Montgomery ladder over max(3*fp.Bits, k.BitLen()) bits with
+// constant-time selections, so that its sequence of operations doesn't depend on x and k (only the sign
+// of k and the bit length of exponents larger than 3*fp.Bits are leaked).
+// It is as constant-time as the fp.Element arithmetic it relies on.
+func (z *E3) ExpCT(x E3, k *big.Int) *E3 {
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ == (x⁻¹)ᵏ
+		x.InverseCT(&x)
+
+		// we negate k in a temp big.Int since
+		// Int.Bit(_) of k and -k is different
+		e = bigIntPool.Get().(*big.Int)
+		defer bigIntPool.Put(e)
+		e.Neg(k)
+	}
+
+	nbBits := 3 * fp.Bits
+	if e.BitLen() > nbBits {
+		nbBits = e.BitLen()
+	}
+
+	// invariant: r1 = r0 * x
+	var r0, r1, a, b E3
+	r0.SetOne()
+	r1.Set(&x)
+	for i := nbBits - 1; i >= 0; i-- {
+		bit := int(e.Bit(i))
+		// (a, b) = (r0, r1) if bit == 0, (r1, r0) otherwise
+		a.Select(bit, &r0, &r1)
+		b.Select(bit, &r1, &r0)
+		b.Mul(&a, &b)
+		a.Square(&a)
+		r0.Select(bit, &a, &b)
+		r1.Select(bit, &b, &a)
+	}
+
+	return z.Set(&r0)
+}
@@ -17,6 +17,7 @@
 package fptower
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc/bw6-756/fp"
@@ -215,6 +216,24 @@ func TestE6Ops(t *testing.T) {
 		genA,
 	))
 
+	properties.Property("[BW6-756] sparse multiplications (014) should match mul", prop.ForAll(
+		func(a *E6, c0, c1, c4, d0, d1, d4 fp.Element) bool {
+			var b, c, d, e E6
+			b.B0.A0, b.B0.A1, b.B1.A1 = c0, c1, c4
+			c.B0.A0, c.B0.A1, c.B1.A1 = d0, d1, d4
+			d.Mul(a, &b)
+			e.Set(a).MulBy014(&c0, &c1, &c4)
+			if !d.Equal(&e) {
+				return false
+			}
+			d.Mul(&b, &c)
+			e.Mul014By014(&d0, &d1, &d4, &c0, &c1, &c4)
+			return d.Equal(&e)
+		},
+		genA,
+		GenFp(), GenFp(), GenFp(), GenFp(), GenFp(), GenFp(),
+	))
+
 	properties.Property("[BW6-756] a + pi(a), a-pi(a) should be real", prop.ForAll(
 		func(a *E6) bool {
 			var b, c, d E6
@@ -436,3 +455,53 @@ func BenchmarkE6Expt(b *testing.B) {
 		a.Expt(&a)
 	}
 }
+
+func TestE6ConstantTime(t *testing.T) {
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 10
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := GenE6()
+	genExp := GenFp()
+
+	properties.Property("[BW6-756] InverseCT must match Inverse", prop.ForAll(
+		func(a *E6) bool {
+			var b, c E6
+			b.InverseCT(a)
+			c.Inverse(a)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[BW6-756] ExpCT must match square and multiply", prop.ForAll(
+		func(a *E6, e fp.Element) bool {
+			var k big.Int
+			e.ToBigIntRegular(&k)
+			// square and multiply reference
+			var b, c E6
+			c.SetOne()
+			for i := k.BitLen() - 1; i >= 0; i-- {
+				c.Square(&c)
+				if k.Bit(i) == 1 {
+					c.Mul(&c, a)
+				}
+			}
+			b.ExpCT(*a, &k)
+			if !b.Equal(&c) {
+				return false
+			}
+			// xᵏ = (x⁻¹)⁻ᵏ
+			k.Neg(&k)
+			c.Inverse(&c)
+			b.ExpCT(*a, &k)
+			return b.Equal(&c)
+		},
+		genA,
+		genExp,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
@@ -140,3 +140,58 @@ func (z *E6) Mul034By034(d0, d3, d4, c0, c3, c4 *fp.Element) *E6 {
 
 	return z
 }
+
+// MulBy014 multiplication by sparse element (c0,c1,0,0,c4,0)
+func (z *E6) MulBy014(c0, c1, c4 *fp.Element) *E6 {
+
+	var a, b, d E3
+	var c1c4 fp.Element
+
+	a.Set(&z.B0)
+	a.MulBy01(c0, c1)
+
+	b.Set(&z.B1)
+	b.MulBy1(c4)
+
+	c1c4.Add(c1, c4)
+	d.Add(&z.B0, &z.B1)
+	d.MulBy01(c0, &c1c4)
+
+	z.B1.Sub(&d, &a).Sub(&z.B1, &b)
+	z.B0.MulByNonResidue(&b).Add(&z.B0, &a)
+
+	return z
+}
+
+// Mul014By014 multiplication of sparse element (c0,c1,0,0,c4,0) by sparse element (d0,d1,0,0,d4,0)
+func (z *E6) Mul014By014(d0, d1, d4, c0, c1, c4 *fp.Element) *E6 {
+	var tmp, x0, x1, x4, x01, x04, x14 fp.Element
+	x0.Mul(c0, d0)
+	x1.Mul(c1, d1)
+	x4.Mul(c4, d4)
+	tmp.Add(c0, c1)
+	x01.Add(d0, d1).
+		Mul(&x01, &tmp).
+		Sub(&x01, &x0).
+		Sub(&x01, &x1)
+	tmp.Add(c0, c4)
+	x04.Add(d0, d4).
+		Mul(&x04, &tmp).
+		Sub(&x04, &x0).
+		Sub(&x04, &x4)
+	tmp.Add(c1, c4)
+	x14.Add(d1, d4).
+		Mul(&x14, &tmp).
+		Sub(&x14, &x1).
+		Sub(&x14, &x4)
+
+	z.B0.A0.MulByNonResidue(&x4).
+		Add(&z.B0.A0, &x0)
+	z.B0.A1.Set(&x01)
+	z.B0.A2.Set(&x1)
+	z.B1.A0.SetZero()
+	z.B1.A1.Set(&x04)
+	z.B1.A2.Set(&x14)
+
+	return z
+}
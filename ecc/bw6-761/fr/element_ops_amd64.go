@@ -39,3 +39,11 @@ func reduce(res *Element)
 //  b = a - b (mod q)
 //go:noescape
 func Butterfly(a, b *Element)
+
+func add(z, x, y *Element) {
+	_addGeneric(z, x, y)
+}
+
+func sub(z, x, y *Element) {
+	_subGeneric(z, x, y)
+}
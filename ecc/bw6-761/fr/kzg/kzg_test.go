@@ -139,6 +139,35 @@ func TestCommit(t *testing.T) {
 
 }
 
+func TestBatchCommit(t *testing.T) {
+
+	// polynomials of different sizes
+	polynomials := [][]fr.Element{randomPolynomial(60), randomPolynomial(17), randomPolynomial(60)}
+
+	digests, err := BatchCommit(polynomials, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(digests) != len(polynomials) {
+		t.Fatal("BatchCommit should return one digest per polynomial")
+	}
+
+	// compare with the commitments of the polynomials one by one
+	for i := range polynomials {
+		digest, err := Commit(polynomials[i], testSRS)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !digests[i].Equal(&digest) {
+			t.Fatal("error KZG batch commitment")
+		}
+	}
+
+	if _, err := BatchCommit([][]fr.Element{polynomials[0], {}}, testSRS); err != ErrInvalidPolynomialSize {
+		t.Fatal("BatchCommit should fail on an empty polynomial")
+	}
+}
+
 func TestVerifySinglePoint(t *testing.T) {
 
 	// create a polynomial
@@ -170,6 +199,20 @@ func TestVerifySinglePoint(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// with a SRS not built by NewSRS nor ReadFrom
+	err = Verify(&digest, &proof, point, &SRS{G1: testSRS.G1, G2: testSRS.G2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// with [α]G₂ modified after NewSRS, the lines of the former [α]G₂ mustn't be used
+	modified := *testSRS
+	modified.G2[1].ScalarMultiplication(&modified.G2[1], big.NewInt(2))
+	err = Verify(&digest, &proof, point, &modified)
+	if err == nil {
+		t.Fatal("verifying with a modified SRS should have failed")
+	}
+
 	{
 		// verify wrong proof
 		proof.ClaimedValue.Double(&proof.ClaimedValue)
@@ -340,6 +383,23 @@ func BenchmarkKZGCommit(b *testing.B) {
 	}
 }
 
+func BenchmarkKZGBatchCommit10(b *testing.B) {
+	benchSRS, err := NewSRS(ecc.NextPowerOfTwo(benchSize), new(big.Int).SetInt64(42))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	polynomials := make([][]fr.Element, 10)
+	for i := range polynomials {
+		polynomials[i] = randomPolynomial(benchSize / 2)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = BatchCommit(polynomials, benchSRS)
+	}
+}
+
 func BenchmarkDivideByXMinusA(b *testing.B) {
 	const pSize = 1 << 22
 
@@ -16,9 +16,10 @@ package bw6761
 
 import (
 	"errors"
+	"io"
 
 	"github.com/consensys/gnark-crypto/ecc/bw6-761/fp"
-	"github.com/consensys/gnark-crypto/ecc/bw6-761/internal/fptower"
+	"github.com/consensys/gnark-crypto/ecc/bw6-761/fptower"
 )
 
 // GT target group of the pairing
@@ -155,6 +156,9 @@ func FinalExponentiation(z *GT, _z ...*GT) GT {
 // computes the multi-Miller loop ∏ᵢ MillerLoop(Pᵢ, Qᵢ)
 // Alg.2 in https://eprint.iacr.org/2021/1359.pdf
 // Eq. (6) in https://hackmd.io/@gnark/BW6-761-changes
+//
+// The loop runs on the points Qᵢ of G2 and evaluates the lines at the points Pᵢ of G1, so that the lines of
+// a fixed Qᵢ can be precomputed (see PrecomputeLines).
 func MillerLoop(P []G1Affine, Q []G2Affine) (GT, error) {
 	// check input size match
 	n := len(P)
@@ -163,62 +167,60 @@ func MillerLoop(P []G1Affine, Q []G2Affine) (GT, error) {
 	}
 
 	// filter infinity points
-	p0 := make([]G1Affine, 0, n)
-	q := make([]G2Affine, 0, n)
+	p := make([]G1Affine, 0, n)
+	q0 := make([]G2Affine, 0, n)
 
 	for k := 0; k < n; k++ {
 		if P[k].IsInfinity() || Q[k].IsInfinity() {
 			continue
 		}
-		p0 = append(p0, P[k])
-		q = append(q, Q[k])
+		p = append(p, P[k])
+		q0 = append(q0, Q[k])
 	}
 
-	n = len(q)
+	n = len(q0)
 
 	// precomputations
-	pProj1 := make([]g1Proj, n)
-	p1 := make([]G1Affine, n)
-	pProj01 := make([]g1Proj, n) // P0+P1
-	pProj10 := make([]g1Proj, n) // P0-P1
+	qProj1 := make([]g2Proj, n)
+	q1 := make([]G2Affine, n)
+	qProj01 := make([]g2Proj, n) // Q0+Q1
+	qProj10 := make([]g2Proj, n) // Q0-Q1
 	l01 := make([]lineEvaluation, n)
-	l10 := make([]lineEvaluation, n)
+	var l lineEvaluation
 	for k := 0; k < n; k++ {
-		p1[k].Y.Neg(&p0[k].Y)
-		p1[k].X.Mul(&p0[k].X, &thirdRootOneG2)
-		pProj1[k].FromAffine(&p1[k])
-
-		// l_{p0,p1}(q)
-		pProj01[k].Set(&pProj1[k])
-		pProj01[k].AddMixedStep(&l01[k], &p0[k])
-		l01[k].r1.Mul(&l01[k].r1, &q[k].X)
-		l01[k].r0.Mul(&l01[k].r0, &q[k].Y)
-
-		// l_{p0,-p1}(q)
-		pProj10[k].Neg(&pProj1[k])
-		pProj10[k].AddMixedStep(&l10[k], &p0[k])
-		l10[k].r1.Mul(&l10[k].r1, &q[k].X)
-		l10[k].r0.Mul(&l10[k].r0, &q[k].Y)
+		q1[k].Y.Neg(&q0[k].Y)
+		q1[k].X.Mul(&q0[k].X, &thirdRootOneG1)
+		qProj1[k].FromAffine(&q1[k])
+
+		// l_{q0,q1}(p)
+		qProj01[k].Set(&qProj1[k])
+		qProj01[k].AddMixedStep(&l01[k], &q0[k])
+		l01[k].r1.Mul(&l01[k].r1, &p[k].X)
+		l01[k].r0.Mul(&l01[k].r0, &p[k].Y)
+
+		// q0-q1
+		qProj10[k].Neg(&qProj1[k])
+		qProj10[k].AddMixedStep(&l, &q0[k])
 	}
-	p01 := BatchProjectiveToAffineG1(pProj01)
-	p10 := BatchProjectiveToAffineG1(pProj10)
+	q01 := BatchProjectiveToAffineG2(qProj01)
+	q10 := BatchProjectiveToAffineG2(qProj10)
 
-	// f_{a0+\lambda*a1,P}(Q)
+	// f_{a0+λ*a1,Q}(P)
 	var result, ss GT
 	result.SetOne()
-	var l, l0 lineEvaluation
+	var l0 lineEvaluation
 
 	var j int8
 
 	// i = len(loopCounter) - 2
 	for k := 0; k < n; k++ {
-		pProj1[k].DoubleStep(&l0)
-		l0.r1.Mul(&l0.r1, &q[k].X)
-		l0.r0.Mul(&l0.r0, &q[k].Y)
-		result.MulBy034(&l0.r0, &l0.r1, &l0.r2)
+		qProj1[k].DoubleStep(&l0)
+		l0.r1.Mul(&l0.r1, &p[k].X)
+		l0.r0.Mul(&l0.r0, &p[k].Y)
+		result.MulBy014(&l0.r2, &l0.r1, &l0.r0)
 	}
 
-	var tmp G1Affine
+	var tmp G2Affine
 	for i := len(loopCounter0) - 3; i >= 0; i-- {
 		// (∏ᵢfᵢ)²
 		result.Square(&result)
@@ -226,68 +228,68 @@ func MillerLoop(P []G1Affine, Q []G2Affine) (GT, error) {
 		j = loopCounter1[i]*3 + loopCounter0[i]
 
 		for k := 0; k < n; k++ {
-			pProj1[k].DoubleStep(&l0)
-			l0.r1.Mul(&l0.r1, &q[k].X)
-			l0.r0.Mul(&l0.r0, &q[k].Y)
+			qProj1[k].DoubleStep(&l0)
+			l0.r1.Mul(&l0.r1, &p[k].X)
+			l0.r0.Mul(&l0.r0, &p[k].Y)
 
 			switch j {
 			case -4:
-				tmp.Neg(&p01[k])
-				pProj1[k].AddMixedStep(&l, &tmp)
-				l.r1.Mul(&l.r1, &q[k].X)
-				l.r0.Mul(&l.r0, &q[k].Y)
-				ss.Mul034By034(&l.r0, &l.r1, &l.r2, &l01[k].r0, &l01[k].r1, &l01[k].r2)
-				result.MulBy034(&l0.r0, &l0.r1, &l0.r2).
+				tmp.Neg(&q01[k])
+				qProj1[k].AddMixedStep(&l, &tmp)
+				l.r1.Mul(&l.r1, &p[k].X)
+				l.r0.Mul(&l.r0, &p[k].Y)
+				ss.Mul014By014(&l.r2, &l.r1, &l.r0, &l01[k].r2, &l01[k].r1, &l01[k].r0)
+				result.MulBy014(&l0.r2, &l0.r1, &l0.r0).
 					Mul(&result, &ss)
 			case -3:
-				tmp.Neg(&p1[k])
-				pProj1[k].AddMixedStep(&l, &tmp)
-				l.r1.Mul(&l.r1, &q[k].X)
-				l.r0.Mul(&l.r0, &q[k].Y)
-				ss.Mul034By034(&l.r0, &l.r1, &l.r2, &l0.r0, &l0.r1, &l0.r2)
+				tmp.Neg(&q1[k])
+				qProj1[k].AddMixedStep(&l, &tmp)
+				l.r1.Mul(&l.r1, &p[k].X)
+				l.r0.Mul(&l.r0, &p[k].Y)
+				ss.Mul014By014(&l.r2, &l.r1, &l.r0, &l0.r2, &l0.r1, &l0.r0)
 				result.Mul(&result, &ss)
 			case -2:
-				pProj1[k].AddMixedStep(&l, &p10[k])
-				l.r1.Mul(&l.r1, &q[k].X)
-				l.r0.Mul(&l.r0, &q[k].Y)
-				ss.Mul034By034(&l.r0, &l.r1, &l.r2, &l01[k].r0, &l01[k].r1, &l01[k].r2)
-				result.MulBy034(&l0.r0, &l0.r1, &l0.r2).
+				qProj1[k].AddMixedStep(&l, &q10[k])
+				l.r1.Mul(&l.r1, &p[k].X)
+				l.r0.Mul(&l.r0, &p[k].Y)
+				ss.Mul014By014(&l.r2, &l.r1, &l.r0, &l01[k].r2, &l01[k].r1, &l01[k].r0)
+				result.MulBy014(&l0.r2, &l0.r1, &l0.r0).
 					Mul(&result, &ss)
 			case -1:
-				tmp.Neg(&p0[k])
-				pProj1[k].AddMixedStep(&l, &tmp)
-				l.r1.Mul(&l.r1, &q[k].X)
-				l.r0.Mul(&l.r0, &q[k].Y)
-				ss.Mul034By034(&l.r0, &l.r1, &l.r2, &l0.r0, &l0.r1, &l0.r2)
+				tmp.Neg(&q0[k])
+				qProj1[k].AddMixedStep(&l, &tmp)
+				l.r1.Mul(&l.r1, &p[k].X)
+				l.r0.Mul(&l.r0, &p[k].Y)
+				ss.Mul014By014(&l.r2, &l.r1, &l.r0, &l0.r2, &l0.r1, &l0.r0)
 				result.Mul(&result, &ss)
 			case 0:
-				result.MulBy034(&l0.r0, &l0.r1, &l0.r2)
+				result.MulBy014(&l0.r2, &l0.r1, &l0.r0)
 			case 1:
-				pProj1[k].AddMixedStep(&l, &p0[k])
-				l.r1.Mul(&l.r1, &q[k].X)
-				l.r0.Mul(&l.r0, &q[k].Y)
-				ss.Mul034By034(&l.r0, &l.r1, &l.r2, &l0.r0, &l0.r1, &l0.r2)
+				qProj1[k].AddMixedStep(&l, &q0[k])
+				l.r1.Mul(&l.r1, &p[k].X)
+				l.r0.Mul(&l.r0, &p[k].Y)
+				ss.Mul014By014(&l.r2, &l.r1, &l.r0, &l0.r2, &l0.r1, &l0.r0)
 				result.Mul(&result, &ss)
 			case 2:
-				tmp.Neg(&p10[k])
-				pProj1[k].AddMixedStep(&l, &tmp)
-				l.r1.Mul(&l.r1, &q[k].X)
-				l.r0.Mul(&l.r0, &q[k].Y)
-				ss.Mul034By034(&l.r0, &l.r1, &l.r2, &l01[k].r0, &l01[k].r1, &l01[k].r2)
-				result.MulBy034(&l0.r0, &l0.r1, &l0.r2).
+				tmp.Neg(&q10[k])
+				qProj1[k].AddMixedStep(&l, &tmp)
+				l.r1.Mul(&l.r1, &p[k].X)
+				l.r0.Mul(&l.r0, &p[k].Y)
+				ss.Mul014By014(&l.r2, &l.r1, &l.r0, &l01[k].r2, &l01[k].r1, &l01[k].r0)
+				result.MulBy014(&l0.r2, &l0.r1, &l0.r0).
 					Mul(&result, &ss)
 			case 3:
-				pProj1[k].AddMixedStep(&l, &p1[k])
-				l.r1.Mul(&l.r1, &q[k].X)
-				l.r0.Mul(&l.r0, &q[k].Y)
-				ss.Mul034By034(&l.r0, &l.r1, &l.r2, &l0.r0, &l0.r1, &l0.r2)
+				qProj1[k].AddMixedStep(&l, &q1[k])
+				l.r1.Mul(&l.r1, &p[k].X)
+				l.r0.Mul(&l.r0, &p[k].Y)
+				ss.Mul014By014(&l.r2, &l.r1, &l.r0, &l0.r2, &l0.r1, &l0.r0)
 				result.Mul(&result, &ss)
 			case 4:
-				pProj1[k].AddMixedStep(&l, &p01[k])
-				l.r1.Mul(&l.r1, &q[k].X)
-				l.r0.Mul(&l.r0, &q[k].Y)
-				ss.Mul034By034(&l.r0, &l.r1, &l.r2, &l01[k].r0, &l01[k].r1, &l01[k].r2)
-				result.MulBy034(&l0.r0, &l0.r1, &l0.r2).
+				qProj1[k].AddMixedStep(&l, &q01[k])
+				l.r1.Mul(&l.r1, &p[k].X)
+				l.r0.Mul(&l.r0, &p[k].Y)
+				ss.Mul014By014(&l.r2, &l.r1, &l.r0, &l01[k].r2, &l01[k].r1, &l01[k].r0)
+				result.MulBy014(&l0.r2, &l0.r1, &l0.r0).
 					Mul(&result, &ss)
 			default:
 				return GT{}, errors.New("invalid loopCounter")
@@ -300,7 +302,7 @@ func MillerLoop(P []G1Affine, Q []G2Affine) (GT, error) {
 
 // DoubleStep doubles a point in Homogenous projective coordinates, and evaluates the line in Miller loop
 // https://eprint.iacr.org/2013/722.pdf (Section 4.3)
-func (p *g1Proj) DoubleStep(evaluations *lineEvaluation) {
+func (p *g2Proj) DoubleStep(evaluations *lineEvaluation) {
 
 	// get some Element from our pool
 	var t1, A, B, C, D, E, EE, F, G, H, I, J, K fp.Element
@@ -311,8 +313,9 @@ func (p *g1Proj) DoubleStep(evaluations *lineEvaluation) {
 	D.Double(&C).
 		Add(&D, &C)
 
-		// E.Mul(&D, &bCurveCoeff)
-	E.Neg(&D)
+	// E.Mul(&D, &bTwistCurveCoeff)
+	E.Double(&D).
+		Double(&E)
 
 	F.Double(&E).
 		Add(&F, &E)
@@ -344,7 +347,7 @@ func (p *g1Proj) DoubleStep(evaluations *lineEvaluation) {
 
 // AddMixedStep point addition in Mixed Homogenous projective and Affine coordinates
 // https://eprint.iacr.org/2013/722.pdf (Section 4.3)
-func (p *g1Proj) AddMixedStep(evaluations *lineEvaluation, a *G1Affine) {
+func (p *g2Proj) AddMixedStep(evaluations *lineEvaluation, a *G2Affine) {
 
 	// get some Element from our pool
 	var Y2Z1, X2Z1, O, L, C, D, E, F, G, H, t0, t1, t2, J fp.Element
@@ -378,3 +381,216 @@ func (p *g1Proj) AddMixedStep(evaluations *lineEvaluation, a *G1Affine) {
 	evaluations.r1.Neg(&O)
 	evaluations.r2.Set(&J)
 }
+
+// LineEvaluations are the lines of the Miller loop of a point Q of G2, which don't depend on the point of G1
+// they're evaluated at (see PrecomputeLines)
+type LineEvaluations struct {
+	lines    []lineEvaluation
+	infinity bool // Q is the infinity
+}
+
+// nbLineEvaluations returns the number of lines of the Miller loop of a point of G2
+func nbLineEvaluations() int {
+	// the line of Q0+Q1 and the doubling at i == len(loopCounter) - 2
+	n := 2
+	for i := len(loopCounter0) - 3; i >= 0; i-- {
+		n++
+		if loopCounter1[i] != 0 || loopCounter0[i] != 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// PrecomputeLines computes the lines of the Miller loop of Q, to compute the Miller loops of several points of
+// G1 with Q without doubling and adding Q each time (see MillerLoopFixedQ)
+func PrecomputeLines(Q G2Affine) LineEvaluations {
+	if Q.IsInfinity() {
+		return LineEvaluations{infinity: true}
+	}
+	lines := make([]lineEvaluation, 0, nbLineEvaluations())
+
+	var l lineEvaluation
+	var q0, q1 G2Affine
+	var qProj1, qProj01, qProj10 g2Proj
+	q0.Set(&Q)
+	q1.Y.Neg(&q0.Y)
+	q1.X.Mul(&q0.X, &thirdRootOneG1)
+	qProj1.FromAffine(&q1)
+
+	// l_{q0,q1}
+	qProj01.Set(&qProj1)
+	qProj01.AddMixedStep(&l, &q0)
+	lines = append(lines, l)
+
+	// q0-q1
+	qProj10.Neg(&qProj1)
+	qProj10.AddMixedStep(&l, &q0)
+	q := BatchProjectiveToAffineG2([]g2Proj{qProj01, qProj10})
+	q01, q10 := q[0], q[1]
+
+	// i = len(loopCounter) - 2
+	qProj1.DoubleStep(&l)
+	lines = append(lines, l)
+
+	var tmp G2Affine
+	for i := len(loopCounter0) - 3; i >= 0; i-- {
+		qProj1.DoubleStep(&l)
+		lines = append(lines, l)
+
+		j := loopCounter1[i]*3 + loopCounter0[i]
+		if j == 0 {
+			continue
+		}
+		switch j {
+		case -4:
+			tmp.Neg(&q01)
+		case -3:
+			tmp.Neg(&q1)
+		case -2:
+			tmp.Set(&q10)
+		case -1:
+			tmp.Neg(&q0)
+		case 1:
+			tmp.Set(&q0)
+		case 2:
+			tmp.Neg(&q10)
+		case 3:
+			tmp.Set(&q1)
+		case 4:
+			tmp.Set(&q01)
+		}
+		qProj1.AddMixedStep(&l, &tmp)
+		lines = append(lines, l)
+	}
+
+	return LineEvaluations{lines: lines}
+}
+
+// MillerLoopFixedQ computes the multi-Miller loop
+// ∏ᵢ MillerLoop(Pᵢ, Qᵢ)
+// from the lines of the Qᵢ computed by PrecomputeLines
+func MillerLoopFixedQ(P []G1Affine, lines []LineEvaluations) (GT, error) {
+	n := len(P)
+	if n == 0 || n != len(lines) {
+		return GT{}, errors.New("invalid inputs sizes")
+	}
+
+	// filter infinity points
+	nbLines := nbLineEvaluations()
+	p := make([]G1Affine, 0, n)
+	q := make([][]lineEvaluation, 0, n)
+
+	for k := 0; k < n; k++ {
+		if !lines[k].infinity && len(lines[k].lines) != nbLines {
+			return GT{}, errors.New("invalid line evaluations")
+		}
+		if P[k].IsInfinity() || lines[k].infinity {
+			continue
+		}
+		p = append(p, P[k])
+		q = append(q, lines[k].lines)
+	}
+	n = len(p)
+
+	// l_{q0,q1}(p)
+	l01 := make([]lineEvaluation, n)
+	for k := 0; k < n; k++ {
+		l01[k].evaluate(&q[k][0], &p[k])
+	}
+
+	var result, ss GT
+	result.SetOne()
+	var l, l0 lineEvaluation
+
+	// i = len(loopCounter) - 2
+	for k := 0; k < n; k++ {
+		l0.evaluate(&q[k][1], &p[k])
+		result.MulBy014(&l0.r2, &l0.r1, &l0.r0)
+	}
+
+	m := 2
+	for i := len(loopCounter0) - 3; i >= 0; i-- {
+		// (∏ᵢfᵢ)²
+		result.Square(&result)
+
+		j := loopCounter1[i]*3 + loopCounter0[i]
+
+		for k := 0; k < n; k++ {
+			l0.evaluate(&q[k][m], &p[k])
+
+			switch j {
+			case 0:
+				result.MulBy014(&l0.r2, &l0.r1, &l0.r0)
+			case -3, -1, 1, 3:
+				l.evaluate(&q[k][m+1], &p[k])
+				ss.Mul014By014(&l.r2, &l.r1, &l.r0, &l0.r2, &l0.r1, &l0.r0)
+				result.Mul(&result, &ss)
+			case -4, -2, 2, 4:
+				l.evaluate(&q[k][m+1], &p[k])
+				ss.Mul014By014(&l.r2, &l.r1, &l.r0, &l01[k].r2, &l01[k].r1, &l01[k].r0)
+				result.MulBy014(&l0.r2, &l0.r1, &l0.r0).
+					Mul(&result, &ss)
+			default:
+				return GT{}, errors.New("invalid loopCounter")
+			}
+		}
+
+		m++
+		if j != 0 {
+			m++
+		}
+	}
+
+	return result, nil
+}
+
+// evaluate sets l to the line evaluated at p
+func (l *lineEvaluation) evaluate(line *lineEvaluation, p *G1Affine) {
+	l.r0.Mul(&line.r0, &p.Y)
+	l.r1.Mul(&line.r1, &p.X)
+	l.r2.Set(&line.r2)
+}
+
+// WriteTo writes the binary encoding of the lines: their coefficients, as a []fp.Element
+// (empty if Q is the infinity)
+func (lines *LineEvaluations) WriteTo(w io.Writer) (int64, error) {
+	if !lines.infinity && len(lines.lines) == 0 {
+		return 0, errors.New("line evaluations not initialized, see PrecomputeLines")
+	}
+	coeffs := make([]fp.Element, 0, len(lines.lines)*3)
+	for i := range lines.lines {
+		l := &lines.lines[i]
+		coeffs = append(coeffs, l.r0, l.r1, l.r2)
+	}
+
+	enc := NewEncoder(w)
+	err := enc.Encode(coeffs)
+	return enc.BytesWritten(), err
+}
+
+// ReadFrom decodes lines written by WriteTo
+//
+// The lines are only checked to be as many as those of PrecomputeLines: they must come from a trusted source.
+func (lines *LineEvaluations) ReadFrom(r io.Reader) (int64, error) {
+	dec := NewDecoder(r)
+	var coeffs []fp.Element
+	if err := dec.Decode(&coeffs); err != nil {
+		return dec.BytesRead(), err
+	}
+	if len(coeffs) == 0 {
+		*lines = LineEvaluations{infinity: true}
+		return dec.BytesRead(), nil
+	}
+	if len(coeffs) != nbLineEvaluations()*3 {
+		return dec.BytesRead(), errors.New("invalid number of line evaluations")
+	}
+
+	lines.infinity = false
+	lines.lines = make([]lineEvaluation, len(coeffs)/3)
+	for i := range lines.lines {
+		l := &lines.lines[i]
+		l.r0, l.r1, l.r2 = coeffs[i*3], coeffs[i*3+1], coeffs[i*3+2]
+	}
+	return dec.BytesRead(), nil
+}
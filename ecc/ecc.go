@@ -109,6 +109,31 @@ func modulus(c *config.Curve, scalarField bool) *big.Int {
 
 // MultiExpConfig enables to set optional configuration attribute to a call to MultiExp
 type MultiExpConfig struct {
-	NbTasks     int  // go routines to be used in the multiexp. can be larger than num cpus.
-	ScalarsMont bool // indicates if the scalars are in montgommery form. Default to false.
+	NbTasks     int             // go routines to be used in the multiexp. can be larger than num cpus.
+	ScalarsMont bool            // indicates if the scalars are in montgommery form. Default to false.
+	Buckets     MultiExpBuckets // coordinates of the buckets of the multiexp. Default to BucketsAuto.
+
+	// MaxScalarBits is the maximum bit length of the scalars (in regular form), to skip the windows
+	// of the multiexp above it. If not set (0), it's computed from the scalars.
+	// A smaller value than the actual bit length of the scalars gives a wrong result.
+	MaxScalarBits int
+
+	// EnableGLV enables the GLV decomposition of the scalars, on the curves with an efficient endomorphism:
+	// MultiExp then splits each scalar in 2 scalars of half the bit length (see SplitScalar)
+	// when it lowers the estimated cost of the multiexp.
+	// The decomposition allocates 2n points and 2n scalars for n input points. Default to false.
+	EnableGLV bool
 }
+
+// MultiExpBuckets selects how the points are accumulated in the buckets of a MultiExp
+type MultiExpBuckets uint8
+
+const (
+	// BucketsAuto uses batch affine buckets for the large MultiExps (large windows), extended Jacobian buckets otherwise
+	BucketsAuto MultiExpBuckets = iota
+	// BucketsExtendedJacobian adds the points to buckets in extended Jacobian coordinates
+	BucketsExtendedJacobian
+	// BucketsBatchAffine adds the points to buckets in affine coordinates, by batches sharing one field inversion;
+	// the points whose bucket is already in the current batch wait in a queue
+	BucketsBatchAffine
+)
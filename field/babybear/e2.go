@@ -0,0 +1,453 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package babybear
+
+import (
+	"crypto/rand"
+	"io"
+	"math/big"
+)
+
+// E2 is a degree 2 extension of Element: Element[u]/(u² - (-11))
+type E2 struct {
+	A0 Element
+	A1 Element
+}
+
+// e2NonResidue α such that u² = α (montgomery form)
+var e2NonResidue = Element{
+	1073741848,
+}
+
+// e2FrobeniusCoefficients α^(i(q-1)/2) for i in [1, 1] (montgomery form)
+var e2FrobeniusCoefficients = [1]Element{
+	{
+		1744830467,
+	},
+}
+
+var _bSqrtExponentE2 *big.Int
+
+func init() {
+	_bSqrtExponentE2, _ = new(big.Int).SetString("1c2000007", 16)
+}
+
+// Equal returns true if z equals x, false otherwise
+func (z *E2) Equal(x *E2) bool {
+	return z.A0.Equal(&x.A0) && z.A1.Equal(&x.A1)
+}
+
+// IsZero returns true if z is zero, false otherwise
+func (z *E2) IsZero() bool {
+	return z.A0.IsZero() && z.A1.IsZero()
+}
+
+// IsOne returns true if z is one, false otherwise
+func (z *E2) IsOne() bool {
+	return z.A0.IsOne() && z.A1.IsZero()
+}
+
+// SetZero sets z to 0 in Montgomery form and returns z
+func (z *E2) SetZero() *E2 {
+	z.A0.SetZero()
+	z.A1.SetZero()
+	return z
+}
+
+// SetOne sets z to 1 in Montgomery form and returns z
+func (z *E2) SetOne() *E2 {
+	z.A0.SetOne()
+	z.A1.SetZero()
+	return z
+}
+
+// Set sets z to x and returns z
+func (z *E2) Set(x *E2) *E2 {
+	z.A0 = x.A0
+	z.A1 = x.A1
+	return z
+}
+
+// SetRandom sets z to a uniform random value
+func (z *E2) SetRandom() (*E2, error) {
+	return z.SetRandomFrom(rand.Reader)
+}
+
+// SetRandomFrom sets z to a uniform random value, reading the randomness from r
+//
+// Each coordinate is sampled uniformly with Element.SetRandomFrom, in order.
+func (z *E2) SetRandomFrom(r io.Reader) (*E2, error) {
+	if _, err := z.A0.SetRandomFrom(r); err != nil {
+		return nil, err
+	}
+	if _, err := z.A1.SetRandomFrom(r); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// Add sets z=x+y and returns z
+func (z *E2) Add(x, y *E2) *E2 {
+	z.A0.Add(&x.A0, &y.A0)
+	z.A1.Add(&x.A1, &y.A1)
+	return z
+}
+
+// Sub sets z=x-y and returns z
+func (z *E2) Sub(x, y *E2) *E2 {
+	z.A0.Sub(&x.A0, &y.A0)
+	z.A1.Sub(&x.A1, &y.A1)
+	return z
+}
+
+// Double sets z=2x and returns z
+func (z *E2) Double(x *E2) *E2 {
+	z.A0.Double(&x.A0)
+	z.A1.Double(&x.A1)
+	return z
+}
+
+// Neg sets z=-x and returns z
+func (z *E2) Neg(x *E2) *E2 {
+	z.A0.Neg(&x.A0)
+	z.A1.Neg(&x.A1)
+	return z
+}
+
+// MulByElement sets z=x*y where y is in the base field and returns z
+func (z *E2) MulByElement(x *E2, y *Element) *E2 {
+	var yCopy Element
+	yCopy.Set(y)
+	z.A0.Mul(&x.A0, &yCopy)
+	z.A1.Mul(&x.A1, &yCopy)
+	return z
+}
+
+// Frobenius sets z=x^q and returns z
+func (z *E2) Frobenius(x *E2) *E2 {
+	// (Σ aᵢuⁱ)^q = Σ aᵢ(uⁱ)^q = Σ aᵢα^(i(q-1)/2)uⁱ
+	z.A0.Set(&x.A0)
+	z.A1.Mul(&x.A1, &e2FrobeniusCoefficients[0])
+	return z
+}
+
+// Div sets z=x/y and returns z
+func (z *E2) Div(x, y *E2) *E2 {
+	var r E2
+	r.Inverse(y).Mul(x, &r)
+	return z.Set(&r)
+}
+
+// Exp sets z=xᵏ (mod q²) and returns it
+func (z *E2) Exp(x E2, k *big.Int) *E2 {
+	if k.IsUint64() && k.Uint64() == 0 {
+		return z.SetOne()
+	}
+
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ (mod q²) == (x⁻¹)ᵏ (mod q²)
+		x.Inverse(&x)
+
+		// we negate k in a temp big.Int since
+		// Int.Bit(_) of k and -k is different
+		e = bigIntPool.Get().(*big.Int)
+		defer bigIntPool.Put(e)
+		e.Neg(k)
+	}
+
+	z.Set(&x)
+
+	for i := e.BitLen() - 2; i >= 0; i-- {
+		z.Square(z)
+		if e.Bit(i) == 1 {
+			z.Mul(z, &x)
+		}
+	}
+
+	return z
+}
+
+// Select is a constant-time conditional move.
+// If c=0, z = x0. Else z = x1
+func (z *E2) Select(c int, x0, x1 *E2) *E2 {
+	z.A0.Select(c, &x0.A0, &x1.A0)
+	z.A1.Select(c, &x0.A1, &x1.A1)
+	return z
+}
+
+// ExpCT sets z=xᵏ (mod q²) and returns it
+//
+// Unlike Exp, ExpCT uses a Montgomery ladder over max(2*Bits, k.BitLen()) bits with
+// constant-time selections, so that its sequence of operations doesn't depend on x and k (only the sign
+// of k and the bit length of exponents larger than 2*Bits are leaked).
+// It is as constant-time as the Element arithmetic it relies on.
+func (z *E2) ExpCT(x E2, k *big.Int) *E2 {
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ (mod q²) == (x⁻¹)ᵏ (mod q²)
+		x.InverseCT(&x)
+
+		// we negate k in a temp big.Int since
+		// Int.Bit(_) of k and -k is different
+		e = bigIntPool.Get().(*big.Int)
+		defer bigIntPool.Put(e)
+		e.Neg(k)
+	}
+
+	nbBits := 2 * Bits
+	if e.BitLen() > nbBits {
+		nbBits = e.BitLen()
+	}
+
+	// invariant: r1 = r0 * x
+	var r0, r1, a, b E2
+	r0.SetOne()
+	r1.Set(&x)
+	for i := nbBits - 1; i >= 0; i-- {
+		bit := int(e.Bit(i))
+		// (a, b) = (r0, r1) if bit == 0, (r1, r0) otherwise
+		a.Select(bit, &r0, &r1)
+		b.Select(bit, &r1, &r0)
+		b.Mul(&a, &b)
+		a.Square(&a)
+		r0.Select(bit, &a, &b)
+		r1.Select(bit, &b, &a)
+	}
+
+	return z.Set(&r0)
+}
+
+// Legendre returns the Legendre symbol of z (either +1, -1, or 0.)
+func (z *E2) Legendre() int {
+	// z is a square in the extension iff its norm is a square in Element
+	var n Element
+	z.norm(&n)
+	return n.Legendre()
+}
+
+// Sqrt sets z to the square root of x and returns z
+// if the square root doesn't exist (x is not a square)
+// Sqrt leaves z unchanged and returns nil
+func (z *E2) Sqrt(x *E2) *E2 {
+	// q² - 1 = 2ᵉ * s, s odd
+	// see modSqrtTonelliShanks in math/big/int.go
+	var y, b, t, w E2
+	// w = x^((s-1)/2))
+	w.Exp(*x, _bSqrtExponentE2)
+
+	// y = x^((s+1)/2)) = w * x
+	y.Mul(x, &w)
+
+	// b = x^s = w * w * x = y * x
+	b.Mul(&w, &y)
+
+	// g = nonResidue ^ s
+	g := E2{
+		A0: Element{
+			0,
+		},
+		A1: Element{
+			30426721,
+		},
+	}
+	r := uint64(28)
+
+	// compute legendre symbol
+	// t = x^((q²-1)/2) = r-1 squaring of x^s
+	t = b
+	for i := uint64(0); i < r-1; i++ {
+		t.Square(&t)
+	}
+	if t.IsZero() {
+		return z.SetZero()
+	}
+	if !t.IsOne() {
+		// t != 1, we don't have a square root
+		return nil
+	}
+	for {
+		var m uint64
+		t = b
+
+		// for t != 1
+		for !t.IsOne() {
+			t.Square(&t)
+			m++
+		}
+
+		if m == 0 {
+			return z.Set(&y)
+		}
+		// t = g^(2^(r-m-1))
+		ge := int(r - m - 1)
+		t = g
+		for ge > 0 {
+			t.Square(&t)
+			ge--
+		}
+
+		g.Square(&t)
+		y.Mul(&y, &t)
+		b.Mul(&b, &g)
+		r = m
+	}
+}
+
+// SqrtCT sets z to the square root of x and returns z
+// if the square root doesn't exist (x is not a square)
+// SqrtCT leaves z unchanged and returns nil
+//
+// Unlike Sqrt, SqrtCT uses the constant-time variant of Tonelli-Shanks described in
+// RFC 9380 (Appendix I.4); only whether x is a square or not is leaked.
+func (z *E2) SqrtCT(x *E2) *E2 {
+	// q² - 1 = 2ᵉ * s, s odd
+	var y, b, t, tmp E2
+
+	// y = x^((s-1)/2)
+	y.ExpCT(*x, _bSqrtExponentE2)
+
+	// t = x^s, y = x^((s+1)/2)
+	t.Square(&y).Mul(&t, x)
+	y.Mul(&y, x)
+
+	// c = g^s, g a non-residue
+	c := E2{
+		A0: Element{
+			0,
+		},
+		A1: Element{
+			30426721,
+		},
+	}
+	b = t
+
+	for i := 28; i >= 2; i-- {
+		for j := 1; j <= i-2; j++ {
+			b.Square(&b)
+		}
+		// if b ≠ 1, y = y * c and t = t * c²
+		notOne := b.notOneCT()
+		tmp.Mul(&y, &c)
+		y.Select(notOne, &y, &tmp)
+		c.Square(&c)
+		tmp.Mul(&t, &c)
+		t.Select(notOne, &t, &tmp)
+		b = t
+	}
+
+	// as we didn't compute the legendre symbol, ensure we found y such that y * y = x
+	tmp.Square(&y)
+	if !tmp.Equal(x) {
+		return nil
+	}
+	return z.Set(&y)
+}
+
+// notOneCT returns 0 if and only if z == 1, without branching
+func (z *E2) notOneCT() int {
+	var one E2
+	one.SetOne()
+	v := z.A0.NotEqual(&one.A0) | z.A1.NotEqual(&one.A1)
+	return int((v | -v) >> 63)
+}
+
+// mulByNonResidueE2 sets z=α*x and returns z
+func mulByNonResidueE2(z, x *Element) *Element {
+	return z.Mul(x, &e2NonResidue)
+}
+
+// String puts z in string form
+func (z *E2) String() string {
+	return z.A0.String() + "+" + z.A1.String() + "*u"
+}
+
+// Conjugate sets z to x conjugated and returns z
+func (z *E2) Conjugate(x *E2) *E2 {
+	z.A0 = x.A0
+	z.A1.Neg(&x.A1)
+	return z
+}
+
+// Mul sets z to the E2-product of x,y, returns z
+func (z *E2) Mul(x, y *E2) *E2 {
+	var a, b, c Element
+	a.Add(&x.A0, &x.A1)
+	b.Add(&y.A0, &y.A1)
+	a.Mul(&a, &b)
+	b.Mul(&x.A0, &y.A0)
+	c.Mul(&x.A1, &y.A1)
+	z.A1.Sub(&a, &b).Sub(&z.A1, &c)
+	mulByNonResidueE2(&c, &c)
+	z.A0.Add(&b, &c)
+	return z
+}
+
+// Square sets z to the E2-product of x,x returns z
+func (z *E2) Square(x *E2) *E2 {
+	// (a0 + a1u)² = a0² + αa1² + 2a0a1u
+	var a, b, c Element
+	c.Mul(&x.A0, &x.A1)
+	mulByNonResidueE2(&b, &x.A1)
+	b.Add(&b, &x.A0)
+	a.Add(&x.A0, &x.A1)
+	a.Mul(&a, &b)
+	mulByNonResidueE2(&b, &c)
+	b.Add(&b, &c)
+	z.A0.Sub(&a, &b)
+	z.A1.Double(&c)
+	return z
+}
+
+// Inverse sets z to the E2-inverse of x, returns z
+//
+// if x == 0, sets and returns z = x
+func (z *E2) Inverse(x *E2) *E2 {
+	// 1/(a0 + a1u) = (a0 - a1u)/(a0² - αa1²)
+	var t Element
+	x.norm(&t)
+	t.Inverse(&t)
+	z.A0.Mul(&x.A0, &t)
+	z.A1.Mul(&x.A1, &t).Neg(&z.A1)
+	return z
+}
+
+// InverseCT sets z to the E2-inverse of x, returns z
+//
+// Unlike Inverse, it inverts the norm of x with Element.InverseCT, in constant time.
+//
+// if x == 0, sets and returns z = x
+func (z *E2) InverseCT(x *E2) *E2 {
+	// 1/(a0 + a1u) = (a0 - a1u)/(a0² - αa1²)
+	var t Element
+	x.norm(&t)
+	t.InverseCT(&t)
+	z.A0.Mul(&x.A0, &t)
+	z.A1.Mul(&x.A1, &t).Neg(&z.A1)
+	return z
+}
+
+// norm sets n to N(x) = x * x^q = a0² - αa1²
+func (z *E2) norm(n *Element) {
+	var t Element
+	n.Square(&z.A0)
+	t.Square(&z.A1)
+	mulByNonResidueE2(&t, &t)
+	n.Sub(n, &t)
+}
@@ -0,0 +1,400 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package babybear
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	ggen "github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestE2ReceiverIsOperand(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := genE2()
+	genB := genE2()
+
+	properties.Property("[E2] Having the receiver as operand (mul) should output the same result", prop.ForAll(
+		func(a, b *E2) bool {
+			var c, d E2
+			d.Set(a)
+			c.Mul(a, b)
+			a.Mul(a, b)
+			b.Mul(&d, b)
+			return a.Equal(b) && a.Equal(&c) && b.Equal(&c)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("[E2] Having the receiver as operand (square) should output the same result", prop.ForAll(
+		func(a *E2) bool {
+			var b E2
+			b.Square(a)
+			a.Square(a)
+			return a.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.Property("[E2] Having the receiver as operand (inverse) should output the same result", prop.ForAll(
+		func(a *E2) bool {
+			var b E2
+			b.Inverse(a)
+			a.Inverse(a)
+			return a.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.Property("[E2] Having the receiver as operand (frobenius) should output the same result", prop.ForAll(
+		func(a *E2) bool {
+			var b E2
+			b.Frobenius(a)
+			a.Frobenius(a)
+			return a.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestE2Ops(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := genE2()
+	genB := genE2()
+	genE := gen()
+
+	// inverses and exponents are only defined for non-zero elements, which are likely to be generated
+	// for small moduli
+	genNonZero := genE2().SuchThat(func(a *E2) bool { return !a.IsZero() })
+
+	properties.Property("[E2] sub & add should leave an element invariant", prop.ForAll(
+		func(a, b *E2) bool {
+			var c E2
+			c.Set(a)
+			c.Add(&c, b).Sub(&c, b)
+			return c.Equal(a)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("[E2] mul should be distributive over add", prop.ForAll(
+		func(a, b *E2) bool {
+			var c, d, e E2
+			c.Add(a, b).Mul(&c, b)
+			d.Mul(a, b)
+			e.Mul(b, b)
+			d.Add(&d, &e)
+			return c.Equal(&d)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("[E2] mul & inverse should leave an element invariant", prop.ForAll(
+		func(a, b *E2) bool {
+			var c, d E2
+			d.Inverse(b)
+			c.Set(a)
+			c.Mul(&c, b).Mul(&c, &d)
+			return c.Equal(a)
+		},
+		genA,
+		genNonZero,
+	))
+
+	properties.Property("[E2] inverse twice should leave an element invariant", prop.ForAll(
+		func(a *E2) bool {
+			var b E2
+			b.Inverse(a).Inverse(&b)
+			return a.Equal(&b)
+		},
+		genNonZero,
+	))
+
+	properties.Property("[E2] square and mul should output the same result", prop.ForAll(
+		func(a *E2) bool {
+			var b, c E2
+			b.Mul(a, a)
+			c.Square(a)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[E2] MulByElement should be the same as Mul by an element of the base field", prop.ForAll(
+		func(a *E2, e testPairElement) bool {
+			var b, c E2
+			b.A0.Set(&e.element)
+			b.Mul(a, &b)
+			c.MulByElement(a, &e.element)
+			return b.Equal(&c)
+		},
+		genA,
+		genE,
+	))
+
+	properties.Property("[E2] Frobenius should be x^q", prop.ForAll(
+		func(a *E2) bool {
+			var b, c E2
+			b.Frobenius(a)
+			c.Exp(*a, Modulus())
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[E2] Frobenius applied 2 times should leave an element invariant", prop.ForAll(
+		func(a *E2) bool {
+			var b E2
+			b.Set(a)
+			for i := 0; i < 2; i++ {
+				b.Frobenius(&b)
+			}
+			return b.Equal(a)
+		},
+		genA,
+	))
+
+	properties.Property("[E2] Exp(x, q²-1) should be one", prop.ForAll(
+		func(a *E2) bool {
+			var b E2
+			k := new(big.Int).Exp(Modulus(), big.NewInt(2), nil)
+			k.Sub(k, big.NewInt(1))
+			b.Exp(*a, k)
+			return b.IsOne()
+		},
+		genNonZero,
+	))
+
+	properties.Property("[E2] Exp(x, -k) should be the inverse of Exp(x, k)", prop.ForAll(
+		func(a *E2, k uint64) bool {
+			var b, c E2
+			e := new(big.Int).SetUint64(k)
+			b.Exp(*a, e)
+			c.Exp(*a, e.Neg(e))
+			b.Mul(&b, &c)
+			return b.IsOne()
+		},
+		genNonZero,
+		ggen.UInt64(),
+	))
+
+	properties.Property("[E2] Div should be the same as mul by inverse", prop.ForAll(
+		func(a, b *E2) bool {
+			var c, d E2
+			c.Div(a, b)
+			d.Inverse(b).Mul(&d, a)
+			return c.Equal(&d)
+		},
+		genA,
+		genNonZero,
+	))
+
+	properties.Property("[E2] squares should be squares (legendre)", prop.ForAll(
+		func(a *E2) bool {
+			var b E2
+			b.Square(a)
+			return b.Legendre() == 1
+		},
+		genNonZero,
+	))
+
+	properties.Property("[E2] sqrt(x²) should be ±x", prop.ForAll(
+		func(a *E2) bool {
+			var b, c, d E2
+			b.Square(a)
+			if c.Sqrt(&b) == nil {
+				return false
+			}
+			d.Neg(&c)
+			return c.Equal(a) || d.Equal(a)
+		},
+		genA,
+	))
+
+	properties.Property("[E2] InverseCT should match Inverse", prop.ForAll(
+		func(a *E2) bool {
+			var b, c E2
+			b.InverseCT(a)
+			c.Inverse(a)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[E2] ExpCT should match Exp", prop.ForAll(
+		func(a *E2, k uint64) bool {
+			var b, c E2
+			e := new(big.Int).SetUint64(k)
+			b.ExpCT(*a, e)
+			c.Exp(*a, e)
+			if !b.Equal(&c) {
+				return false
+			}
+			b.ExpCT(*a, e.Neg(e))
+			c.Exp(*a, e)
+			return b.Equal(&c)
+		},
+		genA,
+		ggen.UInt64(),
+	))
+
+	properties.Property("[E2] SqrtCT should match Sqrt", prop.ForAll(
+		func(a *E2) bool {
+			var b, c, d E2
+			b.Set(a)
+			rc, rd := c.SqrtCT(&b), d.Sqrt(&b)
+			if rc == nil || rd == nil {
+				return rc == nil && rd == nil
+			}
+			var e E2
+			e.Neg(&d)
+			return c.Equal(&d) || c.Equal(&e)
+		},
+		genA,
+	))
+
+	properties.Property("[E2] sqrt of a non square should return nil", prop.ForAll(
+		func(a *E2) bool {
+			var b E2
+			if a.Legendre() != -1 {
+				return true
+			}
+			b.Set(a)
+			return b.Sqrt(a) == nil && b.Equal(a)
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestE2EdgeCases(t *testing.T) {
+	var zero, one, res E2
+	one.SetOne()
+
+	// u² == α
+	var u, expected E2
+	u.A1.SetOne()
+	res.Set(&u)
+	for i := 1; i < 2; i++ {
+		res.Mul(&res, &u)
+	}
+	expected.A0.SetInt64(-11)
+	if !res.Equal(&expected) {
+		t.Fatal("u² should be equal to the non-residue")
+	}
+
+	if res.Inverse(&zero); !res.IsZero() {
+		t.Fatal("inverse of 0 should be 0")
+	}
+	if res.Sqrt(&zero) == nil || !res.IsZero() {
+		t.Fatal("sqrt of 0 should be 0")
+	}
+	if res.InverseCT(&zero); !res.IsZero() {
+		t.Fatal("constant-time inverse of 0 should be 0")
+	}
+	if res.SqrtCT(&zero) == nil || !res.IsZero() {
+		t.Fatal("constant-time sqrt of 0 should be 0")
+	}
+	if res.ExpCT(one, big.NewInt(0)); !res.IsOne() {
+		t.Fatal("constant-time x^0 should be 1")
+	}
+	if zero.Legendre() != 0 {
+		t.Fatal("legendre of 0 should be 0")
+	}
+	if res.Exp(one, big.NewInt(42)); !res.IsOne() {
+		t.Fatal("1^42 should be 1")
+	}
+	if res.Exp(one, big.NewInt(0)); !res.IsOne() {
+		t.Fatal("x^0 should be 1")
+	}
+}
+
+func BenchmarkE2Mul(b *testing.B) {
+	var a, c E2
+	a.SetRandom()
+	c.SetRandom()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.Mul(&a, &c)
+	}
+}
+
+func BenchmarkE2Square(b *testing.B) {
+	var a E2
+	a.SetRandom()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.Square(&a)
+	}
+}
+
+func BenchmarkE2Inverse(b *testing.B) {
+	var a E2
+	a.SetRandom()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.Inverse(&a)
+	}
+}
+
+func BenchmarkE2Sqrt(b *testing.B) {
+	var a E2
+	a.SetRandom()
+	a.Square(&a)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.Sqrt(&a)
+	}
+}
+
+func genE2() gopter.Gen {
+	return gopter.CombineGens(
+		gen(),
+		gen(),
+	).Map(func(values []interface{}) *E2 {
+		return &E2{
+			A0: values[0].(testPairElement).element,
+			A1: values[1].(testPairElement).element,
+		}
+	})
+}
@@ -0,0 +1,522 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package babybear
+
+import (
+	"crypto/rand"
+	"io"
+	"math/big"
+)
+
+// E3 is a degree 3 extension of Element: Element[u]/(u³ - (-2))
+type E3 struct {
+	A0 Element
+	A1 Element
+	A2 Element
+}
+
+// e3NonResidue α such that u³ = α (montgomery form)
+var e3NonResidue = Element{
+	1476395013,
+}
+
+// e3FrobeniusCoefficients α^(i(q-1)/3) for i in [1, 2] (montgomery form)
+var e3FrobeniusCoefficients = [2]Element{
+	{
+		13829627,
+	},
+	{
+		1731000840,
+	},
+}
+
+var _bSqrtExponentE3 *big.Int
+
+func init() {
+	_bSqrtExponentE3, _ = new(big.Int).SetString("1a5e0000a8c000016", 16)
+}
+
+// Equal returns true if z equals x, false otherwise
+func (z *E3) Equal(x *E3) bool {
+	return z.A0.Equal(&x.A0) && z.A1.Equal(&x.A1) && z.A2.Equal(&x.A2)
+}
+
+// IsZero returns true if z is zero, false otherwise
+func (z *E3) IsZero() bool {
+	return z.A0.IsZero() && z.A1.IsZero() && z.A2.IsZero()
+}
+
+// IsOne returns true if z is one, false otherwise
+func (z *E3) IsOne() bool {
+	return z.A0.IsOne() && z.A1.IsZero() && z.A2.IsZero()
+}
+
+// SetZero sets z to 0 in Montgomery form and returns z
+func (z *E3) SetZero() *E3 {
+	z.A0.SetZero()
+	z.A1.SetZero()
+	z.A2.SetZero()
+	return z
+}
+
+// SetOne sets z to 1 in Montgomery form and returns z
+func (z *E3) SetOne() *E3 {
+	z.A0.SetOne()
+	z.A1.SetZero()
+	z.A2.SetZero()
+	return z
+}
+
+// Set sets z to x and returns z
+func (z *E3) Set(x *E3) *E3 {
+	z.A0 = x.A0
+	z.A1 = x.A1
+	z.A2 = x.A2
+	return z
+}
+
+// SetRandom sets z to a uniform random value
+func (z *E3) SetRandom() (*E3, error) {
+	return z.SetRandomFrom(rand.Reader)
+}
+
+// SetRandomFrom sets z to a uniform random value, reading the randomness from r
+//
+// Each coordinate is sampled uniformly with Element.SetRandomFrom, in order.
+func (z *E3) SetRandomFrom(r io.Reader) (*E3, error) {
+	if _, err := z.A0.SetRandomFrom(r); err != nil {
+		return nil, err
+	}
+	if _, err := z.A1.SetRandomFrom(r); err != nil {
+		return nil, err
+	}
+	if _, err := z.A2.SetRandomFrom(r); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// Add sets z=x+y and returns z
+func (z *E3) Add(x, y *E3) *E3 {
+	z.A0.Add(&x.A0, &y.A0)
+	z.A1.Add(&x.A1, &y.A1)
+	z.A2.Add(&x.A2, &y.A2)
+	return z
+}
+
+// Sub sets z=x-y and returns z
+func (z *E3) Sub(x, y *E3) *E3 {
+	z.A0.Sub(&x.A0, &y.A0)
+	z.A1.Sub(&x.A1, &y.A1)
+	z.A2.Sub(&x.A2, &y.A2)
+	return z
+}
+
+// Double sets z=2x and returns z
+func (z *E3) Double(x *E3) *E3 {
+	z.A0.Double(&x.A0)
+	z.A1.Double(&x.A1)
+	z.A2.Double(&x.A2)
+	return z
+}
+
+// Neg sets z=-x and returns z
+func (z *E3) Neg(x *E3) *E3 {
+	z.A0.Neg(&x.A0)
+	z.A1.Neg(&x.A1)
+	z.A2.Neg(&x.A2)
+	return z
+}
+
+// MulByElement sets z=x*y where y is in the base field and returns z
+func (z *E3) MulByElement(x *E3, y *Element) *E3 {
+	var yCopy Element
+	yCopy.Set(y)
+	z.A0.Mul(&x.A0, &yCopy)
+	z.A1.Mul(&x.A1, &yCopy)
+	z.A2.Mul(&x.A2, &yCopy)
+	return z
+}
+
+// Frobenius sets z=x^q and returns z
+func (z *E3) Frobenius(x *E3) *E3 {
+	// (Σ aᵢuⁱ)^q = Σ aᵢ(uⁱ)^q = Σ aᵢα^(i(q-1)/3)uⁱ
+	z.A0.Set(&x.A0)
+	z.A1.Mul(&x.A1, &e3FrobeniusCoefficients[0])
+	z.A2.Mul(&x.A2, &e3FrobeniusCoefficients[1])
+	return z
+}
+
+// Div sets z=x/y and returns z
+func (z *E3) Div(x, y *E3) *E3 {
+	var r E3
+	r.Inverse(y).Mul(x, &r)
+	return z.Set(&r)
+}
+
+// Exp sets z=xᵏ (mod q³) and returns it
+func (z *E3) Exp(x E3, k *big.Int) *E3 {
+	if k.IsUint64() && k.Uint64() == 0 {
+		return z.SetOne()
+	}
+
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ (mod q³) == (x⁻¹)ᵏ (mod q³)
+		x.Inverse(&x)
+
+		// we negate k in a temp big.Int since
+		// Int.Bit(_) of k and -k is different
+		e = bigIntPool.Get().(*big.Int)
+		defer bigIntPool.Put(e)
+		e.Neg(k)
+	}
+
+	z.Set(&x)
+
+	for i := e.BitLen() - 2; i >= 0; i-- {
+		z.Square(z)
+		if e.Bit(i) == 1 {
+			z.Mul(z, &x)
+		}
+	}
+
+	return z
+}
+
+// Select is a constant-time conditional move.
+// If c=0, z = x0. Else z = x1
+func (z *E3) Select(c int, x0, x1 *E3) *E3 {
+	z.A0.Select(c, &x0.A0, &x1.A0)
+	z.A1.Select(c, &x0.A1, &x1.A1)
+	z.A2.Select(c, &x0.A2, &x1.A2)
+	return z
+}
+
+// ExpCT sets z=xᵏ (mod q³) and returns it
+//
+// Unlike Exp, ExpCT uses a Montgomery ladder over max(3*Bits, k.BitLen()) bits with
+// constant-time selections, so that its sequence of operations doesn't depend on x and k (only the sign
+// of k and the bit length of exponents larger than 3*Bits are leaked).
+// It is as constant-time as the Element arithmetic it relies on.
+func (z *E3) ExpCT(x E3, k *big.Int) *E3 {
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ (mod q³) == (x⁻¹)ᵏ (mod q³)
+		x.InverseCT(&x)
+
+		// we negate k in a temp big.Int since
+		// Int.Bit(_) of k and -k is different
+		e = bigIntPool.Get().(*big.Int)
+		defer bigIntPool.Put(e)
+		e.Neg(k)
+	}
+
+	nbBits := 3 * Bits
+	if e.BitLen() > nbBits {
+		nbBits = e.BitLen()
+	}
+
+	// invariant: r1 = r0 * x
+	var r0, r1, a, b E3
+	r0.SetOne()
+	r1.Set(&x)
+	for i := nbBits - 1; i >= 0; i-- {
+		bit := int(e.Bit(i))
+		// (a, b) = (r0, r1) if bit == 0, (r1, r0) otherwise
+		a.Select(bit, &r0, &r1)
+		b.Select(bit, &r1, &r0)
+		b.Mul(&a, &b)
+		a.Square(&a)
+		r0.Select(bit, &a, &b)
+		r1.Select(bit, &b, &a)
+	}
+
+	return z.Set(&r0)
+}
+
+// Legendre returns the Legendre symbol of z (either +1, -1, or 0.)
+func (z *E3) Legendre() int {
+	// z is a square in the extension iff its norm is a square in Element
+	var n Element
+	z.norm(&n)
+	return n.Legendre()
+}
+
+// Sqrt sets z to the square root of x and returns z
+// if the square root doesn't exist (x is not a square)
+// Sqrt leaves z unchanged and returns nil
+func (z *E3) Sqrt(x *E3) *E3 {
+	// q³ - 1 = 2ᵉ * s, s odd
+	// see modSqrtTonelliShanks in math/big/int.go
+	var y, b, t, w E3
+	// w = x^((s-1)/2))
+	w.Exp(*x, _bSqrtExponentE3)
+
+	// y = x^((s+1)/2)) = w * x
+	y.Mul(x, &w)
+
+	// b = x^s = w * w * x = y * x
+	b.Mul(&w, &y)
+
+	// g = nonResidue ^ s
+	g := E3{
+		A0: Element{
+			565804691,
+		},
+		A1: Element{
+			0,
+		},
+		A2: Element{
+			0,
+		},
+	}
+	r := uint64(27)
+
+	// compute legendre symbol
+	// t = x^((q³-1)/2) = r-1 squaring of x^s
+	t = b
+	for i := uint64(0); i < r-1; i++ {
+		t.Square(&t)
+	}
+	if t.IsZero() {
+		return z.SetZero()
+	}
+	if !t.IsOne() {
+		// t != 1, we don't have a square root
+		return nil
+	}
+	for {
+		var m uint64
+		t = b
+
+		// for t != 1
+		for !t.IsOne() {
+			t.Square(&t)
+			m++
+		}
+
+		if m == 0 {
+			return z.Set(&y)
+		}
+		// t = g^(2^(r-m-1))
+		ge := int(r - m - 1)
+		t = g
+		for ge > 0 {
+			t.Square(&t)
+			ge--
+		}
+
+		g.Square(&t)
+		y.Mul(&y, &t)
+		b.Mul(&b, &g)
+		r = m
+	}
+}
+
+// SqrtCT sets z to the square root of x and returns z
+// if the square root doesn't exist (x is not a square)
+// SqrtCT leaves z unchanged and returns nil
+//
+// Unlike Sqrt, SqrtCT uses the constant-time variant of Tonelli-Shanks described in
+// RFC 9380 (Appendix I.4); only whether x is a square or not is leaked.
+func (z *E3) SqrtCT(x *E3) *E3 {
+	// q³ - 1 = 2ᵉ * s, s odd
+	var y, b, t, tmp E3
+
+	// y = x^((s-1)/2)
+	y.ExpCT(*x, _bSqrtExponentE3)
+
+	// t = x^s, y = x^((s+1)/2)
+	t.Square(&y).Mul(&t, x)
+	y.Mul(&y, x)
+
+	// c = g^s, g a non-residue
+	c := E3{
+		A0: Element{
+			565804691,
+		},
+		A1: Element{
+			0,
+		},
+		A2: Element{
+			0,
+		},
+	}
+	b = t
+
+	for i := 27; i >= 2; i-- {
+		for j := 1; j <= i-2; j++ {
+			b.Square(&b)
+		}
+		// if b ≠ 1, y = y * c and t = t * c²
+		notOne := b.notOneCT()
+		tmp.Mul(&y, &c)
+		y.Select(notOne, &y, &tmp)
+		c.Square(&c)
+		tmp.Mul(&t, &c)
+		t.Select(notOne, &t, &tmp)
+		b = t
+	}
+
+	// as we didn't compute the legendre symbol, ensure we found y such that y * y = x
+	tmp.Square(&y)
+	if !tmp.Equal(x) {
+		return nil
+	}
+	return z.Set(&y)
+}
+
+// notOneCT returns 0 if and only if z == 1, without branching
+func (z *E3) notOneCT() int {
+	var one E3
+	one.SetOne()
+	v := z.A0.NotEqual(&one.A0) | z.A1.NotEqual(&one.A1) | z.A2.NotEqual(&one.A2)
+	return int((v | -v) >> 63)
+}
+
+// mulByNonResidueE3 sets z=α*x and returns z
+func mulByNonResidueE3(z, x *Element) *Element {
+	return z.Mul(x, &e3NonResidue)
+}
+
+// String puts z in string form
+func (z *E3) String() string {
+	return z.A0.String() + "+(" + z.A1.String() + ")*u+(" + z.A2.String() + ")*u²"
+}
+
+// Mul sets z to the E3-product of x,y, returns z
+func (z *E3) Mul(x, y *E3) *E3 {
+	// Algorithm 13 from https://eprint.iacr.org/2010/354.pdf
+	var t0, t1, t2, c0, c1, c2, tmp Element
+	t0.Mul(&x.A0, &y.A0)
+	t1.Mul(&x.A1, &y.A1)
+	t2.Mul(&x.A2, &y.A2)
+
+	c0.Add(&x.A1, &x.A2)
+	tmp.Add(&y.A1, &y.A2)
+	c0.Mul(&c0, &tmp).Sub(&c0, &t1).Sub(&c0, &t2)
+	mulByNonResidueE3(&c0, &c0)
+	c0.Add(&c0, &t0)
+
+	c1.Add(&x.A0, &x.A1)
+	tmp.Add(&y.A0, &y.A1)
+	c1.Mul(&c1, &tmp).Sub(&c1, &t0).Sub(&c1, &t1)
+	mulByNonResidueE3(&tmp, &t2)
+	c1.Add(&c1, &tmp)
+
+	c2.Add(&x.A0, &x.A2)
+	tmp.Add(&y.A0, &y.A2)
+	c2.Mul(&c2, &tmp).Sub(&c2, &t0).Sub(&c2, &t2).Add(&c2, &t1)
+
+	z.A0.Set(&c0)
+	z.A1.Set(&c1)
+	z.A2.Set(&c2)
+	return z
+}
+
+// Square sets z to the E3-product of x,x, returns z
+func (z *E3) Square(x *E3) *E3 {
+	// (a0 + a1u + a2u²)² = a0² + 2αa1a2 + (2a0a1 + αa2²)u + (a1² + 2a0a2)u²
+	var c0, c1, c2, tmp Element
+	c0.Mul(&x.A1, &x.A2).Double(&c0)
+	mulByNonResidueE3(&c0, &c0)
+	tmp.Square(&x.A0)
+	c0.Add(&c0, &tmp)
+
+	c1.Square(&x.A2)
+	mulByNonResidueE3(&c1, &c1)
+	tmp.Mul(&x.A0, &x.A1).Double(&tmp)
+	c1.Add(&c1, &tmp)
+
+	c2.Mul(&x.A0, &x.A2).Double(&c2)
+	tmp.Square(&x.A1)
+	c2.Add(&c2, &tmp)
+
+	z.A0.Set(&c0)
+	z.A1.Set(&c1)
+	z.A2.Set(&c2)
+	return z
+}
+
+// Inverse sets z to the E3-inverse of x, returns z
+//
+// if x == 0, sets and returns z = x
+func (z *E3) Inverse(x *E3) *E3 {
+	// Algorithm 17 from https://eprint.iacr.org/2010/354.pdf
+	var c0, c1, c2, t Element
+	x.cofactors(&c0, &c1, &c2, &t)
+	t.Inverse(&t)
+	z.A0.Mul(&c0, &t)
+	z.A1.Mul(&c1, &t)
+	z.A2.Mul(&c2, &t)
+	return z
+}
+
+// InverseCT sets z to the E3-inverse of x, returns z
+//
+// Unlike Inverse, it inverts the norm of x with Element.InverseCT, in constant time.
+//
+// if x == 0, sets and returns z = x
+func (z *E3) InverseCT(x *E3) *E3 {
+	// Algorithm 17 from https://eprint.iacr.org/2010/354.pdf
+	var c0, c1, c2, t Element
+	x.cofactors(&c0, &c1, &c2, &t)
+	t.InverseCT(&t)
+	z.A0.Mul(&c0, &t)
+	z.A1.Mul(&c1, &t)
+	z.A2.Mul(&c2, &t)
+	return z
+}
+
+// norm sets n to N(x) = x * x^q * x^(q²)
+func (z *E3) norm(n *Element) {
+	var c0, c1, c2 Element
+	z.cofactors(&c0, &c1, &c2, n)
+}
+
+// cofactors sets (c0 + c1u + c2u²) = x^q * x^(q²) and n = N(x)
+func (z *E3) cofactors(c0, c1, c2, n *Element) {
+	var tmp Element
+
+	// c0 = a0² - αa1a2
+	c0.Square(&z.A0)
+	tmp.Mul(&z.A1, &z.A2)
+	mulByNonResidueE3(&tmp, &tmp)
+	c0.Sub(c0, &tmp)
+
+	// c1 = αa2² - a0a1
+	c1.Square(&z.A2)
+	mulByNonResidueE3(c1, c1)
+	tmp.Mul(&z.A0, &z.A1)
+	c1.Sub(c1, &tmp)
+
+	// c2 = a1² - a0a2
+	c2.Square(&z.A1)
+	tmp.Mul(&z.A0, &z.A2)
+	c2.Sub(c2, &tmp)
+
+	// n = a0c0 + α(a2c1 + a1c2)
+	n.Mul(&z.A2, c1)
+	tmp.Mul(&z.A1, c2)
+	n.Add(n, &tmp)
+	mulByNonResidueE3(n, n)
+	tmp.Mul(&z.A0, c0)
+	n.Add(n, &tmp)
+}
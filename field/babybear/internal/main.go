@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/internal/field"
+	"github.com/consensys/gnark-crypto/internal/field/generator"
+)
+
+//go:generate go run main.go
+func main() {
+	const modulus = "0x78000001" // 15 * 2²⁷ + 1
+	babybear, err := field.NewFieldConfig("babybear", "Element", modulus, false)
+	if err != nil {
+		panic(err)
+	}
+	if err := generator.GenerateFF(babybear, "../"); err != nil {
+		panic(err)
+	}
+
+	// quadratic and cubic extensions, with the smallest suitable non-residues
+	for _, degree := range []int{2, 3} {
+		E, err := field.NewExtensionConfig(babybear, degree, nil)
+		if err != nil {
+			panic(err)
+		}
+		if err := generator.GenerateExtension(E, "../"); err != nil {
+			panic(err)
+		}
+	}
+	fmt.Println("successfully generated babybear field")
+}
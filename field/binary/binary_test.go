@@ -0,0 +1,247 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binary
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+const nbTests = 1000
+
+func TestMulTower(t *testing.T) {
+	rng := rand.New(rand.NewSource(0)) //#nosec G404 -- test
+	for i := 0; i < nbTests; i++ {
+		x, y := rng.Uint64(), rng.Uint64()
+		if got, want := uint64(mul8(E8(x), E8(y))), mulTower(x, y, 3); got != want {
+			t.Fatalf("E8: %x * %x = %x, want %x", uint8(x), uint8(y), got, want)
+		}
+		if got, want := uint64(mul16(E16(x), E16(y))), mulTower(x, y, 4); got != want {
+			t.Fatalf("E16: %x * %x = %x, want %x", uint16(x), uint16(y), got, want)
+		}
+		if got, want := uint64(mul32(E32(x), E32(y))), mulTower(x, y, 5); got != want {
+			t.Fatalf("E32: %x * %x = %x, want %x", uint32(x), uint32(y), got, want)
+		}
+		if got, want := uint64(mul64(E64(x), E64(y))), mulTower(x, y, 6); got != want {
+			t.Fatalf("E64: %x * %x = %x, want %x", x, y, got, want)
+		}
+		if got, want := uint64(mulX64(E64(x))), mulXTower(x, 6); got != want {
+			t.Fatalf("E64: %x * X₆ = %x, want %x", x, got, want)
+		}
+	}
+}
+
+func TestE128Mul(t *testing.T) {
+	rng := rand.New(rand.NewSource(0)) //#nosec G404 -- test
+	for i := 0; i < nbTests; i++ {
+		xt := [2]uint64{rng.Uint64(), rng.Uint64()}
+		yt := [2]uint64{rng.Uint64(), rng.Uint64()}
+		var x, y, got, want E128
+		x.setTower(xt)
+		y.setTower(yt)
+		got.Mul(&x, &y)
+		want.setTower(mul128Tower(xt, yt))
+		if got != want {
+			t.Fatalf("%s * %s = %s, want %s", x.String(), y.String(), got.String(), want.String())
+		}
+		if x.tower() != xt {
+			t.Fatal("the change of basis is not invertible")
+		}
+	}
+
+	// the subfields are the low bits
+	x, y := E64(0x0123456789abcdef), E64(0xfedcba9876543210)
+	var a, b, c E128
+	a.SetE64(x)
+	b.SetE64(y)
+	c.Mul(&a, &b)
+	if c.tower() != [2]uint64{uint64(mul64(x, y)), 0} {
+		t.Fatal("E64 is not embedded in E128")
+	}
+}
+
+// mul128Tower returns x * y in the tower basis, with Karatsuba on the tower
+func mul128Tower(x, y [2]uint64) [2]uint64 {
+	x0, x1 := E64(x[0]), E64(x[1])
+	y0, y1 := E64(y[0]), E64(y[1])
+	m0 := mul64(x0, y0)
+	m2 := mul64(x1, y1)
+	m1 := mul64(x0^x1, y0^y1)
+	return [2]uint64{uint64(m0 ^ m2), uint64(m1 ^ m0 ^ m2 ^ mulX64(m2))}
+}
+
+func TestCLMUL(t *testing.T) {
+	rng := rand.New(rand.NewSource(0)) //#nosec G404 -- test
+	for i := 0; i < nbTests; i++ {
+		x := [2]uint64{rng.Uint64(), rng.Uint64()}
+		y := [2]uint64{rng.Uint64(), rng.Uint64()}
+		var got, gotGeneric, want [4]uint64
+		clmul128(&got, &x, &y)
+		clmul128Generic(&gotGeneric, &x, &y)
+
+		// schoolbook, bit by bit
+		for j := 0; j < 128; j++ {
+			if x[j/64]>>(j%64)&1 == 0 {
+				continue
+			}
+			for k := 0; k < 128; k++ {
+				want[(j+k)/64] ^= (y[k/64] >> (k % 64) & 1) << ((j + k) % 64)
+			}
+		}
+		if got != want || gotGeneric != want {
+			t.Fatalf("clmul(%x, %x) = %x (generic: %x), want %x", x, y, got, gotGeneric, want)
+		}
+	}
+}
+
+func TestInverse(t *testing.T) {
+	rng := rand.New(rand.NewSource(0)) //#nosec G404 -- test
+	for i := 0; i < nbTests; i++ {
+		x8 := E8(rng.Uint64())
+		x16 := E16(rng.Uint64())
+		x32 := E32(rng.Uint64())
+		x64 := E64(rng.Uint64())
+		x128 := E128{rng.Uint64(), rng.Uint64()}
+
+		var y8 E8
+		if y8.Inverse(&x8).Mul(&y8, &x8); !x8.IsZero() && !y8.IsOne() {
+			t.Fatalf("E8: %s * %s⁻¹ != 1", x8.String(), x8.String())
+		}
+		var y16 E16
+		if y16.Inverse(&x16).Mul(&y16, &x16); !x16.IsZero() && !y16.IsOne() {
+			t.Fatalf("E16: %s * %s⁻¹ != 1", x16.String(), x16.String())
+		}
+		var y32 E32
+		if y32.Inverse(&x32).Mul(&y32, &x32); !x32.IsZero() && !y32.IsOne() {
+			t.Fatalf("E32: %s * %s⁻¹ != 1", x32.String(), x32.String())
+		}
+		var y64 E64
+		if y64.Inverse(&x64).Mul(&y64, &x64); !x64.IsZero() && !y64.IsOne() {
+			t.Fatalf("E64: %s * %s⁻¹ != 1", x64.String(), x64.String())
+		}
+		var y128 E128
+		if y128.Inverse(&x128).Mul(&y128, &x128); !x128.IsZero() && !y128.IsOne() {
+			t.Fatalf("E128: %s * %s⁻¹ != 1", x128.String(), x128.String())
+		}
+	}
+
+	var zero E128
+	if zero.Inverse(&zero); !zero.IsZero() {
+		t.Fatal("0⁻¹ != 0")
+	}
+}
+
+func TestExp(t *testing.T) {
+	// the multiplicative group of E128 has order 2¹²⁸ - 1
+	order := new(big.Int).Lsh(big.NewInt(1), 128)
+	order.Sub(order, big.NewInt(1))
+
+	var x, y E128
+	if _, err := x.SetRandom(); err != nil {
+		t.Fatal(err)
+	}
+	if x.IsZero() {
+		x.SetOne()
+	}
+	if y.Exp(x, order); !y.IsOne() {
+		t.Fatal("x^(2¹²⁸ - 1) != 1")
+	}
+
+	// x⁻¹ = x⁻¹ and x^(2¹²⁸) = x
+	var xInv E128
+	xInv.Inverse(&x)
+	if y.Exp(x, big.NewInt(-1)); y != xInv {
+		t.Fatal("x^(-1) != x⁻¹")
+	}
+	order.Add(order, big.NewInt(1))
+	if y.Exp(x, order); y != x {
+		t.Fatal("x^(2¹²⁸) != x")
+	}
+
+	// X₇ is a root of X₇² + X₆X₇ + 1
+	var x7, x6, s, p E128
+	x7.setTower([2]uint64{0, 1})
+	x6.SetE64(1 << 32)
+	s.Square(&x7)
+	p.Mul(&x6, &x7)
+	s.Add(&s, &p)
+	if !s.IsOne() {
+		t.Fatal("X₇² + X₆X₇ != 1")
+	}
+}
+
+func TestBytes(t *testing.T) {
+	var x E128
+	if _, err := x.SetRandom(); err != nil {
+		t.Fatal(err)
+	}
+	b := x.Bytes()
+	var y E128
+	if y.SetBytes(b[:]); y != x {
+		t.Fatal("SetBytes(Bytes(x)) != x")
+	}
+	if !bytes.Equal(x.Marshal(), b[:]) {
+		t.Fatal("Marshal(x) != Bytes(x)")
+	}
+
+	// big-endian, the subfields in the last bytes
+	x.setTower([2]uint64{0x0123456789abcdef, 0x1122334455667788})
+	if x.String() != "0x11223344556677880123456789abcdef" {
+		t.Fatal("unexpected encoding", x.String())
+	}
+	e := E64(0x0123456789abcdef)
+	eb := e.Bytes()
+	x.SetE64(e)
+	if !bytes.Equal(x.Marshal(), append(make([]byte, 8), eb[:]...)) {
+		t.Fatal("the encoding of E64 is not the end of the encoding of E128")
+	}
+	var z E16
+	if z.SetBytes([]byte{0xff, 0x12, 0x34}); z != 0x1234 {
+		t.Fatal("SetBytes should ignore the first bytes")
+	}
+}
+
+func BenchmarkE128Mul(b *testing.B) {
+	x := E128{0x0123456789abcdef, 0x1122334455667788}
+	y := E128{0xfedcba9876543210, 0x8877665544332211}
+	b.Run("clmul", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.Mul(&x, &y)
+		}
+	})
+	b.Run("clmulGeneric", func(b *testing.B) {
+		var t [4]uint64
+		for i := 0; i < b.N; i++ {
+			clmul128Generic(&t, (*[2]uint64)(&x), (*[2]uint64)(&y))
+			x = reducePoly(&t)
+		}
+	})
+}
+
+func BenchmarkE128Inverse(b *testing.B) {
+	x := E128{0x0123456789abcdef, 0x1122334455667788}
+	for i := 0; i < b.N; i++ {
+		x.Inverse(&x)
+	}
+}
+
+func BenchmarkE64Mul(b *testing.B) {
+	x, y := E64(0x0123456789abcdef), E64(0xfedcba9876543210)
+	for i := 0; i < b.N; i++ {
+		x.Mul(&x, &y)
+	}
+}
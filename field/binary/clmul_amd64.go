@@ -0,0 +1,31 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binary
+
+import "golang.org/x/sys/cpu"
+
+var supportPCLMULQDQ = cpu.X86.HasPCLMULQDQ
+
+// clmul128 sets z = x * y, the carry-less product of x and y on 256 bits
+func clmul128(z *[4]uint64, x, y *[2]uint64) {
+	if !supportPCLMULQDQ {
+		clmul128Generic(z, x, y)
+		return
+	}
+	clmul128PCLMULQDQ(z, x, y)
+}
+
+//go:noescape
+func clmul128PCLMULQDQ(z *[4]uint64, x, y *[2]uint64)
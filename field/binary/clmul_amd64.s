@@ -0,0 +1,47 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+#include "textflag.h"
+
+// clmul128PCLMULQDQ(z *[4]uint64, x, y *[2]uint64)
+// z = x * y with 4 carry-less multiplications of 64-bit words
+TEXT ·clmul128PCLMULQDQ(SB), NOSPLIT, $0-24
+	MOVQ  x+8(FP), AX
+	MOVQ  y+16(FP), BX
+	MOVOU (AX), X0
+	MOVOU (BX), X1
+
+	// X2 = x₀y₀, X3 = x₁y₁
+	MOVOA     X0, X2
+	PCLMULQDQ $0x00, X1, X2
+	MOVOA     X0, X3
+	PCLMULQDQ $0x11, X1, X3
+
+	// X4 = x₀y₁ + x₁y₀
+	MOVOA     X0, X4
+	PCLMULQDQ $0x10, X1, X4
+	PCLMULQDQ $0x01, X1, X0
+	PXOR      X0, X4
+
+	// z = X2 + X4 * 2⁶⁴ + X3 * 2¹²⁸
+	MOVOA  X4, X5
+	PSLLDQ $8, X5
+	PSRLDQ $8, X4
+	PXOR   X5, X2
+	PXOR   X4, X3
+
+	MOVQ  z+0(FP), AX
+	MOVOU X2, (AX)
+	MOVOU X3, 16(AX)
+	RET
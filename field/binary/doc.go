@@ -0,0 +1,40 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package binary provides arithmetic in the binary fields GF(2⁸), GF(2¹⁶), GF(2³²), GF(2⁶⁴)
+// and GF(2¹²⁸), built as a tower of quadratic extensions (Wiedemann's tower):
+//
+//	T₀ = GF(2)
+//	Tᵢ₊₁ = Tᵢ[Xᵢ₊₁] / (Xᵢ₊₁² + XᵢXᵢ₊₁ + 1), with X₀ = 1
+//
+// An element a₀ + a₁Xᵢ₊₁ of Tᵢ₊₁ is stored on 2ⁱ⁺¹ bits, a₀ in the low half and a₁ in the high half;
+// E8, E16, E32, E64 and E128 are T₃, ..., T₇. In this basis, the subfields are the low bits:
+// an E8 x is the E16(x), an E64 x is the E128 whose Bytes are 8 zeros then the Bytes of x (see E128.SetE64).
+//
+// Addition is a XOR. E8 multiplications use log / exp tables, E16 to E64 multiplications use
+// Karatsuba on the tower. E128 elements are stored in the polynomial basis GF(2)[x] / (x¹²⁸ + x⁷ + x² + x + 1),
+// as the prime field elements are stored in Montgomery form: their multiplication is a carry-less
+// multiplication (PCLMULQDQ on amd64, a portable implementation otherwise), and Bytes, SetBytes
+// and SetE64 convert from and to the tower basis.
+//
+// Bytes and SetBytes follow the conventions of the prime field elements: the big-endian
+// encoding on a fixed number of bytes (here, of the bits of the element).
+//
+// See field/binary/fft for the additive FFT over E128.
+//
+// Warning
+//
+// This code has not been audited and is provided as-is. In particular, there is no security guarantees
+// such as constant time implementation or side-channel attack resistance.
+package binary
@@ -0,0 +1,182 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binary
+
+import (
+	"encoding/binary"
+	"io"
+	"math/big"
+)
+
+// E128 is an element of GF(2¹²⁸) = T₇ = E64[X₇] / (X₇² + X₆X₇ + 1)
+//
+// As the prime field elements are stored in Montgomery form, E128 is stored in the polynomial basis
+// of GF(2¹²⁸) = GF(2)[x] / (x¹²⁸ + x⁷ + x² + x + 1), where the multiplication is a carry-less
+// multiplication followed by a reduction (see poly.go); Bytes, SetBytes and SetE64 convert from and to
+// the tower basis x₀ + x₁X₇, with x₀ and x₁ in E64.
+type E128 [2]uint64
+
+// SetZero sets z = 0 and returns z
+func (z *E128) SetZero() *E128 {
+	*z = E128{}
+	return z
+}
+
+// SetOne sets z = 1 and returns z
+func (z *E128) SetOne() *E128 {
+	*z = E128{1, 0}
+	return z
+}
+
+// Set sets z = x and returns z
+func (z *E128) Set(x *E128) *E128 {
+	*z = *x
+	return z
+}
+
+// SetE64 sets z = x, embedding E64 in E128, and returns z
+func (z *E128) SetE64(x E64) *E128 {
+	return z.setTower([2]uint64{uint64(x), 0})
+}
+
+// SetRandom sets z to a uniform random value and returns z
+func (z *E128) SetRandom() (*E128, error) {
+	return z.SetRandomFrom(nil)
+}
+
+// SetRandomFrom sets z to a uniform random value read from r (crypto/rand if r is nil) and returns z
+func (z *E128) SetRandomFrom(r io.Reader) (*E128, error) {
+	var b [E128Bytes]byte
+	if err := randomBytes(r, b[:]); err != nil {
+		return nil, err
+	}
+	return z.SetBytes(b[:]), nil
+}
+
+// Add sets z = x + y and returns z
+func (z *E128) Add(x, y *E128) *E128 {
+	z[0] = x[0] ^ y[0]
+	z[1] = x[1] ^ y[1]
+	return z
+}
+
+// Sub sets z = x - y (= x + y) and returns z
+func (z *E128) Sub(x, y *E128) *E128 {
+	z[0] = x[0] ^ y[0]
+	z[1] = x[1] ^ y[1]
+	return z
+}
+
+// Neg sets z = -x (= x) and returns z
+func (z *E128) Neg(x *E128) *E128 {
+	*z = *x
+	return z
+}
+
+// Mul sets z = x * y and returns z
+func (z *E128) Mul(x, y *E128) *E128 {
+	mul128(z, x, y)
+	return z
+}
+
+// Square sets z = x * x and returns z
+func (z *E128) Square(x *E128) *E128 {
+	mul128(z, x, x)
+	return z
+}
+
+// Inverse sets z = x⁻¹ and returns z; if x == 0, sets z = 0
+func (z *E128) Inverse(x *E128) *E128 {
+	// in the tower basis, (x₀ + x₁X₇)(x₀ + x₁X₆ + x₁X₇) = x₀² + x₀x₁X₆ + x₁², in E64
+	t := x.tower()
+	x0, x1 := E64(t[0]), E64(t[1])
+	y0 := x0 ^ mulX64(x1)
+	nInv := inv64(mul64(x0, y0) ^ mul64(x1, x1))
+	return z.setTower([2]uint64{uint64(mul64(y0, nInv)), uint64(mul64(x1, nInv))})
+}
+
+// Div sets z = x / y and returns z; if y == 0, sets z = 0
+func (z *E128) Div(x, y *E128) *E128 {
+	var yInv E128
+	yInv.Inverse(y)
+	return z.Mul(x, &yInv)
+}
+
+// Exp sets z = xᵏ and returns z
+func (z *E128) Exp(x E128, k *big.Int) *E128 {
+	return exp(z, x, k)
+}
+
+// Equal returns z == x
+func (z *E128) Equal(x *E128) bool {
+	return *z == *x
+}
+
+// IsZero returns z == 0
+func (z *E128) IsZero() bool {
+	return (z[0] | z[1]) == 0
+}
+
+// IsOne returns z == 1
+func (z *E128) IsOne() bool {
+	return z[0] == 1 && z[1] == 0
+}
+
+// Bytes returns the value of z in the tower basis as a big-endian byte array: x₁ then x₀
+func (z *E128) Bytes() (res [E128Bytes]byte) {
+	t := z.tower()
+	binary.BigEndian.PutUint64(res[:8], t[1])
+	binary.BigEndian.PutUint64(res[8:], t[0])
+	return
+}
+
+// Marshal returns the value of z as a big-endian byte slice
+func (z *E128) Marshal() []byte {
+	b := z.Bytes()
+	return b[:]
+}
+
+// SetBytes interprets e as the big-endian encoding of z (see Bytes), sets z to that value and
+// returns z; if e is longer than E128Bytes, its first bytes are ignored
+func (z *E128) SetBytes(e []byte) *E128 {
+	var b [E128Bytes]byte
+	copyLastBytes(b[:], e)
+	return z.setTower([2]uint64{binary.BigEndian.Uint64(b[8:]), binary.BigEndian.Uint64(b[:8])})
+}
+
+// String returns the hexadecimal representation of z, prefixed by 0x
+func (z *E128) String() string {
+	b := z.Bytes()
+	return hexString(b[:])
+}
+
+// setTower sets z to the element of coordinates t in the tower basis, x₀ = t[0] and x₁ = t[1],
+// and returns z
+func (z *E128) setTower(t [2]uint64) *E128 {
+	*z = applyByteTables(&toPolyTable, &t)
+	return z
+}
+
+// tower returns the coordinates of z in the tower basis, x₀ = t[0] and x₁ = t[1]
+func (z *E128) tower() [2]uint64 {
+	return applyByteTables(&fromPolyTable, (*[2]uint64)(z))
+}
+
+// mul128 sets z = x * y
+func mul128(z, x, y *E128) {
+	var t [4]uint64
+	clmul128(&t, (*[2]uint64)(x), (*[2]uint64)(y))
+	*z = reducePoly(&t)
+}
@@ -0,0 +1,174 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binary
+
+import (
+	"encoding/binary"
+	"io"
+	"math/big"
+)
+
+// E16 is an element of GF(2¹⁶) = T₄ = E8[X₄] / (X₄² + X₃X₄ + 1), stored as x₀ + x₁X₄
+// with x₀ the low 8 bits and x₁ the high 8 bits
+type E16 uint16
+
+// SetZero sets z = 0 and returns z
+func (z *E16) SetZero() *E16 {
+	*z = 0
+	return z
+}
+
+// SetOne sets z = 1 and returns z
+func (z *E16) SetOne() *E16 {
+	*z = 1
+	return z
+}
+
+// Set sets z = x and returns z
+func (z *E16) Set(x *E16) *E16 {
+	*z = *x
+	return z
+}
+
+// SetRandom sets z to a uniform random value and returns z
+func (z *E16) SetRandom() (*E16, error) {
+	return z.SetRandomFrom(nil)
+}
+
+// SetRandomFrom sets z to a uniform random value read from r (crypto/rand if r is nil) and returns z
+func (z *E16) SetRandomFrom(r io.Reader) (*E16, error) {
+	var b [E16Bytes]byte
+	if err := randomBytes(r, b[:]); err != nil {
+		return nil, err
+	}
+	return z.SetBytes(b[:]), nil
+}
+
+// Add sets z = x + y and returns z
+func (z *E16) Add(x, y *E16) *E16 {
+	*z = *x ^ *y
+	return z
+}
+
+// Sub sets z = x - y (= x + y) and returns z
+func (z *E16) Sub(x, y *E16) *E16 {
+	*z = *x ^ *y
+	return z
+}
+
+// Neg sets z = -x (= x) and returns z
+func (z *E16) Neg(x *E16) *E16 {
+	*z = *x
+	return z
+}
+
+// Mul sets z = x * y and returns z
+func (z *E16) Mul(x, y *E16) *E16 {
+	*z = mul16(*x, *y)
+	return z
+}
+
+// Square sets z = x * x and returns z
+func (z *E16) Square(x *E16) *E16 {
+	*z = mul16(*x, *x)
+	return z
+}
+
+// Inverse sets z = x⁻¹ and returns z; if x == 0, sets z = 0
+func (z *E16) Inverse(x *E16) *E16 {
+	*z = inv16(*x)
+	return z
+}
+
+// Div sets z = x / y and returns z; if y == 0, sets z = 0
+func (z *E16) Div(x, y *E16) *E16 {
+	*z = mul16(*x, inv16(*y))
+	return z
+}
+
+// Exp sets z = xᵏ and returns z
+func (z *E16) Exp(x E16, k *big.Int) *E16 {
+	return exp(z, x, k)
+}
+
+// Equal returns z == x
+func (z *E16) Equal(x *E16) bool {
+	return *z == *x
+}
+
+// IsZero returns z == 0
+func (z *E16) IsZero() bool {
+	return *z == 0
+}
+
+// IsOne returns z == 1
+func (z *E16) IsOne() bool {
+	return *z == 1
+}
+
+// Bytes returns the value of z as a big-endian byte array
+func (z *E16) Bytes() (res [E16Bytes]byte) {
+	binary.BigEndian.PutUint16(res[:], uint16(*z))
+	return
+}
+
+// Marshal returns the value of z as a big-endian byte slice
+func (z *E16) Marshal() []byte {
+	b := z.Bytes()
+	return b[:]
+}
+
+// SetBytes interprets e as the big-endian encoding of z (see Bytes), sets z to that value and
+// returns z; if e is longer than E16Bytes, its first bytes are ignored
+func (z *E16) SetBytes(e []byte) *E16 {
+	var b [E16Bytes]byte
+	copyLastBytes(b[:], e)
+	*z = E16(binary.BigEndian.Uint16(b[:]))
+	return z
+}
+
+// String returns the hexadecimal representation of z, prefixed by 0x
+func (z *E16) String() string {
+	b := z.Bytes()
+	return hexString(b[:])
+}
+
+// mul16 returns x * y
+func mul16(x, y E16) E16 {
+	// x = x₀ + x₁X₄, y = y₀ + y₁X₄; with X₄² = X₃X₄ + 1 and Karatsuba:
+	// x * y = (x₀y₀ + x₁y₁) + ((x₀ + x₁)(y₀ + y₁) + x₀y₀ + x₁y₁ + x₁y₁X₃)X₄
+	x0, x1 := E8(x), E8(x>>8)
+	y0, y1 := E8(y), E8(y>>8)
+	m0 := mul8(x0, y0)
+	m2 := mul8(x1, y1)
+	m1 := mul8(x0^x1, y0^y1)
+	return E16(m0^m2) | E16(m1^m0^m2^mulX8(m2))<<8
+}
+
+// mulX16 returns x * X₄
+func mulX16(x E16) E16 {
+	// (x₀ + x₁X₄)X₄ = x₁ + (x₀ + x₁X₃)X₄
+	x0, x1 := E8(x), E8(x>>8)
+	return E16(x1) | E16(x0^mulX8(x1))<<8
+}
+
+// inv16 returns x⁻¹, or 0 if x == 0
+func inv16(x E16) E16 {
+	// (x₀ + x₁X₄)(x₀ + x₁X₃ + x₁X₄) = x₀² + x₀x₁X₃ + x₁², in E8
+	x0, x1 := E8(x), E8(x>>8)
+	y0 := x0 ^ mulX8(x1)
+	nInv := inv8(mul8(x0, y0) ^ mul8(x1, x1))
+	return E16(mul8(y0, nInv)) | E16(mul8(x1, nInv))<<8
+}
@@ -0,0 +1,174 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binary
+
+import (
+	"encoding/binary"
+	"io"
+	"math/big"
+)
+
+// E32 is an element of GF(2³²) = T₅ = E16[X₅] / (X₅² + X₄X₅ + 1), stored as x₀ + x₁X₅
+// with x₀ the low 16 bits and x₁ the high 16 bits
+type E32 uint32
+
+// SetZero sets z = 0 and returns z
+func (z *E32) SetZero() *E32 {
+	*z = 0
+	return z
+}
+
+// SetOne sets z = 1 and returns z
+func (z *E32) SetOne() *E32 {
+	*z = 1
+	return z
+}
+
+// Set sets z = x and returns z
+func (z *E32) Set(x *E32) *E32 {
+	*z = *x
+	return z
+}
+
+// SetRandom sets z to a uniform random value and returns z
+func (z *E32) SetRandom() (*E32, error) {
+	return z.SetRandomFrom(nil)
+}
+
+// SetRandomFrom sets z to a uniform random value read from r (crypto/rand if r is nil) and returns z
+func (z *E32) SetRandomFrom(r io.Reader) (*E32, error) {
+	var b [E32Bytes]byte
+	if err := randomBytes(r, b[:]); err != nil {
+		return nil, err
+	}
+	return z.SetBytes(b[:]), nil
+}
+
+// Add sets z = x + y and returns z
+func (z *E32) Add(x, y *E32) *E32 {
+	*z = *x ^ *y
+	return z
+}
+
+// Sub sets z = x - y (= x + y) and returns z
+func (z *E32) Sub(x, y *E32) *E32 {
+	*z = *x ^ *y
+	return z
+}
+
+// Neg sets z = -x (= x) and returns z
+func (z *E32) Neg(x *E32) *E32 {
+	*z = *x
+	return z
+}
+
+// Mul sets z = x * y and returns z
+func (z *E32) Mul(x, y *E32) *E32 {
+	*z = mul32(*x, *y)
+	return z
+}
+
+// Square sets z = x * x and returns z
+func (z *E32) Square(x *E32) *E32 {
+	*z = mul32(*x, *x)
+	return z
+}
+
+// Inverse sets z = x⁻¹ and returns z; if x == 0, sets z = 0
+func (z *E32) Inverse(x *E32) *E32 {
+	*z = inv32(*x)
+	return z
+}
+
+// Div sets z = x / y and returns z; if y == 0, sets z = 0
+func (z *E32) Div(x, y *E32) *E32 {
+	*z = mul32(*x, inv32(*y))
+	return z
+}
+
+// Exp sets z = xᵏ and returns z
+func (z *E32) Exp(x E32, k *big.Int) *E32 {
+	return exp(z, x, k)
+}
+
+// Equal returns z == x
+func (z *E32) Equal(x *E32) bool {
+	return *z == *x
+}
+
+// IsZero returns z == 0
+func (z *E32) IsZero() bool {
+	return *z == 0
+}
+
+// IsOne returns z == 1
+func (z *E32) IsOne() bool {
+	return *z == 1
+}
+
+// Bytes returns the value of z as a big-endian byte array
+func (z *E32) Bytes() (res [E32Bytes]byte) {
+	binary.BigEndian.PutUint32(res[:], uint32(*z))
+	return
+}
+
+// Marshal returns the value of z as a big-endian byte slice
+func (z *E32) Marshal() []byte {
+	b := z.Bytes()
+	return b[:]
+}
+
+// SetBytes interprets e as the big-endian encoding of z (see Bytes), sets z to that value and
+// returns z; if e is longer than E32Bytes, its first bytes are ignored
+func (z *E32) SetBytes(e []byte) *E32 {
+	var b [E32Bytes]byte
+	copyLastBytes(b[:], e)
+	*z = E32(binary.BigEndian.Uint32(b[:]))
+	return z
+}
+
+// String returns the hexadecimal representation of z, prefixed by 0x
+func (z *E32) String() string {
+	b := z.Bytes()
+	return hexString(b[:])
+}
+
+// mul32 returns x * y
+func mul32(x, y E32) E32 {
+	// x = x₀ + x₁X₅, y = y₀ + y₁X₅; with X₅² = X₄X₅ + 1 and Karatsuba:
+	// x * y = (x₀y₀ + x₁y₁) + ((x₀ + x₁)(y₀ + y₁) + x₀y₀ + x₁y₁ + x₁y₁X₄)X₅
+	x0, x1 := E16(x), E16(x>>16)
+	y0, y1 := E16(y), E16(y>>16)
+	m0 := mul16(x0, y0)
+	m2 := mul16(x1, y1)
+	m1 := mul16(x0^x1, y0^y1)
+	return E32(m0^m2) | E32(m1^m0^m2^mulX16(m2))<<16
+}
+
+// mulX32 returns x * X₅
+func mulX32(x E32) E32 {
+	// (x₀ + x₁X₅)X₅ = x₁ + (x₀ + x₁X₄)X₅
+	x0, x1 := E16(x), E16(x>>16)
+	return E32(x1) | E32(x0^mulX16(x1))<<16
+}
+
+// inv32 returns x⁻¹, or 0 if x == 0
+func inv32(x E32) E32 {
+	// (x₀ + x₁X₅)(x₀ + x₁X₄ + x₁X₅) = x₀² + x₀x₁X₄ + x₁², in E16
+	x0, x1 := E16(x), E16(x>>16)
+	y0 := x0 ^ mulX16(x1)
+	nInv := inv16(mul16(x0, y0) ^ mul16(x1, x1))
+	return E32(mul16(y0, nInv)) | E32(mul16(x1, nInv))<<16
+}
@@ -0,0 +1,174 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binary
+
+import (
+	"encoding/binary"
+	"io"
+	"math/big"
+)
+
+// E64 is an element of GF(2⁶⁴) = T₆ = E32[X₆] / (X₆² + X₅X₆ + 1), stored as x₀ + x₁X₆
+// with x₀ the low 32 bits and x₁ the high 32 bits
+type E64 uint64
+
+// SetZero sets z = 0 and returns z
+func (z *E64) SetZero() *E64 {
+	*z = 0
+	return z
+}
+
+// SetOne sets z = 1 and returns z
+func (z *E64) SetOne() *E64 {
+	*z = 1
+	return z
+}
+
+// Set sets z = x and returns z
+func (z *E64) Set(x *E64) *E64 {
+	*z = *x
+	return z
+}
+
+// SetRandom sets z to a uniform random value and returns z
+func (z *E64) SetRandom() (*E64, error) {
+	return z.SetRandomFrom(nil)
+}
+
+// SetRandomFrom sets z to a uniform random value read from r (crypto/rand if r is nil) and returns z
+func (z *E64) SetRandomFrom(r io.Reader) (*E64, error) {
+	var b [E64Bytes]byte
+	if err := randomBytes(r, b[:]); err != nil {
+		return nil, err
+	}
+	return z.SetBytes(b[:]), nil
+}
+
+// Add sets z = x + y and returns z
+func (z *E64) Add(x, y *E64) *E64 {
+	*z = *x ^ *y
+	return z
+}
+
+// Sub sets z = x - y (= x + y) and returns z
+func (z *E64) Sub(x, y *E64) *E64 {
+	*z = *x ^ *y
+	return z
+}
+
+// Neg sets z = -x (= x) and returns z
+func (z *E64) Neg(x *E64) *E64 {
+	*z = *x
+	return z
+}
+
+// Mul sets z = x * y and returns z
+func (z *E64) Mul(x, y *E64) *E64 {
+	*z = mul64(*x, *y)
+	return z
+}
+
+// Square sets z = x * x and returns z
+func (z *E64) Square(x *E64) *E64 {
+	*z = mul64(*x, *x)
+	return z
+}
+
+// Inverse sets z = x⁻¹ and returns z; if x == 0, sets z = 0
+func (z *E64) Inverse(x *E64) *E64 {
+	*z = inv64(*x)
+	return z
+}
+
+// Div sets z = x / y and returns z; if y == 0, sets z = 0
+func (z *E64) Div(x, y *E64) *E64 {
+	*z = mul64(*x, inv64(*y))
+	return z
+}
+
+// Exp sets z = xᵏ and returns z
+func (z *E64) Exp(x E64, k *big.Int) *E64 {
+	return exp(z, x, k)
+}
+
+// Equal returns z == x
+func (z *E64) Equal(x *E64) bool {
+	return *z == *x
+}
+
+// IsZero returns z == 0
+func (z *E64) IsZero() bool {
+	return *z == 0
+}
+
+// IsOne returns z == 1
+func (z *E64) IsOne() bool {
+	return *z == 1
+}
+
+// Bytes returns the value of z as a big-endian byte array
+func (z *E64) Bytes() (res [E64Bytes]byte) {
+	binary.BigEndian.PutUint64(res[:], uint64(*z))
+	return
+}
+
+// Marshal returns the value of z as a big-endian byte slice
+func (z *E64) Marshal() []byte {
+	b := z.Bytes()
+	return b[:]
+}
+
+// SetBytes interprets e as the big-endian encoding of z (see Bytes), sets z to that value and
+// returns z; if e is longer than E64Bytes, its first bytes are ignored
+func (z *E64) SetBytes(e []byte) *E64 {
+	var b [E64Bytes]byte
+	copyLastBytes(b[:], e)
+	*z = E64(binary.BigEndian.Uint64(b[:]))
+	return z
+}
+
+// String returns the hexadecimal representation of z, prefixed by 0x
+func (z *E64) String() string {
+	b := z.Bytes()
+	return hexString(b[:])
+}
+
+// mul64 returns x * y
+func mul64(x, y E64) E64 {
+	// x = x₀ + x₁X₆, y = y₀ + y₁X₆; with X₆² = X₅X₆ + 1 and Karatsuba:
+	// x * y = (x₀y₀ + x₁y₁) + ((x₀ + x₁)(y₀ + y₁) + x₀y₀ + x₁y₁ + x₁y₁X₅)X₆
+	x0, x1 := E32(x), E32(x>>32)
+	y0, y1 := E32(y), E32(y>>32)
+	m0 := mul32(x0, y0)
+	m2 := mul32(x1, y1)
+	m1 := mul32(x0^x1, y0^y1)
+	return E64(m0^m2) | E64(m1^m0^m2^mulX32(m2))<<32
+}
+
+// mulX64 returns x * X₆
+func mulX64(x E64) E64 {
+	// (x₀ + x₁X₆)X₆ = x₁ + (x₀ + x₁X₅)X₆
+	x0, x1 := E32(x), E32(x>>32)
+	return E64(x1) | E64(x0^mulX32(x1))<<32
+}
+
+// inv64 returns x⁻¹, or 0 if x == 0
+func inv64(x E64) E64 {
+	// (x₀ + x₁X₆)(x₀ + x₁X₅ + x₁X₆) = x₀² + x₀x₁X₅ + x₁², in E32
+	x0, x1 := E32(x), E32(x>>32)
+	y0 := x0 ^ mulX32(x1)
+	nInv := inv32(mul32(x0, y0) ^ mul32(x1, x1))
+	return E64(mul32(y0, nInv)) | E64(mul32(x1, nInv))<<32
+}
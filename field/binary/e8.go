@@ -0,0 +1,189 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binary
+
+import (
+	"io"
+	"math/big"
+)
+
+// E8 is an element of GF(2⁸) = T₃, the bottom of the tower
+type E8 uint8
+
+// g8 generates the multiplicative group of E8
+const g8 = 0x13
+
+var (
+	// log8[x] = i such that x = g8ⁱ, for x ≠ 0
+	log8 [256]uint8
+	// exp8[i] = g8ⁱ, for i < 2 * 255 so that exp8[log8[x] + log8[y]] = x * y
+	exp8 [2 * 255]E8
+	// mulX8Table[x] = x * X₃
+	mulX8Table [256]E8
+)
+
+func init() {
+	x := uint64(1)
+	for i := 0; i < 255; i++ {
+		exp8[i], exp8[i+255] = E8(x), E8(x)
+		log8[x] = uint8(i)
+		x = mulTower(x, g8, 3)
+	}
+	for i := range mulX8Table {
+		mulX8Table[i] = E8(mulXTower(uint64(i), 3))
+	}
+}
+
+// SetZero sets z = 0 and returns z
+func (z *E8) SetZero() *E8 {
+	*z = 0
+	return z
+}
+
+// SetOne sets z = 1 and returns z
+func (z *E8) SetOne() *E8 {
+	*z = 1
+	return z
+}
+
+// Set sets z = x and returns z
+func (z *E8) Set(x *E8) *E8 {
+	*z = *x
+	return z
+}
+
+// SetRandom sets z to a uniform random value and returns z
+func (z *E8) SetRandom() (*E8, error) {
+	return z.SetRandomFrom(nil)
+}
+
+// SetRandomFrom sets z to a uniform random value read from r (crypto/rand if r is nil) and returns z
+func (z *E8) SetRandomFrom(r io.Reader) (*E8, error) {
+	var b [E8Bytes]byte
+	if err := randomBytes(r, b[:]); err != nil {
+		return nil, err
+	}
+	return z.SetBytes(b[:]), nil
+}
+
+// Add sets z = x + y and returns z
+func (z *E8) Add(x, y *E8) *E8 {
+	*z = *x ^ *y
+	return z
+}
+
+// Sub sets z = x - y (= x + y) and returns z
+func (z *E8) Sub(x, y *E8) *E8 {
+	*z = *x ^ *y
+	return z
+}
+
+// Neg sets z = -x (= x) and returns z
+func (z *E8) Neg(x *E8) *E8 {
+	*z = *x
+	return z
+}
+
+// Mul sets z = x * y and returns z
+func (z *E8) Mul(x, y *E8) *E8 {
+	*z = mul8(*x, *y)
+	return z
+}
+
+// Square sets z = x * x and returns z
+func (z *E8) Square(x *E8) *E8 {
+	*z = mul8(*x, *x)
+	return z
+}
+
+// Inverse sets z = x⁻¹ and returns z; if x == 0, sets z = 0
+func (z *E8) Inverse(x *E8) *E8 {
+	*z = inv8(*x)
+	return z
+}
+
+// Div sets z = x / y and returns z; if y == 0, sets z = 0
+func (z *E8) Div(x, y *E8) *E8 {
+	*z = mul8(*x, inv8(*y))
+	return z
+}
+
+// Exp sets z = xᵏ and returns z
+func (z *E8) Exp(x E8, k *big.Int) *E8 {
+	return exp(z, x, k)
+}
+
+// Equal returns z == x
+func (z *E8) Equal(x *E8) bool {
+	return *z == *x
+}
+
+// IsZero returns z == 0
+func (z *E8) IsZero() bool {
+	return *z == 0
+}
+
+// IsOne returns z == 1
+func (z *E8) IsOne() bool {
+	return *z == 1
+}
+
+// Bytes returns the value of z as a big-endian byte array
+func (z *E8) Bytes() (res [E8Bytes]byte) {
+	res[0] = byte(*z)
+	return
+}
+
+// Marshal returns the value of z as a big-endian byte slice
+func (z *E8) Marshal() []byte {
+	b := z.Bytes()
+	return b[:]
+}
+
+// SetBytes interprets e as the big-endian encoding of z (see Bytes), sets z to that value and
+// returns z; if e is longer than E8Bytes, its first bytes are ignored
+func (z *E8) SetBytes(e []byte) *E8 {
+	var b [E8Bytes]byte
+	copyLastBytes(b[:], e)
+	*z = E8(b[0])
+	return z
+}
+
+// String returns the hexadecimal representation of z, prefixed by 0x
+func (z *E8) String() string {
+	b := z.Bytes()
+	return hexString(b[:])
+}
+
+// mul8 returns x * y
+func mul8(x, y E8) E8 {
+	if x == 0 || y == 0 {
+		return 0
+	}
+	return exp8[int(log8[x])+int(log8[y])]
+}
+
+// mulX8 returns x * X₃
+func mulX8(x E8) E8 {
+	return mulX8Table[x]
+}
+
+// inv8 returns x⁻¹, or 0 if x == 0
+func inv8(x E8) E8 {
+	if x == 0 {
+		return 0
+	}
+	return exp8[255-int(log8[x])]
+}
@@ -0,0 +1,30 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fft provides the additive FFT of Lin, Chung and Han over binary.E128.
+//
+// It evaluates a polynomial of the "novel polynomial basis" on an affine subspace
+// Shift + span(β₀, ..., βₗ₋₁) of E128, where βₘ is the E128 whose only bit in the tower basis is m
+// (so that the integer j denotes the point ∑ jₘβₘ, the E64 j embedded in E128):
+//
+//	Wᵢ(x) = ∏ (x - u), u ∈ span(β₀, ..., βᵢ₋₁), a GF(2)-linear map
+//	Ŵᵢ(x) = Wᵢ(x) / Wᵢ(βᵢ)
+//	Xⱼ(x) = ∏ Ŵᵢ(x), for the bits i of j
+//
+// {X₀, ..., Xₖ₋₁} is a basis of the polynomials of degree < k: to compute a Reed–Solomon codeword of
+// rate k/n, set the first k coefficients and let the n - k others be 0.
+//
+// See "Novel Polynomial Basis and Its Application to Reed-Solomon Erasure Codes",
+// S.-J. Lin, W.-H. Chung and Y. S. Han, https://arxiv.org/abs/1404.3458
+package fft
@@ -0,0 +1,96 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fft
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/field/binary"
+)
+
+// Domain is the affine subspace Shift + span(β₀, ..., βₗ₋₁) of E128, with a power of 2 cardinality 2ˡ
+type Domain struct {
+	Cardinality uint64
+	Shift       binary.E128
+
+	// Twiddles[i][k] = Ŵᵢ(Shift + k2ⁱ⁺¹), the twiddle of the k-th block of stage i of the FFT
+	Twiddles [][]binary.E128
+}
+
+// NewDomain returns the affine subspace Shift + span(β₀, ..., βₗ₋₁), of cardinality 2ˡ ⩾ m,
+// with Shift = shift[0] if set, 0 otherwise
+func NewDomain(m uint64, shift ...binary.E128) *Domain {
+	domain := &Domain{}
+	domain.Cardinality = ecc.NextPowerOfTwo(m)
+	if len(shift) > 0 {
+		domain.Shift = shift[0]
+	}
+	if domain.Cardinality > 1<<63 {
+		panic(fmt.Sprintf("m (%d) is too big", m))
+	}
+
+	domain.preComputeTwiddles()
+
+	return domain
+}
+
+// Point returns Shift + j, the j-th point of the domain
+func (domain *Domain) Point(j uint64) binary.E128 {
+	var res binary.E128
+	res.SetE64(binary.E64(j))
+	return *res.Add(&res, &domain.Shift)
+}
+
+func (domain *Domain) preComputeTwiddles() {
+	// nb fft stages
+	nbStages := bits.TrailingZeros64(domain.Cardinality)
+	domain.Twiddles = make([][]binary.E128, nbStages)
+
+	// w[j] = Wᵢ(βⱼ) and wShift = Wᵢ(Shift), at stage i; W₀(x) = x
+	w := make([]binary.E128, nbStages)
+	for j := range w {
+		w[j].SetE64(binary.E64(1) << j)
+	}
+	wShift := domain.Shift
+
+	for i := 0; i < nbStages; i++ {
+		var normInv binary.E128
+		normInv.Inverse(&w[i])
+
+		// Ŵᵢ is linear, and vanishes on β₀, ..., βᵢ₋₁:
+		// Ŵᵢ(Shift + k2ⁱ⁺¹) = Ŵᵢ(Shift) + ∑ kₘŴᵢ(βᵢ₊₁₊ₘ)
+		twiddles := make([]binary.E128, domain.Cardinality>>(i+1))
+		twiddles[0].Mul(&wShift, &normInv)
+		for k := 1; k < len(twiddles); k++ {
+			var t binary.E128
+			t.Mul(&w[i+1+bits.TrailingZeros(uint(k))], &normInv)
+			twiddles[k].Add(&twiddles[k&(k-1)], &t)
+		}
+		domain.Twiddles[i] = twiddles
+
+		// Wᵢ₊₁(x) = Wᵢ(x)Wᵢ(x + βᵢ) = Wᵢ(x)² + Wᵢ(βᵢ)Wᵢ(x)
+		next := func(x *binary.E128) {
+			var t binary.E128
+			t.Mul(x, &w[i])
+			x.Square(x).Add(x, &t)
+		}
+		for j := i + 1; j < nbStages; j++ {
+			next(&w[j])
+		}
+		next(&wShift)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fft
+
+import (
+	"math/bits"
+	"runtime"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/field/binary"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// parallelize threshold for a single butterfly op, if the fft stage is not parallelized already
+const butterflyThreshold = 16
+
+// FFT sets a[j] = P(Shift + j), where P = ∑ a[j]Xⱼ is given in the novel polynomial basis
+// len(a) must be the Cardinality of the domain
+func (domain *Domain) FFT(a []binary.E128) {
+	if uint64(len(a)) != domain.Cardinality {
+		panic("fft: len(a) must be the cardinality of the domain")
+	}
+	additiveFFT(a, domain.Twiddles, 0, 0, maxSplits(), nil)
+}
+
+// FFTInverse sets a to the coefficients in the novel polynomial basis of the polynomial P of
+// degree < Cardinality such that P(Shift + j) = a[j]
+// len(a) must be the Cardinality of the domain
+func (domain *Domain) FFTInverse(a []binary.E128) {
+	if uint64(len(a)) != domain.Cardinality {
+		panic("fft: len(a) must be the cardinality of the domain")
+	}
+	additiveFFTInverse(a, domain.Twiddles, 0, 0, maxSplits(), nil)
+}
+
+// maxSplits returns the stage where we should stop spawning go routines in our recursive calls
+// (ie when we have as many go routines running as we have available CPUs)
+func maxSplits() int {
+	numCPU := uint64(runtime.NumCPU())
+	if numCPU <= 1 {
+		return -1
+	}
+	return bits.TrailingZeros64(ecc.NextPowerOfTwo(numCPU))
+}
+
+// additiveFFT processes the block of index block of the FFT stage log(len(a)) - 1, and the
+// following stages recursively; depth is the number of stages above it
+//
+// With P = P₀ + ŴᵢP₁ and t = Ŵᵢ(Shift + block2ⁱ⁺¹) (Ŵᵢ is linear and Ŵᵢ(βᵢ) = 1), the first half of
+// the block is evaluated at P₀ + tP₁ and the second half at P₀ + (t + 1)P₁.
+func additiveFFT(a []binary.E128, twiddles [][]binary.E128, block, depth, maxSplits int, chDone chan struct{}) {
+	if chDone != nil {
+		defer close(chDone)
+	}
+
+	n := len(a)
+	if n == 1 {
+		return
+	}
+	m := n >> 1
+	t := &twiddles[bits.TrailingZeros(uint(m))][block]
+
+	butterfly := func(start, end int) {
+		var u binary.E128
+		for i := start; i < end; i++ {
+			u.Mul(&a[i+m], t)
+			a[i].Add(&a[i], &u)
+			a[i+m].Add(&a[i+m], &a[i])
+		}
+	}
+
+	// if depth < maxSplits, we parallelize this butterfly
+	// but we have only numCPU / 2^depth cpus available
+	if (m > butterflyThreshold) && (depth < maxSplits) {
+		parallel.Execute(m, butterfly, runtime.NumCPU()/(1<<depth))
+	} else {
+		butterfly(0, m)
+	}
+
+	if depth < maxSplits {
+		chDone := make(chan struct{}, 1)
+		go additiveFFT(a[m:n], twiddles, 2*block+1, depth+1, maxSplits, chDone)
+		additiveFFT(a[0:m], twiddles, 2*block, depth+1, maxSplits, nil)
+		<-chDone
+	} else {
+		additiveFFT(a[0:m], twiddles, 2*block, depth+1, maxSplits, nil)
+		additiveFFT(a[m:n], twiddles, 2*block+1, depth+1, maxSplits, nil)
+	}
+}
+
+// additiveFFTInverse undoes additiveFFT, the stages in the reverse order
+func additiveFFTInverse(a []binary.E128, twiddles [][]binary.E128, block, depth, maxSplits int, chDone chan struct{}) {
+	if chDone != nil {
+		defer close(chDone)
+	}
+
+	n := len(a)
+	if n == 1 {
+		return
+	}
+	m := n >> 1
+
+	if depth < maxSplits {
+		chDone := make(chan struct{}, 1)
+		go additiveFFTInverse(a[m:n], twiddles, 2*block+1, depth+1, maxSplits, chDone)
+		additiveFFTInverse(a[0:m], twiddles, 2*block, depth+1, maxSplits, nil)
+		<-chDone
+	} else {
+		additiveFFTInverse(a[0:m], twiddles, 2*block, depth+1, maxSplits, nil)
+		additiveFFTInverse(a[m:n], twiddles, 2*block+1, depth+1, maxSplits, nil)
+	}
+
+	t := &twiddles[bits.TrailingZeros(uint(m))][block]
+	butterfly := func(start, end int) {
+		var u binary.E128
+		for i := start; i < end; i++ {
+			a[i+m].Add(&a[i+m], &a[i])
+			u.Mul(&a[i+m], t)
+			a[i].Add(&a[i], &u)
+		}
+	}
+
+	if (m > butterflyThreshold) && (depth < maxSplits) {
+		parallel.Execute(m, butterfly, runtime.NumCPU()/(1<<depth))
+	} else {
+		butterfly(0, m)
+	}
+}
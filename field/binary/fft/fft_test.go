@@ -0,0 +1,163 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fft
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/field/binary"
+)
+
+func TestFFT(t *testing.T) {
+	var shift binary.E128
+	shift.SetBytes([]byte{0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0, 0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0})
+
+	for _, s := range []binary.E128{{}, shift} {
+		for logSize := 0; logSize <= 5; logSize++ {
+			domain := NewDomain(1<<logSize, s)
+			pol := randomVector(t, int(domain.Cardinality))
+			evals := make([]binary.E128, len(pol))
+			copy(evals, pol)
+			domain.FFT(evals)
+
+			for j := range evals {
+				x := domain.Point(uint64(j))
+				want := evalNaive(pol, x)
+				if evals[j] != want {
+					t.Fatalf("size %d: P(Shift + %d) = %s, want %s", len(pol), j, evals[j].String(), want.String())
+				}
+			}
+		}
+	}
+}
+
+func TestFFTInverse(t *testing.T) {
+	var shift binary.E128
+	shift.SetE64(0xdeadbeef)
+	for _, size := range []int{1, 2, 1 << 5, 1 << 12} {
+		domain := NewDomain(uint64(size), shift)
+		pol := randomVector(t, size)
+		backup := make([]binary.E128, size)
+		copy(backup, pol)
+
+		domain.FFT(pol)
+		domain.FFTInverse(pol)
+		for i := range pol {
+			if pol[i] != backup[i] {
+				t.Fatalf("size %d: FFTInverse(FFT(a)) != a", size)
+			}
+		}
+	}
+}
+
+func TestReedSolomon(t *testing.T) {
+	// a codeword of rate 1/4 is the evaluation of a polynomial of degree < n/4
+	const n = 1 << 8
+	domain := NewDomain(n)
+	codeword := randomVector(t, n/4)
+	codeword = append(codeword, make([]binary.E128, n-n/4)...)
+	domain.FFT(codeword)
+
+	// the restriction to the first n/4 points (a subspace) has the same coefficients
+	small := NewDomain(n / 4)
+	coeffs := make([]binary.E128, n/4)
+	copy(coeffs, codeword[:n/4])
+	small.FFTInverse(coeffs)
+
+	pol := make([]binary.E128, n)
+	copy(pol, coeffs)
+	domain.FFT(pol)
+	for i := range pol {
+		if pol[i] != codeword[i] {
+			t.Fatal("the codeword is not a Reed-Solomon codeword")
+		}
+	}
+}
+
+// evalNaive returns ∑ pol[j]Xⱼ(x), with Ŵᵢ computed from the definition Wᵢ(x) = ∏ (x - u)
+func evalNaive(pol []binary.E128, x binary.E128) binary.E128 {
+	var nbStages int
+	for 1<<nbStages < len(pol) {
+		nbStages++
+	}
+
+	// w[i] = Ŵᵢ(x)
+	w := make([]binary.E128, nbStages)
+	for i := range w {
+		var bi binary.E128
+		bi.SetE64(binary.E64(1) << i)
+		num, den := vanishing(x, i), vanishing(bi, i)
+		w[i].Div(&num, &den)
+	}
+
+	var res binary.E128
+	for j := range pol {
+		var xj binary.E128
+		xj.SetOne()
+		for i := 0; i < nbStages; i++ {
+			if j>>i&1 == 1 {
+				xj.Mul(&xj, &w[i])
+			}
+		}
+		xj.Mul(&xj, &pol[j])
+		res.Add(&res, &xj)
+	}
+	return res
+}
+
+// vanishing returns Wᵢ(x) = ∏ (x - u), u ∈ span(β₀, ..., βᵢ₋₁)
+func vanishing(x binary.E128, i int) binary.E128 {
+	var res binary.E128
+	res.SetOne()
+	for u := 0; u < 1<<i; u++ {
+		var t binary.E128
+		t.SetE64(binary.E64(u))
+		t.Sub(&x, &t)
+		res.Mul(&res, &t)
+	}
+	return res
+}
+
+func randomVector(t testing.TB, size int) []binary.E128 {
+	res := make([]binary.E128, size)
+	for i := range res {
+		if _, err := res[i].SetRandom(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return res
+}
+
+func BenchmarkFFT(b *testing.B) {
+	const maxSize = 1 << 20
+
+	pol := randomVector(b, maxSize)
+	for i := 10; i <= 20; i += 5 {
+		domain := NewDomain(1 << i)
+		b.Run("fft 2**"+strconv.Itoa(i), func(b *testing.B) {
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				domain.FFT(pol[:1<<i])
+			}
+		})
+		b.Run("fftInverse 2**"+strconv.Itoa(i), func(b *testing.B) {
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				domain.FFTInverse(pol[:1<<i])
+			}
+		})
+	}
+}
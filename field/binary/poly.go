@@ -0,0 +1,258 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binary
+
+import "math/bits"
+
+// E128 is stored in the polynomial basis of GF(2¹²⁸) = GF(2)[x] / (x¹²⁸ + x⁷ + x² + x + 1), where
+// the product is a carry-less multiplication followed by a reduction.
+//
+// The change of basis from the tower basis is the GF(2)-linear isomorphism sending Xᵢ to a root Yᵢ of t² + Yᵢ₋₁t + 1 (Y₀ = 1), so
+// that the tower basis element ∏ Xᵢ₊₁^bᵢ (bit b of an E128) is sent to ∏ Yᵢ₊₁^bᵢ. It is applied
+// with tables, indexed by the bytes of the input.
+
+var (
+	// toPolyTable[i][v] is the image in the polynomial basis of v << 8i (tower basis)
+	toPolyTable [16][256][2]uint64
+	// fromPolyTable[i][v] is the image in the tower basis of v << 8i (polynomial basis)
+	fromPolyTable [16][256][2]uint64
+)
+
+func init() {
+	// Y₁, ..., Y₇, in the polynomial basis
+	var y [8][2]uint64
+	y[0] = [2]uint64{1, 0}
+	for i := 1; i < 8; i++ {
+		y[i] = solveQuadratic(y[i-1])
+	}
+
+	// the images of the tower basis (the columns of the change of basis matrix)
+	var toPoly [128][2]uint64
+	for b := 0; b < 128; b++ {
+		toPoly[b] = [2]uint64{1, 0}
+		for i := 0; i < 7; i++ {
+			if b>>i&1 == 1 {
+				toPoly[b] = polyMul(toPoly[b], y[i+1])
+			}
+		}
+	}
+	fromPoly, ok := invertMatrix(&toPoly)
+	if !ok {
+		panic("binary: the change of basis is not invertible")
+	}
+
+	buildByteTables(&toPolyTable, &toPoly)
+	buildByteTables(&fromPolyTable, &fromPoly)
+}
+
+// buildByteTables sets tables[i][v] = ∑ⱼ vⱼ cols[8i + j], where vⱼ is the bit j of v
+func buildByteTables(tables *[16][256][2]uint64, cols *[128][2]uint64) {
+	for i := range tables {
+		for v := 1; v < 256; v++ {
+			// v = (v with its lowest bit cleared) + (lowest bit of v)
+			j := bits.TrailingZeros(uint(v))
+			prev := &tables[i][v&(v-1)]
+			col := &cols[8*i+j]
+			tables[i][v] = [2]uint64{prev[0] ^ col[0], prev[1] ^ col[1]}
+		}
+	}
+}
+
+// applyByteTables returns ∑ᵢ tables[i][byte i of x]
+func applyByteTables(tables *[16][256][2]uint64, x *[2]uint64) (r [2]uint64) {
+	for i := 0; i < 16; i++ {
+		t := &tables[i][uint8(x[i/8]>>(8*(i%8)))]
+		r[0] ^= t[0]
+		r[1] ^= t[1]
+	}
+	return
+}
+
+// reducePoly returns t mod x¹²⁸ + x⁷ + x² + x + 1, with t = ∑ t[i] x⁶⁴ⁱ
+func reducePoly(t *[4]uint64) [2]uint64 {
+	// t = h x¹²⁸ + l ≡ l + h(x⁷ + x² + x + 1)
+	h0, h1 := t[2], t[3]
+	r0 := t[0] ^ h0 ^ h0<<1 ^ h0<<2 ^ h0<<7
+	r1 := t[1] ^ h1 ^ (h1<<1 | h0>>63) ^ (h1<<2 | h0>>62) ^ (h1<<7 | h0>>57)
+
+	// the bits of h(x⁷ + x² + x + 1) above x¹²⁸, o < 2⁷, are reduced the same way
+	o := h1>>63 ^ h1>>62 ^ h1>>57
+	r0 ^= o ^ o<<1 ^ o<<2 ^ o<<7
+	return [2]uint64{r0, r1}
+}
+
+// clmul128Generic sets z = x * y, the carry-less product of x and y on 256 bits
+func clmul128Generic(z *[4]uint64, x, y *[2]uint64) {
+	// Karatsuba
+	h0, l0 := clmul64(x[0], y[0])
+	h2, l2 := clmul64(x[1], y[1])
+	h1, l1 := clmul64(x[0]^x[1], y[0]^y[1])
+	h1 ^= h0 ^ h2
+	l1 ^= l0 ^ l2
+	z[0] = l0
+	z[1] = h0 ^ l1
+	z[2] = l2 ^ h1
+	z[3] = h2
+}
+
+// clmul64 returns the carry-less product of x and y, on 128 bits
+func clmul64(x, y uint64) (hi, lo uint64) {
+	lo = bmul64(x, y)
+	// the high bits are the low bits of the product of the bit-reversed inputs, reversed
+	hi = bits.Reverse64(bmul64(bits.Reverse64(x), bits.Reverse64(y))) >> 1
+	return
+}
+
+// bmul64 returns the low 64 bits of the carry-less product of x and y
+//
+// The inputs are split in 4 interleaved parts, whose products with integer multiplications
+// have "holes" to absorb the carries (see BearSSL's ghash_ctmul64.c).
+func bmul64(x, y uint64) uint64 {
+	const (
+		m0 = 0x1111111111111111
+		m1 = 0x2222222222222222
+		m2 = 0x4444444444444444
+		m3 = 0x8888888888888888
+	)
+	x0, x1, x2, x3 := x&m0, x&m1, x&m2, x&m3
+	y0, y1, y2, y3 := y&m0, y&m1, y&m2, y&m3
+	z0 := (x0 * y0) ^ (x1 * y3) ^ (x2 * y2) ^ (x3 * y1)
+	z1 := (x0 * y1) ^ (x1 * y0) ^ (x2 * y3) ^ (x3 * y2)
+	z2 := (x0 * y2) ^ (x1 * y1) ^ (x2 * y0) ^ (x3 * y3)
+	z3 := (x0 * y3) ^ (x1 * y2) ^ (x2 * y1) ^ (x3 * y0)
+	return (z0 & m0) | (z1 & m1) | (z2 & m2) | (z3 & m3)
+}
+
+// polyMul returns x * y in the polynomial basis
+func polyMul(x, y [2]uint64) [2]uint64 {
+	var t [4]uint64
+	clmul128Generic(&t, &x, &y)
+	return reducePoly(&t)
+}
+
+// polyInverse returns x⁻¹ = x^(2¹²⁸ - 2) in the polynomial basis
+func polyInverse(x [2]uint64) [2]uint64 {
+	// 2¹²⁸ - 2 = ∑_{1 ⩽ i < 128} 2ⁱ
+	res := [2]uint64{1, 0}
+	for i := 1; i < 128; i++ {
+		x = polyMul(x, x)
+		res = polyMul(res, x)
+	}
+	return res
+}
+
+// solveQuadratic returns a root of t² + bt + 1, in the polynomial basis
+func solveQuadratic(b [2]uint64) [2]uint64 {
+	// t = bu, with u² + u = b⁻²; u ↦ u² + u is GF(2)-linear
+	var cols [128][2]uint64
+	for i := 0; i < 128; i++ {
+		var e [2]uint64
+		e[i/64] = 1 << (i % 64)
+		cols[i] = polyMul(e, e)
+		cols[i][0] ^= e[0]
+		cols[i][1] ^= e[1]
+	}
+	bInv := polyInverse(b)
+	u, ok := solveLinear(&cols, polyMul(bInv, bInv))
+	if !ok {
+		panic("binary: the tower doesn't embed in GF(2¹²⁸)")
+	}
+	return polyMul(b, u)
+}
+
+// matrixRows returns the rows of the 128x128 matrix over GF(2) of columns cols, extended
+// with the columns of ext
+func matrixRows(cols *[128][2]uint64, ext func(r int) [2]uint64) (rows [128][4]uint64) {
+	for i := 0; i < 128; i++ {
+		for r := 0; r < 128; r++ {
+			rows[r][i/64] |= (cols[i][r/64] >> (r % 64) & 1) << (i % 64)
+		}
+	}
+	for r := 0; r < 128; r++ {
+		e := ext(r)
+		rows[r][2], rows[r][3] = e[0], e[1]
+	}
+	return
+}
+
+// gaussJordan reduces the left 128 columns of rows to their reduced row echelon form,
+// and returns the pivot column of each row (-1 for the null rows)
+func gaussJordan(rows *[128][4]uint64) (pivots [128]int) {
+	r := 0
+	for c := 0; c < 128 && r < 128; c++ {
+		w, bit := c/64, uint64(1)<<(c%64)
+		p := r
+		for p < 128 && rows[p][w]&bit == 0 {
+			p++
+		}
+		if p == 128 {
+			continue
+		}
+		rows[r], rows[p] = rows[p], rows[r]
+		for i := 0; i < 128; i++ {
+			if i != r && rows[i][w]&bit != 0 {
+				for j := range rows[i] {
+					rows[i][j] ^= rows[r][j]
+				}
+			}
+		}
+		pivots[r] = c
+		r++
+	}
+	for ; r < 128; r++ {
+		pivots[r] = -1
+	}
+	return
+}
+
+// solveLinear returns a solution u of Mu = c, where M is the matrix of columns cols, and
+// false if there is none
+func solveLinear(cols *[128][2]uint64, c [2]uint64) (u [2]uint64, ok bool) {
+	rows := matrixRows(cols, func(r int) [2]uint64 {
+		return [2]uint64{c[r/64] >> (r % 64) & 1, 0}
+	})
+	pivots := gaussJordan(&rows)
+	for r := 0; r < 128; r++ {
+		if pivots[r] == -1 {
+			if rows[r][2] != 0 {
+				return u, false
+			}
+			continue
+		}
+		// the free variables are 0
+		u[pivots[r]/64] |= rows[r][2] << (pivots[r] % 64)
+	}
+	return u, true
+}
+
+// invertMatrix returns the columns of M⁻¹, where M is the matrix of columns cols, and false
+// if M is not invertible
+func invertMatrix(cols *[128][2]uint64) (inv [128][2]uint64, ok bool) {
+	rows := matrixRows(cols, func(r int) (e [2]uint64) {
+		e[r/64] = 1 << (r % 64)
+		return
+	})
+	pivots := gaussJordan(&rows)
+	if pivots[127] != 127 {
+		return inv, false
+	}
+	// rows = [I | M⁻¹]
+	for r := 0; r < 128; r++ {
+		for j := 0; j < 128; j++ {
+			inv[j][r/64] |= (rows[r][2+j/64] >> (j % 64) & 1) << (r % 64)
+		}
+	}
+	return inv, true
+}
@@ -0,0 +1,120 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binary
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"math/big"
+)
+
+// Number of bytes of the elements (Bytes returns an array of that size)
+const (
+	E8Bytes   = 1
+	E16Bytes  = 2
+	E32Bytes  = 4
+	E64Bytes  = 8
+	E128Bytes = 16
+)
+
+// element is satisfied by the pointers to the tower elements
+type element[T any] interface {
+	*T
+	SetOne() *T
+	Mul(x, y *T) *T
+	Square(x *T) *T
+	Inverse(x *T) *T
+}
+
+// exp sets z = xᵏ and returns z
+func exp[T any, PT element[T]](z PT, x T, k *big.Int) PT {
+	e := k
+	if k.Sign() == -1 {
+		// xᵏ = (x⁻¹)⁻ᵏ
+		PT(&x).Inverse(&x)
+		e = new(big.Int).Neg(k)
+	}
+
+	var res T
+	PT(&res).SetOne()
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		PT(&res).Square(&res)
+		if e.Bit(i) == 1 {
+			PT(&res).Mul(&res, &x)
+		}
+	}
+	*z = res
+	return z
+}
+
+// mulTower returns x * y in Tₗ (l ⩽ 6), the bits above 2ˡ being ignored
+//
+// It computes the product bit by bit, and is only used to build the tables and check the
+// faster implementations.
+func mulTower(x, y uint64, l int) uint64 {
+	if l == 0 {
+		return x & y & 1
+	}
+	// x = x₀ + x₁Xₗ, y = y₀ + y₁Xₗ
+	h := uint(1) << (l - 1)
+	mask := uint64(1)<<h - 1
+	x0, x1 := x&mask, (x>>h)&mask
+	y0, y1 := y&mask, (y>>h)&mask
+
+	// Karatsuba, with Xₗ² = Xₗ₋₁Xₗ + 1
+	m0 := mulTower(x0, y0, l-1)
+	m2 := mulTower(x1, y1, l-1)
+	m1 := mulTower(x0^x1, y0^y1, l-1)
+	lo := m0 ^ m2
+	hi := m1 ^ m0 ^ m2 ^ mulXTower(m2, l-1)
+	return lo | hi<<h
+}
+
+// mulXTower returns x * Xₗ in Tₗ (l ⩽ 6)
+func mulXTower(x uint64, l int) uint64 {
+	if l == 0 {
+		// X₀ = 1
+		return x
+	}
+	// (x₀ + x₁Xₗ)Xₗ = x₁ + (x₀ + x₁Xₗ₋₁)Xₗ
+	h := uint(1) << (l - 1)
+	mask := uint64(1)<<h - 1
+	x0, x1 := x&mask, (x>>h)&mask
+	return x1 | (x0^mulXTower(x1, l-1))<<h
+}
+
+// randomBytes fills b with random bytes read from r
+func randomBytes(r io.Reader, b []byte) error {
+	if r == nil {
+		r = rand.Reader
+	}
+	_, err := io.ReadFull(r, b)
+	return err
+}
+
+// hexString returns the hexadecimal representation of b, prefixed by 0x
+func hexString(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+// copyLastBytes copies the last len(dst) bytes of e at the end of dst; if e is shorter than dst,
+// the first bytes of dst are left unchanged
+func copyLastBytes(dst, e []byte) {
+	if len(e) > len(dst) {
+		e = e[len(e)-len(dst):]
+	}
+	copy(dst[len(dst)-len(e):], e)
+}
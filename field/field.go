@@ -0,0 +1,101 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package field provides the Element constraint, satisfied by the field elements generated
+// by goff (ecc/bn254/fr, ecc/bls12-381/fp, field/goldilocks, ...).
+//
+// It allows writing algorithms once, for all fields:
+//
+//	func Sum[T any, PT field.Element[T]](v []T) T {
+//		var res T
+//		for i := range v {
+//			PT(&res).Add(&res, &v[i])
+//		}
+//		return res
+//	}
+//
+//	s := Sum(v) // v []fr.Element, type parameters are inferred
+//
+// The methods of the generated elements have pointer receivers; T is the element type, and PT
+// its pointer type, which carries the methods. Elements are manipulated by value (T), without
+// interface conversion; as the methods are called through PT, the compiler may move the
+// temporaries of a generic function to the heap (a few allocations per call, not per operation).
+//
+// See field/generic for algorithms built on it.
+package field
+
+import (
+	"io"
+	"math/big"
+)
+
+// Element is the constraint satisfied by *T, where T is a field element generated by goff.
+//
+// Note that Bytes() returns an array, whose size depends on the field; Marshal() returns
+// the same bytes as a slice.
+type Element[T any] interface {
+	*T
+
+	// Set sets z = x and returns z
+	Set(x *T) *T
+	// SetZero sets z = 0 and returns z
+	SetZero() *T
+	// SetOne sets z = 1 (in Montgomery form) and returns z
+	SetOne() *T
+	// SetUint64 sets z = v and returns z
+	SetUint64(v uint64) *T
+	// SetInt64 sets z = v and returns z
+	SetInt64(v int64) *T
+	// SetBigInt sets z = v mod q and returns z
+	SetBigInt(v *big.Int) *T
+	// SetBytes interprets e as the bytes of a big-endian unsigned integer, sets z = e mod q and returns z
+	SetBytes(e []byte) *T
+	// SetRandom sets z to a uniform random value in [0, q)
+	SetRandom() (*T, error)
+	// SetRandomFrom sets z to a uniform random value in [0, q), read from r
+	SetRandomFrom(r io.Reader) (*T, error)
+
+	// Add sets z = x + y and returns z
+	Add(x, y *T) *T
+	// Double sets z = 2x and returns z
+	Double(x *T) *T
+	// Sub sets z = x - y and returns z
+	Sub(x, y *T) *T
+	// Neg sets z = -x and returns z
+	Neg(x *T) *T
+	// Mul sets z = x * y and returns z
+	Mul(x, y *T) *T
+	// Square sets z = x * x and returns z
+	Square(x *T) *T
+	// Inverse sets z = x⁻¹ and returns z; if x == 0, sets z = 0
+	Inverse(x *T) *T
+	// Div sets z = x / y and returns z
+	Div(x, y *T) *T
+	// Exp sets z = xᵏ and returns z
+	Exp(x T, k *big.Int) *T
+
+	// Equal returns z == x
+	Equal(x *T) bool
+	// IsZero returns z == 0
+	IsZero() bool
+	// IsOne returns z == 1
+	IsOne() bool
+
+	// ToBigIntRegular sets res = z (in regular form) and returns res
+	ToBigIntRegular(res *big.Int) *big.Int
+	// Marshal returns the big-endian encoding of z (in regular form), on the field byte size
+	Marshal() []byte
+	// String returns the decimal representation of z (in regular form)
+	String() string
+}
@@ -0,0 +1,71 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package field_test
+
+import (
+	"testing"
+
+	bls12377fp "github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	bls12377fr "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	bls12378fp "github.com/consensys/gnark-crypto/ecc/bls12-378/fp"
+	bls12378fr "github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	bls12381fp "github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	bls12381fr "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	bls24315fp "github.com/consensys/gnark-crypto/ecc/bls24-315/fp"
+	bls24315fr "github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	bls24317fp "github.com/consensys/gnark-crypto/ecc/bls24-317/fp"
+	bls24317fr "github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	bn254fp "github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	bn254fr "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	bw6633fp "github.com/consensys/gnark-crypto/ecc/bw6-633/fp"
+	bw6633fr "github.com/consensys/gnark-crypto/ecc/bw6-633/fr"
+	bw6756fp "github.com/consensys/gnark-crypto/ecc/bw6-756/fp"
+	bw6756fr "github.com/consensys/gnark-crypto/ecc/bw6-756/fr"
+	bw6761fp "github.com/consensys/gnark-crypto/ecc/bw6-761/fp"
+	bw6761fr "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+	"github.com/consensys/gnark-crypto/field"
+	"github.com/consensys/gnark-crypto/field/babybear"
+	"github.com/consensys/gnark-crypto/field/goldilocks"
+	"github.com/consensys/gnark-crypto/field/koalabear"
+	"github.com/consensys/gnark-crypto/field/mersenne31"
+)
+
+// satisfies doesn't compile if *T doesn't satisfy field.Element[T]
+func satisfies[T any, PT field.Element[T]]() {}
+
+func TestElementConstraint(t *testing.T) {
+	satisfies[bls12377fp.Element]()
+	satisfies[bls12377fr.Element]()
+	satisfies[bls12378fp.Element]()
+	satisfies[bls12378fr.Element]()
+	satisfies[bls12381fp.Element]()
+	satisfies[bls12381fr.Element]()
+	satisfies[bls24315fp.Element]()
+	satisfies[bls24315fr.Element]()
+	satisfies[bls24317fp.Element]()
+	satisfies[bls24317fr.Element]()
+	satisfies[bn254fp.Element]()
+	satisfies[bn254fr.Element]()
+	satisfies[bw6633fp.Element]()
+	satisfies[bw6633fr.Element]()
+	satisfies[bw6756fp.Element]()
+	satisfies[bw6756fr.Element]()
+	satisfies[bw6761fp.Element]()
+	satisfies[bw6761fr.Element]()
+	satisfies[babybear.Element]()
+	satisfies[goldilocks.Element]()
+	satisfies[koalabear.Element]()
+	satisfies[mersenne31.Element]()
+}
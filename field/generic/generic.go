@@ -0,0 +1,221 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package generic provides algorithms on vectors and polynomials, written once for all the
+// fields satisfying field.Element (all the fields generated by goff).
+//
+// The type parameters are inferred from the arguments:
+//
+//	var v []fr.Element
+//	// ...
+//	inv := generic.BatchInvert(v)
+package generic
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/field"
+)
+
+var (
+	ErrLengthMismatch     = errors.New("vectors don't have the same length")
+	ErrDuplicateAbscissae = errors.New("interpolation abscissae must be distinct")
+)
+
+// Sum returns ∑ᵢ v[i]
+func Sum[T any, PT field.Element[T]](v []T) T {
+	var res T
+	for i := range v {
+		PT(&res).Add(&res, &v[i])
+	}
+	return res
+}
+
+// InnerProduct returns ∑ᵢ a[i]b[i]
+//
+// a and b must have the same length
+func InnerProduct[T any, PT field.Element[T]](a, b []T) (T, error) {
+	var res, tmp T
+	if len(a) != len(b) {
+		return res, ErrLengthMismatch
+	}
+	for i := range a {
+		PT(&tmp).Mul(&a[i], &b[i])
+		PT(&res).Add(&res, &tmp)
+	}
+	return res, nil
+}
+
+// BatchInvert returns a new slice with every element inverted; zeros are left unchanged.
+// Uses Montgomery batch inversion trick
+func BatchInvert[T any, PT field.Element[T]](a []T) []T {
+	res := make([]T, len(a))
+	if len(a) == 0 {
+		return res
+	}
+
+	zeroes := make([]bool, len(a))
+	var accumulator T
+	PT(&accumulator).SetOne()
+
+	for i := 0; i < len(a); i++ {
+		if PT(&a[i]).IsZero() {
+			zeroes[i] = true
+			continue
+		}
+		res[i] = accumulator
+		PT(&accumulator).Mul(&accumulator, &a[i])
+	}
+
+	PT(&accumulator).Inverse(&accumulator)
+
+	for i := len(a) - 1; i >= 0; i-- {
+		if zeroes[i] {
+			continue
+		}
+		PT(&res[i]).Mul(&res[i], &accumulator)
+		PT(&accumulator).Mul(&accumulator, &a[i])
+	}
+
+	return res
+}
+
+// Eval returns p(x), where p is the polynomial ∑ᵢ p[i]Xⁱ
+func Eval[T any, PT field.Element[T]](p []T, x *T) T {
+	var res T
+	for i := len(p) - 1; i >= 0; i-- {
+		PT(&res).Mul(&res, x)
+		PT(&res).Add(&res, &p[i])
+	}
+	return res
+}
+
+// InterpolateOnRange returns P(x), where P is the polynomial of degree < len(values)
+// such that P(i) = values[i] for i = 0, ..., len(values)-1
+//
+// The abscissae 0, ..., len(values)-1 must be distinct in the field (len(values) ⩽ q).
+func InterpolateOnRange[T any, PT field.Element[T]](values []T, x *T) T {
+	n := len(values)
+	var res T
+	if n == 0 {
+		return res
+	}
+
+	// P(x) = ∑ᵢ values[i] ∏_{j≠i} (x-j)/(i-j)
+	// ∏_{j<i} (x-j) and ∏_{j>i} (x-j) are prefix and suffix products,
+	// ∏_{j≠i} (i-j) = (-1)ⁿ⁻¹⁻ⁱ i! (n-1-i)!
+	var tmp T
+
+	// prefix[i] = ∏_{j<i} (x-j)
+	prefix := make([]T, n)
+	PT(&prefix[0]).SetOne()
+	for i := 1; i < n; i++ {
+		PT(&tmp).SetUint64(uint64(i - 1))
+		PT(&tmp).Sub(x, &tmp)
+		PT(&prefix[i]).Mul(&prefix[i-1], &tmp)
+	}
+
+	// factorials[i] = i!
+	factorials := make([]T, n)
+	PT(&factorials[0]).SetOne()
+	for i := 1; i < n; i++ {
+		PT(&tmp).SetUint64(uint64(i))
+		PT(&factorials[i]).Mul(&factorials[i-1], &tmp)
+	}
+	denominators := make([]T, n)
+	for i := 0; i < n; i++ {
+		PT(&denominators[i]).Mul(&factorials[i], &factorials[n-1-i])
+	}
+	denominators = BatchInvert[T, PT](denominators)
+
+	// suffix = ∏_{j>i} (x-j), updated from i = n-1 down to 0
+	var suffix, term T
+	PT(&suffix).SetOne()
+	for i := n - 1; i >= 0; i-- {
+		PT(&term).Mul(&values[i], &denominators[i])
+		PT(&term).Mul(&term, &prefix[i])
+		PT(&term).Mul(&term, &suffix)
+		if (n-1-i)%2 == 0 {
+			PT(&res).Add(&res, &term)
+		} else {
+			PT(&res).Sub(&res, &term)
+		}
+
+		PT(&tmp).SetUint64(uint64(i))
+		PT(&tmp).Sub(x, &tmp)
+		PT(&suffix).Mul(&suffix, &tmp)
+	}
+
+	return res
+}
+
+// Interpolate returns the coefficients of the polynomial P of degree < len(xs)
+// such that P(xs[i]) = ys[i]
+//
+// The xs must be distinct and have the same length as ys. The cost is quadratic in len(xs).
+func Interpolate[T any, PT field.Element[T]](xs, ys []T) ([]T, error) {
+	if len(xs) != len(ys) {
+		return nil, ErrLengthMismatch
+	}
+	n := len(xs)
+	if n == 0 {
+		return []T{}, nil
+	}
+
+	// M = ∏ⱼ (X-xs[j]), on n+1 coefficients
+	var tmp T
+	m := make([]T, n+1)
+	PT(&m[0]).SetOne()
+	for j := 0; j < n; j++ {
+		// M ← M * (X - xs[j])
+		for k := j + 1; k > 0; k-- {
+			PT(&tmp).Mul(&m[k], &xs[j])
+			PT(&m[k]).Sub(&m[k-1], &tmp)
+		}
+		PT(&m[0]).Mul(&m[0], &xs[j])
+		PT(&m[0]).Neg(&m[0])
+	}
+
+	// Lᵢ = M / (X-xs[i]), and P = ∑ᵢ ys[i] Lᵢ / Lᵢ(xs[i])
+	// the division by (X-xs[i]) is done by synthetic division, and the Lᵢ(xs[i]) are
+	// inverted all at once
+	quotients := make([][]T, n)
+	denominators := make([]T, n)
+	for i := 0; i < n; i++ {
+		q := make([]T, n)
+		q[n-1] = m[n]
+		for k := n - 1; k > 0; k-- {
+			PT(&tmp).Mul(&q[k], &xs[i])
+			PT(&q[k-1]).Add(&m[k], &tmp)
+		}
+		quotients[i] = q
+		denominators[i] = Eval[T, PT](q, &xs[i])
+		if PT(&denominators[i]).IsZero() {
+			return nil, ErrDuplicateAbscissae
+		}
+	}
+	denominators = BatchInvert[T, PT](denominators)
+
+	res := make([]T, n)
+	var c T
+	for i := 0; i < n; i++ {
+		PT(&c).Mul(&ys[i], &denominators[i])
+		for k := 0; k < n; k++ {
+			PT(&tmp).Mul(&quotients[i][k], &c)
+			PT(&res[k]).Add(&res[k], &tmp)
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generic
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/field"
+	"github.com/consensys/gnark-crypto/field/babybear"
+	"github.com/consensys/gnark-crypto/field/goldilocks"
+)
+
+func TestGeneric(t *testing.T) {
+	t.Run("bn254/fr", testGeneric[fr.Element])
+	t.Run("goldilocks", testGeneric[goldilocks.Element])
+	t.Run("babybear", testGeneric[babybear.Element])
+}
+
+func randomVector[T any, PT field.Element[T]](n int) []T {
+	v := make([]T, n)
+	for i := range v {
+		PT(&v[i]).SetRandom()
+	}
+	return v
+}
+
+func testGeneric[T any, PT field.Element[T]](t *testing.T) {
+	const n = 17
+	a := randomVector[T, PT](n)
+	b := randomVector[T, PT](n)
+
+	t.Run("Sum and InnerProduct", func(t *testing.T) {
+		// ∑ᵢ aᵢ(bᵢ+1) = ∑ᵢ aᵢbᵢ + ∑ᵢ aᵢ
+		var one T
+		PT(&one).SetOne()
+		c := make([]T, n)
+		for i := range c {
+			PT(&c[i]).Add(&b[i], &one)
+		}
+		left, err := InnerProduct[T, PT](a, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		right, err := InnerProduct[T, PT](a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := Sum[T, PT](a)
+		PT(&right).Add(&right, &s)
+		if !PT(&left).Equal(&right) {
+			t.Fatal("inner product doesn't match")
+		}
+
+		if _, err := InnerProduct[T, PT](a, b[1:]); err != ErrLengthMismatch {
+			t.Fatal("expected a length mismatch")
+		}
+	})
+
+	t.Run("BatchInvert", func(t *testing.T) {
+		c := append([]T{}, a...)
+		PT(&c[3]).SetZero()
+		inv := BatchInvert[T, PT](c)
+		for i := range c {
+			var expected T
+			PT(&expected).Inverse(&c[i])
+			if !PT(&expected).Equal(&inv[i]) {
+				t.Fatal("batch inversion failed", i)
+			}
+		}
+	})
+
+	t.Run("Interpolate", func(t *testing.T) {
+		p, err := Interpolate[T, PT](a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(p) != n {
+			t.Fatal("wrong number of coefficients")
+		}
+		for i := range a {
+			y := Eval[T, PT](p, &a[i])
+			if !PT(&y).Equal(&b[i]) {
+				t.Fatal("interpolation failed", i)
+			}
+		}
+
+		c := append([]T{}, a...)
+		c[5] = c[2]
+		if _, err := Interpolate[T, PT](c, b); err != ErrDuplicateAbscissae {
+			t.Fatal("expected duplicate abscissae to be rejected")
+		}
+	})
+
+	t.Run("InterpolateOnRange", func(t *testing.T) {
+		xs := make([]T, n)
+		for i := range xs {
+			PT(&xs[i]).SetUint64(uint64(i))
+		}
+		p, err := Interpolate[T, PT](xs, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range a {
+			expected := Eval[T, PT](p, &a[i])
+			y := InterpolateOnRange[T, PT](b, &a[i])
+			if !PT(&y).Equal(&expected) {
+				t.Fatal("interpolation on range failed at random point", i)
+			}
+			// on the range
+			y = InterpolateOnRange[T, PT](b, &xs[i])
+			if !PT(&y).Equal(&b[i]) {
+				t.Fatal("interpolation on range failed on the range", i)
+			}
+		}
+	})
+}
+
+func BenchmarkInnerProduct(b *testing.B) {
+	const n = 1 << 10
+	x := make([]fr.Element, n)
+	y := make([]fr.Element, n)
+	for i := range x {
+		x[i].SetRandom()
+		y[i].SetRandom()
+	}
+
+	b.Run("generic", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, _ = InnerProduct(x, y)
+		}
+	})
+
+	b.Run("fr", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var res, tmp fr.Element
+			for j := range x {
+				tmp.Mul(&x[j], &y[j])
+				res.Add(&res, &tmp)
+			}
+		}
+	})
+}
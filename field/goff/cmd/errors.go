@@ -17,5 +17,7 @@ package cmd
 import "errors"
 
 var (
-	errMissingArgument = errors.New("missing argument")
+	errMissingArgument            = errors.New("missing argument")
+	errNonResidueWithoutExtension = errors.New("a non-residue is given for an extension that is not generated (missing --e2 or --e3)")
+	errPseudoMersenneFlag         = errors.New("--pseudo-mersenne must be off, auto or on")
 )
@@ -36,10 +36,21 @@ var rootCmd = &cobra.Command{
 
 // flags
 var (
-	fModulus     string
-	fOutputDir   string
-	fPackageName string
-	fElementName string
+	fModulus        string
+	fOutputDir      string
+	fPackageName    string
+	fElementName    string
+	fE2             bool
+	fE2NonResidue   int64
+	fE3             bool
+	fE3NonResidue   int64
+	fPseudoMersenne string
+
+	pseudoMersenneModes = map[string]field.PseudoMersenneMode{
+		"off":  field.PseudoMersenneOff,
+		"auto": field.PseudoMersenneAuto,
+		"on":   field.PseudoMersenneOn,
+	}
 )
 
 func init() {
@@ -48,6 +59,11 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&fModulus, "modulus", "m", "", "field modulus (base 10)")
 	rootCmd.PersistentFlags().StringVarP(&fOutputDir, "output", "o", "", "destination path to create output files")
 	rootCmd.PersistentFlags().StringVarP(&fPackageName, "package", "p", "", "package name in generated files")
+	rootCmd.PersistentFlags().BoolVar(&fE2, "e2", false, "also generate the quadratic extension E2 = Element[u]/(u² - α)")
+	rootCmd.PersistentFlags().Int64Var(&fE2NonResidue, "e2-nonresidue", 0, "non-residue α of E2 (if not set, the smallest suitable one is picked)")
+	rootCmd.PersistentFlags().BoolVar(&fE3, "e3", false, "also generate the cubic extension E3 = Element[u]/(u³ - α)")
+	rootCmd.PersistentFlags().Int64Var(&fE3NonResidue, "e3-nonresidue", 0, "non-residue α of E3 (if not set, the smallest suitable one is picked)")
+	rootCmd.PersistentFlags().StringVar(&fPseudoMersenne, "pseudo-mersenne", "off", "reduction by folding of pseudo-Mersenne moduli q = 2ᵏ - c: off, auto (if q is pseudo-Mersenne) or on")
 	if bits.UintSize != 64 {
 		panic("goff only supports 64bits architectures")
 	}
@@ -66,7 +82,7 @@ func cmdGenerate(cmd *cobra.Command, args []string) {
 	}
 
 	// generate code
-	F, err := field.NewFieldConfig(fPackageName, fElementName, fModulus, false)
+	F, err := field.NewFieldConfig(fPackageName, fElementName, fModulus, false, field.WithPseudoMersenne(pseudoMersenneModes[fPseudoMersenne]))
 	if err != nil {
 		fmt.Printf("\n%s\n", err.Error())
 		os.Exit(-1)
@@ -75,6 +91,39 @@ func cmdGenerate(cmd *cobra.Command, args []string) {
 		fmt.Printf("\n%s\n", err.Error())
 		os.Exit(-1)
 	}
+
+	// generate extensions
+	extensions := []struct {
+		enabled    bool
+		degree     int
+		nonResidue *int64
+	}{
+		{fE2, 2, nonResidueFlag(cmd, "e2-nonresidue", fE2NonResidue)},
+		{fE3, 3, nonResidueFlag(cmd, "e3-nonresidue", fE3NonResidue)},
+	}
+	for _, ext := range extensions {
+		if !ext.enabled {
+			continue
+		}
+		E, err := field.NewExtensionConfig(F, ext.degree, ext.nonResidue)
+		if err != nil {
+			fmt.Printf("\n%s\n", err.Error())
+			os.Exit(-1)
+		}
+		fmt.Printf("generating %s with non-residue %d\n", E.Name, E.NonResidue)
+		if err := generator.GenerateExtension(E, fOutputDir); err != nil {
+			fmt.Printf("\n%s\n", err.Error())
+			os.Exit(-1)
+		}
+	}
+}
+
+// nonResidueFlag returns nil if the flag was not set by the user
+func nonResidueFlag(cmd *cobra.Command, name string, value int64) *int64 {
+	if !cmd.Flags().Changed(name) {
+		return nil
+	}
+	return &value
 }
 
 func parseFlags(cmd *cobra.Command) error {
@@ -85,6 +134,15 @@ func parseFlags(cmd *cobra.Command) error {
 		return errMissingArgument
 	}
 
+	if (cmd.Flags().Changed("e2-nonresidue") && !fE2) ||
+		(cmd.Flags().Changed("e3-nonresidue") && !fE3) {
+		return errNonResidueWithoutExtension
+	}
+
+	if _, ok := pseudoMersenneModes[fPseudoMersenne]; !ok {
+		return errPseudoMersenneFlag
+	}
+
 	// clean inputs
 	fOutputDir = filepath.Clean(fOutputDir)
 	fPackageName = strings.ToLower(fPackageName)
@@ -16,9 +16,20 @@
 //
 // Generated code is optimized for x86 (amd64) targets, and most methods do not allocate memory on the heap.
 //
+// Large moduli (1024 to 4096 bits, i.e. 16 to 64 words) are supported: their multiplication uses Karatsuba,
+// and Exp a fixed window.
+//
+// Pseudo-Mersenne moduli q = 2ᵏ - c with c small (e.g. 2²⁵⁵ - 19, or the base field of secp256k1) can be reduced
+// by folding with --pseudo-mersenne auto (or on, which fails if q isn't pseudo-Mersenne): their elements are then
+// not in Montgomery form (ToMont and FromMont do nothing). Default to off.
+//
 // Example usage:
 //		goff -m 0xffffffff00000001 -o ./goldilocks/ -p goldilocks -e Element
 //
+// Quadratic and cubic extensions (E2 = Element[u]/(u² - α) and E3 = Element[u]/(u³ - α)) can be generated
+// alongside the base field; if not provided, the non-residue α is picked automatically:
+//		goff -m 0xffffffff00000001 -o ./goldilocks/ -p goldilocks -e Element --e2 --e3 --e3-nonresidue 7
+//
 // Warning
 //
 // The generated code has not been audited for all moduli (only bn254 and bls12-381) and is provided as-is. In particular, there is no security guarantees such as constant time implementation or side-channel attack resistance.
@@ -0,0 +1,90 @@
+//go:build ctcount
+// +build ctcount
+
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package goldilocks
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// traceCTElement returns the sequence of operations of the constant-time methods performed by f
+func traceCTElement(f func()) []ctOpElement {
+	var trace []ctOpElement
+	ctHook = func(op ctOpElement) { trace = append(trace, op) }
+	defer func() { ctHook = nil }()
+	f()
+	return trace
+}
+
+// TestElementCTOperationSequence is not parallel, as it sets the package level ctHook
+func TestElementCTOperationSequence(t *testing.T) {
+	assert := require.New(t)
+
+	inputs := []Element{{}, One()}
+	var x Element
+	for i := 0; i < 8; i++ {
+		x.SetRandom()
+		inputs = append(inputs, x)
+		x.Square(&x)
+		inputs = append(inputs, x)
+	}
+	x.SetOne()
+	x.Neg(&x)
+	inputs = append(inputs, x)
+
+	// exponents of same bit length (at most Bits) must yield the same sequence of operations
+	exponents := []*big.Int{big.NewInt(0), big.NewInt(1), new(big.Int).Sub(Modulus(), big.NewInt(1))}
+	for i := 0; i < 4; i++ {
+		x.SetRandom()
+		exponents = append(exponents, x.ToBigIntRegular(new(big.Int)))
+	}
+
+	var z Element
+	traceInverse := traceCTElement(func() { z.InverseCT(&inputs[0]) })
+	traceExp := traceCTElement(func() { z.ExpCT(inputs[0], exponents[0]) })
+	traceSqrt := traceCTElement(func() { z.SqrtCT(&inputs[0]) })
+	traceArith := traceCTElement(func() { arithmeticCTElement(&z, &inputs[0], &inputs[1]) })
+	assert.Contains(traceExp, ctSwapElement, "the masked operations should be recorded")
+
+	for i := range inputs {
+		a := inputs[i]
+		assert.Equal(traceInverse, traceCTElement(func() { z.InverseCT(&a) }), "InverseCT")
+		assert.Equal(traceSqrt, traceCTElement(func() { z.SqrtCT(&a) }), "SqrtCT")
+		for _, k := range exponents {
+			assert.Equal(traceExp, traceCTElement(func() { z.ExpCT(a, k) }), "ExpCT")
+		}
+		for j := range inputs {
+			b := inputs[j]
+			assert.Equal(traceArith, traceCTElement(func() { arithmeticCTElement(&z, &a, &b) }), "arithmetic")
+		}
+	}
+}
+
+// arithmeticCTElement runs all the constant-time arithmetic operations on a and b
+func arithmeticCTElement(z, a, b *Element) {
+	z.AddCT(a, b)
+	z.SubCT(a, b)
+	z.DoubleCT(a)
+	z.NegCT(a)
+	z.MulCT(a, b)
+	z.SquareCT(a)
+}
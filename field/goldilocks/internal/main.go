@@ -17,5 +17,21 @@ func main() {
 	if err := generator.GenerateFF(goldilocks, "../"); err != nil {
 		panic(err)
 	}
+
+	// quadratic and cubic extensions, with the same non-residues as plonky2
+	// (u² = 7 and u³ = 3) so that challenges sampled in E2 or E3 are interoperable
+	for _, ext := range []struct {
+		degree     int
+		nonResidue int64
+	}{{2, 7}, {3, 3}} {
+		nonResidue := ext.nonResidue
+		E, err := field.NewExtensionConfig(goldilocks, ext.degree, &nonResidue)
+		if err != nil {
+			panic(err)
+		}
+		if err := generator.GenerateExtension(E, "../"); err != nil {
+			panic(err)
+		}
+	}
 	fmt.Println("successfully generated goldilocks field")
 }
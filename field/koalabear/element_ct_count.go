@@ -0,0 +1,30 @@
+//go:build ctcount
+// +build ctcount
+
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package koalabear
+
+// ctHook is called by each operation of the constant-time methods if set; the tests use it to
+// check that their sequence of operations is the same whatever their inputs are.
+var ctHook func(op ctOpElement)
+
+func onCT(op ctOpElement) {
+	if ctHook != nil {
+		ctHook(op)
+	}
+}
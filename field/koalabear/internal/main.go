@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/internal/field"
+	"github.com/consensys/gnark-crypto/internal/field/generator"
+)
+
+//go:generate go run main.go
+func main() {
+	const modulus = "0x7f000001" // 2³¹ - 2²⁴ + 1
+	koalabear, err := field.NewFieldConfig("koalabear", "Element", modulus, false)
+	if err != nil {
+		panic(err)
+	}
+	if err := generator.GenerateFF(koalabear, "../"); err != nil {
+		panic(err)
+	}
+
+	// quadratic extension; q ≡ 2 (mod 3), so there is no cubic extension of the form u³ - α
+	E, err := field.NewExtensionConfig(koalabear, 2, nil)
+	if err != nil {
+		panic(err)
+	}
+	if err := generator.GenerateExtension(E, "../"); err != nil {
+		panic(err)
+	}
+	fmt.Println("successfully generated koalabear field")
+}
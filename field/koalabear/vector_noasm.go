@@ -0,0 +1,130 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package koalabear
+
+import (
+	"math/bits"
+)
+
+// Add adds two vectors element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector *Vector) Add(a, b Vector) {
+	if len(a) != len(b) || len(a) != len(*vector) {
+		panic("vector.Add: vectors don't have the same length")
+	}
+	res := *vector
+	n := len(a) - len(a)%4
+	for i := 0; i < n; i += 4 {
+		res[i][0] = reduceSigned(a[i][0] + b[i][0] - q)
+		res[i+1][0] = reduceSigned(a[i+1][0] + b[i+1][0] - q)
+		res[i+2][0] = reduceSigned(a[i+2][0] + b[i+2][0] - q)
+		res[i+3][0] = reduceSigned(a[i+3][0] + b[i+3][0] - q)
+	}
+	for i := n; i < len(a); i++ {
+		res[i][0] = reduceSigned(a[i][0] + b[i][0] - q)
+	}
+}
+
+// Sub subtracts two vectors element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector *Vector) Sub(a, b Vector) {
+	if len(a) != len(b) || len(a) != len(*vector) {
+		panic("vector.Sub: vectors don't have the same length")
+	}
+	res := *vector
+	n := len(a) - len(a)%4
+	for i := 0; i < n; i += 4 {
+		res[i][0] = reduceSigned(a[i][0] - b[i][0])
+		res[i+1][0] = reduceSigned(a[i+1][0] - b[i+1][0])
+		res[i+2][0] = reduceSigned(a[i+2][0] - b[i+2][0])
+		res[i+3][0] = reduceSigned(a[i+3][0] - b[i+3][0])
+	}
+	for i := n; i < len(a); i++ {
+		res[i][0] = reduceSigned(a[i][0] - b[i][0])
+	}
+}
+
+// Mul multiplies two vectors element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector *Vector) Mul(a, b Vector) {
+	if len(a) != len(b) || len(a) != len(*vector) {
+		panic("vector.Mul: vectors don't have the same length")
+	}
+	res := *vector
+	n := len(a) - len(a)%4
+	for i := 0; i < n; i += 4 {
+		res[i][0] = montReduce(uint64(a[i][0]) * uint64(b[i][0]))
+		res[i+1][0] = montReduce(uint64(a[i+1][0]) * uint64(b[i+1][0]))
+		res[i+2][0] = montReduce(uint64(a[i+2][0]) * uint64(b[i+2][0]))
+		res[i+3][0] = montReduce(uint64(a[i+3][0]) * uint64(b[i+3][0]))
+	}
+	for i := n; i < len(a); i++ {
+		res[i][0] = montReduce(uint64(a[i][0]) * uint64(b[i][0]))
+	}
+}
+
+// ScalarMul multiplies a vector by a scalar element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector *Vector) ScalarMul(a Vector, b *Element) {
+	if len(a) != len(*vector) {
+		panic("vector.ScalarMul: vectors don't have the same length")
+	}
+	res := *vector
+	s := uint64(b[0])
+	n := len(a) - len(a)%4
+	for i := 0; i < n; i += 4 {
+		res[i][0] = montReduce(uint64(a[i][0]) * s)
+		res[i+1][0] = montReduce(uint64(a[i+1][0]) * s)
+		res[i+2][0] = montReduce(uint64(a[i+2][0]) * s)
+		res[i+3][0] = montReduce(uint64(a[i+3][0]) * s)
+	}
+	for i := n; i < len(a); i++ {
+		res[i][0] = montReduce(uint64(a[i][0]) * s)
+	}
+}
+
+// Sum computes the sum of all elements in the vector.
+func (vector *Vector) Sum() (res Element) {
+	// the Montgomery form is linear: we sum the words of the elements (< 2³¹) on 64 bits,
+	// which can't overflow for less than 2³³ elements, and reduce once.
+	var acc uint64
+	for i := 0; i < len(*vector); i++ {
+		acc += uint64((*vector)[i][0])
+	}
+	res[0] = uint32(acc % uint64(q))
+	return
+}
+
+// InnerProduct computes the inner product of two vectors.
+// It panics if the vectors don't have the same length.
+func (vector *Vector) InnerProduct(other Vector) (res Element) {
+	if len(*vector) != len(other) {
+		panic("vector.InnerProduct: vectors don't have the same length")
+	}
+	a := *vector
+
+	// the products (< 2⁶²) are accumulated on 128 bits, and reduced once:
+	// acc mod q = Σ aᵢ * bᵢ * R² (mod q), and montReduce divides it by R
+	var hi, lo, carry uint64
+	for i := 0; i < len(a); i++ {
+		lo, carry = bits.Add64(lo, uint64(a[i][0])*uint64(other[i][0]), 0)
+		hi += carry
+	}
+	_, r := bits.Div64(hi%uint64(q), lo, uint64(q))
+	res[0] = montReduce(r)
+	return
+}
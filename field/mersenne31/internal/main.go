@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/internal/field"
+	"github.com/consensys/gnark-crypto/internal/field/generator"
+)
+
+//go:generate go run main.go
+func main() {
+	const modulus = "0x7fffffff" // 2³¹ - 1
+	mersenne31, err := field.NewFieldConfig("mersenne31", "Element", modulus, false)
+	if err != nil {
+		panic(err)
+	}
+	if err := generator.GenerateFF(mersenne31, "../"); err != nil {
+		panic(err)
+	}
+
+	// quadratic extension u² = -1 (the "complex" extension, -1 is a non-residue as q ≡ 3 (mod 4))
+	// and cubic extension with the smallest suitable non-residue
+	minusOne := int64(-1)
+	for _, ext := range []struct {
+		degree     int
+		nonResidue *int64
+	}{{2, &minusOne}, {3, nil}} {
+		E, err := field.NewExtensionConfig(mersenne31, ext.degree, ext.nonResidue)
+		if err != nil {
+			panic(err)
+		}
+		if err := generator.GenerateExtension(E, "../"); err != nil {
+			panic(err)
+		}
+	}
+	fmt.Println("successfully generated mersenne31 field")
+}
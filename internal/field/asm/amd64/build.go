@@ -158,6 +158,16 @@ func Generate(w io.Writer, F *field.FieldConfig) error {
 	// fft butterflies
 	f.generateButterfly()
 
+	// vector ops
+	if F.ASMVector {
+		f.generateAddVec()
+		f.generateSubVec()
+		f.generateSumVec()
+		f.generateMulVec(false)
+		f.generateMulVec(true)
+		f.generateInnerProdVec()
+	}
+
 	return nil
 }
 
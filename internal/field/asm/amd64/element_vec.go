@@ -0,0 +1,377 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package amd64
+
+import (
+	"fmt"
+
+	"github.com/consensys/bavard/amd64"
+)
+
+// addVec res = a + b
+// func addVec(res, a, b *{{.ElementName}}, n uint64) {
+// 	for i := 0; i < n; i++ {
+// 		res[i].Add(&a[i], &b[i])
+// 	}
+// }
+func (f *FFAmd64) generateAddVec() {
+	f.Comment("addVec(res, a, b *Element, n uint64) res[0...n] = a[0...n] + b[0...n]")
+
+	nbRegisters := f.NbWords*2 + 4
+	stackSize := f.StackSize(nbRegisters, 0, 0)
+	registers := f.FnHeader("addVec", stackSize, 32)
+	defer f.AssertCleanStack(stackSize, 0)
+
+	// registers
+	addrA := f.Pop(&registers)
+	addrB := f.Pop(&registers)
+	addrRes := f.Pop(&registers)
+	n := f.Pop(&registers)
+
+	a := f.PopN(&registers)
+	t := f.PopN(&registers)
+
+	loop := f.NewLabel()
+	done := f.NewLabel()
+
+	f.MOVQ("res+0(FP)", addrRes)
+	f.MOVQ("a+8(FP)", addrA)
+	f.MOVQ("b+16(FP)", addrB)
+	f.MOVQ("n+24(FP)", n)
+
+	f.LABEL(loop)
+
+	f.TESTQ(n, n)
+	f.JEQ(done)
+
+	// a = a + b
+	f.Mov(addrA, a)
+	f.Add(addrB, a)
+
+	// reduce a
+	f.ReduceElement(a, t)
+
+	// save a into res
+	f.Mov(a, addrRes)
+
+	f.Comment("increment pointers to visit next element")
+	f.ADDQ(fmt.Sprintf("$%d", f.NbWords*8), addrA)
+	f.ADDQ(fmt.Sprintf("$%d", f.NbWords*8), addrB)
+	f.ADDQ(fmt.Sprintf("$%d", f.NbWords*8), addrRes)
+	f.DECQ(n)
+	f.JMP(loop)
+
+	f.LABEL(done)
+
+	f.RET()
+
+	f.Push(&registers, a...)
+	f.Push(&registers, t...)
+	f.Push(&registers, addrA, addrB, addrRes, n)
+}
+
+// subVec res = a - b
+// func subVec(res, a, b *{{.ElementName}}, n uint64) {
+// 	for i := 0; i < n; i++ {
+// 		res[i].Sub(&a[i], &b[i])
+// 	}
+// }
+func (f *FFAmd64) generateSubVec() {
+	f.Comment("subVec(res, a, b *Element, n uint64) res[0...n] = a[0...n] - b[0...n]")
+
+	nbRegisters := f.NbWords + 4
+	stackSize := f.StackSize(nbRegisters, 0, 0)
+	registers := f.FnHeader("subVec", stackSize, 32)
+	defer f.AssertCleanStack(stackSize, 0)
+
+	// registers
+	addrA := f.Pop(&registers)
+	addrB := f.Pop(&registers)
+	addrRes := f.Pop(&registers)
+	n := f.Pop(&registers)
+
+	a := f.PopN(&registers)
+
+	loop := f.NewLabel()
+	done := f.NewLabel()
+
+	f.MOVQ("res+0(FP)", addrRes)
+	f.MOVQ("a+8(FP)", addrA)
+	f.MOVQ("b+16(FP)", addrB)
+	f.MOVQ("n+24(FP)", n)
+
+	f.LABEL(loop)
+
+	f.TESTQ(n, n)
+	f.JEQ(done)
+
+	// a = a - b
+	f.Mov(addrA, a)
+	f.Sub(addrB, a)
+
+	// if we borrowed, we add q back
+	noReduce := f.NewLabel()
+	f.JCC(noReduce)
+	for i := 0; i < f.NbWords; i++ {
+		if i == 0 {
+			f.ADDQ(f.qAt(i), a[i])
+		} else {
+			f.ADCQ(f.qAt(i), a[i])
+		}
+	}
+	f.LABEL(noReduce)
+
+	// save a into res
+	f.Mov(a, addrRes)
+
+	f.Comment("increment pointers to visit next element")
+	f.ADDQ(fmt.Sprintf("$%d", f.NbWords*8), addrA)
+	f.ADDQ(fmt.Sprintf("$%d", f.NbWords*8), addrB)
+	f.ADDQ(fmt.Sprintf("$%d", f.NbWords*8), addrRes)
+	f.DECQ(n)
+	f.JMP(loop)
+
+	f.LABEL(done)
+
+	f.RET()
+
+	f.Push(&registers, a...)
+	f.Push(&registers, addrA, addrB, addrRes, n)
+}
+
+// mulVec res = a * b
+// func mulVec(res, a, b *{{.ElementName}}, n uint64) {
+// 	for i := 0; i < n; i++ {
+// 		res[i].Mul(&a[i], &b[i])
+// 	}
+// }
+//
+// scalarMulVec res = a * b
+// func scalarMulVec(res, a, b *{{.ElementName}}, n uint64) {
+// 	for i := 0; i < n; i++ {
+// 		res[i].Mul(&a[i], b)
+// 	}
+// }
+//
+// the caller must check that the ADX instructions are supported.
+func (f *FFAmd64) generateMulVec(scalarMul bool) {
+	funcName := "mulVec"
+	if scalarMul {
+		funcName = "scalarMulVec"
+		f.Comment("scalarMulVec(res, a, b *Element, n uint64) res[0...n] = a[0...n] * b")
+	} else {
+		f.Comment("mulVec(res, a, b *Element, n uint64) res[0...n] = a[0...n] * b[0...n]")
+	}
+
+	reserved := []amd64.Register{amd64.DX, amd64.AX}
+	if f.NbWords <= 5 {
+		// see generateMul
+		reserved = append(reserved, amd64.R15)
+	}
+	// MulADX uses BP: a frame is needed for the assembler to save it
+	const minStackSize = 8
+	nbRegisters := f.NbWords*2 + 4
+	stackSize := f.StackSize(nbRegisters, len(reserved), minStackSize)
+	registers := f.FnHeader(funcName, stackSize, 32, reserved...)
+	defer f.AssertCleanStack(stackSize, minStackSize)
+
+	// registers
+	addrA := f.Pop(&registers)
+	addrB := f.Pop(&registers)
+	addrRes := f.Pop(&registers)
+	n := f.Pop(&registers)
+
+	// the product is computed in registers, the scratch of the reduction can be on the stack
+	t := registers.PopN(f.NbWords)
+
+	loop := f.NewLabel()
+	done := f.NewLabel()
+
+	f.MOVQ("res+0(FP)", addrRes)
+	f.MOVQ("a+8(FP)", addrA)
+	f.MOVQ("b+16(FP)", addrB)
+	f.MOVQ("n+24(FP)", n)
+
+	f.LABEL(loop)
+
+	f.TESTQ(n, n)
+	f.JEQ(done)
+
+	// t = a * b
+	f.MulADX(&registers, func(i int) string { return addrA.At(i) }, func(i int) string { return addrB.At(i) }, t)
+	f.Reduce(&registers, t)
+
+	// save t into res
+	f.Mov(t, addrRes)
+
+	f.Comment("increment pointers to visit next element")
+	f.ADDQ(fmt.Sprintf("$%d", f.NbWords*8), addrA)
+	if !scalarMul {
+		f.ADDQ(fmt.Sprintf("$%d", f.NbWords*8), addrB)
+	}
+	f.ADDQ(fmt.Sprintf("$%d", f.NbWords*8), addrRes)
+	f.DECQ(n)
+	f.JMP(loop)
+
+	f.LABEL(done)
+
+	f.RET()
+
+	f.Push(&registers, t...)
+	f.Push(&registers, addrA, addrB, addrRes, n)
+}
+
+// sumVec res = a[0] + a[1] + ... + a[n-1]
+// func sumVec(res, a *{{.ElementName}}, n uint64) {
+// 	for i := 0; i < n; i++ {
+// 		res.Add(res, &a[i])
+// 	}
+// }
+func (f *FFAmd64) generateSumVec() {
+	f.Comment("sumVec(res, a *Element, n uint64) res = a[0] + ... + a[n-1]")
+
+	nbRegisters := f.NbWords*2 + 2
+	stackSize := f.StackSize(nbRegisters, 0, 0)
+	registers := f.FnHeader("sumVec", stackSize, 24)
+	defer f.AssertCleanStack(stackSize, 0)
+
+	// registers
+	addrA := f.Pop(&registers)
+	n := f.Pop(&registers)
+
+	acc := f.PopN(&registers)
+	t := f.PopN(&registers)
+
+	loop := f.NewLabel()
+	done := f.NewLabel()
+
+	f.MOVQ("a+8(FP)", addrA)
+	f.MOVQ("n+16(FP)", n)
+
+	f.Comment("acc = 0")
+	for i := 0; i < f.NbWords; i++ {
+		f.XORQ(acc[i], acc[i])
+	}
+
+	f.LABEL(loop)
+
+	f.TESTQ(n, n)
+	f.JEQ(done)
+
+	// acc = acc + a
+	f.Add(addrA, acc)
+
+	// reduce acc
+	f.ReduceElement(acc, t)
+
+	f.Comment("increment pointer to visit next element")
+	f.ADDQ(fmt.Sprintf("$%d", f.NbWords*8), addrA)
+	f.DECQ(n)
+	f.JMP(loop)
+
+	f.LABEL(done)
+
+	// save acc into res
+	f.MOVQ("res+0(FP)", addrA)
+	f.Mov(acc, addrA)
+
+	f.RET()
+
+	f.Push(&registers, acc...)
+	f.Push(&registers, t...)
+	f.Push(&registers, addrA, n)
+}
+
+// innerProdVec res = a[0] * b[0] + a[1] * b[1] + ... + a[n-1] * b[n-1]
+// func innerProdVec(res, a, b *{{.ElementName}}, n uint64) {
+// 	var tmp {{.ElementName}}
+// 	for i := 0; i < n; i++ {
+// 		tmp.Mul(&a[i], &b[i])
+// 		res.Add(res, &tmp)
+// 	}
+// }
+//
+// the caller must check that the ADX instructions are supported.
+func (f *FFAmd64) generateInnerProdVec() {
+	f.Comment("innerProdVec(res, a, b *Element, n uint64) res = a[0] * b[0] + ... + a[n-1] * b[n-1]")
+
+	reserved := []amd64.Register{amd64.DX, amd64.AX}
+	if f.NbWords <= 5 {
+		// see generateMul
+		reserved = append(reserved, amd64.R15)
+	}
+	// MulADX uses BP: a frame is needed for the assembler to save it
+	const minStackSize = 8
+	nbRegisters := f.NbWords*3 + 3
+	stackSize := f.StackSize(nbRegisters, len(reserved), minStackSize)
+	registers := f.FnHeader("innerProdVec", stackSize, 32, reserved...)
+	defer f.AssertCleanStack(stackSize, minStackSize)
+
+	// registers
+	addrA := f.Pop(&registers)
+	addrB := f.Pop(&registers)
+	n := f.Pop(&registers)
+
+	// the product is computed in registers, the accumulator and the scratch of the reduction
+	// can be on the stack
+	t := registers.PopN(f.NbWords)
+	acc := f.PopN(&registers)
+
+	loop := f.NewLabel()
+	done := f.NewLabel()
+
+	f.MOVQ("a+8(FP)", addrA)
+	f.MOVQ("b+16(FP)", addrB)
+	f.MOVQ("n+24(FP)", n)
+
+	f.Comment("acc = 0")
+	f.XORQ(amd64.AX, amd64.AX)
+	for i := 0; i < f.NbWords; i++ {
+		f.MOVQ(amd64.AX, acc[i])
+	}
+
+	f.LABEL(loop)
+
+	f.TESTQ(n, n)
+	f.JEQ(done)
+
+	// t = a * b
+	f.MulADX(&registers, func(i int) string { return addrA.At(i) }, func(i int) string { return addrB.At(i) }, t)
+	f.Reduce(&registers, t)
+
+	// acc = acc + t
+	f.Add(acc, t)
+	f.Reduce(&registers, t)
+	f.Mov(t, acc)
+
+	f.Comment("increment pointers to visit next element")
+	f.ADDQ(fmt.Sprintf("$%d", f.NbWords*8), addrA)
+	f.ADDQ(fmt.Sprintf("$%d", f.NbWords*8), addrB)
+	f.DECQ(n)
+	f.JMP(loop)
+
+	f.LABEL(done)
+
+	// save acc into res
+	f.Mov(acc, t)
+	f.MOVQ("res+0(FP)", addrA)
+	f.Mov(t, addrA)
+
+	f.RET()
+
+	f.Push(&registers, acc...)
+	f.Push(&registers, t...)
+	f.Push(&registers, addrA, addrB, n)
+}
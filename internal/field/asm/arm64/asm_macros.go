@@ -0,0 +1,102 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arm64
+
+import (
+	"fmt"
+
+	"github.com/consensys/bavard/arm64"
+)
+
+// stp stores the register pair (x, y) at address
+//
+// note: arm64.STP writes the operands in the wrong order (address first)
+func (f *FFArm64) stp(x, y arm64.Register, address string) {
+	f.WriteLn(fmt.Sprintf("    STP (%s, %s), %s", x.Name(), y.Name(), address))
+}
+
+// load z from the address in zPtr, two words at a time
+func (f *FFArm64) load(zPtr arm64.Register, z []arm64.Register) {
+	for i := 0; i < f.NbWords-1; i += 2 {
+		f.LDP(zPtr.At(i), z[i], z[i+1])
+	}
+	if f.NbWords%2 == 1 {
+		f.MOVD(zPtr.At(f.NbWordsLastIndex), z[f.NbWordsLastIndex])
+	}
+}
+
+// store z at the address in zPtr, two words at a time
+func (f *FFArm64) store(z []arm64.Register, zPtr arm64.Register) {
+	for i := 0; i < f.NbWords-1; i += 2 {
+		f.stp(z[i], z[i+1], zPtr.At(i))
+	}
+	if f.NbWords%2 == 1 {
+		f.MOVD(z[f.NbWordsLastIndex], zPtr.At(f.NbWordsLastIndex))
+	}
+}
+
+// loadQ loads the modulus in q
+func (f *FFArm64) loadQ(q []arm64.Register) {
+	for i := 0; i < f.NbWords-1; i += 2 {
+		f.LDP(f.qAt(i), q[i], q[i+1])
+	}
+	if f.NbWords%2 == 1 {
+		f.MOVD(f.qAt(f.NbWordsLastIndex), q[f.NbWordsLastIndex])
+	}
+}
+
+// reduceAndStore sets t = t mod q (t must be smaller than 2q) and stores it in zPtr
+//
+// q must contain the modulus, and is overwritten
+func (f *FFArm64) reduceAndStore(t, q []arm64.Register, zPtr arm64.Register) {
+	f.Comment("q = t - q")
+	f.SUBS(q[0], t[0], q[0])
+	for i := 1; i < f.NbWords; i++ {
+		f.SBCS(q[i], t[i], q[i])
+	}
+
+	f.Comment("if no borrow, return q, else return t")
+	for i := 0; i < f.NbWords; i++ {
+		f.CSEL("CS", q[i], t[i], t[i])
+	}
+	f.store(t, zPtr)
+}
+
+// addQIfBorrow adds q to t if the carry flag is clear (i.e. the previous
+// subtraction borrowed); q is overwritten
+func (f *FFArm64) addQIfBorrow(t, q []arm64.Register) {
+	f.Comment("add q if underflow, 0 if not")
+	for i := 0; i < f.NbWords; i++ {
+		f.CSEL("CS", "ZR", q[i], q[i])
+	}
+	f.add(q, t, t)
+}
+
+// add sets z = x + y, ignoring the last carry
+func (f *FFArm64) add(x, y, z []arm64.Register) {
+	f.ADDS(x[0], y[0], z[0])
+	for i := 1; i < f.NbWordsLastIndex; i++ {
+		f.ADCS(x[i], y[i], z[i])
+	}
+	f.ADC(x[f.NbWordsLastIndex], y[f.NbWordsLastIndex], z[f.NbWordsLastIndex])
+}
+
+// sub sets z = x - y and the carry flag if it didn't borrow
+func (f *FFArm64) sub(x, y, z []arm64.Register) {
+	f.SUBS(y[0], x[0], z[0])
+	for i := 1; i < f.NbWords; i++ {
+		f.SBCS(y[i], x[i], z[i])
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package arm64 contains syntactic sugar to generate arm64 assembly code
+package arm64
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/consensys/bavard"
+
+	"github.com/consensys/bavard/arm64"
+	"github.com/consensys/gnark-crypto/internal/field"
+)
+
+func NewFFArm64(w io.Writer, F *field.FieldConfig) *FFArm64 {
+	return &FFArm64{F, arm64.NewArm64(w)}
+}
+
+type FFArm64 struct {
+	*field.FieldConfig
+	*arm64.Arm64
+}
+
+// FnHeader writes the function header and returns the available registers.
+//
+// On top of R18 (platform register), we don't use R26 (closure context) and
+// R27 (REGTMP, used by the assembler to load the globals such as q<>)
+func (f *FFArm64) FnHeader(funcName string, stackSize, argSize int) arm64.Registers {
+	return f.Arm64.FnHeader(funcName, stackSize, argSize, arm64.Register(26), arm64.Register(27))
+}
+
+func (f *FFArm64) qAt(index int) string {
+	return fmt.Sprintf("q<>+%d(SB)", index*8)
+}
+
+func (f *FFArm64) qInv0() string {
+	return "qInv0<>(SB)"
+}
+
+// Generate generates assembly code for the base field provided to goff
+// see internal/templates/ops*
+func Generate(w io.Writer, F *field.FieldConfig) error {
+	f := NewFFArm64(w, F)
+	f.WriteLn(bavard.Apache2Header("ConsenSys Software Inc.", 2020))
+
+	f.WriteLn("#include \"textflag.h\"")
+	f.WriteLn("#include \"funcdata.h\"")
+	f.WriteLn("")
+
+	f.generateDefines()
+
+	// add, sub
+	f.generateAdd()
+	f.generateSub()
+
+	// mul
+	f.generateMul()
+
+	// fft butterflies
+	f.generateButterfly()
+
+	return nil
+}
+
+func (f *FFArm64) generateDefines() {
+	f.Comment("modulus q")
+	for i, w := range f.Q {
+		f.WriteLn(fmt.Sprintf("DATA %s/8, $%#016x", f.qAt(i), w))
+	}
+	f.WriteLn(fmt.Sprintf("GLOBL q<>(SB), (RODATA+NOPTR), $%d", 8*f.NbWords))
+	f.WriteLn("")
+	f.Comment("qInv0 q'[0]")
+	f.WriteLn(fmt.Sprintf("DATA %s/8, $%#016x", f.qInv0(), f.QInverse[0]))
+	f.WriteLn("GLOBL qInv0<>(SB), (RODATA+NOPTR), $8")
+	f.WriteLn("")
+}
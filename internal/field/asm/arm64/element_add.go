@@ -0,0 +1,68 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arm64
+
+// add(res, x, y *Element) sets res = x + y (mod q)
+func (f *FFArm64) generateAdd() {
+	f.Comment("add(res, x, y *Element)")
+	registers := f.FnHeader("add", 0, 24)
+
+	// registers
+	ptr := registers.Pop()
+	x := registers.PopN(f.NbWords)
+	y := registers.PopN(f.NbWords)
+
+	f.MOVD("x+8(FP)", ptr)
+	f.load(ptr, x)
+	f.MOVD("y+16(FP)", ptr)
+	f.load(ptr, y)
+
+	// x + y < 2q, it fits on NbWords words since the modulus has a spare bit
+	f.add(x, y, x)
+
+	f.loadQ(y)
+	f.MOVD("res+0(FP)", ptr)
+	f.reduceAndStore(x, y, ptr)
+
+	f.RET()
+	f.WriteLn("")
+}
+
+// sub(res, x, y *Element) sets res = x - y (mod q)
+func (f *FFArm64) generateSub() {
+	f.Comment("sub(res, x, y *Element)")
+	registers := f.FnHeader("sub", 0, 24)
+
+	// registers
+	ptr := registers.Pop()
+	x := registers.PopN(f.NbWords)
+	y := registers.PopN(f.NbWords)
+
+	f.MOVD("x+8(FP)", ptr)
+	f.load(ptr, x)
+	f.MOVD("y+16(FP)", ptr)
+	f.load(ptr, y)
+
+	f.sub(x, y, x)
+
+	f.loadQ(y)
+	f.addQIfBorrow(x, y)
+
+	f.MOVD("res+0(FP)", ptr)
+	f.store(x, ptr)
+
+	f.RET()
+	f.WriteLn("")
+}
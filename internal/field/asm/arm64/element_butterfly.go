@@ -0,0 +1,57 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arm64
+
+// Butterfly sets
+// a = a + b
+// b = a - b
+// func Butterfly(a, b *{{.ElementName}}) {
+// 	t := *a
+// 	a.Add(a, b)
+// 	b.Sub(&t, b)
+// }
+func (f *FFArm64) generateButterfly() {
+	f.Comment("Butterfly(a, b *Element) sets a = a + b; b = a - b")
+	registers := f.FnHeader("Butterfly", 0, 16)
+
+	// registers
+	aPtr := registers.Pop()
+	bPtr := registers.Pop()
+	a := registers.PopN(f.NbWords)
+	b := registers.PopN(f.NbWords)
+	r := registers.PopN(f.NbWords)
+
+	f.MOVD("a+0(FP)", aPtr)
+	f.load(aPtr, a)
+	f.MOVD("b+8(FP)", bPtr)
+	f.load(bPtr, b)
+
+	// r = a + b
+	f.add(a, b, r)
+
+	// b = a - b
+	f.sub(a, b, b)
+
+	// a is not needed anymore, we use it to store q
+	f.loadQ(a)
+	f.addQIfBorrow(b, a)
+	f.store(b, bPtr)
+
+	f.loadQ(a)
+	f.reduceAndStore(r, a, aPtr)
+
+	f.RET()
+	f.WriteLn("")
+}
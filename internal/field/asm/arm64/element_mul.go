@@ -0,0 +1,121 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arm64
+
+// mul(res, x, y *Element) sets res = x * y (mod q)
+//
+// This is the same CIOS algorithm as the generic implementation (see Element.Mul),
+// interleaving the multiplication by a word of y with the Montgomery reduction step.
+// The low and high words of the products are accumulated in two separate carry chains.
+func (f *FFArm64) generateMul() {
+	f.Comment("mul(res, x, y *Element)")
+	registers := f.FnHeader("mul", 0, 24)
+
+	// registers
+	xPtr := registers.Pop()
+	yPtr := registers.Pop()
+	bi := registers.Pop()
+	qInv0 := registers.Pop()
+	m := registers.Pop()
+	a := registers.PopN(f.NbWords)
+	q := registers.PopN(f.NbWords)
+	t := registers.PopN(f.NbWords + 1)
+
+	// once x is loaded, xPtr is used as scratch register
+	ax := xPtr
+
+	f.MOVD("x+8(FP)", xPtr)
+	f.load(xPtr, a)
+	f.MOVD("y+16(FP)", yPtr)
+	f.loadQ(q)
+	f.MOVD(f.qInv0(), qInv0)
+
+	for i := 0; i < f.NbWords; i++ {
+		f.MOVD(yPtr.At(i), bi)
+		f.Comment("(C,t[j])  := t[j] + x[j]*y[i] + C")
+		if i == 0 {
+			// t is zero, we set its low bits directly
+			for j := 0; j < f.NbWords; j++ {
+				f.MUL(a[j], bi, t[j])
+			}
+			f.UMULH(a[0], bi, ax)
+			f.ADDS(ax, t[1], t[1])
+			for j := 1; j < f.NbWordsLastIndex; j++ {
+				f.UMULH(a[j], bi, ax)
+				f.ADCS(ax, t[j+1], t[j+1])
+			}
+			f.UMULH(a[f.NbWordsLastIndex], bi, ax)
+			f.ADC(ax, "ZR", t[f.NbWords])
+		} else {
+			// low bits
+			for j := 0; j < f.NbWords; j++ {
+				f.MUL(a[j], bi, ax)
+				f.addm(j)(ax, t[j], t[j])
+			}
+			f.ADC("ZR", "ZR", t[f.NbWords])
+
+			// high bits
+			for j := 0; j < f.NbWords; j++ {
+				f.UMULH(a[j], bi, ax)
+				if j == 0 {
+					f.ADDS(ax, t[1], t[1])
+				} else if j == f.NbWordsLastIndex {
+					f.ADC(ax, t[j+1], t[j+1])
+				} else {
+					f.ADCS(ax, t[j+1], t[j+1])
+				}
+			}
+		}
+
+		f.Comment("m := t[0]*q'[0] mod W")
+		f.MUL(t[0], qInv0, m)
+
+		f.Comment("(C,t[j-1]) := t[j] + m*q[j] + C")
+		// low bits; t[0] + m*q[0] == 0 mod W, we only need the carry
+		for j := 0; j < f.NbWords; j++ {
+			f.MUL(q[j], m, ax)
+			f.addm(j)(ax, t[j], t[j])
+		}
+		f.ADC("ZR", t[f.NbWords], t[f.NbWords])
+
+		// high bits, shifting t by one word
+		for j := 0; j < f.NbWords; j++ {
+			f.UMULH(q[j], m, ax)
+			if j == 0 {
+				f.ADDS(ax, t[1], t[0])
+			} else if j == f.NbWordsLastIndex {
+				f.ADC(ax, t[j+1], t[j])
+			} else {
+				f.ADCS(ax, t[j+1], t[j])
+			}
+		}
+	}
+
+	f.Comment("reduce if necessary")
+	f.MOVD("res+0(FP)", ax)
+	f.reduceAndStore(t[:f.NbWords], q, ax)
+
+	f.RET()
+	f.WriteLn("")
+}
+
+// addm returns the instruction adding the j-th word of a NbWords words
+// carry chain that propagates to an extra word
+func (f *FFArm64) addm(j int) func(op1, op2, dst interface{}, comment ...string) {
+	if j == 0 {
+		return f.ADDS
+	}
+	return f.ADCS
+}
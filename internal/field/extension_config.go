@@ -0,0 +1,201 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package field
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	errUnsupportedExtensionDegree = errors.New("only extensions of degree 2 and 3 are supported")
+	errNoCubicExtension           = errors.New("q ≢ 1 (mod 3): there is no cubic non-residue in the base field")
+)
+
+// ExtensionConfig precomputed values used in template for code generation of
+// a simple radical extension Fp[u]/(uⁿ - α) of degree n ∈ {2, 3}
+type ExtensionConfig struct {
+	Base                  *FieldConfig
+	Name                  string     // name of the generated type (E2, E3)
+	Degree                int        // n
+	Coordinates           []string   // names of the coordinates (A0, A1, ...)
+	NonResidue            int64      // α
+	NonResidueMont        []uint64   // α (montgomery form)
+	FrobeniusCoefficients [][]uint64 // α^(i(q-1)/n) for i in [1, n-1] (montgomery form)
+	SqrtE                 uint64     // qⁿ - 1 = 2ᵉ * s, s odd
+	SqrtSMinusOneOver2    string     // (s-1)/2, big.Int to base16 string
+	SqrtG                 [][]uint64 // gˢ where g is a non square in the extension (coordinates in montgomery form)
+}
+
+// NewExtensionConfig returns a data structure with needed information to generate the arithmetic
+// of the extension of degree 2 or 3 of base.
+//
+// If nonResidue is nil, the smallest (in absolute value) suitable non-residue is picked.
+// Otherwise, it must not be a square (degree 2) or a cube (degree 3) in the base field.
+func NewExtensionConfig(base *FieldConfig, degree int, nonResidue *int64) (*ExtensionConfig, error) {
+	if degree != 2 && degree != 3 {
+		return nil, errUnsupportedExtensionDegree
+	}
+
+	var alpha int64
+	if nonResidue != nil {
+		alpha = *nonResidue
+		if !IsNonResidue(base, degree, alpha) {
+			return nil, fmt.Errorf("%d is not a valid non-residue for an extension of degree %d", alpha, degree)
+		}
+	} else {
+		var err error
+		if alpha, err = FindNonResidue(base, degree); err != nil {
+			return nil, err
+		}
+	}
+
+	E := &ExtensionConfig{
+		Base:       base,
+		Name:       fmt.Sprintf("E%d", degree),
+		Degree:     degree,
+		NonResidue: alpha,
+	}
+	for i := 0; i < degree; i++ {
+		E.Coordinates = append(E.Coordinates, fmt.Sprintf("A%d", i))
+	}
+
+	q := base.ModulusBig
+	bAlpha := big.NewInt(alpha)
+	bAlpha.Mod(bAlpha, q)
+	E.NonResidueMont = base.toMontSlice(bAlpha)
+
+	// Frobenius: (uⁱ)^q = uⁱ * α^(i(q-1)/n)
+	var exp, c big.Int
+	exp.Sub(q, big.NewInt(1)).Div(&exp, big.NewInt(int64(degree)))
+	for i := 1; i < degree; i++ {
+		var e big.Int
+		e.Mul(&exp, big.NewInt(int64(i)))
+		c.Exp(bAlpha, &e, q)
+		E.FrobeniusCoefficients = append(E.FrobeniusCoefficients, base.toMontSlice(&c))
+	}
+
+	// Tonelli-Shanks precomputations
+	// qⁿ - 1 = 2ᵉ * s, s odd
+	var s big.Int
+	s.Exp(q, big.NewInt(int64(degree)), nil).Sub(&s, big.NewInt(1))
+	e := s.TrailingZeroBits()
+	s.Rsh(&s, e)
+	E.SqrtE = uint64(e)
+
+	// g is a non square in the extension, i.e an element which norm is a non square in the base field.
+	ext := NewTower(base, uint8(degree), alpha)
+	var g Element
+	if degree == 3 {
+		// the norm map of an extension of odd degree preserves squares and non squares in the base field
+		n, err := nonSquare(q)
+		if err != nil {
+			return nil, err
+		}
+		g = ext.FromInt64(n)
+	} else {
+		// N(a + u) = a² - α
+		for a := int64(0); ; a++ {
+			var n big.Int
+			n.SetInt64(a*a-alpha).Mod(&n, q)
+			if big.Jacobi(&n, q) == -1 {
+				g = ext.FromInt64(a, 1)
+				break
+			}
+		}
+	}
+	for i := range g {
+		g[i].Mod(&g[i], q)
+	}
+	gs := ext.Exp(g, &s)
+	for i := range gs {
+		gs[i].Mod(&gs[i], q)
+		E.SqrtG = append(E.SqrtG, base.toMontSlice(&gs[i]))
+	}
+
+	s.Sub(&s, big.NewInt(1)).Rsh(&s, 1)
+	E.SqrtSMinusOneOver2 = s.Text(16)
+
+	return E, nil
+}
+
+// IsNonResidue returns true if α is neither a square (degree 2) nor a cube (degree 3)
+// in the base field, that is, if uⁿ - α is irreducible over the base field.
+func IsNonResidue(base *FieldConfig, degree int, alpha int64) bool {
+	q := base.ModulusBig
+	var a big.Int
+	a.SetInt64(alpha).Mod(&a, q)
+	if a.Sign() == 0 {
+		return false
+	}
+	switch degree {
+	case 2:
+		return big.Jacobi(&a, q) == -1
+	case 3:
+		var qMinusOne, exp, r big.Int
+		qMinusOne.Sub(q, big.NewInt(1))
+		if r.Mod(&qMinusOne, big.NewInt(3)).Sign() != 0 {
+			return false
+		}
+		exp.Div(&qMinusOne, big.NewInt(3))
+		return r.Exp(&a, &exp, q).Cmp(big.NewInt(1)) != 0
+	default:
+		return false
+	}
+}
+
+// FindNonResidue returns the non-residue α of smallest absolute value (favoring negative values)
+// such that uⁿ - α is irreducible over the base field.
+func FindNonResidue(base *FieldConfig, degree int) (int64, error) {
+	if degree != 2 && degree != 3 {
+		return 0, errUnsupportedExtensionDegree
+	}
+	if degree == 3 {
+		var r big.Int
+		if r.Mod(base.ModulusBig, big.NewInt(3)).Cmp(big.NewInt(1)) != 0 {
+			return 0, errNoCubicExtension
+		}
+	}
+	// a non-residue exists in the first 2 * log(q)² integers (under GRH)
+	bound := int64(base.NbBits) * int64(base.NbBits) * 2
+	for a := int64(1); a <= bound; a++ {
+		if IsNonResidue(base, degree, -a) {
+			return -a, nil
+		}
+		if IsNonResidue(base, degree, a) {
+			return a, nil
+		}
+	}
+	return 0, fmt.Errorf("couldn't find a non-residue for an extension of degree %d", degree)
+}
+
+// nonSquare returns the smallest positive quadratic non-residue modulo q
+func nonSquare(q *big.Int) (int64, error) {
+	for a := int64(2); a < 1<<20; a++ {
+		if big.Jacobi(big.NewInt(a), q) == -1 {
+			return a, nil
+		}
+	}
+	return 0, errors.New("couldn't find a quadratic non-residue")
+}
+
+func (f *FieldConfig) toMontSlice(x *big.Int) []uint64 {
+	if f.F31 {
+		return []uint64{f.toMontF31(x)}
+	}
+	mont := f.ToMont(*x)
+	return toUint64Slice(&mont, f.NbWords)
+}
@@ -28,7 +28,9 @@ import (
 )
 
 var (
-	errParseModulus = errors.New("can't parse modulus")
+	errParseModulus       = errors.New("can't parse modulus")
+	errNotPseudoMersenne  = errors.New("modulus is not pseudo-Mersenne")
+	errPseudoMersenneMode = errors.New("unknown pseudo-Mersenne mode")
 )
 
 // FieldConfig precomputed values used in template for code generation of field element APIs
@@ -45,17 +47,24 @@ type FieldConfig struct {
 	NbWordsIndexesFull        []int
 	P20InversionCorrectiveFac []uint64
 	P20InversionNbIterations  int
+	P20InversionPSq           []uint64 // 2⁶² in the representation of the elements (PseudoMersenne only)
 	UsingP20Inverse           bool
 	IsMSWSaturated            bool // indicates if the most significant word is 0xFFFFF...FFFF
 	Q                         []uint64
 	QInverse                  []uint64
 	QMinusOneHalvedP          []uint64 // ((q-1) / 2 ) + 1
 	ASM                       bool
+	ASMVector                 bool // indicates if the Vector operations have an assembly implementation
+	ASMArm64                  bool // indicates if mul, add, sub and Butterfly have an arm64 assembly implementation
 	RSquare                   []uint64
 	One, Thirteen             []uint64
 	LegendreExponent          string // big.Int to base16 string
 	NoCarry                   bool
-	NoCarrySquare             bool // used if NoCarry is set, but some op may overflow in square optimization
+	NoCarrySquare             bool   // used if NoCarry is set, but some op may overflow in square optimization
+	Karatsuba                 bool   // large moduli (16 words or more): the products are computed with Karatsuba, see element.MulKaratsuba
+	PseudoMersenne            bool   // q = 2ᵏ - c with 2⁶⁴ᴺ mod q < 2⁶³ (e.g. 2²⁵⁵ - 19): reduction by folding, elements are not in Montgomery form
+	PseudoMersenneC           uint64 // 2⁶⁴ᴺ mod q = c * 2⁶⁴ᴺ⁻ᵏ (PseudoMersenne only)
+	PseudoMersenneShift       int    // 64N - k, the number of unused bits in the most significant word (PseudoMersenne only)
 	SqrtQ3Mod4                bool
 	SqrtAtkin                 bool
 	SqrtTonelliShanks         bool
@@ -66,17 +75,58 @@ type FieldConfig struct {
 	SqrtQ3Mod4Exponent        string   // big.Int to base16 string
 	SqrtG                     []uint64 // NonResidue ^  SqrtR (montgomery form)
 	NonResidue                big.Int  // (montgomery form)
+	QMinusTwo                 []uint64 // q - 2, exponent of the constant-time inversion
+	SqrtCTE                   uint64   // q - 1 = 2ᵉ * s, s odd (for all q, used by SqrtCT)
+	SqrtCTExponent            []uint64 // (s-1)/2
+	SqrtCTG                   []uint64 // g ^ s, g a quadratic non-residue (montgomery form)
 	LegendreExponentData      *addchain.AddChainData
 	SqrtAtkinExponentData     *addchain.AddChainData
 	SqrtSMinusOneOver2Data    *addchain.AddChainData
 	SqrtQ3Mod4ExponentData    *addchain.AddChainData
 	UseAddChain               bool
+	F31                       bool   // q fits on 31 bits: elements are stored on a single uint32 word
+	Mersenne31                bool   // q = 2³¹ - 1: reduction by folding, elements are not in Montgomery form
+	F31QInvNeg                uint64 // - q⁻¹ mod 2³² (F31 only)
+	F31RSquare                uint64 // R² mod q with R = 2³² (F31 only, 1 if Mersenne31)
+	F31One                    uint64 // R mod q with R = 2³² (F31 only, 1 if Mersenne31)
+	F31SqrtCTG                uint64 // SqrtCTG in the F31 representation
+}
+
+// PseudoMersenneMode selects the reduction of the moduli q = 2ᵏ - c with c small (see FieldConfig.PseudoMersenne)
+type PseudoMersenneMode uint8
+
+const (
+	PseudoMersenneOff  PseudoMersenneMode = iota // Montgomery form and reduction for all moduli (default)
+	PseudoMersenneAuto                           // reduction by folding if the modulus is pseudo-Mersenne
+	PseudoMersenneOn                             // reduction by folding, NewFieldConfig fails if the modulus isn't pseudo-Mersenne
+)
+
+// Option sets an optional parameter of NewFieldConfig
+type Option func(*fieldOptions)
+
+type fieldOptions struct {
+	pseudoMersenne PseudoMersenneMode
+}
+
+// WithPseudoMersenne sets the reduction of pseudo-Mersenne moduli. Default to PseudoMersenneOff.
+func WithPseudoMersenne(mode PseudoMersenneMode) Option {
+	return func(opt *fieldOptions) {
+		opt.pseudoMersenne = mode
+	}
 }
 
 // NewFieldConfig returns a data structure with needed information to generate apis for field element
 //
 // See field/generator package
-func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool) (*FieldConfig, error) {
+func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool, opts ...Option) (*FieldConfig, error) {
+	var opt fieldOptions
+	for _, o := range opts {
+		o(&opt)
+	}
+	if opt.pseudoMersenne > PseudoMersenneOn {
+		return nil, errPseudoMersenneMode
+	}
+
 	// parse modulus
 	var bModulus big.Int
 	if _, ok := bModulus.SetString(modulus, 0); !ok {
@@ -96,6 +146,11 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 	F.NbBits = bModulus.BitLen()
 	F.NbWords = len(bModulus.Bits())
 
+	// 31-bit fields don't use addition chains: their exponents fit on a single word
+	F.F31 = F.NbBits <= 31
+	F.Mersenne31 = F.F31 && bModulus.Cmp(big.NewInt(1<<31-1)) == 0
+	F.UseAddChain = useAddChain && !F.F31
+
 	F.NbWordsLastIndex = F.NbWords - 1
 
 	// set q from big int repr
@@ -115,6 +170,26 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 	_qInv.Mod(_qInv, _r)
 	F.QInverse = toUint64Slice(_qInv, F.NbWords)
 
+	// pseudo-Mersenne primes q = 2ᵏ - c, with c small, have a cheaper reduction than Montgomery's:
+	// with R = 2⁶⁴ᴺ, R ≡ c * 2⁶⁴ᴺ⁻ᵏ (mod q) so that the high words of a product are folded onto its
+	// low words with a multiplication by a single word. Elements are then kept in regular form.
+	// 31-bit fields have their own representation, and large moduli use Karatsuba.
+	// The folding is opt-in (WithPseudoMersenne): it changes the representation of the elements.
+	if opt.pseudoMersenne != PseudoMersenneOff && F.NbWords > 1 && F.NbWords < 16 {
+		// c = 2ᵏ - q and 2⁶⁴ᴺ mod q = c * 2⁶⁴ᴺ⁻ᵏ < 2⁶³
+		shift := F.NbWords*64 - F.NbBits
+		var pmC big.Int
+		pmC.Lsh(big.NewInt(1), uint(F.NbBits)).Sub(&pmC, &bModulus).Lsh(&pmC, uint(shift))
+		if pmC.BitLen() < 64 {
+			F.PseudoMersenne = true
+			F.PseudoMersenneC = pmC.Uint64()
+			F.PseudoMersenneShift = shift
+		}
+	}
+	if opt.pseudoMersenne == PseudoMersenneOn && !F.PseudoMersenne {
+		return nil, errNotPseudoMersenne
+	}
+
 	// Pornin20 inversion correction factors
 	k := 32 // Optimized for 64 bit machines, still works for 32
 
@@ -128,6 +203,20 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 	p20InversionCorrectiveFac := big.NewInt(1)
 	p20InversionCorrectiveFac.Lsh(p20InversionCorrectiveFac, uint(p20InversionCorrectiveFacPower))
 	p20InversionCorrectiveFac.Mod(p20InversionCorrectiveFac, &bModulus)
+	if F.PseudoMersenne {
+		// the inversion computes Montgomery reductions on the raw words, for elements in Montgomery form;
+		// in regular form, the factor also cancels R³ (see element.Inverse), and the padding
+		// multiplications by 2⁶² need 2⁶² * R⁻¹ to match the Montgomery multiplication
+		var rInv big.Int
+		rInv.ModInverse(_r, &bModulus)
+		p20InversionCorrectiveFac.Mul(p20InversionCorrectiveFac, new(big.Int).Exp(&rInv, big.NewInt(3), &bModulus))
+		p20InversionCorrectiveFac.Mod(p20InversionCorrectiveFac, &bModulus)
+
+		var pSq big.Int
+		pSq.Lsh(big.NewInt(1), uint(2*(k-1)))
+		pSq.Mul(&pSq, &rInv).Mod(&pSq, &bModulus)
+		F.P20InversionPSq = toUint64Slice(&pSq, F.NbWords)
+	}
 	F.P20InversionCorrectiveFac = toUint64Slice(p20InversionCorrectiveFac, F.NbWords)
 
 	{
@@ -135,21 +224,20 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 		F.UsingP20Inverse = F.NbWords > 1 && F.NbBits < c
 	}
 
-	// rsquare
+	// rsquare (1 if PseudoMersenne: ToMont is the identity)
 	_rSquare := big.NewInt(2)
 	exponent := big.NewInt(int64(F.NbWords) * 64 * 2)
 	_rSquare.Exp(_rSquare, exponent, &bModulus)
+	if F.PseudoMersenne {
+		_rSquare.SetUint64(1)
+	}
 	F.RSquare = toUint64Slice(_rSquare, F.NbWords)
 
-	var one big.Int
-	one.SetUint64(1)
-	one.Lsh(&one, uint(F.NbWords)*64).Mod(&one, &bModulus)
+	one := F.ToMont(*big.NewInt(1))
 	F.One = toUint64Slice(&one, F.NbWords)
 
 	{
-		var n big.Int
-		n.SetUint64(13)
-		n.Lsh(&n, uint(F.NbWords)*64).Mod(&n, &bModulus)
+		n := F.ToMont(*big.NewInt(13))
 		F.Thirteen = toUint64Slice(&n, F.NbWords)
 	}
 
@@ -171,6 +259,9 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 	const BSquare = ^uint64(0) >> 2
 	F.NoCarrySquare = F.Q[len(F.Q)-1] <= BSquare
 
+	// on 16 words or more, Karatsuba beats the (quadratic) CIOS multiplication
+	F.Karatsuba = F.NbWords >= 16
+
 	// Legendre exponent (p-1)/2
 	var legendreExponent big.Int
 	legendreExponent.SetUint64(1)
@@ -238,7 +329,7 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 			var g big.Int
 			g.Exp(&nonResidue, &s, &bModulus)
 			// store g in montgomery form
-			g.Lsh(&g, uint(F.NbWords)*64).Mod(&g, &bModulus)
+			g = F.ToMont(g)
 			F.SqrtG = toUint64Slice(&g, F.NbWords)
 
 			// store non residue in montgomery form
@@ -254,10 +345,64 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 		}
 	}
 
+	// constant-time inversion and square root pre computes
+	// SqrtCT uses a constant-time variant of Tonelli-Shanks for all q, so these are computed
+	// independently of the algorithm picked for Sqrt
+	{
+		var qMinusTwo big.Int
+		qMinusTwo.Sub(&bModulus, big.NewInt(2))
+		F.QMinusTwo = toUint64Slice(&qMinusTwo, F.NbWords)
+
+		// q-1 = 2ᵉ * s, s odd
+		var s big.Int
+		s.Sub(&bModulus, big.NewInt(1))
+		e := s.TrailingZeroBits()
+		s.Rsh(&s, e)
+		F.SqrtCTE = uint64(e)
+
+		// g = nonResidue ^ s
+		nonResidue := big.NewInt(2)
+		for big.Jacobi(nonResidue, &bModulus) != -1 {
+			nonResidue.Add(nonResidue, big.NewInt(1))
+		}
+		var g big.Int
+		g.Exp(nonResidue, &s, &bModulus)
+		if F.F31 {
+			F.F31SqrtCTG = F.toMontF31(&g)
+		}
+		g = F.ToMont(g)
+		F.SqrtCTG = toUint64Slice(&g, F.NbWords)
+
+		// (s-1)/2
+		s.Rsh(&s, 1)
+		F.SqrtCTExponent = toUint64Slice(&s, F.NbWords)
+	}
+
+	// 31-bit fields are stored on a uint32 and use a Montgomery multiplication with R = 2³²,
+	// except q = 2³¹ - 1 for which x * 2³¹ ≡ x (mod q) gives a cheaper reduction without Montgomery form
+	if F.F31 {
+		var r, qInvNeg big.Int
+		r.Lsh(big.NewInt(1), 32)
+		qInvNeg.ModInverse(&bModulus, &r).Neg(&qInvNeg).Mod(&qInvNeg, &r)
+		F.F31QInvNeg = qInvNeg.Uint64()
+
+		F.F31One = F.toMontF31(big.NewInt(1))
+		F.F31RSquare = F.toMontF31(new(big.Int).SetUint64(F.F31One))
+	}
+
 	// note: to simplify output files generated, we generated ASM code only for
 	// moduli that meet the condition F.NoCarry
 	// asm code generation for moduli with more than 6 words can be optimized further
-	F.ASM = F.NoCarry && F.NbWords <= 12 && F.NbWords > 1
+	// pseudo-Mersenne moduli use the generic implementation (the assembly is Montgomery's)
+	F.ASM = F.NoCarry && F.NbWords <= 12 && F.NbWords > 1 && !F.PseudoMersenne
+
+	// vector operations keep the pointers, the loop counter and 2 elements in registers;
+	// beyond 6 words, we fall back to the generic implementation.
+	F.ASMVector = F.ASM && F.NbWords <= 6
+
+	// arm64 assembly keeps x, y (or the modulus) and the accumulator in registers;
+	// beyond 6 words, we fall back to the generic implementation.
+	F.ASMArm64 = F.ASM && F.NbWords <= 6
 
 	return F, nil
 }
@@ -320,19 +465,42 @@ func (f *FieldConfig) StringToMont(str string) big.Int {
 	return i
 }
 
+// ToMont returns nonMont in the representation of the elements, that is
+// in Montgomery form, or reduced mod q if f.PseudoMersenne
 func (f *FieldConfig) ToMont(nonMont big.Int) big.Int {
 	var mont big.Int
-	mont.Lsh(&nonMont, uint(f.NbWords)*64)
+	mont.Set(&nonMont)
+	if !f.PseudoMersenne {
+		mont.Lsh(&mont, uint(f.NbWords)*64)
+	}
 	mont.Mod(&mont, f.ModulusBig)
 	return mont
 }
 
+// toMontF31 returns x in the representation used by 31-bit fields, that is
+// x * 2³² mod q (Montgomery form), or x mod q if q = 2³¹ - 1
+func (f *FieldConfig) toMontF31(x *big.Int) uint64 {
+	var mont big.Int
+	mont.Set(x)
+	if !f.Mersenne31 {
+		mont.Lsh(&mont, 32)
+	}
+	mont.Mod(&mont, f.ModulusBig)
+	return mont.Uint64()
+}
+
+// FromMont sets nonMont to the regular form of mont, in the representation of the elements
+// (identity if f.PseudoMersenne)
 func (f *FieldConfig) FromMont(nonMont *big.Int, mont *big.Int) *FieldConfig {
 
 	if f.NbWords == 0 {
 		nonMont.SetInt64(0)
 		return f
 	}
+	if f.PseudoMersenne {
+		nonMont.Set(mont)
+		return f
+	}
 	f.halve(nonMont, mont)
 	for i := 1; i < f.NbWords*64; i++ {
 		f.halve(nonMont, nonMont)
@@ -160,6 +160,120 @@ func TestExponentiationBls12381G2(t *testing.T) {
 	}
 }
 
+func TestExtensionConfig(t *testing.T) {
+	t.Parallel()
+
+	base, err := NewFieldConfig("dummyName", "dummyElement", "0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// bls12-381 uses u² = -1 for Fp2
+	E2, err := NewExtensionConfig(base, 2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if E2.NonResidue != -1 || E2.Name != "E2" || len(E2.Coordinates) != 2 {
+		t.Fatal("unexpected E2 config", E2.Name, E2.NonResidue)
+	}
+
+	// the Frobenius coefficient of a quadratic extension is α^((q-1)/2) = -1
+	var minusOne big.Int
+	minusOne.Sub(base.ModulusBig, big.NewInt(1))
+	minusOne = base.ToMont(minusOne)
+	if !equalUint64Slice(E2.FrobeniusCoefficients[0], toUint64Slice(&minusOne, base.NbWords)) {
+		t.Fatal("unexpected E2 Frobenius coefficient")
+	}
+
+	E3, err := NewExtensionConfig(base, 3, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsNonResidue(base, 3, E3.NonResidue) || len(E3.FrobeniusCoefficients) != 2 {
+		t.Fatal("unexpected E3 config", E3.NonResidue)
+	}
+
+	one := int64(1)
+	if _, err := NewExtensionConfig(base, 2, &one); err == nil {
+		t.Fatal("1 is a square and should be rejected")
+	}
+	if _, err := NewExtensionConfig(base, 4, nil); err == nil {
+		t.Fatal("extensions of degree 4 should be rejected")
+	}
+
+	// 47 ≡ 2 (mod 3): every element is a cube
+	small, err := NewFieldConfig("dummyName", "dummyElement", "47", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FindNonResidue(small, 3); err == nil {
+		t.Fatal("there is no cubic non-residue mod 47")
+	}
+}
+
+func TestPseudoMersenne(t *testing.T) {
+	for _, tc := range []struct {
+		modulus        string
+		pseudoMersenne bool
+		c              uint64
+		shift          int
+	}{
+		{"0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed", true, 38, 1},                                 // 2²⁵⁵ - 19
+		{"0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", true, 1<<32 + 977, 0},                        // secp256k1
+		{"0x7fffffffffffffffffffffffffffffff", true, 2, 1},                                                                  // 2¹²⁷ - 1
+		{"0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab", false, 0, 0}, // bls12-381
+		{"0x100000000000000000000000000000033", false, 0, 0},                                                                // 2¹²⁸ + 51, on 3 words
+	} {
+		// the folding is opt-in
+		f, err := NewFieldConfig("dummyName", "dummyElement", tc.modulus, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.PseudoMersenne {
+			t.Fatalf("%s: pseudo-Mersenne reduction should be off by default", tc.modulus)
+		}
+		if _, err := NewFieldConfig("dummyName", "dummyElement", tc.modulus, false, WithPseudoMersenne(PseudoMersenneOn)); (err == nil) != tc.pseudoMersenne {
+			t.Fatalf("%s: unexpected error %v with PseudoMersenneOn", tc.modulus, err)
+		}
+
+		f, err = NewFieldConfig("dummyName", "dummyElement", tc.modulus, false, WithPseudoMersenne(PseudoMersenneAuto))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.PseudoMersenne != tc.pseudoMersenne || f.PseudoMersenneC != tc.c || f.PseudoMersenneShift != tc.shift {
+			t.Fatalf("%s: got (%v, %d, %d)", tc.modulus, f.PseudoMersenne, f.PseudoMersenneC, f.PseudoMersenneShift)
+		}
+		if !f.PseudoMersenne {
+			continue
+		}
+
+		// elements are not in Montgomery form
+		x := big.NewInt(42)
+		if mont := f.ToMont(*x); mont.Cmp(x) != 0 {
+			t.Fatal("ToMont should be the identity")
+		}
+		var res big.Int
+		if f.FromMont(&res, x); res.Cmp(x) != 0 {
+			t.Fatal("FromMont should be the identity")
+		}
+		if f.One[0] != 1 || f.RSquare[0] != 1 || f.Thirteen[0] != 13 || f.ASM {
+			t.Fatal("unexpected constants")
+		}
+	}
+}
+
+func equalUint64Slice(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 const minNbWords = 1
 const maxNbWords = 15
 
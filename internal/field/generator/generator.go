@@ -12,8 +12,10 @@ import (
 	"github.com/consensys/bavard"
 	"github.com/consensys/gnark-crypto/internal/field"
 	"github.com/consensys/gnark-crypto/internal/field/asm/amd64"
+	"github.com/consensys/gnark-crypto/internal/field/asm/arm64"
 	"github.com/consensys/gnark-crypto/internal/field/internal/addchain"
 	"github.com/consensys/gnark-crypto/internal/field/internal/templates/element"
+	"github.com/consensys/gnark-crypto/internal/field/internal/templates/extensions"
 )
 
 // TODO @gbotrel → pattern for code generation is different than gnark-crypto/internal because a binary like goff can generate
@@ -34,9 +36,13 @@ func GenerateFF(F *field.FieldConfig, outputDir string) error {
 		element.Conv,
 		element.MulCIOS,
 		element.MulNoCarry,
+		element.MulKaratsuba,
+		element.MulPseudoMersenne,
 		element.Sqrt,
 		element.Inverse,
+		element.ConstantTime,
 		element.BigNum,
+		element.Accumulator,
 	}
 
 	// test file templates
@@ -46,6 +52,8 @@ func GenerateFF(F *field.FieldConfig, outputDir string) error {
 		element.Reduce,
 		element.Test,
 		element.InverseTests,
+		element.ConstantTimeTests,
+		element.AccumulatorTests,
 	}
 	// output files
 	eName := strings.ToLower(F.ElementName)
@@ -62,6 +70,8 @@ func GenerateFF(F *field.FieldConfig, outputDir string) error {
 		"_ops_amd64.s",
 		"_mul_adx_amd64.s",
 		"_ops_amd64.go",
+		"_ops_arm64.s",
+		"_ops_arm64.go",
 		"_fuzz.go",
 	}
 
@@ -70,6 +80,7 @@ func GenerateFF(F *field.FieldConfig, outputDir string) error {
 	}
 	_ = os.Remove(filepath.Join(outputDir, "asm.go"))
 	_ = os.Remove(filepath.Join(outputDir, "asm_noadx.go"))
+	_ = os.Remove(filepath.Join(outputDir, "vector_amd64.go"))
 
 	funcs := template.FuncMap{}
 	if F.UseAddChain {
@@ -87,6 +98,10 @@ func GenerateFF(F *field.FieldConfig, outputDir string) error {
 		bavard.Funcs(funcs),
 	}
 
+	if F.F31 {
+		return generateF31(F, outputDir, bavardOpts)
+	}
+
 	// generate source file
 	if err := bavard.GenerateFromString(pathSrc, sourceFiles, F, bavardOpts...); err != nil {
 		return err
@@ -189,6 +204,41 @@ func GenerateFF(F *field.FieldConfig, outputDir string) error {
 
 	}
 
+	if F.ASMArm64 {
+		// generate ops_arm64.s
+		pathSrc := filepath.Join(outputDir, eName+"_ops_arm64.s")
+		fmt.Println("generating", pathSrc)
+		f, err := os.Create(pathSrc)
+		if err != nil {
+			return err
+		}
+
+		if err := arm64.Generate(f, F); err != nil {
+			_ = f.Close()
+			return err
+		}
+		_ = f.Close()
+
+		// run asmfmt
+		cmd := exec.Command("asmfmt", "-w", pathSrc)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return err
+		}
+	}
+
+	if F.ASMArm64 {
+		// generate ops_arm64.go
+		src := []string{
+			element.OpsArm64,
+		}
+		pathSrc := filepath.Join(outputDir, eName+"_ops_arm64.go")
+		if err := bavard.GenerateFromString(pathSrc, src, F, bavardOpts...); err != nil {
+			return err
+		}
+	}
+
 	if F.ASM {
 		// generate ops_amd64.go
 		src := []string{
@@ -211,7 +261,47 @@ func GenerateFF(F *field.FieldConfig, outputDir string) error {
 		pathSrc := filepath.Join(outputDir, eName+"_ops_noasm.go")
 		bavardOptsCpy := make([]func(*bavard.Bavard) error, len(bavardOpts))
 		copy(bavardOptsCpy, bavardOpts)
-		if F.ASM {
+		if F.ASMArm64 {
+			bavardOptsCpy = append(bavardOptsCpy, bavard.BuildTag("!amd64,!arm64"))
+		} else if F.ASM {
+			bavardOptsCpy = append(bavardOptsCpy, bavard.BuildTag("!amd64"))
+		}
+		if err := bavard.GenerateFromString(pathSrc, src, F, bavardOptsCpy...); err != nil {
+			return err
+		}
+	}
+
+	{
+		// generate vector.go
+		src := []string{
+			element.Vector,
+		}
+		pathSrc := filepath.Join(outputDir, "vector.go")
+		if err := bavard.GenerateFromString(pathSrc, src, F, bavardOpts...); err != nil {
+			return err
+		}
+	}
+
+	if F.ASMVector {
+		// generate vector_amd64.go
+		src := []string{
+			element.VectorOpsAmd64,
+		}
+		pathSrc := filepath.Join(outputDir, "vector_amd64.go")
+		if err := bavard.GenerateFromString(pathSrc, src, F, bavardOpts...); err != nil {
+			return err
+		}
+	}
+
+	{
+		// generate vector_noasm.go
+		src := []string{
+			element.VectorOpsNoAsm,
+		}
+		pathSrc := filepath.Join(outputDir, "vector_noasm.go")
+		bavardOptsCpy := make([]func(*bavard.Bavard) error, len(bavardOpts))
+		copy(bavardOptsCpy, bavardOpts)
+		if F.ASMVector {
 			bavardOptsCpy = append(bavardOptsCpy, bavard.BuildTag("!amd64"))
 		}
 		if err := bavard.GenerateFromString(pathSrc, src, F, bavardOptsCpy...); err != nil {
@@ -219,6 +309,14 @@ func GenerateFF(F *field.FieldConfig, outputDir string) error {
 		}
 	}
 
+	{
+		// generate vector_test.go
+		pathSrc := filepath.Join(outputDir, "vector_test.go")
+		if err := bavard.GenerateFromString(pathSrc, []string{element.TestVector}, F, bavardOpts...); err != nil {
+			return err
+		}
+	}
+
 	{
 		// generate doc.go
 		src := []string{
@@ -257,6 +355,10 @@ func GenerateFF(F *field.FieldConfig, outputDir string) error {
 		}
 	}
 
+	if err := generateCTCount(F, outputDir, bavardOpts); err != nil {
+		return err
+	}
+
 	// run go fmt on whole directory
 	cmd := exec.Command("gofmt", "-s", "-w", outputDir)
 	cmd.Stdout = os.Stdout
@@ -268,6 +370,116 @@ func GenerateFF(F *field.FieldConfig, outputDir string) error {
 	return nil
 }
 
+// generateCTCount generates the hook recording the operations of the constant-time methods, used by
+// their tests behind the ctcount build tag, and its empty default.
+func generateCTCount(F *field.FieldConfig, outputDir string, bavardOpts []func(*bavard.Bavard) error) error {
+	eName := strings.ToLower(F.ElementName)
+	files := []struct {
+		name, tag string
+		src       []string
+	}{
+		{eName + "_ct_count.go", "ctcount", []string{element.ConstantTimeCount}},
+		{eName + "_ct_nocount.go", "!ctcount", []string{element.ConstantTimeNoCount}},
+		{eName + "_ct_count_test.go", "ctcount", []string{element.ConstantTimeCountTests}},
+	}
+	for _, f := range files {
+		bavardOptsCpy := make([]func(*bavard.Bavard) error, len(bavardOpts))
+		copy(bavardOptsCpy, bavardOpts)
+		bavardOptsCpy = append(bavardOptsCpy, bavard.BuildTag(f.tag))
+		if err := bavard.GenerateFromString(filepath.Join(outputDir, f.name), f.src, F, bavardOptsCpy...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateF31 generates the fields whose modulus fits on 31 bits, see element.F31.
+//
+// The files have the same names as for the other moduli, minus the ones that are
+// not needed on a single word (assembly, arith.go, fixed exponents).
+func generateF31(F *field.FieldConfig, outputDir string, bavardOpts []func(*bavard.Bavard) error) error {
+	eName := strings.ToLower(F.ElementName)
+
+	// remove files from a previous generation with a larger modulus
+	for _, of := range []string{eName + "_exp.go", eName + "_ops_amd64.go", eName + "_ops_amd64.s",
+		eName + "_mul_amd64.s", eName + "_mul_adx_amd64.s", eName + "_ops_arm64.go", eName + "_ops_arm64.s",
+		"arith.go", "vector_amd64.go"} {
+		_ = os.Remove(filepath.Join(outputDir, of))
+	}
+
+	files := []struct {
+		name string
+		src  []string
+	}{
+		{eName + ".go", []string{element.F31, element.ConstantTime, element.MulCIOS, element.Accumulator}},
+		{eName + "_ops_noasm.go", []string{element.OpsNoAsm}},
+		{eName + "_test.go", []string{element.Test, element.ConstantTimeTests, element.AccumulatorTests}},
+		{"vector.go", []string{element.Vector}},
+		{"vector_noasm.go", []string{element.VectorOpsF31}},
+		{"vector_test.go", []string{element.TestVector}},
+		{"doc.go", []string{element.Doc}},
+	}
+	for _, f := range files {
+		if err := bavard.GenerateFromString(filepath.Join(outputDir, f.name), f.src, F, bavardOpts...); err != nil {
+			return err
+		}
+	}
+	if err := generateCTCount(F, outputDir, bavardOpts); err != nil {
+		return err
+	}
+
+	// run go fmt on whole directory
+	cmd := exec.Command("gofmt", "-s", "-w", outputDir)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// GenerateExtension will generate go files in outputDir for the extension E of the base field E.Base
+//
+// The base field must be generated (see GenerateFF) in the same outputDir.
+//
+// Example usage
+//
+// 	fp, _ = field.NewFieldConfig("fp", "Element", fpModulus, false)
+// 	e2, _ = field.NewExtensionConfig(fp, 2, nil)
+// 	generator.GenerateExtension(e2, filepath.Join(baseDir, "fp"))
+func GenerateExtension(E *field.ExtensionConfig, outputDir string) error {
+	var src string
+	switch E.Degree {
+	case 2:
+		src = extensions.E2
+	case 3:
+		src = extensions.E3
+	default:
+		return fmt.Errorf("unsupported extension degree %d", E.Degree)
+	}
+
+	bavardOpts := []func(*bavard.Bavard) error{
+		bavard.Apache2("ConsenSys Software Inc.", 2020),
+		bavard.Package(E.Base.PackageName),
+		bavard.GeneratedBy("consensys/gnark-crypto"),
+	}
+
+	eName := strings.ToLower(E.Name)
+
+	pathSrc := filepath.Join(outputDir, eName+".go")
+	if err := bavard.GenerateFromString(pathSrc, []string{extensions.Base, src}, E, bavardOpts...); err != nil {
+		return err
+	}
+
+	pathTest := filepath.Join(outputDir, eName+"_test.go")
+	if err := bavard.GenerateFromString(pathTest, []string{extensions.Tests}, E, bavardOpts...); err != nil {
+		return err
+	}
+
+	// run go fmt on generated files
+	cmd := exec.Command("gofmt", "-s", "-w", pathSrc, pathTest)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
 func shorten(input string) string {
 	const maxLen = 15
 	if len(input) > maxLen {
@@ -15,13 +15,15 @@
 package generator
 
 import (
-	"crypto/rand"
 	"fmt"
 	"math/big"
+	"math/rand"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/consensys/gnark-crypto/internal/field"
 )
@@ -38,6 +40,11 @@ func TestIntegration(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// the random moduli are drawn from a seeded source, logged to reproduce a failure
+	seed := time.Now().UnixNano()
+	t.Logf("moduli seed: %d", seed)
+	rng := rand.New(rand.NewSource(seed))
+
 	var bits []int
 	for i := 64; i <= 448; i += 64 {
 		bits = append(bits, i-3, i-2, i-1, i, i+1)
@@ -48,11 +55,11 @@ func TestIntegration(t *testing.T) {
 		var q *big.Int
 		var nbWords int
 		if i%64 == 0 {
-			q, _ = rand.Prime(rand.Reader, i)
+			q = randomPrime(rng, i)
 			moduli[fmt.Sprintf("e_cios_%04d", i)] = q.String()
 		} else {
 			for {
-				q, _ = rand.Prime(rand.Reader, i)
+				q = randomPrime(rng, i)
 				nbWords = len(q.Bits())
 				const B = (^uint64(0) >> 1) - 1
 				if uint64(q.Bits()[nbWords-1]) <= B {
@@ -69,21 +76,66 @@ func TestIntegration(t *testing.T) {
 
 	moduli["e_secp256k1"] = "115792089237316195423570985008687907853269984665640564039457584007908834671663"
 
+	// pseudo-Mersenne moduli are reduced by folding (field.FieldConfig.PseudoMersenne) with field.PseudoMersenneOn
+	moduli["e_pm_25519"] = "57896044618658097711785492504343953926634992332820282019728792003956564819949" // 2²⁵⁵ - 19
+	moduli["e_pm_0254"] = "28948022309329048855892746252171976963317496166410141009864396001978282409739"  // 2²⁵⁴ - 245
+
+	// large moduli use Karatsuba (field.FieldConfig.Karatsuba)
+	for _, i := range []int{1024, 1535, 2048} {
+		moduli[fmt.Sprintf("e_karatsuba_%04d", i)] = randomPrime(rng, i).String()
+	}
+	// 64 words, the largest supported size (drawing a random prime is too slow at this size)
+	if !testing.Short() {
+		moduli["e_karatsuba_4095"] = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 4095), big.NewInt(1615)).String() // 2⁴⁰⁹⁵ - 1615
+		moduli["e_karatsuba_4096"] = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 4096), big.NewInt(2549)).String() // 2⁴⁰⁹⁶ - 2549
+	}
+
 	// JUST fails to be nocarry -- only the following two can occur for < 3000 bits
 	moduli["e_nocarry_edge_0127"] = "170141183460469231731687303715884105727"
 	moduli["e_nocarry_edge_1279"] = "10407932194664399081925240327364085538615262247266704805319112350403608059673360298012239441732324184842421613954281007791383566248323464908139906605677320762924129509389220345773183349661583550472959420547689811211693677147548478866962501384438260291732348885311160828538416585028255604666224831890918801847068222203140521026698435488732958028878050869736186900714720710555703168729087"
 
+	// extensions are only generated for a few moduli to keep the test duration reasonable
+	withExtensions := map[string]bool{
+		"forty_seven":            true,
+		"small":                  true,
+		"small_without_no_carry": true,
+		"e_secp256k1":            true,
+		"e_nocarry_edge_0127":    true,
+	}
+
 	for elementName, modulus := range moduli {
 		var fIntegration *field.FieldConfig
 		// generate field
 		childDir := filepath.Join(rootDir, elementName)
-		fIntegration, err = field.NewFieldConfig("integration", elementName, modulus, false)
+		pseudoMersenne := field.PseudoMersenneOff
+		if strings.HasPrefix(elementName, "e_pm_") {
+			pseudoMersenne = field.PseudoMersenneOn
+		}
+		fIntegration, err = field.NewFieldConfig("integration", elementName, modulus, false, field.WithPseudoMersenne(pseudoMersenne))
 		if err != nil {
 			t.Fatal(elementName, err)
 		}
 		if err = GenerateFF(fIntegration, childDir); err != nil {
 			t.Fatal(elementName, err)
 		}
+
+		// generate extensions
+		if !withExtensions[elementName] {
+			continue
+		}
+		for _, degree := range []int{2, 3} {
+			if _, err := field.FindNonResidue(fIntegration, degree); err != nil {
+				// q ≢ 1 (mod 3)
+				continue
+			}
+			E, err := field.NewExtensionConfig(fIntegration, degree, nil)
+			if err != nil {
+				t.Fatal(elementName, err)
+			}
+			if err = GenerateExtension(E, childDir); err != nil {
+				t.Fatal(elementName, err)
+			}
+		}
 	}
 
 	// run go test
@@ -92,7 +144,8 @@ func TestIntegration(t *testing.T) {
 		t.Fatal(err)
 	}
 	packageDir := filepath.Join(wd, rootDir) + string(filepath.Separator) + "..."
-	cmd := exec.Command("go", "test", packageDir)
+	// the test names are built from the element names (e.g. Teste_nocarry_0065LinearComb), which vet rejects
+	cmd := exec.Command("go", "test", "-vet=off", packageDir)
 	out, err := cmd.CombinedOutput()
 	fmt.Println(string(out))
 	if err != nil {
@@ -100,3 +153,17 @@ func TestIntegration(t *testing.T) {
 	}
 
 }
+
+// randomPrime returns a prime of nbBits bits drawn from rng
+// (crypto/rand.Prime doesn't return the same prime for the same stream)
+func randomPrime(rng *rand.Rand, nbBits int) *big.Int {
+	var bound big.Int
+	bound.Lsh(big.NewInt(1), uint(nbBits-1))
+	for {
+		q := new(big.Int).Rand(rng, &bound)
+		q.SetBit(q, nbBits-1, 1).SetBit(q, 0, 1)
+		if q.ProbablyPrime(20) {
+			return q
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package element
+
+// Accumulator is an unreduced sum of products of elements, see MulAdd and Reduce.
+const Accumulator = `
+
+// Accumulator accumulates sums of products of elements without modular reduction;
+// a sum of n products costs n multiplications without reduction and a single
+{{- if .PseudoMersenne}} reduction,
+// instead of n Mul.
+{{- else}} Montgomery
+// reduction, instead of n Mul.
+{{- end}}
+//
+// The zero value is an empty sum, ready to use.
+//
+// 	var acc Accumulator
+// 	for i := range a {
+// 		acc.MulAdd(&a[i], &b[i])
+// 	}
+// 	res := acc.Reduce() // ∑ aᵢbᵢ
+{{- if .F31}}
+type Accumulator struct {
+	// the sum of the (montgomery) products, on 128 bits
+	lo, hi uint64
+}
+
+// MulAdd adds x * y to the accumulator
+func (acc *Accumulator) MulAdd(x, y *{{.ElementName}}) {
+	var c uint64
+	acc.lo, c = bits.Add64(acc.lo, uint64(x[0])*uint64(y[0]), 0)
+	acc.hi += c
+}
+
+// Add adds x to the accumulator
+func (acc *Accumulator) Add(x *{{.ElementName}}) {
+	var c uint64
+	{{- if .Mersenne31}}
+	acc.lo, c = bits.Add64(acc.lo, uint64(x[0]), 0)
+	{{- else}}
+	// x * R, so that the Montgomery reduction gives x
+	acc.lo, c = bits.Add64(acc.lo, uint64(x[0])<<32, 0)
+	{{- end}}
+	acc.hi += c
+}
+
+// Reduce returns the accumulated sum (mod q), and doesn't modify the accumulator
+func (acc *Accumulator) Reduce() {{.ElementName}} {
+	_, r := bits.Div64(acc.hi%uint64(q), acc.lo, uint64(q))
+	return {{.ElementName}}{montReduce(r)}
+}
+{{- else}}
+type Accumulator struct {
+	{{- if .PseudoMersenne}}
+	// the sum of the products is lo + hi * R, hi is reduced
+	{{- else}}
+	// the sum of the (montgomery) products is lo + hi * R, hi is reduced
+	{{- end}}
+	lo, hi {{.ElementName}}
+}
+
+// MulAdd adds x * y to the accumulator
+//
+// x and y must be strictly inferior to q
+func (acc *Accumulator) MulAdd(x, y *{{.ElementName}}) {
+	// t = x * y on {{mul 2 .NbWords}} words
+	var t [{{mul 2 .NbWords}}]uint64
+	var c uint64
+	{{- if .Karatsuba}}
+	karatsuba(t[:], x[:], y[:])
+	{{- else}}
+	{{- template "mul_wide" dict "all" . "V1" "x" "V2" "y" }}
+	{{- end}}
+
+	// lo += t mod R
+	{{- range $i := .NbWordsIndexesFull}}
+	acc.lo[{{$i}}], c = bits.Add64(acc.lo[{{$i}}], t[{{$i}}], {{- if eq $i 0}}0{{- else}}c{{- end}})
+	{{- end}}
+
+	// hi += t / R + c; t < q * R so that t / R + c ⩽ q
+	var h {{.ElementName}}
+	{{- range $i := .NbWordsIndexesFull}}
+	h[{{$i}}], c = bits.Add64(t[{{add $i $.NbWords}}], {{- if eq $i 0}}c, 0{{- else}}0, c{{- end}})
+	{{- end}}
+	acc.hi.Add(&acc.hi, &h)
+}
+
+// Add adds x to the accumulator
+//
+// x must be strictly inferior to q
+func (acc *Accumulator) Add(x *{{.ElementName}}) {
+	{{- if .PseudoMersenne}}
+	// lo += x, the carry goes to hi
+	var c uint64
+	{{- range $i := .NbWordsIndexesFull}}
+	acc.lo[{{$i}}], c = bits.Add64(acc.lo[{{$i}}], x[{{$i}}], {{- if eq $i 0}}0{{- else}}c{{- end}})
+	{{- end}}
+	acc.hi.Add(&acc.hi, &{{.ElementName}}{c})
+	{{- else}}
+	// x * R, so that the Montgomery reduction gives x
+	acc.hi.Add(&acc.hi, x)
+	{{- end}}
+}
+
+// Reduce returns the accumulated sum (mod q), and doesn't modify the accumulator
+func (acc *Accumulator) Reduce() {{.ElementName}} {
+	{{- if .PseudoMersenne}}
+	var t [2 * Limbs]uint64
+	copy(t[:Limbs], acc.lo[:])
+	copy(t[Limbs:], acc.hi[:])
+	var z {{.ElementName}}
+	reducePM(&z, &t)
+	return z
+}
+	{{- else}}
+	// (lo + hi * R) * R⁻¹ = lo * R⁻¹ + hi
+	// lo < R so that the montgomery reduction of lo is at most q, and is reduced by fromMont
+	z := acc.lo
+	fromMont(&z)
+	z.Add(&z, &acc.hi)
+	return z
+}
+	{{- end}}
+{{- end}}
+
+// Reset sets the accumulator to the empty sum
+func (acc *Accumulator) Reset() {
+	*acc = Accumulator{}
+}
+
+`
+
+// AccumulatorTests checks the Accumulator against Mul and Add.
+const AccumulatorTests = `
+
+func Test{{toTitle .ElementName}}Accumulator(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+	genB := gen()
+
+	properties.Property("Accumulator: MulAdd then Reduce must match Mul then Add", prop.ForAll(
+		func(a, b testPair{{.ElementName}}) bool {
+			var acc Accumulator
+			var expected, tmp {{.ElementName}}
+			x, y := a.element, b.element
+			for i := 0; i < 10; i++ {
+				acc.MulAdd(&x, &y)
+				tmp.Mul(&x, &y)
+				expected.Add(&expected, &tmp)
+				x.Add(&x, &y)
+				y.Square(&y)
+			}
+			acc.Add(&a.element)
+			expected.Add(&expected, &a.element)
+			r := acc.Reduce()
+			return r.Equal(&expected)
+		},
+		genA,
+		genB,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// long sums of the special values, such as (q-1)²
+	var acc Accumulator
+	var expected, tmp {{.ElementName}}
+	for _, a := range staticTestValues {
+		for _, b := range staticTestValues {
+			for i := 0; i < 100; i++ {
+				acc.MulAdd(&a, &b)
+				tmp.Mul(&a, &b)
+				expected.Add(&expected, &tmp)
+			}
+			r := acc.Reduce()
+			if !r.Equal(&expected) {
+				t.Fatal("Accumulator failed special test values")
+			}
+		}
+	}
+
+	acc.Reset()
+	if r := acc.Reduce(); !r.IsZero() {
+		t.Fatal("the empty sum should be 0")
+	}
+}
+
+func Benchmark{{toTitle .ElementName}}Accumulator(b *testing.B) {
+	var x, y [64]{{.ElementName}}
+	for i := range x {
+		x[i].SetRandom()
+		y[i].SetRandom()
+	}
+
+	b.Run("MulAdd+Reduce", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var acc Accumulator
+			for j := range x {
+				acc.MulAdd(&x[j], &y[j])
+			}
+			benchRes{{.ElementName}} = acc.Reduce()
+		}
+	})
+
+	b.Run("Mul+Add", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var res, tmp {{.ElementName}}
+			for j := range x {
+				tmp.Mul(&x[j], &y[j])
+				res.Add(&res, &tmp)
+			}
+			benchRes{{.ElementName}} = res
+		}
+	})
+}
+
+`
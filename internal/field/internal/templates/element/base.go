@@ -82,15 +82,23 @@ func init() {
 // 		v.SetUint64(...)
 func New{{.ElementName}}(v uint64) {{.ElementName}} {
 	z := {{.ElementName}}{v}
+	{{- if not .PseudoMersenne}}
 	z.Mul(&z, &rSquare)
+	{{- end}}
 	return z
 }
 
 // SetUint64 sets z to v and returns z
 func (z *{{.ElementName}}) SetUint64(v uint64) *{{.ElementName}} {
+	{{- if .PseudoMersenne}}
+	//  sets z LSB to v (elements are not in Montgomery form, and v < q)
+	*z = {{.ElementName}}{v}
+	return z
+	{{- else}}
 	//  sets z LSB to v (non-Montgomery form) and convert z to Montgomery form
 	*z = {{.ElementName}}{v}
 	return z.Mul(z, &rSquare) // z.ToMont()
+	{{- end}}
 }
 
 // SetInt64 sets z to v and returns z
@@ -310,6 +318,17 @@ func (z *{{.ElementName}}) LexicographicallyLargest() bool {
 // This might error only if reading from crypto/rand.Reader errors, 
 // in which case, value of z is undefined.
 func (z *{{.ElementName}}) SetRandom() (*{{.ElementName}}, error) {
+	return z.SetRandomFrom(rand.Reader)
+}
+
+// SetRandomFrom sets z to a uniform random value in [0, q), reading the randomness from r.
+//
+// Candidates are sampled by rejection, so the number of bytes read from r isn't fixed;
+// given the same stream of bytes, SetRandomFrom always returns the same value.
+//
+// This might error only if reading from r errors, 
+// in which case, value of z is undefined.
+func (z *{{.ElementName}}) SetRandomFrom(r io.Reader) (*{{.ElementName}}, error) {
 	// this code is generated for all modulus
 	// and derived from go/src/crypto/rand/util.go
 
@@ -332,7 +351,7 @@ func (z *{{.ElementName}}) SetRandom() (*{{.ElementName}}, error) {
 
 	for {
 		// note that bytes[k:l] is always 0
-		if _, err := io.ReadFull(rand.Reader, bytes[:k]); err != nil {
+		if _, err := io.ReadFull(r, bytes[:k]); err != nil {
 			return nil, err
 		}
 
@@ -433,7 +452,7 @@ func (z *{{.ElementName}}) Mul(x, y *{{.ElementName}}) *{{.ElementName}} {
 	// → q'[0] is the lowest word of the number -q⁻¹ mod r. This quantity is pre-computed, as it does not depend on the inputs.
 	// → t is a temporary array of size N+2 
 	// → C, S are machine words. A pair (C,S) refers to (hi-bits, lo-bits) of a two-word number
-	{{- if $.NoCarry}}
+	{{- if and $.NoCarry (not $.PseudoMersenne)}}
 	// 
 	// As described here https://hackmd.io/@gnark/modular_multiplication we can get rid of one carry chain and simplify:
 	//
@@ -450,6 +469,15 @@ func (z *{{.ElementName}}) Mul(x, y *{{.ElementName}}) *{{.ElementName}} {
 	// This optimization saves 5N + 2 additions in the algorithm, and can be used whenever the highest bit 
 	// of the modulus is zero (and not all of the remaining bits are set).
 	{{- end}}
+	{{- if $.Karatsuba}}
+	//
+	// On {{$.NbWords}} words, the products are computed with Karatsuba instead, see mulKaratsuba.
+	{{- end}}
+	{{- if $.PseudoMersenne}}
+	//
+	// q = 2^{{$.NbBits}} - c is a pseudo-Mersenne prime: the elements are not in Montgomery form, and
+	// the product is reduced by folding instead, see mulPM.
+	{{- end}}
 
 	{{- if eq $.NbWords 1}}
 		{{ template "mul_cios_one_limb" dict "all" . "V1" "x" "V2" "y" }}
@@ -475,7 +503,11 @@ func (z *{{.ElementName}}) Square(x *{{.ElementName}}) *{{.ElementName}} {
 }
 
 // FromMont converts z in place (i.e. mutates) from Montgomery to regular representation
+{{- if .PseudoMersenne}}
+// the elements are not in Montgomery form: it doesn't modify z, and returns z
+{{- else}}
 // sets and returns z = z * 1
+{{- end}}
 func (z *{{.ElementName}}) FromMont() *{{.ElementName}} {
 	fromMont(z)
 	return z
@@ -483,6 +515,13 @@ func (z *{{.ElementName}}) FromMont() *{{.ElementName}} {
 
 // Add z = x + y (mod q)
 func (z *{{.ElementName}}) Add( x, y *{{.ElementName}}) *{{.ElementName}} {
+	{{- if .ASMArm64}}
+	add(z, x, y)
+	return z
+}
+
+func _addGeneric(z, x, y *{{.ElementName}}) {
+	{{- end}}
 	{{ $hasCarry := or (not $.NoCarry) (gt $.NbWords 1)}}
 	{{- if $hasCarry}}
 		var carry uint64
@@ -513,7 +552,9 @@ func (z *{{.ElementName}}) Add( x, y *{{.ElementName}}) *{{.ElementName}} {
 
 		{{ template "reduce" .}}
 	{{- end}}
+	{{- if not .ASMArm64}}
 	return z
+	{{- end}}
 }
 
 // Double z = x + x (mod q), aka Lsh 1
@@ -560,6 +601,13 @@ func (z *{{.ElementName}}) Double( x *{{.ElementName}}) *{{.ElementName}} {
 
 // Sub z = x - y (mod q)
 func (z *{{.ElementName}}) Sub( x, y *{{.ElementName}}) *{{.ElementName}} {
+	{{- if .ASMArm64}}
+	sub(z, x, y)
+	return z
+}
+
+func _subGeneric(z, x, y *{{.ElementName}}) {
+	{{- end}}
 	var b uint64
 	z[0], b = bits.Sub64(x[0], y[0], 0)
 	{{- range $i := .NbWordsIndexesNoZero}}
@@ -580,7 +628,9 @@ func (z *{{.ElementName}}) Sub( x, y *{{.ElementName}}) *{{.ElementName}} {
 			{{- end}}
 		{{- end}}
 	}
+	{{- if not .ASMArm64}}
 	return z
+	{{- end}}
 }
 
 // Neg z = q - x
@@ -620,9 +670,13 @@ func _mulGeneric(z,x,y *{{.ElementName}}) {
 	// see Mul for algorithm documentation
 	{{ if eq $.NbWords 1}}
 		{{ template "mul_cios_one_limb" dict "all" . "V1" "x" "V2" "y" }}
+	{{ else if .PseudoMersenne}}
+		mulPM(z, x, y)
 	{{ else if .NoCarry}}
 		{{ template "mul_nocarry" dict "all" . "V1" "x" "V2" "y"}}
 		{{ template "reduce"  . }}
+	{{ else if .Karatsuba}}
+		mulKaratsuba(z, x, y)
 	{{ else }}
 		{{ template "mul_cios" dict "all" . "V1" "x" "V2" "y" }}
 		{{ template "reduce"  . }}
@@ -631,6 +685,16 @@ func _mulGeneric(z,x,y *{{.ElementName}}) {
 
 
 func _fromMontGeneric(z *{{.ElementName}}) {
+	{{- if .PseudoMersenne}}
+	// the elements are not in Montgomery form
+}
+	{{- else if .Karatsuba}}
+	// z = z * R⁻¹
+	var t [2 * Limbs]uint64
+	copy(t[:], z[:])
+	redc(z, &t)
+}
+	{{- else}}
 	// the following lines implement z = z * 1
 	// with a modified CIOS montgomery multiplication
 	// see Mul for algorithm documentation
@@ -648,6 +712,7 @@ func _fromMontGeneric(z *{{.ElementName}}) {
 
 	{{ template "reduce" .}}
 }
+	{{- end}}
 
 func _reduceGeneric(z *{{.ElementName}})  {
 	{{ template "reduce"  . }}
@@ -5,16 +5,27 @@ const Conv = `
 // rSquare where r is the Montgommery constant
 // see section 2.3.2 of Tolga Acar's thesis
 // https://www.microsoft.com/en-us/research/wp-content/uploads/1998/06/97Acar.pdf
+{{- if .PseudoMersenne}}
+//
+// the elements are not in Montgomery form: rSquare is 1
+{{- end}}
 var rSquare = {{.ElementName}}{
 	{{- range $i := .RSquare}}
 	{{$i}},{{end}}
 }
 
 // ToMont converts z to Montgomery form
+{{- if .PseudoMersenne}}
+// the elements are not in Montgomery form: it doesn't modify z, and returns z
+func (z *{{.ElementName}}) ToMont() *{{.ElementName}} {
+	return z
+}
+{{- else}}
 // sets and returns z = z * r²
 func (z *{{.ElementName}}) ToMont() *{{.ElementName}} {
 	return z.Mul(z, &rSquare)
 }
+{{- end}}
 
 // ToRegular returns z in regular form (doesn't mutate z)
 func (z {{.ElementName}}) ToRegular() {{.ElementName}} {
@@ -138,6 +149,56 @@ func (z *{{.ElementName}}) SetBytes(e []byte) *{{.ElementName}} {
 	return z
 }
 
+// SetBytesWide interprets e as the bytes of a big-endian unsigned integer, reduces it modulo q,
+// sets z to that value, and returns z.
+//
+// Unlike SetBytes, it is meant for inputs wider than q: if e is uniformly random, z is within
+// statistical distance q / 2^(8*len(e)) of the uniform distribution (for example, it is negligible
+// for a 64-byte input and a 256-bit q). Inputs of at most 2*Bytes bytes are reduced with
+{{- if .PseudoMersenne}} reducePM,
+// without allocations; longer inputs go through SetBytes.
+{{- else}} Montgomery
+// multiplications, without allocations; longer inputs go through SetBytes.
+{{- end}}
+func (z *{{.ElementName}}) SetBytesWide(e []byte) *{{.ElementName}} {
+	if len(e) > 2*Bytes {
+		return z.SetBytes(e)
+	}
+
+	// e = hi * 2^(64*Limbs) + lo, hi and lo not necessarily reduced
+	var buf [2 * Bytes]byte
+	copy(buf[2*Bytes-len(e):], e)
+
+	{{- if .PseudoMersenne}}
+
+	var t [2 * Limbs]uint64
+	for i := 0; i < 2*Limbs; i++ {
+		t[i] = binary.BigEndian.Uint64(buf[2*Bytes-8*(i+1) : 2*Bytes-8*i])
+	}
+
+	// the elements are not in Montgomery form, reducePM reduces any value on 2*Limbs words
+	reducePM(z, &t)
+	return z
+	{{- else}}
+
+	var hi, lo {{.ElementName}}
+	for i := 0; i < Limbs; i++ {
+		hi[i] = binary.BigEndian.Uint64(buf[Bytes-8*(i+1) : Bytes-8*i])
+		lo[i] = binary.BigEndian.Uint64(buf[2*Bytes-8*(i+1) : 2*Bytes-8*i])
+	}
+
+	// the CIOS multiplication only needs x * y < q * R to output a reduced result,
+	// which holds for x < R and y = R² mod q
+	// lo * R² * R⁻¹ = lo * R, the montgomery form of lo
+	mulCT(&lo, &lo, &rSquare)
+
+	// hi * R² * R⁻¹ * R² * R⁻¹ = (hi * R) * R, the montgomery form of hi * 2^(64*Limbs)
+	mulCT(&hi, &hi, &rSquare)
+	mulCT(&hi, &hi, &rSquare)
+
+	return z.Add(&hi, &lo)
+	{{- end}}
+}
 
 // SetBigInt sets z to v and returns z
 func (z *{{.ElementName}}) SetBigInt(v *big.Int) *{{.ElementName}} {
@@ -0,0 +1,603 @@
+package element
+
+// ConstantTime contains the constant-time variants of Inverse, Exp and Sqrt, and of the
+// arithmetic operations (Add, Sub, Double, Neg, Mul, Square).
+//
+// They only use mulCT (a portable CIOS multiplication with a branch-free final reduction)
+// and masked selections, so that the sequence of operations doesn't depend on the inputs.
+// Each of these operations calls onCT, which records it with the ctcount build tag.
+const ConstantTime = `
+
+// ctOp{{.ElementName}} is an operation of the constant-time methods, recorded by onCT
+type ctOp{{.ElementName}} uint8
+
+const (
+	ctMul{{.ElementName}} ctOp{{.ElementName}} = iota // mulCT
+	ctAdd{{.ElementName}}                              // AddCT, a masked subtraction of q
+	ctSub{{.ElementName}}                              // SubCT, a masked addition of q
+	ctSwap{{.ElementName}}                             // cswapCT
+	ctSelect{{.ElementName}}                           // a masked selection of SqrtCT
+)
+
+// qMinusTwo{{.ElementName}} q - 2, exponent of the constant-time inversion
+var qMinusTwo{{.ElementName}} = [{{.NbWords}}]uint64{
+	{{- range $w := .QMinusTwo}}
+	{{$w}},{{end}}
+}
+
+// sqrtCTExponent{{.ElementName}} (s-1)/2 where q - 1 = 2ᵉ * s, s odd
+var sqrtCTExponent{{.ElementName}} = [{{.NbWords}}]uint64{
+	{{- range $w := .SqrtCTExponent}}
+	{{$w}},{{end}}
+}
+
+// InverseCT z = x⁻¹ (mod q)
+//
+// Unlike Inverse, InverseCT runs in constant time: it computes x^(q-2) with a fixed sequence
+// of multiplications.
+//
+// note: allowing cases where x == 0, it sets and returns z = x
+func (z *{{.ElementName}}) InverseCT(x *{{.ElementName}}) *{{.ElementName}} {
+	return z.expCT(x, qMinusTwo{{.ElementName}}[:], Bits)
+}
+
+// ExpCT z = xᵏ (mod q)
+//
+// Unlike Exp, ExpCT runs in constant time with respect to x and k: it uses a Montgomery ladder
+// over max(Bits, k.BitLen()) bits, that is, only the bit length of exponents larger than the
+// modulus and the sign of k are leaked.
+func (z *{{.ElementName}}) ExpCT(x {{.ElementName}}, k *big.Int) *{{.ElementName}} {
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ (mod q) == (x⁻¹)ᵏ (mod q)
+		x.InverseCT(&x)
+
+		// we negate k in a temp big.Int since
+		// Int.Bit(_) of k and -k is different
+		e = bigIntPool.Get().(*big.Int)
+		defer bigIntPool.Put(e)
+		e.Neg(k)
+	}
+
+	nbBits := Bits
+	if e.BitLen() > nbBits {
+		nbBits = e.BitLen()
+	}
+
+	// copy the words of e in a fixed size buffer
+	var buf [Limbs]uint64
+	words := buf[:]
+	if n := (nbBits + 63) / 64; n > Limbs {
+		words = make([]uint64, n)
+	}
+	for i := 0; i < nbBits; i++ {
+		words[i/64] |= uint64(e.Bit(i)) << uint(i%64)
+	}
+
+	return z.expCT(&x, words, nbBits)
+}
+
+// SqrtCT z = √x (mod q)
+//
+// Unlike Sqrt, SqrtCT runs in constant time: it uses the constant-time variant of
+// Tonelli-Shanks described in RFC 9380 (Appendix I.4), for all q.
+// Only whether x is a square or not is leaked:
+// if the square root doesn't exist (x is not a square mod q)
+// SqrtCT leaves z unchanged and returns nil
+func (z *{{.ElementName}}) SqrtCT(x *{{.ElementName}}) *{{.ElementName}} {
+	// q - 1 = 2ᵉ * s, s odd
+	var y, b, t, c, tmp {{.ElementName}}
+
+	// y = x^((s-1)/2)
+	y.expCT(x, sqrtCTExponent{{.ElementName}}[:], Bits)
+
+	// t = x^s, y = x^((s+1)/2)
+	mulCT(&t, &y, &y)
+	mulCT(&t, &t, x)
+	mulCT(&y, &y, x)
+
+	// c = g^s, g a non-residue
+	c = {{.ElementName}}{
+		{{- if .F31}}
+		{{.F31SqrtCTG}},
+		{{- else}}
+		{{- range $w := .SqrtCTG}}
+		{{$w}},{{end}}
+		{{- end}}
+	}
+	b = t
+
+	for i := {{.SqrtCTE}}; i >= 2; i-- {
+		for j := 1; j <= i-2; j++ {
+			mulCT(&b, &b, &b)
+		}
+		// if b ≠ 1, y = y * c and t = t * c²
+		isOne := int(b.isOneCT())
+		mulCT(&tmp, &y, &c)
+		onCT(ctSelect{{.ElementName}})
+		y.Select(isOne, &tmp, &y)
+		mulCT(&c, &c, &c)
+		mulCT(&tmp, &t, &c)
+		onCT(ctSelect{{.ElementName}})
+		t.Select(isOne, &tmp, &t)
+		b = t
+	}
+
+	// as we didn't compute the legendre symbol, ensure we found y such that y * y = x
+	mulCT(&tmp, &y, &y)
+	if tmp.NotEqual(x) != 0 {
+		return nil
+	}
+	return z.Set(&y)
+}
+
+// AddCT z = x + y (mod q)
+//
+// Unlike Add, AddCT reduces the sum with a masked subtraction of q: it doesn't branch on x and y.
+func (z *{{.ElementName}}) AddCT(x, y *{{.ElementName}}) *{{.ElementName}} {
+	onCT(ctAdd{{.ElementName}})
+	{{- if .F31}}
+	// Add is already branch-free on a single word
+	return z.Add(x, y)
+	{{- else}}
+	var t [{{.NbWords}}]uint64
+	var carry uint64
+	{{- range $i := .NbWordsIndexesFull}}
+	t[{{$i}}], carry = bits.Add64(x[{{$i}}], y[{{$i}}], {{- if eq $i 0}}0{{- else}}carry{{- end}})
+	{{- end}}
+
+	// z = t - q if t ≥ q, t otherwise
+	var b uint64
+	{{- range $i := .NbWordsIndexesFull}}
+	z[{{$i}}], b = bits.Sub64(t[{{$i}}], q{{$i}}, {{- if eq $i 0}}0{{- else}}b{{- end}})
+	{{- end}}
+	_, b = bits.Sub64(carry, 0, b)
+
+	// b == 1 iff t < q
+	mask := -b
+	{{- range $i := .NbWordsIndexesFull}}
+	z[{{$i}}] ^= mask & (z[{{$i}}] ^ t[{{$i}}])
+	{{- end}}
+	return z
+	{{- end}}
+}
+
+// DoubleCT z = x + x (mod q), without branching (see AddCT)
+func (z *{{.ElementName}}) DoubleCT(x *{{.ElementName}}) *{{.ElementName}} {
+	return z.AddCT(x, x)
+}
+
+// SubCT z = x - y (mod q)
+//
+// Unlike Sub, SubCT adds q to a negative difference with a mask: it doesn't branch on x and y.
+func (z *{{.ElementName}}) SubCT(x, y *{{.ElementName}}) *{{.ElementName}} {
+	onCT(ctSub{{.ElementName}})
+	{{- if .F31}}
+	// Sub is already branch-free on a single word
+	return z.Sub(x, y)
+	{{- else}}
+	var b uint64
+	{{- range $i := .NbWordsIndexesFull}}
+	z[{{$i}}], b = bits.Sub64(x[{{$i}}], y[{{$i}}], {{- if eq $i 0}}0{{- else}}b{{- end}})
+	{{- end}}
+
+	// z = z + q if x < y
+	mask := -b
+	{{- if gt .NbWords 1}}
+	var c uint64
+	{{- end}}
+	{{- range $i := .NbWordsIndexesFull}}
+	z[{{$i}}], {{- if eq $i $.NbWordsLastIndex}}_{{- else}}c{{- end}} = bits.Add64(z[{{$i}}], q{{$i}}&mask, {{- if eq $i 0}}0{{- else}}c{{- end}})
+	{{- end}}
+	return z
+	{{- end}}
+}
+
+// NegCT z = q - x, and z = 0 if x = 0, without branching (see SubCT)
+func (z *{{.ElementName}}) NegCT(x *{{.ElementName}}) *{{.ElementName}} {
+	var zero {{.ElementName}}
+	return z.SubCT(&zero, x)
+}
+
+// MulCT z = x * y (mod q)
+//
+// Unlike Mul, whose final reduction may branch on targets without assembly, MulCT is
+// branch-free on all targets.
+func (z *{{.ElementName}}) MulCT(x, y *{{.ElementName}}) *{{.ElementName}} {
+	mulCT(z, x, y)
+	return z
+}
+
+// SquareCT z = x * x (mod q), without branching (see MulCT)
+func (z *{{.ElementName}}) SquareCT(x *{{.ElementName}}) *{{.ElementName}} {
+	mulCT(z, x, x)
+	return z
+}
+
+// expCT z = xᵏ (mod q), processing the nbBits low bits of k (little endian words)
+// with a Montgomery ladder
+func (z *{{.ElementName}}) expCT(x *{{.ElementName}}, k []uint64, nbBits int) *{{.ElementName}} {
+	// invariant: r1 = r0 * x
+	var r0, r1 {{.ElementName}}
+	r0.SetOne()
+	r1.Set(x)
+
+	for i := nbBits - 1; i >= 0; i-- {
+		bit := (k[i/64] >> uint(i%64)) & 1
+		cswapCT(&r0, &r1, bit)
+		mulCT(&r1, &r0, &r1)
+		mulCT(&r0, &r0, &r0)
+		cswapCT(&r0, &r1, bit)
+	}
+
+	return z.Set(&r0)
+}
+
+// isOneCT returns 1 if z == 1, 0 otherwise, without branching
+func (z *{{.ElementName}}) isOneCT() uint64 {
+	one := One()
+	v := z.NotEqual(&one)
+	return 1 ^ ((v | -v) >> 63)
+}
+
+// cswapCT swaps a and b if c == 1, leaves them unchanged if c == 0, without branching
+func cswapCT(a, b *{{.ElementName}}, c uint64) {
+	onCT(ctSwap{{.ElementName}})
+	{{- if .F31}}
+	mask := -uint32(c)
+	{{- else}}
+	mask := -c
+	{{- end}}
+	{{- range $i := .NbWordsIndexesFull}}
+	t{{$i}} := mask & (a[{{$i}}] ^ b[{{$i}}])
+	a[{{$i}}] ^= t{{$i}}
+	b[{{$i}}] ^= t{{$i}}
+	{{- end}}
+}
+
+// mulCT z = x * y (mod q)
+//
+{{- if .F31}}
+// It implements the same multiplication as Mul, which is already branch-free on a single word.
+{{- else if .Karatsuba}}
+// It implements the same multiplication as Mul, mulKaratsuba, which is already branch-free.
+{{- else if .PseudoMersenne}}
+// It implements the same multiplication as Mul, mulPM, which is already branch-free.
+{{- else}}
+// It implements the same CIOS multiplication as Mul, but its final reduction is branch-free
+// on all targets.
+{{- end}}
+//
+// x and y must be strictly inferior to q
+func mulCT(z, x, y *{{.ElementName}}) {
+	onCT(ctMul{{.ElementName}})
+	{{- if .F31}}
+	z[0] = montReduce(uint64(x[0]) * uint64(y[0]))
+}
+	{{- else if .Karatsuba}}
+	mulKaratsuba(z, x, y)
+}
+	{{- else if .PseudoMersenne}}
+	mulPM(z, x, y)
+}
+	{{- else}}
+
+	{{- template "mul_cios_loop" dict "all" . "V1" "x" "V2" "y" }}
+
+	// t < 2q is on {{add .NbWords 1}} words; z = t - q if t ≥ q, t otherwise
+	var b uint64
+	{{- range $i := .NbWordsIndexesFull}}
+	z[{{$i}}], b = bits.Sub64(t[{{$i}}], q{{$i}}, {{- if eq $i 0}}0{{- else}}b{{- end}})
+	{{- end}}
+	_, b = bits.Sub64(t[{{.NbWords}}], 0, b)
+
+	// b == 1 iff t < q
+	mask := -b
+	{{- range $i := .NbWordsIndexesFull}}
+	z[{{$i}}] ^= mask & (z[{{$i}}] ^ t[{{$i}}])
+	{{- end}}
+}
+	{{- end}}
+
+`
+
+// ConstantTimeTests checks the constant-time methods against their variable-time counterparts,
+// and that their sequence of multiplications doesn't depend on their inputs.
+const ConstantTimeTests = `
+
+func Test{{toTitle .ElementName}}InverseCT(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+
+	properties.Property("InverseCT must match Inverse", prop.ForAll(
+		func(a testPair{{.ElementName}}) bool {
+			var b, c {{.ElementName}}
+			b.InverseCT(&a.element)
+			c.Inverse(&a.element)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	for _, a := range staticTestValues {
+		var b, c {{.ElementName}}
+		b.InverseCT(&a)
+		c.Inverse(&a)
+		if !b.Equal(&c) {
+			t.Fatal("InverseCT failed special test values")
+		}
+	}
+}
+
+func Test{{toTitle .ElementName}}ExpCT(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+	genB := gen()
+
+	properties.Property("ExpCT must match Exp", prop.ForAll(
+		func(a, b testPair{{.ElementName}}) bool {
+			var c, d {{.ElementName}}
+			c.ExpCT(a.element, &b.bigint)
+			d.Exp(a.element, &b.bigint)
+			return c.Equal(&d)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("ExpCT must match Exp with negative and large exponents", prop.ForAll(
+		func(a, b testPair{{.ElementName}}) bool {
+			var k big.Int
+			k.Lsh(&b.bigint, Bits+3).Neg(&k)
+			var c, d {{.ElementName}}
+			c.ExpCT(a.element, &k)
+			d.Exp(a.element, &k)
+			return c.Equal(&d)
+		},
+		genA,
+		genB,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	for _, a := range staticTestValues {
+		for _, b := range staticTestValues {
+			var bBig big.Int
+			b.ToBigIntRegular(&bBig)
+			var c, d {{.ElementName}}
+			c.ExpCT(a, &bBig)
+			d.Exp(a, &bBig)
+			if !c.Equal(&d) {
+				t.Fatal("ExpCT failed special test values")
+			}
+		}
+	}
+}
+
+func Test{{toTitle .ElementName}}SqrtCT(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+
+	properties.Property("SqrtCT must match Sqrt", prop.ForAll(
+		func(a testPair{{.ElementName}}) bool {
+			return checkSqrtCT{{.ElementName}}(&a.element)
+		},
+		genA,
+	))
+
+	properties.Property("SqrtCT of a square must be a square root", prop.ForAll(
+		func(a testPair{{.ElementName}}) bool {
+			var s {{.ElementName}}
+			s.Square(&a.element)
+			return checkSqrtCT{{.ElementName}}(&s)
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	for _, a := range staticTestValues {
+		if !checkSqrtCT{{.ElementName}}(&a) {
+			t.Fatal("SqrtCT failed special test values")
+		}
+	}
+}
+
+func Test{{toTitle .ElementName}}ArithmeticCT(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+	genB := gen()
+
+	properties.Property("the CT operations must match their variable-time counterparts", prop.ForAll(
+		func(a, b testPair{{.ElementName}}) bool {
+			return checkArithmeticCT{{.ElementName}}(&a.element, &b.element)
+		},
+		genA,
+		genB,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	for _, a := range staticTestValues {
+		for _, b := range staticTestValues {
+			if !checkArithmeticCT{{.ElementName}}(&a, &b) {
+				t.Fatal("CT operations failed special test values")
+			}
+		}
+	}
+}
+
+func checkArithmeticCT{{.ElementName}}(a, b *{{.ElementName}}) bool {
+	var c, d {{.ElementName}}
+	if !c.AddCT(a, b).Equal(d.Add(a, b)) {
+		return false
+	}
+	if !c.SubCT(a, b).Equal(d.Sub(a, b)) {
+		return false
+	}
+	if !c.DoubleCT(a).Equal(d.Double(a)) {
+		return false
+	}
+	if !c.NegCT(a).Equal(d.Neg(a)) {
+		return false
+	}
+	if !c.MulCT(a, b).Equal(d.Mul(a, b)) {
+		return false
+	}
+	return c.SquareCT(a).Equal(d.Square(a))
+}
+
+func checkSqrtCT{{.ElementName}}(a *{{.ElementName}}) bool {
+	var b, c {{.ElementName}}
+	rb := b.SqrtCT(a)
+	rc := c.Sqrt(a)
+	if rb == nil || rc == nil {
+		// both must agree on the existence of a square root
+		return rb == nil && rc == nil && b.IsZero()
+	}
+	b.Square(&b)
+	return b.Equal(a)
+}
+
+`
+
+// ConstantTimeCount records the operations of the constant-time methods; it is only built with
+// the ctcount build tag, so that the production methods don't pay for the hook.
+const ConstantTimeCount = `
+
+// ctHook is called by each operation of the constant-time methods if set; the tests use it to
+// check that their sequence of operations is the same whatever their inputs are.
+var ctHook func(op ctOp{{.ElementName}})
+
+func onCT(op ctOp{{.ElementName}}) {
+	if ctHook != nil {
+		ctHook(op)
+	}
+}
+`
+
+// ConstantTimeNoCount is the default, empty, onCT.
+const ConstantTimeNoCount = `
+
+// onCT does nothing; build with the ctcount tag to record the operations of the constant-time methods.
+func onCT(op ctOp{{.ElementName}}) {}
+`
+
+// ConstantTimeCountTests checks that the sequence of multiplications, masked additions and
+// subtractions, swaps and selections of the constant-time methods doesn't depend on their inputs;
+// it is only built with the ctcount build tag.
+//
+// It doesn't measure time: the operations themselves are branch-free and don't index memory
+// with their inputs by construction, which the tests can't check.
+const ConstantTimeCountTests = `
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// traceCT{{.ElementName}} returns the sequence of operations of the constant-time methods performed by f
+func traceCT{{.ElementName}}(f func()) []ctOp{{.ElementName}} {
+	var trace []ctOp{{.ElementName}}
+	ctHook = func(op ctOp{{.ElementName}}) { trace = append(trace, op) }
+	defer func() { ctHook = nil }()
+	f()
+	return trace
+}
+
+// Test{{toTitle .ElementName}}CTOperationSequence is not parallel, as it sets the package level ctHook
+func Test{{toTitle .ElementName}}CTOperationSequence(t *testing.T) {
+	assert := require.New(t)
+
+	inputs := []{{.ElementName}}{ {}, One()}
+	var x {{.ElementName}}
+	for i := 0; i < 8; i++ {
+		x.SetRandom()
+		inputs = append(inputs, x)
+		x.Square(&x)
+		inputs = append(inputs, x)
+	}
+	x.SetOne()
+	x.Neg(&x)
+	inputs = append(inputs, x)
+
+	// exponents of same bit length (at most Bits) must yield the same sequence of operations
+	exponents := []*big.Int{big.NewInt(0), big.NewInt(1), new(big.Int).Sub(Modulus(), big.NewInt(1))}
+	for i := 0; i < 4; i++ {
+		x.SetRandom()
+		exponents = append(exponents, x.ToBigIntRegular(new(big.Int)))
+	}
+
+	var z {{.ElementName}}
+	traceInverse := traceCT{{.ElementName}}(func() { z.InverseCT(&inputs[0]) })
+	traceExp := traceCT{{.ElementName}}(func() { z.ExpCT(inputs[0], exponents[0]) })
+	traceSqrt := traceCT{{.ElementName}}(func() { z.SqrtCT(&inputs[0]) })
+	traceArith := traceCT{{.ElementName}}(func() { arithmeticCT{{.ElementName}}(&z, &inputs[0], &inputs[1]) })
+	assert.Contains(traceExp, ctSwap{{.ElementName}}, "the masked operations should be recorded")
+
+	for i := range inputs {
+		a := inputs[i]
+		assert.Equal(traceInverse, traceCT{{.ElementName}}(func() { z.InverseCT(&a) }), "InverseCT")
+		assert.Equal(traceSqrt, traceCT{{.ElementName}}(func() { z.SqrtCT(&a) }), "SqrtCT")
+		for _, k := range exponents {
+			assert.Equal(traceExp, traceCT{{.ElementName}}(func() { z.ExpCT(a, k) }), "ExpCT")
+		}
+		for j := range inputs {
+			b := inputs[j]
+			assert.Equal(traceArith, traceCT{{.ElementName}}(func() { arithmeticCT{{.ElementName}}(&z, &a, &b) }), "arithmetic")
+		}
+	}
+}
+
+// arithmeticCT{{.ElementName}} runs all the constant-time arithmetic operations on a and b
+func arithmeticCT{{.ElementName}}(z, a, b *{{.ElementName}}) {
+	z.AddCT(a, b)
+	z.SubCT(a, b)
+	z.DoubleCT(a)
+	z.NegCT(a)
+	z.MulCT(a, b)
+	z.SquareCT(a)
+}
+
+`
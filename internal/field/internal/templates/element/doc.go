@@ -7,8 +7,14 @@ const Doc = `
 // 
 // The modulus is hardcoded in all the operations.
 // 
+{{- if .F31}}
+// Field elements are represented as an array of a single uint32 word{{if .Mersenne31}}, in regular form since
+// 2³¹ ≡ 1 (mod q) gives a cheap reduction{{else}}, and assumed to be in Montgomery form (R = 2³²) in all methods{{end}}:
+// 	type {{.ElementName}} [1]uint32
+{{- else}}
 // Field elements are represented as an array, and assumed to be in Montgomery form in all methods:
 // 	type {{.ElementName}} [{{.NbWords}}]uint64
+{{- end}}
 //
 // Usage
 //
@@ -26,6 +32,11 @@ const Doc = `
 // 	 .Inv(a)
 // 	b.Exp(b, new(big.Int).SetUint64(42))
 //
+// Slices of field elements can be manipulated in batch through the Vector type:
+// 	var a, b, c Vector
+// 	c.Mul(a, b)
+// 	s := c.InnerProduct(a)
+//
 // Modulus q =
 //
 // 	q[base10] = {{.Modulus}}
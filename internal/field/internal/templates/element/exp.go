@@ -19,6 +19,38 @@ func (z *{{.ElementName}}) Exp(x {{.ElementName}}, k *big.Int) *{{.ElementName}}
 		defer bigIntPool.Put(e)
 		e.Neg(k)
 	}
+	{{- if .Karatsuba}}
+
+	// fixed-window exponentiation: e is processed by windows of expWindowSize bits,
+	// using the precomputed powers x⁰, x¹, ..., x^(2ʷ-1)
+	const expWindowSize = 4
+	var table [1 << expWindowSize]{{.ElementName}}
+	table[0].SetOne()
+	table[1] = x
+	for i := 2; i < len(table); i++ {
+		table[i].Mul(&table[i-1], &x)
+	}
+
+	// window returns the bits [i*w, (i+1)*w) of e
+	window := func(i int) uint {
+		var d uint
+		for j := expWindowSize - 1; j >= 0; j-- {
+			d = d<<1 | e.Bit(i*expWindowSize+j)
+		}
+		return d
+	}
+
+	nbWindows := (e.BitLen() + expWindowSize - 1) / expWindowSize
+	z.Set(&table[window(nbWindows-1)])
+	for i := nbWindows - 2; i >= 0; i-- {
+		for j := 0; j < expWindowSize; j++ {
+			z.Square(z)
+		}
+		if d := window(i); d != 0 {
+			z.Mul(z, &table[d])
+		}
+	}
+	{{- else}}
 
 	z.Set(&x)
 
@@ -28,6 +60,7 @@ func (z *{{.ElementName}}) Exp(x {{.ElementName}}, k *big.Int) *{{.ElementName}}
 			z.Mul(z, &x)
 		}
 	}
+	{{- end}}
 
 	return z
 }
@@ -0,0 +1,1024 @@
+package element
+
+// F31 contains the arithmetic of the fields whose modulus fits on 31 bits.
+//
+// Elements are stored on a single uint32 word, and the multiplication is a Montgomery
+// multiplication with R = 2³², or a reduction by folding for q = 2³¹ - 1. The API is the same
+// as the multi-word Element (see Base, Conv, ...).
+const F31 = `
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+	"math/bits"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// {{.ElementName}} represents a field element stored on 1 word (uint32)
+//
+{{- if .Mersenne31}}
+// {{.ElementName}} are stored in regular form: as 2³¹ ≡ 1 (mod q), the reduction folds the high bits
+// on the low ones and the Montgomery form isn't needed. ToMont and FromMont are no-ops.
+{{- else}}
+// {{.ElementName}} are assumed to be in Montgomery form (R = 2³²) in all methods.
+{{- end}}
+//
+// Modulus q =
+//
+// 	q[base10] = {{.Modulus}}
+// 	q[base16] = 0x{{.ModulusHex}}
+//
+// Warning
+//
+// This code has not been audited and is provided as-is. In particular, there is no security guarantees such as constant time implementation or side-channel attack resistance.
+type {{.ElementName}} [1]uint32
+
+const (
+	Limbs = 1  // number of 32 bits words needed to represent a {{.ElementName}}
+	Bits  = {{.NbBits}} // number of bits needed to represent a {{.ElementName}}
+	Bytes = Limbs * 4 // number of bytes needed to represent a {{.ElementName}}
+)
+
+// Field modulus q
+const (
+	q0 uint32 = {{index .Q 0}}
+	q  uint32 = q0
+)
+
+var q{{.ElementName}} = {{.ElementName}}{
+	q0,
+}
+
+var _modulus big.Int // q stored as big.Int
+
+// Modulus returns q as a big.Int
+//
+// 	q[base10] = {{.Modulus}}
+// 	q[base16] = 0x{{.ModulusHex}}
+func Modulus() *big.Int {
+	return new(big.Int).Set(&_modulus)
+}
+
+{{- if not .Mersenne31}}
+
+// q + r'.r = 1, i.e., qInvNeg = - q⁻¹ mod r
+// used for Montgomery reduction
+const qInvNeg uint32 = {{.F31QInvNeg}}
+{{- end}}
+
+var bigIntPool = sync.Pool{
+	New: func() interface{} {
+		return new(big.Int)
+	},
+}
+
+func init() {
+	_modulus.SetString("{{.ModulusHex}}", 16)
+}
+
+// New{{.ElementName}} returns a new {{.ElementName}} from a uint64 value
+//
+// it is equivalent to
+// 		var v {{.ElementName}}
+// 		v.SetUint64(...)
+func New{{.ElementName}}(v uint64) {{.ElementName}} {
+	z := {{.ElementName}}{uint32(v % uint64(q))}
+	z.ToMont()
+	return z
+}
+
+// SetUint64 sets z to v and returns z
+func (z *{{.ElementName}}) SetUint64(v uint64) *{{.ElementName}} {
+	//  sets z to v mod q (non-Montgomery form) and convert z to Montgomery form
+	*z = {{.ElementName}}{uint32(v % uint64(q))}
+	return z.ToMont()
+}
+
+// SetInt64 sets z to v and returns z
+func (z *{{.ElementName}}) SetInt64(v int64) *{{.ElementName}} {
+
+	// absolute value of v
+	m := v >> 63
+	z.SetUint64(uint64((v ^ m) - m))
+
+	if m != 0 {
+		// v is negative
+		z.Neg(z)
+	}
+
+	return z
+}
+
+// Set z = x and returns z
+func (z *{{.ElementName}}) Set(x *{{.ElementName}}) *{{.ElementName}} {
+	z[0] = x[0]
+	return z
+}
+
+// SetInterface converts provided interface into {{.ElementName}}
+// returns an error if provided type is not supported
+// supported types:
+//  {{.ElementName}}
+//  *{{.ElementName}}
+//  uint64
+//  int
+//  string (see SetString for valid formats)
+//  *big.Int
+//  big.Int
+//  []byte
+func (z *{{.ElementName}}) SetInterface(i1 interface{}) (*{{.ElementName}}, error) {
+	if i1 == nil {
+		return nil, errors.New("can't set {{.PackageName}}.{{.ElementName}} with <nil>")
+	}
+
+	switch c1 := i1.(type) {
+	case {{.ElementName}}:
+		return z.Set(&c1), nil
+	case *{{.ElementName}}:
+		if c1 == nil {
+			return nil, errors.New("can't set {{.PackageName}}.{{.ElementName}} with <nil>")
+		}
+		return z.Set(c1), nil
+	case uint8:
+		return z.SetUint64(uint64(c1)), nil
+	case uint16:
+		return z.SetUint64(uint64(c1)), nil
+	case uint32:
+		return z.SetUint64(uint64(c1)), nil
+	case uint:
+		return z.SetUint64(uint64(c1)), nil
+	case uint64:
+		return z.SetUint64(c1), nil
+	case int8:
+		return z.SetInt64(int64(c1)), nil
+	case int16:
+		return z.SetInt64(int64(c1)), nil
+	case int32:
+		return z.SetInt64(int64(c1)), nil
+	case int64:
+		return z.SetInt64(c1), nil
+	case int:
+		return z.SetInt64(int64(c1)), nil
+	case string:
+		return z.SetString(c1)
+	case *big.Int:
+		if c1 == nil {
+			return nil, errors.New("can't set {{.PackageName}}.{{.ElementName}} with <nil>")
+		}
+		return z.SetBigInt(c1), nil
+	case big.Int:
+		return z.SetBigInt(&c1), nil
+	case []byte:
+		return z.SetBytes(c1), nil
+	default:
+		return nil, errors.New("can't set {{.PackageName}}.{{.ElementName}} from type " + reflect.TypeOf(i1).String())
+	}
+}
+
+// SetZero z = 0
+func (z *{{.ElementName}}) SetZero() *{{.ElementName}} {
+	z[0] = 0
+	return z
+}
+
+// SetOne z = 1 (in Montgomery form)
+func (z *{{.ElementName}}) SetOne() *{{.ElementName}} {
+	z[0] = {{.F31One}}
+	return z
+}
+
+// Div z = x*y⁻¹ (mod q)
+func (z *{{.ElementName}}) Div(x, y *{{.ElementName}}) *{{.ElementName}} {
+	var yInv {{.ElementName}}
+	yInv.Inverse(y)
+	z.Mul(x, &yInv)
+	return z
+}
+
+// Bit returns the i'th bit, with lsb == bit 0.
+//
+// It is the responsibility of the caller to convert from Montgomery to Regular form if needed.
+func (z *{{.ElementName}}) Bit(i uint64) uint64 {
+	if i >= 32 {
+		return 0
+	}
+	return uint64(z[0] >> i & 1)
+}
+
+// Equal returns z == x; constant-time
+func (z *{{.ElementName}}) Equal(x *{{.ElementName}}) bool {
+	return z.NotEqual(x) == 0
+}
+
+// NotEqual returns 0 if and only if z == x; constant-time
+func (z *{{.ElementName}}) NotEqual(x *{{.ElementName}}) uint64 {
+	return uint64(z[0] ^ x[0])
+}
+
+// IsZero returns z == 0
+func (z *{{.ElementName}}) IsZero() bool {
+	return z[0] == 0
+}
+
+// IsOne returns z == 1
+func (z *{{.ElementName}}) IsOne() bool {
+	return z[0] == {{.F31One}}
+}
+
+// IsUint64 reports whether z can be represented as an uint64.
+func (z *{{.ElementName}}) IsUint64() bool {
+	return true
+}
+
+// Uint64 returns the uint64 representation of x. If x cannot be represented in a uint64, the result is undefined.
+func (z *{{.ElementName}}) Uint64() uint64 {
+	zz := *z
+	zz.FromMont()
+	return uint64(zz[0])
+}
+
+// FitsOnOneWord reports whether z words (except the least significant word) are 0
+//
+// It is the responsibility of the caller to convert from Montgomery to Regular form if needed.
+func (z *{{.ElementName}}) FitsOnOneWord() bool {
+	return true
+}
+
+// Cmp compares (lexicographic order) z and x and returns:
+//
+//   -1 if z <  x
+//    0 if z == x
+//   +1 if z >  x
+//
+func (z *{{.ElementName}}) Cmp(x *{{.ElementName}}) int {
+	_z := *z
+	_x := *x
+	_z.FromMont()
+	_x.FromMont()
+	if _z[0] > _x[0] {
+		return 1
+	} else if _z[0] < _x[0] {
+		return -1
+	}
+	return 0
+}
+
+// LexicographicallyLargest returns true if this element is strictly lexicographically
+// larger than its negation, false otherwise
+func (z *{{.ElementName}}) LexicographicallyLargest() bool {
+	// we check if the element is larger than (q-1) / 2
+	_z := *z
+	_z.FromMont()
+	return _z[0] >= {{index .QMinusOneHalvedP 0}}
+}
+
+// SetRandom sets z to a uniform random value in [0, q).
+//
+// This might error only if reading from crypto/rand.Reader errors,
+// in which case, value of z is undefined.
+func (z *{{.ElementName}}) SetRandom() (*{{.ElementName}}, error) {
+	return z.SetRandomFrom(rand.Reader)
+}
+
+// SetRandomFrom sets z to a uniform random value in [0, q), reading the randomness from r.
+//
+// Candidates are sampled by rejection, so the number of bytes read from r isn't fixed;
+// given the same stream of bytes, SetRandomFrom always returns the same value.
+//
+// This might error only if reading from r errors,
+// in which case, value of z is undefined.
+func (z *{{.ElementName}}) SetRandomFrom(r io.Reader) (*{{.ElementName}}, error) {
+	// derived from go/src/crypto/rand/util.go
+
+	// bitLen is the maximum bit length needed to encode a value < q.
+	const bitLen = {{.NbBits}}
+
+	// k is the maximum byte length needed to encode a value < q.
+	const k = (bitLen + 7) / 8
+
+	// b is the number of bits in the most significant byte of q-1.
+	b := uint(bitLen % 8)
+	if b == 0 {
+		b = 8
+	}
+
+	var bytes [Bytes]byte
+
+	for {
+		// note that bytes[k:Bytes] is always 0
+		if _, err := io.ReadFull(r, bytes[:k]); err != nil {
+			return nil, err
+		}
+
+		// Clear unused bits in in the most signicant byte to increase probability
+		// that the candidate is < q.
+		bytes[k-1] &= uint8(int(1<<b) - 1)
+		z[0] = binary.LittleEndian.Uint32(bytes[:])
+
+		if !z.smallerThanModulus() {
+			continue // ignore the candidate and re-sample
+		}
+
+		return z, nil
+	}
+}
+
+// smallerThanModulus returns true if z < q
+// This is not constant time
+func (z *{{.ElementName}}) smallerThanModulus() bool {
+	return z[0] < q
+}
+
+// One returns 1
+func One() {{.ElementName}} {
+	var one {{.ElementName}}
+	one.SetOne()
+	return one
+}
+
+// Halve sets z to z / 2 (mod q)
+func (z *{{.ElementName}}) Halve() {
+	// if z is odd, z + q is even and doesn't overflow since q < 2³¹
+	z[0] = (z[0] + (q & -(z[0] & 1))) >> 1
+}
+
+// Mul z = x * y (mod q)
+func (z *{{.ElementName}}) Mul(x, y *{{.ElementName}}) *{{.ElementName}} {
+	// x * y < q² fits on a uint64, see montReduce for the reduction
+	z[0] = montReduce(uint64(x[0]) * uint64(y[0]))
+	return z
+}
+
+// Square z = x * x (mod q)
+func (z *{{.ElementName}}) Square(x *{{.ElementName}}) *{{.ElementName}} {
+	// see Mul for algorithm documentation
+	z[0] = montReduce(uint64(x[0]) * uint64(x[0]))
+	return z
+}
+
+// FromMont converts z in place (i.e. mutates) from Montgomery to regular representation
+// sets and returns z = z * 1
+func (z *{{.ElementName}}) FromMont() *{{.ElementName}} {
+	fromMont(z)
+	return z
+}
+
+// Add z = x + y (mod q)
+func (z *{{.ElementName}}) Add(x, y *{{.ElementName}}) *{{.ElementName}} {
+	z[0] = reduceSigned(x[0] + y[0] - q)
+	return z
+}
+
+// Double z = x + x (mod q), aka Lsh 1
+func (z *{{.ElementName}}) Double(x *{{.ElementName}}) *{{.ElementName}} {
+	z[0] = reduceSigned((x[0] << 1) - q)
+	return z
+}
+
+// Sub z = x - y (mod q)
+func (z *{{.ElementName}}) Sub(x, y *{{.ElementName}}) *{{.ElementName}} {
+	z[0] = reduceSigned(x[0] - y[0])
+	return z
+}
+
+// Neg z = q - x
+func (z *{{.ElementName}}) Neg(x *{{.ElementName}}) *{{.ElementName}} {
+	z[0] = reduceSigned(-x[0])
+	return z
+}
+
+// Select is a constant-time conditional move.
+// If c=0, z = x0. Else z = x1
+func (z *{{.ElementName}}) Select(c int, x0 *{{.ElementName}}, x1 *{{.ElementName}}) *{{.ElementName}} {
+	cC := uint32((int64(c) | -int64(c)) >> 63) // "canonicized" into: 0 if c=0, -1 otherwise
+	z[0] = x0[0] ^ cC&(x0[0]^x1[0])
+	return z
+}
+
+// reduceSigned returns r + q if r, seen as a signed integer, is negative; r otherwise.
+//
+// It is branch-free, and maps r ∈ (-q, q) to [0, q) since q < 2³¹.
+func reduceSigned(r uint32) uint32 {
+	return r + (q & uint32(int32(r)>>31))
+}
+
+{{- if .Mersenne31}}
+
+// montReduce returns t (mod q)
+//
+// As 2³¹ ≡ 1 (mod q), t ≡ (t mod 2³¹) + (t >> 31): two foldings bring any t < 2⁶⁴
+// below 2³¹ + 8, and a conditional subtraction completes the reduction.
+// The name matches the other 31-bit fields, for which the reduction divides by R = 2³²;
+// here R = 1.
+func montReduce(t uint64) uint32 {
+	t = (t & uint64(q)) + (t >> 31)
+	t = (t & uint64(q)) + (t >> 31)
+	return reduceSigned(uint32(t) - q)
+}
+{{- else}}
+
+// montReduce returns t * R⁻¹ (mod q), for t < q * R with R = 2³²
+//
+// m = t * (-q⁻¹) mod R is such that t + m * q ≡ 0 (mod R), and (t + m * q) / R < 2q
+// fits on a uint32; a conditional subtraction completes the reduction.
+func montReduce(t uint64) uint32 {
+	m := uint32(t) * qInvNeg
+	r := uint32((t + uint64(m)*uint64(q)) >> 32)
+	return reduceSigned(r - q)
+}
+{{- end}}
+
+func _mulGeneric(z, x, y *{{.ElementName}}) {
+	// see Mul for algorithm documentation
+	z[0] = montReduce(uint64(x[0]) * uint64(y[0]))
+}
+
+func _fromMontGeneric(z *{{.ElementName}}) {
+	{{- if .Mersenne31}}
+	// z is already in regular form, montReduce only ensures it is reduced
+	{{- else}}
+	// z = z * 1 * R⁻¹
+	{{- end}}
+	z[0] = montReduce(uint64(z[0]))
+}
+
+func _reduceGeneric(z *{{.ElementName}}) {
+
+	// if z >= q → z -= q
+	if !z.smallerThanModulus() {
+		z[0] -= q
+	}
+}
+
+// BatchInvert returns a new slice with every element inverted.
+// Uses Montgomery batch inversion trick
+func BatchInvert(a []{{.ElementName}}) []{{.ElementName}} {
+	res := make([]{{.ElementName}}, len(a))
+	if len(a) == 0 {
+		return res
+	}
+
+	zeroes := make([]bool, len(a))
+	accumulator := One()
+
+	for i := 0; i < len(a); i++ {
+		if a[i].IsZero() {
+			zeroes[i] = true
+			continue
+		}
+		res[i] = accumulator
+		accumulator.Mul(&accumulator, &a[i])
+	}
+
+	accumulator.Inverse(&accumulator)
+
+	for i := len(a) - 1; i >= 0; i-- {
+		if zeroes[i] {
+			continue
+		}
+		res[i].Mul(&res[i], &accumulator)
+		accumulator.Mul(&accumulator, &a[i])
+	}
+
+	return res
+}
+
+func _butterflyGeneric(a, b *{{.ElementName}}) {
+	t := *a
+	a.Add(a, b)
+	b.Sub(&t, b)
+}
+
+// BitLen returns the minimum number of bits needed to represent z
+// returns 0 if z == 0
+func (z *{{.ElementName}}) BitLen() int {
+	return bits.Len32(z[0])
+}
+
+// Exp z = xᵏ (mod q)
+func (z *{{.ElementName}}) Exp(x {{.ElementName}}, k *big.Int) *{{.ElementName}} {
+	if k.IsUint64() && k.Uint64() == 0 {
+		return z.SetOne()
+	}
+
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ (mod q) == (x⁻¹)ᵏ (mod q)
+		x.Inverse(&x)
+
+		// we negate k in a temp big.Int since
+		// Int.Bit(_) of k and -k is different
+		e = bigIntPool.Get().(*big.Int)
+		defer bigIntPool.Put(e)
+		e.Neg(k)
+	}
+
+	z.Set(&x)
+
+	for i := e.BitLen() - 2; i >= 0; i-- {
+		z.Square(z)
+		if e.Bit(i) == 1 {
+			z.Mul(z, &x)
+		}
+	}
+
+	return z
+}
+
+// expUint64 z = xᵏ (mod q) for a public exponent k
+func (z *{{.ElementName}}) expUint64(x {{.ElementName}}, k uint64) *{{.ElementName}} {
+	z.SetOne()
+	for i := bits.Len64(k) - 1; i >= 0; i-- {
+		z.Square(z)
+		if (k>>uint(i))&1 == 1 {
+			z.Mul(z, &x)
+		}
+	}
+	return z
+}
+
+// rSquare where r is the Montgommery constant
+{{- if .Mersenne31}}
+// (r = 1, as {{.ElementName}} are not in Montgomery form)
+{{- end}}
+var rSquare = {{.ElementName}}{
+	{{.F31RSquare}},
+}
+
+// ToMont converts z to Montgomery form
+// sets and returns z = z * r²
+func (z *{{.ElementName}}) ToMont() *{{.ElementName}} {
+	{{- if .Mersenne31}}
+	return z
+	{{- else}}
+	return z.Mul(z, &rSquare)
+	{{- end}}
+}
+
+// ToRegular returns z in regular form (doesn't mutate z)
+func (z {{.ElementName}}) ToRegular() {{.ElementName}} {
+	return *z.FromMont()
+}
+
+// String returns the decimal representation of z as generated by
+// z.Text(10).
+func (z *{{.ElementName}}) String() string {
+	return z.Text(10)
+}
+
+// Text returns the string representation of z in the given base.
+// Base must be between 2 and 36, inclusive. The result uses the
+// lower-case letters 'a' to 'z' for digit values 10 to 35.
+// No prefix (such as "0x") is added to the string. If z is a nil
+// pointer it returns "<nil>".
+// If base == 10 and -z fits in a uint16 prefix "-" is added to the string.
+func (z *{{.ElementName}}) Text(base int) string {
+	if base < 2 || base > 36 {
+		panic("invalid base")
+	}
+	if z == nil {
+		return "<nil>"
+	}
+
+	const maxUint16 = 65535
+	if base == 10 {
+		var zzNeg {{.ElementName}}
+		zzNeg.Neg(z)
+		zzNeg.FromMont()
+		if zzNeg[0] <= maxUint16 && zzNeg[0] != 0 {
+			return "-" + strconv.FormatUint(uint64(zzNeg[0]), base)
+		}
+	}
+	zz := *z
+	zz.FromMont()
+	return strconv.FormatUint(uint64(zz[0]), base)
+}
+
+// ToBigInt returns z as a big.Int in Montgomery form
+func (z *{{.ElementName}}) ToBigInt(res *big.Int) *big.Int {
+	return res.SetUint64(uint64(z[0]))
+}
+
+// ToBigIntRegular returns z as a big.Int in regular form
+func (z {{.ElementName}}) ToBigIntRegular(res *big.Int) *big.Int {
+	z.FromMont()
+	return z.ToBigInt(res)
+}
+
+// Bytes returns the value of z as a big-endian byte array
+func (z *{{.ElementName}}) Bytes() (res [Bytes]byte) {
+	_z := z.ToRegular()
+	binary.BigEndian.PutUint32(res[:], _z[0])
+	return
+}
+
+// Marshal returns the value of z as a big-endian byte slice
+func (z *{{.ElementName}}) Marshal() []byte {
+	b := z.Bytes()
+	return b[:]
+}
+
+// SetBytes interprets e as the bytes of a big-endian unsigned integer,
+// sets z to that value, and returns z.
+func (z *{{.ElementName}}) SetBytes(e []byte) *{{.ElementName}} {
+	if len(e) <= 2*Bytes {
+		// fast path
+		return z.SetBytesWide(e)
+	}
+	// get a big int from our pool
+	vv := bigIntPool.Get().(*big.Int)
+	vv.SetBytes(e)
+
+	// set big int
+	z.SetBigInt(vv)
+
+	// put temporary object back in pool
+	bigIntPool.Put(vv)
+
+	return z
+}
+
+// SetBytesWide interprets e as the bytes of a big-endian unsigned integer, reduces it modulo q,
+// sets z to that value, and returns z.
+//
+// Unlike SetBytes, it is meant for inputs wider than q: if e is uniformly random, z is within
+// statistical distance q / 2^(8*len(e)) of the uniform distribution. Inputs of at most 2*Bytes
+// bytes are reduced without allocations; longer inputs go through SetBytes.
+func (z *{{.ElementName}}) SetBytesWide(e []byte) *{{.ElementName}} {
+	if len(e) > 2*Bytes {
+		return z.SetBytes(e)
+	}
+
+	var buf [2 * Bytes]byte
+	copy(buf[2*Bytes-len(e):], e)
+	v := binary.BigEndian.Uint64(buf[:])
+
+	{{- if .Mersenne31}}
+	z[0] = montReduce(v)
+	return z
+	{{- else}}
+
+	// v = hi * 2³² + lo, hi and lo not necessarily reduced
+	// montReduce only needs t < q * R to output a reduced result, which holds for
+	// t = x * (R² mod q) with x < R
+	// lo * R² * R⁻¹ = lo * R, the montgomery form of lo
+	lo := montReduce((v & 0xffffffff) * uint64(rSquare[0]))
+
+	// hi * R² * R⁻¹ * R² * R⁻¹ = (hi * R) * R, the montgomery form of hi * 2³²
+	hi := montReduce((v >> 32) * uint64(rSquare[0]))
+	hi = montReduce(uint64(hi) * uint64(rSquare[0]))
+
+	z[0] = reduceSigned(lo + hi - q)
+	return z
+	{{- end}}
+}
+
+// SetBigInt sets z to v and returns z
+func (z *{{.ElementName}}) SetBigInt(v *big.Int) *{{.ElementName}} {
+	z.SetZero()
+
+	var zero big.Int
+
+	// fast path
+	c := v.Cmp(&_modulus)
+	if c == 0 {
+		// v == 0
+		return z
+	} else if c != 1 && v.Cmp(&zero) != -1 {
+		// 0 < v < q
+		return z.setBigInt(v)
+	}
+
+	// get temporary big int from the pool
+	vv := bigIntPool.Get().(*big.Int)
+
+	// copy input + modular reduction
+	vv.Set(v)
+	vv.Mod(v, &_modulus)
+
+	// set big int byte value
+	z.setBigInt(vv)
+
+	// release object into pool
+	bigIntPool.Put(vv)
+	return z
+}
+
+// setBigInt assumes 0 ⩽ v < q
+func (z *{{.ElementName}}) setBigInt(v *big.Int) *{{.ElementName}} {
+	z[0] = uint32(v.Uint64())
+	return z.ToMont()
+}
+
+// SetString creates a big.Int with number and calls SetBigInt on z
+//
+// The number prefix determines the actual base: A prefix of
+// ''0b'' or ''0B'' selects base 2, ''0'', ''0o'' or ''0O'' selects base 8,
+// and ''0x'' or ''0X'' selects base 16. Otherwise, the selected base is 10
+// and no prefix is accepted.
+//
+// For base 16, lower and upper case letters are considered the same:
+// The letters 'a' to 'f' and 'A' to 'F' represent digit values 10 to 15.
+//
+// An underscore character ''_'' may appear between a base
+// prefix and an adjacent digit, and between successive digits; such
+// underscores do not change the value of the number.
+// Incorrect placement of underscores is reported as a panic if there
+// are no other errors.
+//
+// If the number is invalid this method leaves z unchanged and returns nil, error.
+func (z *{{.ElementName}}) SetString(number string) (*{{.ElementName}}, error) {
+	// get temporary big int from the pool
+	vv := bigIntPool.Get().(*big.Int)
+
+	if _, ok := vv.SetString(number, 0); !ok {
+		return nil, errors.New("{{.ElementName}}.SetString failed -> can't parse number into a big.Int " + number)
+	}
+
+	z.SetBigInt(vv)
+
+	// release object into pool
+	bigIntPool.Put(vv)
+
+	return z, nil
+}
+
+// MarshalJSON returns json encoding of z (z.Text(10))
+// If z == nil, returns null
+func (z *{{.ElementName}}) MarshalJSON() ([]byte, error) {
+	if z == nil {
+		return []byte("null"), nil
+	}
+	const maxSafeBound = 15 // we encode it as number if it's small
+	s := z.Text(10)
+	if len(s) <= maxSafeBound {
+		return []byte(s), nil
+	}
+	var sbb strings.Builder
+	sbb.WriteByte('"')
+	sbb.WriteString(s)
+	sbb.WriteByte('"')
+	return []byte(sbb.String()), nil
+}
+
+// UnmarshalJSON accepts numbers and strings as input
+// See {{.ElementName}}.SetString for valid prefixes (0x, 0b, ...)
+func (z *{{.ElementName}}) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if len(s) > Bits*3 {
+		return errors.New("value too large (max = {{.ElementName}}.Bits * 3)")
+	}
+
+	// we accept numbers and strings, remove leading and trailing quotes if any
+	if len(s) > 0 && s[0] == '"' {
+		s = s[1:]
+	}
+	if len(s) > 0 && s[len(s)-1] == '"' {
+		s = s[:len(s)-1]
+	}
+
+	// get temporary big int from the pool
+	vv := bigIntPool.Get().(*big.Int)
+
+	if _, ok := vv.SetString(s, 0); !ok {
+		return errors.New("can't parse into a big.Int: " + s)
+	}
+
+	z.SetBigInt(vv)
+
+	// release object into pool
+	bigIntPool.Put(vv)
+	return nil
+}
+
+// Legendre returns the Legendre symbol of z (either +1, -1, or 0.)
+func (z *{{.ElementName}}) Legendre() int {
+	var l {{.ElementName}}
+	// z^((q-1)/2)
+	l.expUint64(*z, 0x{{.LegendreExponent}})
+
+	if l.IsZero() {
+		return 0
+	}
+
+	// if l == 1
+	if l.IsOne() {
+		return 1
+	}
+	return -1
+}
+
+// Sqrt z = √x (mod q)
+// if the square root doesn't exist (x is not a square mod q)
+// Sqrt leaves z unchanged and returns nil
+func (z *{{.ElementName}}) Sqrt(x *{{.ElementName}}) *{{.ElementName}} {
+	{{- if .SqrtQ3Mod4}}
+	// q ≡ 3 (mod 4)
+	// using  z ≡ ± x^((p+1)/4) (mod q)
+	var y, square {{.ElementName}}
+	y.expUint64(*x, 0x{{.SqrtQ3Mod4Exponent}})
+	// as we didn't compute the legendre symbol, ensure we found y such that y * y = x
+	square.Square(&y)
+	if square.Equal(x) {
+		return z.Set(&y)
+	}
+	return nil
+	{{- else}}
+	// q ≡ 1 (mod 4)
+	// see modSqrtTonelliShanks in math/big/int.go
+	// using https://www.maa.org/sites/default/files/pdf/upload_library/22/Polya/07468342.di020786.02p0470a.pdf
+
+	var y, b, t, w {{.ElementName}}
+	// w = x^((s-1)/2))
+	w.expUint64(*x, {{index .SqrtCTExponent 0}})
+
+	// y = x^((s+1)/2)) = w * x
+	y.Mul(x, &w)
+
+	// b = x^s = w * w * x = y * x
+	b.Mul(&w, &y)
+
+	// g = nonResidue ^ s
+	var g = {{.ElementName}}{
+		{{.F31SqrtCTG}},
+	}
+	r := uint64({{.SqrtCTE}})
+
+	// compute legendre symbol
+	// t = x^((q-1)/2) = r-1 squaring of x^s
+	t = b
+	for i := uint64(0); i < r-1; i++ {
+		t.Square(&t)
+	}
+	if t.IsZero() {
+		return z.SetZero()
+	}
+	if !t.IsOne() {
+		// t != 1, we don't have a square root
+		return nil
+	}
+	for {
+		var m uint64
+		t = b
+
+		// for t != 1
+		for !t.IsOne() {
+			t.Square(&t)
+			m++
+		}
+
+		if m == 0 {
+			return z.Set(&y)
+		}
+		// t = g^(2^(r-m-1)) (mod q)
+		ge := int(r - m - 1)
+		t = g
+		for ge > 0 {
+			t.Square(&t)
+			ge--
+		}
+
+		g.Square(&t)
+		y.Mul(&y, &t)
+		b.Mul(&b, &g)
+		r = m
+	}
+	{{- end}}
+}
+
+// Inverse z = x⁻¹ (mod q)
+//
+// if x == 0, sets and returns z = x
+func (z *{{.ElementName}}) Inverse(x *{{.ElementName}}) *{{.ElementName}} {
+	// Fermat's little theorem: x⁻¹ = x^(q-2) (mod q)
+	// on a single word, the exponentiation is as fast as the binary extended Euclidean algorithm
+	return z.expUint64(*x, {{index .QMinusTwo 0}})
+}
+`
+
+// VectorOpsF31 implements the Vector methods of 31-bit fields.
+//
+// Elements are packed on 32 bits: the element-wise operations are unrolled and branch-free,
+// and Sum and InnerProduct accumulate unreduced values on 64 (resp. 128) bits, reducing only once.
+const VectorOpsF31 = `
+
+import (
+	"math/bits"
+)
+
+// Add adds two vectors element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector *Vector) Add(a, b Vector) {
+	if len(a) != len(b) || len(a) != len(*vector) {
+		panic("vector.Add: vectors don't have the same length")
+	}
+	res := *vector
+	n := len(a) - len(a)%4
+	for i := 0; i < n; i += 4 {
+		res[i][0] = reduceSigned(a[i][0] + b[i][0] - q)
+		res[i+1][0] = reduceSigned(a[i+1][0] + b[i+1][0] - q)
+		res[i+2][0] = reduceSigned(a[i+2][0] + b[i+2][0] - q)
+		res[i+3][0] = reduceSigned(a[i+3][0] + b[i+3][0] - q)
+	}
+	for i := n; i < len(a); i++ {
+		res[i][0] = reduceSigned(a[i][0] + b[i][0] - q)
+	}
+}
+
+// Sub subtracts two vectors element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector *Vector) Sub(a, b Vector) {
+	if len(a) != len(b) || len(a) != len(*vector) {
+		panic("vector.Sub: vectors don't have the same length")
+	}
+	res := *vector
+	n := len(a) - len(a)%4
+	for i := 0; i < n; i += 4 {
+		res[i][0] = reduceSigned(a[i][0] - b[i][0])
+		res[i+1][0] = reduceSigned(a[i+1][0] - b[i+1][0])
+		res[i+2][0] = reduceSigned(a[i+2][0] - b[i+2][0])
+		res[i+3][0] = reduceSigned(a[i+3][0] - b[i+3][0])
+	}
+	for i := n; i < len(a); i++ {
+		res[i][0] = reduceSigned(a[i][0] - b[i][0])
+	}
+}
+
+// Mul multiplies two vectors element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector *Vector) Mul(a, b Vector) {
+	if len(a) != len(b) || len(a) != len(*vector) {
+		panic("vector.Mul: vectors don't have the same length")
+	}
+	res := *vector
+	n := len(a) - len(a)%4
+	for i := 0; i < n; i += 4 {
+		res[i][0] = montReduce(uint64(a[i][0]) * uint64(b[i][0]))
+		res[i+1][0] = montReduce(uint64(a[i+1][0]) * uint64(b[i+1][0]))
+		res[i+2][0] = montReduce(uint64(a[i+2][0]) * uint64(b[i+2][0]))
+		res[i+3][0] = montReduce(uint64(a[i+3][0]) * uint64(b[i+3][0]))
+	}
+	for i := n; i < len(a); i++ {
+		res[i][0] = montReduce(uint64(a[i][0]) * uint64(b[i][0]))
+	}
+}
+
+// ScalarMul multiplies a vector by a scalar element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector *Vector) ScalarMul(a Vector, b *{{.ElementName}}) {
+	if len(a) != len(*vector) {
+		panic("vector.ScalarMul: vectors don't have the same length")
+	}
+	res := *vector
+	s := uint64(b[0])
+	n := len(a) - len(a)%4
+	for i := 0; i < n; i += 4 {
+		res[i][0] = montReduce(uint64(a[i][0]) * s)
+		res[i+1][0] = montReduce(uint64(a[i+1][0]) * s)
+		res[i+2][0] = montReduce(uint64(a[i+2][0]) * s)
+		res[i+3][0] = montReduce(uint64(a[i+3][0]) * s)
+	}
+	for i := n; i < len(a); i++ {
+		res[i][0] = montReduce(uint64(a[i][0]) * s)
+	}
+}
+
+// Sum computes the sum of all elements in the vector.
+func (vector *Vector) Sum() (res {{.ElementName}}) {
+	// the Montgomery form is linear: we sum the words of the elements (< 2³¹) on 64 bits,
+	// which can't overflow for less than 2³³ elements, and reduce once.
+	var acc uint64
+	for i := 0; i < len(*vector); i++ {
+		acc += uint64((*vector)[i][0])
+	}
+	res[0] = uint32(acc % uint64(q))
+	return
+}
+
+// InnerProduct computes the inner product of two vectors.
+// It panics if the vectors don't have the same length.
+func (vector *Vector) InnerProduct(other Vector) (res {{.ElementName}}) {
+	if len(*vector) != len(other) {
+		panic("vector.InnerProduct: vectors don't have the same length")
+	}
+	a := *vector
+
+	// the products (< 2⁶²) are accumulated on 128 bits, and reduced once:
+	// acc mod q = Σ aᵢ * bᵢ * R² (mod q), and montReduce divides it by R
+	var hi, lo, carry uint64
+	for i := 0; i < len(a); i++ {
+		lo, carry = bits.Add64(lo, uint64(a[i][0])*uint64(other[i][0]), 0)
+		hi += carry
+	}
+	_, r := bits.Div64(hi%uint64(q), lo, uint64(q))
+	res[0] = montReduce(r)
+	return
+}
+`
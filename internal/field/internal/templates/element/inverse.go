@@ -250,8 +250,16 @@ func (z *{{.ElementName}}) Inverse(x *{{.ElementName}}) *{{.ElementName}} {
 	}
 
 	// For every iteration that we miss, v is not being multiplied by 2ᵏ⁻²
+	{{- if .PseudoMersenne}}
+	// mul is not a Montgomery multiplication: a = 2²⁽ᵏ⁻¹⁾ * R⁻¹ matches the Montgomery multiplication by 2²⁽ᵏ⁻¹⁾
+	a = {{.ElementName}}{
+		{{- range $i := .P20InversionPSq}}
+		{{$i}},{{end}}
+	}
+	{{- else}}
 	const pSq uint64 = 1 << (2 * (k - 1))
 	a = {{.ElementName}}{pSq}
+	{{- end}}
 	// If the function is constant-time ish, this loop will not run (no need to take it out explicitly)
 	for ; i < invIterationsN; i += 2 {
 		// could optimize further with mul by word routine or by pre-computing a table since with k=26,
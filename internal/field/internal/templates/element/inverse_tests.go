@@ -30,6 +30,14 @@ func Test{{.ElementName}}InversionCorrectionFactorFormula(t *testing.T) {
 	factorInt := big.NewInt(1)
 	factorInt.Lsh(factorInt, power)
 	factorInt.Mod(factorInt, Modulus())
+	{{- if .PseudoMersenne}}
+
+	// the elements are not in Montgomery form: the factor also cancels R³, with R = 2^(64*Limbs)
+	var rInv big.Int
+	rInv.Lsh(big.NewInt(1), 64*Limbs).ModInverse(&rInv, Modulus())
+	rInv.Exp(&rInv, big.NewInt(3), Modulus())
+	factorInt.Mul(factorInt, &rInv).Mod(factorInt, Modulus())
+	{{- end}}
 
 	var refFactorInt big.Int
 	inversionCorrectionFactor := {{.ElementName}}{
@@ -2,6 +2,32 @@ package element
 
 const MulCIOS = `
 {{ define "mul_cios" }}
+	{{- template "mul_cios_loop" . }}
+
+	if t[{{$.all.NbWords}}] != 0 {
+		// we need to reduce, we have a result on {{add 1 $.all.NbWords}} words
+		{{- if gt $.all.NbWords 1}}
+		var b uint64
+		{{- end}}
+		z[0], {{- if gt $.all.NbWords 1}}b{{- else}}_{{- end}} = bits.Sub64(t[0], q0, 0)
+		{{- range $i := .all.NbWordsIndexesNoZero}}
+			{{-  if eq $i $.all.NbWordsLastIndex}}
+				z[{{$i}}], _ = bits.Sub64(t[{{$i}}], q{{$i}}, b)
+			{{-  else  }}
+				z[{{$i}}], b = bits.Sub64(t[{{$i}}], q{{$i}}, b)
+			{{- end}}
+		{{- end}}
+		return
+	}
+
+	// copy t into z 
+	{{- range $i := $.all.NbWordsIndexesFull}}
+		z[{{$i}}] = t[{{$i}}]
+	{{- end}}
+
+{{ end }}
+
+{{ define "mul_cios_loop" }}
 	var t [{{add .all.NbWords 1}}]uint64
 	var D uint64
 	var m, C uint64
@@ -36,28 +62,6 @@ const MulCIOS = `
 		 t[{{$.all.NbWords}}], _ = bits.Add64(0, D, C)
 	{{- end}}
 
-
-	if t[{{$.all.NbWords}}] != 0 {
-		// we need to reduce, we have a result on {{add 1 $.all.NbWords}} words
-		{{- if gt $.all.NbWords 1}}
-		var b uint64
-		{{- end}}
-		z[0], {{- if gt $.all.NbWords 1}}b{{- else}}_{{- end}} = bits.Sub64(t[0], q0, 0)
-		{{- range $i := .all.NbWordsIndexesNoZero}}
-			{{-  if eq $i $.all.NbWordsLastIndex}}
-				z[{{$i}}], _ = bits.Sub64(t[{{$i}}], q{{$i}}, b)
-			{{-  else  }}
-				z[{{$i}}], b = bits.Sub64(t[{{$i}}], q{{$i}}, b)
-			{{- end}}
-		{{- end}}
-		return
-	}
-
-	// copy t into z 
-	{{- range $i := $.all.NbWordsIndexesFull}}
-		z[{{$i}}] = t[{{$i}}]
-	{{- end}}
-
 {{ end }}
 
 {{ define "mul_cios_one_limb" }}
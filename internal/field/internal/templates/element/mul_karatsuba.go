@@ -0,0 +1,204 @@
+package element
+
+// MulKaratsuba is the Montgomery multiplication of the large fields (see FieldConfig.Karatsuba),
+// with the products computed by Karatsuba.
+const MulKaratsuba = `
+{{- if .Karatsuba}}
+
+// karatsubaThreshold is the number of words under which karatsuba uses the schoolbook multiplication
+const karatsubaThreshold = 8
+
+// qInvNegFull = - q⁻¹ mod R, on Limbs words (qInvNeg is its least significant word)
+var qInvNegFull = [Limbs]uint64{
+	{{- range $i := .QInverse}}
+	{{$i}},
+	{{- end}}
+}
+
+// mulKaratsuba z = x * y * R⁻¹ (mod q)
+//
+// The CIOS multiplication performs 2N² word multiplications; instead, the products are
+// computed with Karatsuba, followed by the (original) Montgomery reduction redc.
+//
+// It is branch-free (the sequence of operations only depends on Limbs), and also implements mulCT.
+//
+// x and y must be strictly inferior to q
+func mulKaratsuba(z, x, y *{{.ElementName}}) {
+	var t [2 * Limbs]uint64
+	karatsuba(t[:], x[:], y[:])
+	redc(z, &t)
+}
+
+// redc z = t * R⁻¹ (mod q), with R = 2^{{mul 64 .NbWords}}
+//
+// The algorithm:
+//
+// 	m := (t mod R) * (-q⁻¹) mod R
+// 	z := (t + m * q) / R
+// 	if z ⩾ q, z := z - q
+//
+// t must be strictly inferior to q * R
+func redc(z *{{.ElementName}}, t *[2 * Limbs]uint64) {
+	var m, u [2 * Limbs]uint64
+	karatsuba(m[:], t[:Limbs], qInvNegFull[:])
+	karatsuba(u[:], m[:Limbs], q{{.ElementName}}[:])
+
+	// t + u = 0 mod R: the low words of the sum are 0, with a carry iff t mod R ≠ 0
+	var c uint64
+	for i := 0; i < Limbs; i++ {
+		_, c = bits.Add64(t[i], u[i], c)
+	}
+	for i := 0; i < Limbs; i++ {
+		z[i], c = bits.Add64(t[Limbs+i], u[Limbs+i], c)
+	}
+
+	// (t + u) / R < (qR + qR) / R = 2q is on Limbs words and the carry c;
+	// z = (t + u) / R - q if it is ⩾ q
+	var d {{.ElementName}}
+	var b uint64
+	for i := 0; i < Limbs; i++ {
+		d[i], b = bits.Sub64(z[i], q{{.ElementName}}[i], b)
+	}
+	_, b = bits.Sub64(c, 0, b)
+
+	// b == 1 iff (t + u) / R < q
+	mask := -b
+	for i := 0; i < Limbs; i++ {
+		z[i] = d[i] ^ mask&(d[i]^z[i])
+	}
+}
+
+// karatsuba z = x * y, with len(x) == len(y) == n ⩽ Limbs and len(z) == 2n
+func karatsuba(z, x, y []uint64) {
+	// see karatsubaRec for the size of the scratch space
+	var scratch [5 * Limbs]uint64
+	karatsubaRec(z, x, y, scratch[:])
+}
+
+// karatsubaRec z = x * y, with len(x) == len(y) == n and len(z) == 2n
+//
+// scratch is a temporary space of at least S(n) words, where S(n) = 0 if n ⩽ karatsubaThreshold,
+// S(n) = 4h + max(S(h), 2h + 1) with h = ⌈n/2⌉ otherwise; S(n) ⩽ 5n.
+func karatsubaRec(z, x, y, scratch []uint64) {
+	n := len(x)
+	if n <= karatsubaThreshold {
+		mulSchoolbook(z, x, y)
+		return
+	}
+
+	// x = x₁Bʰ + x₀ and y = y₁Bʰ + y₀, with B = 2⁶⁴ and h = ⌈n/2⌉
+	// x * y = x₁y₁B²ʰ + (x₁y₁ + x₀y₀ - (x₀ - x₁)(y₀ - y₁))Bʰ + x₀y₀
+	h := (n + 1) / 2
+	x0, x1 := x[:h], x[h:]
+	y0, y1 := y[:h], y[h:]
+
+	karatsubaRec(z[:2*h], x0, y0, scratch)
+	karatsubaRec(z[2*h:], x1, y1, scratch)
+
+	// p = |x₀ - x₁| * |y₀ - y₁|
+	dx, dy, p := scratch[:h], scratch[h:2*h], scratch[2*h:4*h]
+	sx := absDiff(dx, x0, x1)
+	sy := absDiff(dy, y0, y1)
+	karatsubaRec(p, dx, dy, scratch[4*h:])
+
+	// mid = x₀y₀ + x₁y₁ ∓ p = x₀y₁ + x₁y₀, on 2h + 1 words
+	mid := scratch[4*h : 6*h+1]
+	var c uint64
+	l := 2 * (n - h)
+	for i := 0; i < l; i++ {
+		mid[i], c = bits.Add64(z[i], z[2*h+i], c)
+	}
+	for i := l; i < 2*h; i++ {
+		mid[i], c = bits.Add64(z[i], 0, c)
+	}
+	mid[2*h] = c
+
+	// if sx == sy, mid -= p, i.e. mid += ^p + 1 (on 2h + 1 words); else mid += p
+	mask := (sx ^ sy) - 1
+	c = mask & 1
+	for i := 0; i < 2*h; i++ {
+		mid[i], c = bits.Add64(mid[i], p[i]^mask, c)
+	}
+	mid[2*h], _ = bits.Add64(mid[2*h], mask, c)
+
+	// z += mid * Bʰ
+	c = 0
+	for i := 0; i < len(mid); i++ {
+		z[h+i], c = bits.Add64(z[h+i], mid[i], c)
+	}
+	for i := h + len(mid); i < len(z); i++ {
+		z[i], c = bits.Add64(z[i], 0, c)
+	}
+}
+
+// absDiff z = |x - y| and returns 1 if x < y, 0 otherwise
+//
+// len(z) == len(x) ⩾ len(y)
+func absDiff(z, x, y []uint64) uint64 {
+	var b uint64
+	for i := 0; i < len(y); i++ {
+		z[i], b = bits.Sub64(x[i], y[i], b)
+	}
+	for i := len(y); i < len(x); i++ {
+		z[i], b = bits.Sub64(x[i], 0, b)
+	}
+	// if b == 1, z = x - y + 2⁶⁴ˡᵉⁿ⁽ᶻ⁾ and we negate it: z = ^z + 1
+	mask := -b
+	c := b
+	for i := range z {
+		z[i], c = bits.Add64(z[i]^mask, 0, c)
+	}
+	return b
+}
+
+// mulSchoolbook z = x * y, with len(x) == len(y) == n and len(z) == 2n
+//
+// The products are unrolled for the sizes of the leaves of karatsubaRec (n ⩾ karatsubaThreshold / 2).
+func mulSchoolbook(z, x, y []uint64) {
+	switch len(x) {
+	{{- range $n := iterate 4 9}}
+	case {{$n}}:
+		mulSchoolbook{{$n}}((*[{{mul 2 $n}}]uint64)(z), (*[{{$n}}]uint64)(x), (*[{{$n}}]uint64)(y))
+		return
+	{{- end}}
+	}
+	n := len(x)
+	for i := 0; i < n; i++ {
+		z[i] = 0
+	}
+	for i := 0; i < n; i++ {
+		var c uint64
+		for j := 0; j < n; j++ {
+			c, z[i+j] = madd2(x[j], y[i], z[i+j], c)
+		}
+		z[i+n] = c
+	}
+}
+{{- range $n := iterate 4 9}}
+
+// mulSchoolbook{{$n}} z = x * y, on {{$n}} words
+func mulSchoolbook{{$n}}(z *[{{mul 2 $n}}]uint64, x, y *[{{$n}}]uint64) {
+	var c uint64
+	{{- range $i := iterate 0 $n}}
+	{{- range $j := iterate 0 $n}}
+	{{- if eq $i 0}}
+	{{- if eq $j 0}}
+	c, z[0] = bits.Mul64(x[0], y[0])
+	{{- else}}
+	c, z[{{$j}}] = madd1(x[{{$j}}], y[0], c)
+	{{- end}}
+	{{- else}}
+	{{- if eq $j 0}}
+	c, z[{{$i}}] = madd1(x[0], y[{{$i}}], z[{{$i}}])
+	{{- else}}
+	c, z[{{add $i $j}}] = madd2(x[{{$j}}], y[{{$i}}], z[{{add $i $j}}], c)
+	{{- end}}
+	{{- end}}
+	{{- end}}
+	z[{{add $i $n}}] = c
+	{{- end}}
+}
+{{- end}}
+{{- end}}
+
+`
@@ -0,0 +1,124 @@
+package element
+
+// MulPseudoMersenne is the multiplication of the pseudo-Mersenne fields (see FieldConfig.PseudoMersenne),
+// whose elements are not in Montgomery form.
+const MulPseudoMersenne = `
+{{ define "mul_wide" }}
+	{{- range $i := .all.NbWordsIndexesFull}}
+	{{- range $j := $.all.NbWordsIndexesFull}}
+	{{- if eq $i 0}}
+	{{- if eq $j 0}}
+	c, t[0] = bits.Mul64({{$.V1}}[0], {{$.V2}}[0])
+	{{- else}}
+	c, t[{{$j}}] = madd1({{$.V1}}[{{$j}}], {{$.V2}}[0], c)
+	{{- end}}
+	{{- else}}
+	{{- if eq $j 0}}
+	c, t[{{$i}}] = madd1({{$.V1}}[0], {{$.V2}}[{{$i}}], t[{{$i}}])
+	{{- else}}
+	c, t[{{add $i $j}}] = madd2({{$.V1}}[{{$j}}], {{$.V2}}[{{$i}}], t[{{add $i $j}}], c)
+	{{- end}}
+	{{- end}}
+	{{- end}}
+	t[{{add $i $.all.NbWords}}] = c
+	{{- end}}
+{{- end }}
+
+{{- if .PseudoMersenne}}
+
+// pmC = 2^{{mul 64 .NbWords}} mod q
+//
+// q = 2^{{.NbBits}} - c, so that 2^{{mul 64 .NbWords}} ≡ c * 2^{{.PseudoMersenneShift}} (mod q)
+const pmC uint64 = {{.PseudoMersenneC}}
+
+// mulPM z = x * y (mod q)
+//
+// The elements are not in Montgomery form: the {{mul 2 .NbWords}}-word product is reduced by folding
+// its high words onto its low words, see reducePM.
+//
+// It is branch-free (the sequence of operations doesn't depend on x and y), and also implements mulCT.
+//
+// x and y must be strictly inferior to q
+func mulPM(z, x, y *{{.ElementName}}) {
+	var t [2 * Limbs]uint64
+	var c uint64
+	{{- template "mul_wide" dict "all" . "V1" "x" "V2" "y" }}
+	reducePM(z, &t)
+}
+
+// reducePM z = t (mod q), with R = 2^{{mul 64 .NbWords}} and t = hi * R + lo on {{mul 2 .NbWords}} words
+//
+// The algorithm:
+//
+// 	z := lo + hi * pmC, on {{add .NbWords 1}} words since pmC < 2⁶³
+// 	z := (z mod R) + (z / R) * pmC, and z + R ≡ z + pmC on a carry
+{{- if ne .PseudoMersenneShift 0}}
+// 	z := (z mod 2^{{.NbBits}}) + (z / 2^{{.NbBits}}) * c, which is < 2q
+{{- end}}
+// 	if z ⩾ q, z := z - q
+//
+// It is branch-free.
+func reducePM(z *{{.ElementName}}, t *[2 * Limbs]uint64) {
+	var c, carry uint64
+
+	// z = lo + hi * pmC, c is the top word (c ⩽ 2⁶³)
+	{{- range $i := .NbWordsIndexesFull}}
+	{{- if eq $i 0}}
+	c, z[0] = madd1(t[{{$.NbWords}}], pmC, t[0])
+	{{- else}}
+	c, z[{{$i}}] = madd2(t[{{add $i $.NbWords}}], pmC, t[{{$i}}], c)
+	{{- end}}
+	{{- end}}
+
+	// z += c * pmC
+	hi, lo := bits.Mul64(c, pmC)
+	z[0], carry = bits.Add64(z[0], lo, 0)
+	z[1], carry = bits.Add64(z[1], hi, carry)
+	{{- range $i := .NbWordsIndexesFull}}
+	{{- if gt $i 1}}
+	z[{{$i}}], carry = bits.Add64(z[{{$i}}], 0, carry)
+	{{- end}}
+	{{- end}}
+
+	// on a carry, z < 2¹²⁶ and adding pmC doesn't overflow
+	z[0], carry = bits.Add64(z[0], pmC&-carry, 0)
+	{{- range $i := .NbWordsIndexesNoZero}}
+	{{- if eq $i $.NbWordsLastIndex}}
+	z[{{$i}}], _ = bits.Add64(z[{{$i}}], 0, carry)
+	{{- else}}
+	z[{{$i}}], carry = bits.Add64(z[{{$i}}], 0, carry)
+	{{- end}}
+	{{- end}}
+
+	{{- if ne .PseudoMersenneShift 0}}
+
+	// z = h * 2^{{.NbBits}} + l ≡ l + h * c, with h * c < pmC
+	const c0 = pmC >> {{.PseudoMersenneShift}}
+	h := z[{{.NbWordsLastIndex}}] >> {{sub 64 .PseudoMersenneShift}}
+	z[{{.NbWordsLastIndex}}] &= (1 << {{sub 64 .PseudoMersenneShift}}) - 1
+	z[0], carry = bits.Add64(z[0], h*c0, 0)
+	{{- range $i := .NbWordsIndexesNoZero}}
+	{{- if eq $i $.NbWordsLastIndex}}
+	z[{{$i}}], _ = bits.Add64(z[{{$i}}], 0, carry)
+	{{- else}}
+	z[{{$i}}], carry = bits.Add64(z[{{$i}}], 0, carry)
+	{{- end}}
+	{{- end}}
+	{{- end}}
+
+	// z < 2q; z = z - q if z ⩾ q
+	var d {{.ElementName}}
+	var b uint64
+	{{- range $i := .NbWordsIndexesFull}}
+	d[{{$i}}], b = bits.Sub64(z[{{$i}}], q{{$i}}, {{- if eq $i 0}}0{{- else}}b{{- end}})
+	{{- end}}
+
+	// b == 1 iff z < q
+	mask := -b
+	{{- range $i := .NbWordsIndexesFull}}
+	z[{{$i}}] = d[{{$i}}] ^ mask&(d[{{$i}}]^z[{{$i}}])
+	{{- end}}
+}
+{{- end}}
+
+`
@@ -29,8 +29,49 @@ func reduce(res *{{.ElementName}})
 //go:noescape
 func Butterfly(a, b *{{.ElementName}})
 
+{{- if .ASMArm64}}
+
+func add(z, x, y *{{.ElementName}}) {
+	_addGeneric(z, x, y)
+}
+
+func sub(z, x, y *{{.ElementName}}) {
+	_subGeneric(z, x, y)
+}
+{{- end}}
+
 {{end}}
 
 
 
+`
+
+// OpsArm64 is included with ARM64 builds if F.ASMArm64 is set
+//
+// mul, add, sub and Butterfly are implemented in assembly, the other
+// operations use the generic implementation.
+const OpsArm64 = MulByConstants + `
+
+//go:noescape
+func mul(res, x, y *{{.ElementName}})
+
+//go:noescape
+func add(res, x, y *{{.ElementName}})
+
+//go:noescape
+func sub(res, x, y *{{.ElementName}})
+
+// Butterfly sets
+//  a = a + b (mod q)
+//  b = a - b (mod q)
+//go:noescape
+func Butterfly(a, b *{{.ElementName}})
+
+func fromMont(z *{{.ElementName}} ) {
+	_fromMontGeneric(z)
+}
+
+func reduce(z *{{.ElementName}})  {
+	_reduceGeneric(z)
+}
 `
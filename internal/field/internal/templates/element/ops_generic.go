@@ -1,6 +1,7 @@
 package element
 
-const OpsNoAsm = `
+// MulByConstants implements MulBy3, MulBy5 and MulBy13 in Go
+const MulByConstants = `
 
 {{ $mulConsts := list 3 5 13 }}
 {{- range $i := $mulConsts }}
@@ -30,7 +31,9 @@ func MulBy{{$i}}(x *{{$.ElementName}}) {
 	{{- end}}
 }
 
-{{- end}}
+{{- end}}`
+
+const OpsNoAsm = MulByConstants + `
 
 // Butterfly sets
 //  a = a + b (mod q)
@@ -39,6 +42,17 @@ func Butterfly(a, b *{{.ElementName}}) {
 	_butterflyGeneric(a, b)
 }
 
+{{- if .ASMArm64}}
+
+func add(z, x, y *{{.ElementName}}) {
+	_addGeneric(z, x, y)
+}
+
+func sub(z, x, y *{{.ElementName}}) {
+	_subGeneric(z, x, y)
+}
+{{- end}}
+
 {{- if ne .NbWords 1}}
 func mul(z, x, y *{{.ElementName}}) {
 	_mulGeneric(z, x, y)
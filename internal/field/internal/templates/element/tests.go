@@ -6,14 +6,16 @@ const Test = `
 import (
 	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"math/big"
 	"math/bits"
 	"fmt"
+	mrand "math/rand"
 	{{if .UsingP20Inverse}} 
 	"github.com/consensys/gnark-crypto/internal/field"
-	mrand "math/rand" 
 	{{end}}
 	"testing"
+	"testing/iotest"
 	
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/prop"
@@ -318,7 +320,12 @@ func Test{{toTitle .ElementName}}NegZero(t *testing.T) {
 // Gopter tests
 // most of them are generated with a template
 
-{{ if gt .NbWords 6}}
+{{ if .Karatsuba}}
+const (
+	nbFuzzShort = 5
+	nbFuzz = 20
+)
+{{ else if gt .NbWords 6}}
 const (
 	nbFuzzShort = 20
 	nbFuzz = 100
@@ -484,6 +491,83 @@ func Test{{toTitle .ElementName}}Bytes(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func Test{{toTitle .ElementName}}SetRandomFrom(t *testing.T) {
+	t.Parallel()
+
+	// two readers with the same seed must yield the same values
+	r1 := mrand.New(mrand.NewSource(42)) //#nosec G404 -- deterministic source on purpose
+	r2 := mrand.New(mrand.NewSource(42)) //#nosec G404 -- deterministic source on purpose
+
+	var a, b {{.ElementName}}
+	for i := 0; i < 100; i++ {
+		if _, err := a.SetRandomFrom(r1); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := b.SetRandomFrom(r2); err != nil {
+			t.Fatal(err)
+		}
+		if !a.Equal(&b) {
+			t.Fatal("SetRandomFrom with the same seed should output the same values")
+		}
+		if !a.smallerThanModulus() {
+			t.Fatal("SetRandomFrom should output a value smaller than the modulus")
+		}
+	}
+
+	// reader errors are returned
+	if _, err := a.SetRandomFrom(iotest.ErrReader(errors.New("test"))); err == nil {
+		t.Fatal("SetRandomFrom should return the error of the reader")
+	}
+}
+
+func Test{{toTitle .ElementName}}SetBytesWide(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	// inputs of all sizes up to 2*Bytes + 1, to go through the SetBytes fallback
+	genE := ggen.SliceOfN(2*Bytes+1, ggen.UInt8())
+	genL := ggen.IntRange(0, 2*Bytes+1)
+
+	properties.Property("SetBytesWide must match big.Int reduction", prop.ForAll(
+		func(e []uint8, l int) bool {
+			var a {{.ElementName}}
+			a.SetBytesWide(e[:l])
+
+			var b big.Int
+			b.SetBytes(e[:l]).Mod(&b, Modulus())
+
+			var c big.Int
+			a.ToBigIntRegular(&c)
+
+			return b.Cmp(&c) == 0
+		},
+		genE,
+		genL,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// all ones is the largest input of the fast path
+	var e [2 * Bytes]byte
+	for i := range e {
+		e[i] = 0xff
+	}
+	var a {{.ElementName}}
+	a.SetBytesWide(e[:])
+	var b, c big.Int
+	b.SetBytes(e[:]).Mod(&b, Modulus())
+	if a.ToBigIntRegular(&c).Cmp(&b) != 0 {
+		t.Fatal("SetBytesWide failed on 2*Bytes 0xff bytes")
+	}
+}
+
 func Test{{toTitle .ElementName}}InverseExp(t *testing.T) {
 	// inverse must be equal to exp^-2
 	exp := Modulus()
@@ -736,8 +820,13 @@ func Test{{toTitle .ElementName}}LexicographicallyLargest(t *testing.T) {
 }
 
 
+{{if .ASMArm64}}
+{{template "testBinaryOp" dict "all" . "Op" "Add" "GenericOp" "_addGeneric"}}
+{{template "testBinaryOp" dict "all" . "Op" "Sub" "GenericOp" "_subGeneric"}}
+{{- else}}
 {{template "testBinaryOp" dict "all" . "Op" "Add"}}
 {{template "testBinaryOp" dict "all" . "Op" "Sub"}}
+{{- end}}
 {{template "testBinaryOp" dict "all" . "Op" "Mul" "GenericOp" "_mulGeneric"}}
 {{template "testBinaryOp" dict "all" . "Op" "Div"}}
 {{template "testBinaryOp" dict "all" . "Op" "Exp"}}
@@ -1579,7 +1668,11 @@ type testPair{{.ElementName}} struct {
 func gen() gopter.Gen {
 	return func(genParams *gopter.GenParameters) *gopter.GenResult {
 		var g testPair{{.ElementName}}
-
+{{if .F31}}
+		g.element = {{.ElementName}}{
+			uint32(genParams.NextUint64() % uint64(q{{.ElementName}}[0])),
+		}
+{{- else}}
 		g.element = {{.ElementName}}{
 			{{- range $i := .NbWordsIndexesFull}}
 			genParams.NextUint64(),{{end}}
@@ -1598,6 +1691,7 @@ func gen() gopter.Gen {
 				g.element[{{.NbWordsLastIndex}}] %= (q{{.ElementName}}[{{.NbWordsLastIndex}}] +1 )
 			}
 		}
+		{{- end}}
 
 		g.element.ToBigIntRegular(&g.bigint)
 		genResult := gopter.NewGenResult(g, gopter.NoShrinker)
@@ -1611,6 +1705,9 @@ func genFull() gopter.Gen {
 
 		genRandomFq := func() {{.ElementName}} {
 			var g {{.ElementName}}
+			{{- if .F31}}
+			g[0] = uint32(genParams.NextUint64() % uint64(q{{.ElementName}}[0]))
+			{{- else}}
 
 			g = {{.ElementName}}{
 				{{- range $i := .NbWordsIndexesFull}}
@@ -1630,11 +1727,14 @@ func genFull() gopter.Gen {
 					g[{{.NbWordsLastIndex}}] %= (q{{.ElementName}}[{{.NbWordsLastIndex}}] +1 )
 				}
 			}
+			{{- end}}
 
 			return g 
 		}
 		a := genRandomFq()
-
+{{if .F31}}
+		a[0], _ = bits.Add32(a[0], q{{.ElementName}}[0], 0)
+{{- else}}
 		var carry uint64
 		{{- range $i := .NbWordsIndexesFull}}
 			{{- if eq $i $.NbWordsLastIndex}}
@@ -1643,6 +1743,7 @@ func genFull() gopter.Gen {
 			a[{{$i}}], carry = bits.Add64(a[{{$i}}], q{{$.ElementName}}[{{$i}}], carry)
 			{{- end}}
 		{{- end}}
+		{{- end}}
 		
 		genResult := gopter.NewGenResult(a, gopter.NoShrinker)
 		return genResult
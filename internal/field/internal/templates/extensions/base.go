@@ -0,0 +1,371 @@
+package extensions
+
+// Base contains the operations shared by all the simple radical extensions Fp[u]/(uⁿ - α)
+const Base = `
+{{ $B := .Base.ElementName }}
+{{ $l := toLower .Name }}
+
+import (
+	"crypto/rand"
+	"io"
+	"math/big"
+)
+
+// {{.Name}} is a degree {{.Degree}} extension of {{$B}}: {{$B}}[u]/(u{{supScr .Degree}} - ({{.NonResidue}}))
+type {{.Name}} struct {
+	{{- range $c := .Coordinates}}
+	{{$c}} {{$B}}{{end}}
+}
+
+// {{$l}}NonResidue α such that u{{supScr .Degree}} = α (montgomery form)
+var {{$l}}NonResidue = {{$B}}{
+	{{- range $w := .NonResidueMont}}
+	{{$w}},{{end}}
+}
+
+// {{$l}}FrobeniusCoefficients α^(i(q-1)/{{.Degree}}) for i in [1, {{sub .Degree 1}}] (montgomery form)
+var {{$l}}FrobeniusCoefficients = [{{sub .Degree 1}}]{{$B}}{
+	{{- range $c := .FrobeniusCoefficients}}
+	{
+		{{- range $w := $c}}
+		{{$w}},{{end}}
+	},{{end}}
+}
+
+var _bSqrtExponent{{.Name}} *big.Int
+
+func init() {
+	_bSqrtExponent{{.Name}}, _ = new(big.Int).SetString("{{.SqrtSMinusOneOver2}}", 16)
+}
+
+// Equal returns true if z equals x, false otherwise
+func (z *{{.Name}}) Equal(x *{{.Name}}) bool {
+	return {{- range $i, $c := .Coordinates}}{{if $i}} &&{{end}} z.{{$c}}.Equal(&x.{{$c}}){{end}}
+}
+
+// IsZero returns true if z is zero, false otherwise
+func (z *{{.Name}}) IsZero() bool {
+	return {{- range $i, $c := .Coordinates}}{{if $i}} &&{{end}} z.{{$c}}.IsZero(){{end}}
+}
+
+// IsOne returns true if z is one, false otherwise
+func (z *{{.Name}}) IsOne() bool {
+	return {{- range $i, $c := .Coordinates}}{{if $i}} && z.{{$c}}.IsZero(){{else}} z.{{$c}}.IsOne(){{end}}{{end}}
+}
+
+// SetZero sets z to 0 in Montgomery form and returns z
+func (z *{{.Name}}) SetZero() *{{.Name}} {
+	{{- range $c := .Coordinates}}
+	z.{{$c}}.SetZero(){{end}}
+	return z
+}
+
+// SetOne sets z to 1 in Montgomery form and returns z
+func (z *{{.Name}}) SetOne() *{{.Name}} {
+	{{- range $i, $c := .Coordinates}}
+	{{- if $i}}
+	z.{{$c}}.SetZero()
+	{{- else}}
+	z.{{$c}}.SetOne()
+	{{- end}}{{end}}
+	return z
+}
+
+// Set sets z to x and returns z
+func (z *{{.Name}}) Set(x *{{.Name}}) *{{.Name}} {
+	{{- range $c := .Coordinates}}
+	z.{{$c}} = x.{{$c}}{{end}}
+	return z
+}
+
+// SetRandom sets z to a uniform random value
+func (z *{{.Name}}) SetRandom() (*{{.Name}}, error) {
+	return z.SetRandomFrom(rand.Reader)
+}
+
+// SetRandomFrom sets z to a uniform random value, reading the randomness from r
+//
+// Each coordinate is sampled uniformly with {{$B}}.SetRandomFrom, in order.
+func (z *{{.Name}}) SetRandomFrom(r io.Reader) (*{{.Name}}, error) {
+	{{- range $c := .Coordinates}}
+	if _, err := z.{{$c}}.SetRandomFrom(r); err != nil {
+		return nil, err
+	}{{end}}
+	return z, nil
+}
+
+// Add sets z=x+y and returns z
+func (z *{{.Name}}) Add(x, y *{{.Name}}) *{{.Name}} {
+	{{- range $c := .Coordinates}}
+	z.{{$c}}.Add(&x.{{$c}}, &y.{{$c}}){{end}}
+	return z
+}
+
+// Sub sets z=x-y and returns z
+func (z *{{.Name}}) Sub(x, y *{{.Name}}) *{{.Name}} {
+	{{- range $c := .Coordinates}}
+	z.{{$c}}.Sub(&x.{{$c}}, &y.{{$c}}){{end}}
+	return z
+}
+
+// Double sets z=2x and returns z
+func (z *{{.Name}}) Double(x *{{.Name}}) *{{.Name}} {
+	{{- range $c := .Coordinates}}
+	z.{{$c}}.Double(&x.{{$c}}){{end}}
+	return z
+}
+
+// Neg sets z=-x and returns z
+func (z *{{.Name}}) Neg(x *{{.Name}}) *{{.Name}} {
+	{{- range $c := .Coordinates}}
+	z.{{$c}}.Neg(&x.{{$c}}){{end}}
+	return z
+}
+
+// MulByElement sets z=x*y where y is in the base field and returns z
+func (z *{{.Name}}) MulByElement(x *{{.Name}}, y *{{$B}}) *{{.Name}} {
+	var yCopy {{$B}}
+	yCopy.Set(y)
+	{{- range $c := .Coordinates}}
+	z.{{$c}}.Mul(&x.{{$c}}, &yCopy){{end}}
+	return z
+}
+
+// Frobenius sets z=x^q and returns z
+func (z *{{.Name}}) Frobenius(x *{{.Name}}) *{{.Name}} {
+	// (Σ aᵢuⁱ)^q = Σ aᵢ(uⁱ)^q = Σ aᵢα^(i(q-1)/{{.Degree}})uⁱ
+	{{- range $i, $c := .Coordinates}}
+	{{- if $i}}
+	z.{{$c}}.Mul(&x.{{$c}}, &{{$l}}FrobeniusCoefficients[{{sub $i 1}}])
+	{{- else}}
+	z.{{$c}}.Set(&x.{{$c}})
+	{{- end}}{{end}}
+	return z
+}
+
+// Div sets z=x/y and returns z
+func (z *{{.Name}}) Div(x, y *{{.Name}}) *{{.Name}} {
+	var r {{.Name}}
+	r.Inverse(y).Mul(x, &r)
+	return z.Set(&r)
+}
+
+// Exp sets z=xᵏ (mod q{{supScr .Degree}}) and returns it
+func (z *{{.Name}}) Exp(x {{.Name}}, k *big.Int) *{{.Name}} {
+	if k.IsUint64() && k.Uint64() == 0 {
+		return z.SetOne()
+	}
+
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ (mod q{{supScr .Degree}}) == (x⁻¹)ᵏ (mod q{{supScr .Degree}})
+		x.Inverse(&x)
+
+		// we negate k in a temp big.Int since
+		// Int.Bit(_) of k and -k is different
+		e = bigIntPool.Get().(*big.Int)
+		defer bigIntPool.Put(e)
+		e.Neg(k)
+	}
+
+	z.Set(&x)
+
+	for i := e.BitLen() - 2; i >= 0; i-- {
+		z.Square(z)
+		if e.Bit(i) == 1 {
+			z.Mul(z, &x)
+		}
+	}
+
+	return z
+}
+
+// Select is a constant-time conditional move.
+// If c=0, z = x0. Else z = x1
+func (z *{{.Name}}) Select(c int, x0, x1 *{{.Name}}) *{{.Name}} {
+	{{- range $c := .Coordinates}}
+	z.{{$c}}.Select(c, &x0.{{$c}}, &x1.{{$c}}){{end}}
+	return z
+}
+
+// ExpCT sets z=xᵏ (mod q{{supScr .Degree}}) and returns it
+//
+// Unlike Exp, ExpCT uses a Montgomery ladder over max({{.Degree}}*Bits, k.BitLen()) bits with
+// constant-time selections, so that its sequence of operations doesn't depend on x and k (only the sign
+// of k and the bit length of exponents larger than {{.Degree}}*Bits are leaked).
+// It is as constant-time as the {{$B}} arithmetic it relies on.
+func (z *{{.Name}}) ExpCT(x {{.Name}}, k *big.Int) *{{.Name}} {
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ (mod q{{supScr .Degree}}) == (x⁻¹)ᵏ (mod q{{supScr .Degree}})
+		x.InverseCT(&x)
+
+		// we negate k in a temp big.Int since
+		// Int.Bit(_) of k and -k is different
+		e = bigIntPool.Get().(*big.Int)
+		defer bigIntPool.Put(e)
+		e.Neg(k)
+	}
+
+	nbBits := {{.Degree}} * Bits
+	if e.BitLen() > nbBits {
+		nbBits = e.BitLen()
+	}
+
+	// invariant: r1 = r0 * x
+	var r0, r1, a, b {{.Name}}
+	r0.SetOne()
+	r1.Set(&x)
+	for i := nbBits - 1; i >= 0; i-- {
+		bit := int(e.Bit(i))
+		// (a, b) = (r0, r1) if bit == 0, (r1, r0) otherwise
+		a.Select(bit, &r0, &r1)
+		b.Select(bit, &r1, &r0)
+		b.Mul(&a, &b)
+		a.Square(&a)
+		r0.Select(bit, &a, &b)
+		r1.Select(bit, &b, &a)
+	}
+
+	return z.Set(&r0)
+}
+
+// Legendre returns the Legendre symbol of z (either +1, -1, or 0.)
+func (z *{{.Name}}) Legendre() int {
+	// z is a square in the extension iff its norm is a square in {{$B}}
+	var n {{$B}}
+	z.norm(&n)
+	return n.Legendre()
+}
+
+// Sqrt sets z to the square root of x and returns z
+// if the square root doesn't exist (x is not a square)
+// Sqrt leaves z unchanged and returns nil
+func (z *{{.Name}}) Sqrt(x *{{.Name}}) *{{.Name}} {
+	// q{{supScr .Degree}} - 1 = 2ᵉ * s, s odd
+	// see modSqrtTonelliShanks in math/big/int.go
+	var y, b, t, w {{.Name}}
+	// w = x^((s-1)/2))
+	w.Exp(*x, _bSqrtExponent{{.Name}})
+
+	// y = x^((s+1)/2)) = w * x
+	y.Mul(x, &w)
+
+	// b = x^s = w * w * x = y * x
+	b.Mul(&w, &y)
+
+	// g = nonResidue ^ s
+	g := {{.Name}}{
+		{{- range $i, $c := .Coordinates}}
+		{{$c}}: {{$B}}{
+			{{- range $w := index $.SqrtG $i}}
+			{{$w}},{{end}}
+		},{{end}}
+	}
+	r := uint64({{.SqrtE}})
+
+	// compute legendre symbol
+	// t = x^((q{{supScr .Degree}}-1)/2) = r-1 squaring of x^s
+	t = b
+	for i := uint64(0); i < r-1; i++ {
+		t.Square(&t)
+	}
+	if t.IsZero() {
+		return z.SetZero()
+	}
+	if !t.IsOne() {
+		// t != 1, we don't have a square root
+		return nil
+	}
+	for {
+		var m uint64
+		t = b
+
+		// for t != 1
+		for !t.IsOne() {
+			t.Square(&t)
+			m++
+		}
+
+		if m == 0 {
+			return z.Set(&y)
+		}
+		// t = g^(2^(r-m-1))
+		ge := int(r - m - 1)
+		t = g
+		for ge > 0 {
+			t.Square(&t)
+			ge--
+		}
+
+		g.Square(&t)
+		y.Mul(&y, &t)
+		b.Mul(&b, &g)
+		r = m
+	}
+}
+
+// SqrtCT sets z to the square root of x and returns z
+// if the square root doesn't exist (x is not a square)
+// SqrtCT leaves z unchanged and returns nil
+//
+// Unlike Sqrt, SqrtCT uses the constant-time variant of Tonelli-Shanks described in
+// RFC 9380 (Appendix I.4); only whether x is a square or not is leaked.
+func (z *{{.Name}}) SqrtCT(x *{{.Name}}) *{{.Name}} {
+	// q{{supScr .Degree}} - 1 = 2ᵉ * s, s odd
+	var y, b, t, tmp {{.Name}}
+
+	// y = x^((s-1)/2)
+	y.ExpCT(*x, _bSqrtExponent{{.Name}})
+
+	// t = x^s, y = x^((s+1)/2)
+	t.Square(&y).Mul(&t, x)
+	y.Mul(&y, x)
+
+	// c = g^s, g a non-residue
+	c := {{.Name}}{
+		{{- range $i, $c := .Coordinates}}
+		{{$c}}: {{$B}}{
+			{{- range $w := index $.SqrtG $i}}
+			{{$w}},{{end}}
+		},{{end}}
+	}
+	b = t
+
+	for i := {{.SqrtE}}; i >= 2; i-- {
+		for j := 1; j <= i-2; j++ {
+			b.Square(&b)
+		}
+		// if b ≠ 1, y = y * c and t = t * c²
+		notOne := b.notOneCT()
+		tmp.Mul(&y, &c)
+		y.Select(notOne, &y, &tmp)
+		c.Square(&c)
+		tmp.Mul(&t, &c)
+		t.Select(notOne, &t, &tmp)
+		b = t
+	}
+
+	// as we didn't compute the legendre symbol, ensure we found y such that y * y = x
+	tmp.Square(&y)
+	if !tmp.Equal(x) {
+		return nil
+	}
+	return z.Set(&y)
+}
+
+// notOneCT returns 0 if and only if z == 1, without branching
+func (z *{{.Name}}) notOneCT() int {
+	var one {{.Name}}
+	one.SetOne()
+	v := {{- range $i, $c := .Coordinates}}{{if $i}} |{{end}} z.{{$c}}.NotEqual(&one.{{$c}}){{end}}
+	return int((v | -v) >> 63)
+}
+
+// mulByNonResidue{{.Name}} sets z=α*x and returns z
+func mulByNonResidue{{.Name}}(z, x *{{$B}}) *{{$B}} {
+	return z.Mul(x, &{{$l}}NonResidue)
+}
+`
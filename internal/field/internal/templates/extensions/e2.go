@@ -0,0 +1,85 @@
+package extensions
+
+// E2 contains the arithmetic specific to quadratic extensions Fp[u]/(u² - α)
+const E2 = `
+{{ $B := .Base.ElementName }}
+
+// String puts z in string form
+func (z *{{.Name}}) String() string {
+	return z.A0.String() + "+" + z.A1.String() + "*u"
+}
+
+// Conjugate sets z to x conjugated and returns z
+func (z *{{.Name}}) Conjugate(x *{{.Name}}) *{{.Name}} {
+	z.A0 = x.A0
+	z.A1.Neg(&x.A1)
+	return z
+}
+
+// Mul sets z to the {{.Name}}-product of x,y, returns z
+func (z *{{.Name}}) Mul(x, y *{{.Name}}) *{{.Name}} {
+	var a, b, c {{$B}}
+	a.Add(&x.A0, &x.A1)
+	b.Add(&y.A0, &y.A1)
+	a.Mul(&a, &b)
+	b.Mul(&x.A0, &y.A0)
+	c.Mul(&x.A1, &y.A1)
+	z.A1.Sub(&a, &b).Sub(&z.A1, &c)
+	mulByNonResidue{{.Name}}(&c, &c)
+	z.A0.Add(&b, &c)
+	return z
+}
+
+// Square sets z to the {{.Name}}-product of x,x returns z
+func (z *{{.Name}}) Square(x *{{.Name}}) *{{.Name}} {
+	// (a0 + a1u)² = a0² + αa1² + 2a0a1u
+	var a, b, c {{$B}}
+	c.Mul(&x.A0, &x.A1)
+	mulByNonResidue{{.Name}}(&b, &x.A1)
+	b.Add(&b, &x.A0)
+	a.Add(&x.A0, &x.A1)
+	a.Mul(&a, &b)
+	mulByNonResidue{{.Name}}(&b, &c)
+	b.Add(&b, &c)
+	z.A0.Sub(&a, &b)
+	z.A1.Double(&c)
+	return z
+}
+
+// Inverse sets z to the {{.Name}}-inverse of x, returns z
+//
+// if x == 0, sets and returns z = x
+func (z *{{.Name}}) Inverse(x *{{.Name}}) *{{.Name}} {
+	// 1/(a0 + a1u) = (a0 - a1u)/(a0² - αa1²)
+	var t {{$B}}
+	x.norm(&t)
+	t.Inverse(&t)
+	z.A0.Mul(&x.A0, &t)
+	z.A1.Mul(&x.A1, &t).Neg(&z.A1)
+	return z
+}
+
+// InverseCT sets z to the {{.Name}}-inverse of x, returns z
+//
+// Unlike Inverse, it inverts the norm of x with {{$B}}.InverseCT, in constant time.
+//
+// if x == 0, sets and returns z = x
+func (z *{{.Name}}) InverseCT(x *{{.Name}}) *{{.Name}} {
+	// 1/(a0 + a1u) = (a0 - a1u)/(a0² - αa1²)
+	var t {{$B}}
+	x.norm(&t)
+	t.InverseCT(&t)
+	z.A0.Mul(&x.A0, &t)
+	z.A1.Mul(&x.A1, &t).Neg(&z.A1)
+	return z
+}
+
+// norm sets n to N(x) = x * x^q = a0² - αa1²
+func (z *{{.Name}}) norm(n *{{$B}}) {
+	var t {{$B}}
+	n.Square(&z.A0)
+	t.Square(&z.A1)
+	mulByNonResidue{{.Name}}(&t, &t)
+	n.Sub(n, &t)
+}
+`
@@ -0,0 +1,131 @@
+package extensions
+
+// E3 contains the arithmetic specific to cubic extensions Fp[u]/(u³ - α)
+const E3 = `
+{{ $B := .Base.ElementName }}
+
+// String puts z in string form
+func (z *{{.Name}}) String() string {
+	return z.A0.String() + "+(" + z.A1.String() + ")*u+(" + z.A2.String() + ")*u²"
+}
+
+// Mul sets z to the {{.Name}}-product of x,y, returns z
+func (z *{{.Name}}) Mul(x, y *{{.Name}}) *{{.Name}} {
+	// Algorithm 13 from https://eprint.iacr.org/2010/354.pdf
+	var t0, t1, t2, c0, c1, c2, tmp {{$B}}
+	t0.Mul(&x.A0, &y.A0)
+	t1.Mul(&x.A1, &y.A1)
+	t2.Mul(&x.A2, &y.A2)
+
+	c0.Add(&x.A1, &x.A2)
+	tmp.Add(&y.A1, &y.A2)
+	c0.Mul(&c0, &tmp).Sub(&c0, &t1).Sub(&c0, &t2)
+	mulByNonResidue{{.Name}}(&c0, &c0)
+	c0.Add(&c0, &t0)
+
+	c1.Add(&x.A0, &x.A1)
+	tmp.Add(&y.A0, &y.A1)
+	c1.Mul(&c1, &tmp).Sub(&c1, &t0).Sub(&c1, &t1)
+	mulByNonResidue{{.Name}}(&tmp, &t2)
+	c1.Add(&c1, &tmp)
+
+	c2.Add(&x.A0, &x.A2)
+	tmp.Add(&y.A0, &y.A2)
+	c2.Mul(&c2, &tmp).Sub(&c2, &t0).Sub(&c2, &t2).Add(&c2, &t1)
+
+	z.A0.Set(&c0)
+	z.A1.Set(&c1)
+	z.A2.Set(&c2)
+	return z
+}
+
+// Square sets z to the {{.Name}}-product of x,x, returns z
+func (z *{{.Name}}) Square(x *{{.Name}}) *{{.Name}} {
+	// (a0 + a1u + a2u²)² = a0² + 2αa1a2 + (2a0a1 + αa2²)u + (a1² + 2a0a2)u²
+	var c0, c1, c2, tmp {{$B}}
+	c0.Mul(&x.A1, &x.A2).Double(&c0)
+	mulByNonResidue{{.Name}}(&c0, &c0)
+	tmp.Square(&x.A0)
+	c0.Add(&c0, &tmp)
+
+	c1.Square(&x.A2)
+	mulByNonResidue{{.Name}}(&c1, &c1)
+	tmp.Mul(&x.A0, &x.A1).Double(&tmp)
+	c1.Add(&c1, &tmp)
+
+	c2.Mul(&x.A0, &x.A2).Double(&c2)
+	tmp.Square(&x.A1)
+	c2.Add(&c2, &tmp)
+
+	z.A0.Set(&c0)
+	z.A1.Set(&c1)
+	z.A2.Set(&c2)
+	return z
+}
+
+// Inverse sets z to the {{.Name}}-inverse of x, returns z
+//
+// if x == 0, sets and returns z = x
+func (z *{{.Name}}) Inverse(x *{{.Name}}) *{{.Name}} {
+	// Algorithm 17 from https://eprint.iacr.org/2010/354.pdf
+	var c0, c1, c2, t {{$B}}
+	x.cofactors(&c0, &c1, &c2, &t)
+	t.Inverse(&t)
+	z.A0.Mul(&c0, &t)
+	z.A1.Mul(&c1, &t)
+	z.A2.Mul(&c2, &t)
+	return z
+}
+
+// InverseCT sets z to the {{.Name}}-inverse of x, returns z
+//
+// Unlike Inverse, it inverts the norm of x with {{$B}}.InverseCT, in constant time.
+//
+// if x == 0, sets and returns z = x
+func (z *{{.Name}}) InverseCT(x *{{.Name}}) *{{.Name}} {
+	// Algorithm 17 from https://eprint.iacr.org/2010/354.pdf
+	var c0, c1, c2, t {{$B}}
+	x.cofactors(&c0, &c1, &c2, &t)
+	t.InverseCT(&t)
+	z.A0.Mul(&c0, &t)
+	z.A1.Mul(&c1, &t)
+	z.A2.Mul(&c2, &t)
+	return z
+}
+
+// norm sets n to N(x) = x * x^q * x^(q²)
+func (z *{{.Name}}) norm(n *{{$B}}) {
+	var c0, c1, c2 {{$B}}
+	z.cofactors(&c0, &c1, &c2, n)
+}
+
+// cofactors sets (c0 + c1u + c2u²) = x^q * x^(q²) and n = N(x)
+func (z *{{.Name}}) cofactors(c0, c1, c2, n *{{$B}}) {
+	var tmp {{$B}}
+
+	// c0 = a0² - αa1a2
+	c0.Square(&z.A0)
+	tmp.Mul(&z.A1, &z.A2)
+	mulByNonResidue{{.Name}}(&tmp, &tmp)
+	c0.Sub(c0, &tmp)
+
+	// c1 = αa2² - a0a1
+	c1.Square(&z.A2)
+	mulByNonResidue{{.Name}}(c1, c1)
+	tmp.Mul(&z.A0, &z.A1)
+	c1.Sub(c1, &tmp)
+
+	// c2 = a1² - a0a2
+	c2.Square(&z.A1)
+	tmp.Mul(&z.A0, &z.A2)
+	c2.Sub(c2, &tmp)
+
+	// n = a0c0 + α(a2c1 + a1c2)
+	n.Mul(&z.A2, c1)
+	tmp.Mul(&z.A1, c2)
+	n.Add(n, &tmp)
+	mulByNonResidue{{.Name}}(n, n)
+	tmp.Mul(&z.A0, c0)
+	n.Add(n, &tmp)
+}
+`
@@ -0,0 +1,389 @@
+package extensions
+
+// Tests contains the property based tests of the extension
+const Tests = `
+{{ $B := .Base.ElementName }}
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	ggen "github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func Test{{.Name}}ReceiverIsOperand(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen{{.Name}}()
+	genB := gen{{.Name}}()
+
+	properties.Property("[{{.Name}}] Having the receiver as operand (mul) should output the same result", prop.ForAll(
+		func(a, b *{{.Name}}) bool {
+			var c, d {{.Name}}
+			d.Set(a)
+			c.Mul(a, b)
+			a.Mul(a, b)
+			b.Mul(&d, b)
+			return a.Equal(b) && a.Equal(&c) && b.Equal(&c)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("[{{.Name}}] Having the receiver as operand (square) should output the same result", prop.ForAll(
+		func(a *{{.Name}}) bool {
+			var b {{.Name}}
+			b.Square(a)
+			a.Square(a)
+			return a.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.Property("[{{.Name}}] Having the receiver as operand (inverse) should output the same result", prop.ForAll(
+		func(a *{{.Name}}) bool {
+			var b {{.Name}}
+			b.Inverse(a)
+			a.Inverse(a)
+			return a.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.Property("[{{.Name}}] Having the receiver as operand (frobenius) should output the same result", prop.ForAll(
+		func(a *{{.Name}}) bool {
+			var b {{.Name}}
+			b.Frobenius(a)
+			a.Frobenius(a)
+			return a.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func Test{{.Name}}Ops(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen{{.Name}}()
+	genB := gen{{.Name}}()
+	genE := gen()
+
+	// inverses and exponents are only defined for non-zero elements, which are likely to be generated
+	// for small moduli
+	genNonZero := gen{{.Name}}().SuchThat(func(a *{{.Name}}) bool { return !a.IsZero() })
+
+	properties.Property("[{{.Name}}] sub & add should leave an element invariant", prop.ForAll(
+		func(a, b *{{.Name}}) bool {
+			var c {{.Name}}
+			c.Set(a)
+			c.Add(&c, b).Sub(&c, b)
+			return c.Equal(a)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("[{{.Name}}] mul should be distributive over add", prop.ForAll(
+		func(a, b *{{.Name}}) bool {
+			var c, d, e {{.Name}}
+			c.Add(a, b).Mul(&c, b)
+			d.Mul(a, b)
+			e.Mul(b, b)
+			d.Add(&d, &e)
+			return c.Equal(&d)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("[{{.Name}}] mul & inverse should leave an element invariant", prop.ForAll(
+		func(a, b *{{.Name}}) bool {
+			var c, d {{.Name}}
+			d.Inverse(b)
+			c.Set(a)
+			c.Mul(&c, b).Mul(&c, &d)
+			return c.Equal(a)
+		},
+		genA,
+		genNonZero,
+	))
+
+	properties.Property("[{{.Name}}] inverse twice should leave an element invariant", prop.ForAll(
+		func(a *{{.Name}}) bool {
+			var b {{.Name}}
+			b.Inverse(a).Inverse(&b)
+			return a.Equal(&b)
+		},
+		genNonZero,
+	))
+
+	properties.Property("[{{.Name}}] square and mul should output the same result", prop.ForAll(
+		func(a *{{.Name}}) bool {
+			var b, c {{.Name}}
+			b.Mul(a, a)
+			c.Square(a)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[{{.Name}}] MulByElement should be the same as Mul by an element of the base field", prop.ForAll(
+		func(a *{{.Name}}, e testPair{{$B}}) bool {
+			var b, c {{.Name}}
+			b.A0.Set(&e.element)
+			b.Mul(a, &b)
+			c.MulByElement(a, &e.element)
+			return b.Equal(&c)
+		},
+		genA,
+		genE,
+	))
+
+	properties.Property("[{{.Name}}] Frobenius should be x^q", prop.ForAll(
+		func(a *{{.Name}}) bool {
+			var b, c {{.Name}}
+			b.Frobenius(a)
+			c.Exp(*a, Modulus())
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[{{.Name}}] Frobenius applied {{.Degree}} times should leave an element invariant", prop.ForAll(
+		func(a *{{.Name}}) bool {
+			var b {{.Name}}
+			b.Set(a)
+			for i := 0; i < {{.Degree}}; i++ {
+				b.Frobenius(&b)
+			}
+			return b.Equal(a)
+		},
+		genA,
+	))
+
+	properties.Property("[{{.Name}}] Exp(x, q{{supScr .Degree}}-1) should be one", prop.ForAll(
+		func(a *{{.Name}}) bool {
+			var b {{.Name}}
+			k := new(big.Int).Exp(Modulus(), big.NewInt({{.Degree}}), nil)
+			k.Sub(k, big.NewInt(1))
+			b.Exp(*a, k)
+			return b.IsOne()
+		},
+		genNonZero,
+	))
+
+	properties.Property("[{{.Name}}] Exp(x, -k) should be the inverse of Exp(x, k)", prop.ForAll(
+		func(a *{{.Name}}, k uint64) bool {
+			var b, c {{.Name}}
+			e := new(big.Int).SetUint64(k)
+			b.Exp(*a, e)
+			c.Exp(*a, e.Neg(e))
+			b.Mul(&b, &c)
+			return b.IsOne()
+		},
+		genNonZero,
+		ggen.UInt64(),
+	))
+
+	properties.Property("[{{.Name}}] Div should be the same as mul by inverse", prop.ForAll(
+		func(a, b *{{.Name}}) bool {
+			var c, d {{.Name}}
+			c.Div(a, b)
+			d.Inverse(b).Mul(&d, a)
+			return c.Equal(&d)
+		},
+		genA,
+		genNonZero,
+	))
+
+	properties.Property("[{{.Name}}] squares should be squares (legendre)", prop.ForAll(
+		func(a *{{.Name}}) bool {
+			var b {{.Name}}
+			b.Square(a)
+			return b.Legendre() == 1
+		},
+		genNonZero,
+	))
+
+	properties.Property("[{{.Name}}] sqrt(x²) should be ±x", prop.ForAll(
+		func(a *{{.Name}}) bool {
+			var b, c, d {{.Name}}
+			b.Square(a)
+			if c.Sqrt(&b) == nil {
+				return false
+			}
+			d.Neg(&c)
+			return c.Equal(a) || d.Equal(a)
+		},
+		genA,
+	))
+
+	properties.Property("[{{.Name}}] InverseCT should match Inverse", prop.ForAll(
+		func(a *{{.Name}}) bool {
+			var b, c {{.Name}}
+			b.InverseCT(a)
+			c.Inverse(a)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[{{.Name}}] ExpCT should match Exp", prop.ForAll(
+		func(a *{{.Name}}, k uint64) bool {
+			var b, c {{.Name}}
+			e := new(big.Int).SetUint64(k)
+			b.ExpCT(*a, e)
+			c.Exp(*a, e)
+			if !b.Equal(&c) {
+				return false
+			}
+			b.ExpCT(*a, e.Neg(e))
+			c.Exp(*a, e)
+			return b.Equal(&c)
+		},
+		genA,
+		ggen.UInt64(),
+	))
+
+	properties.Property("[{{.Name}}] SqrtCT should match Sqrt", prop.ForAll(
+		func(a *{{.Name}}) bool {
+			var b, c, d {{.Name}}
+			b.Set(a)
+			rc, rd := c.SqrtCT(&b), d.Sqrt(&b)
+			if rc == nil || rd == nil {
+				return rc == nil && rd == nil
+			}
+			var e {{.Name}}
+			e.Neg(&d)
+			return c.Equal(&d) || c.Equal(&e)
+		},
+		genA,
+	))
+
+	properties.Property("[{{.Name}}] sqrt of a non square should return nil", prop.ForAll(
+		func(a *{{.Name}}) bool {
+			var b {{.Name}}
+			if a.Legendre() != -1 {
+				return true
+			}
+			b.Set(a)
+			return b.Sqrt(a) == nil && b.Equal(a)
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func Test{{.Name}}EdgeCases(t *testing.T) {
+	var zero, one, res {{.Name}}
+	one.SetOne()
+
+	// u{{supScr .Degree}} == α
+	var u, expected {{.Name}}
+	u.A1.SetOne()
+	res.Set(&u)
+	for i := 1; i < {{.Degree}}; i++ {
+		res.Mul(&res, &u)
+	}
+	expected.A0.SetInt64({{.NonResidue}})
+	if !res.Equal(&expected) {
+		t.Fatal("u{{supScr .Degree}} should be equal to the non-residue")
+	}
+
+	if res.Inverse(&zero); !res.IsZero() {
+		t.Fatal("inverse of 0 should be 0")
+	}
+	if res.Sqrt(&zero) == nil || !res.IsZero() {
+		t.Fatal("sqrt of 0 should be 0")
+	}
+	if res.InverseCT(&zero); !res.IsZero() {
+		t.Fatal("constant-time inverse of 0 should be 0")
+	}
+	if res.SqrtCT(&zero) == nil || !res.IsZero() {
+		t.Fatal("constant-time sqrt of 0 should be 0")
+	}
+	if res.ExpCT(one, big.NewInt(0)); !res.IsOne() {
+		t.Fatal("constant-time x^0 should be 1")
+	}
+	if zero.Legendre() != 0 {
+		t.Fatal("legendre of 0 should be 0")
+	}
+	if res.Exp(one, big.NewInt(42)); !res.IsOne() {
+		t.Fatal("1^42 should be 1")
+	}
+	if res.Exp(one, big.NewInt(0)); !res.IsOne() {
+		t.Fatal("x^0 should be 1")
+	}
+}
+
+func Benchmark{{.Name}}Mul(b *testing.B) {
+	var a, c {{.Name}}
+	a.SetRandom()
+	c.SetRandom()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.Mul(&a, &c)
+	}
+}
+
+func Benchmark{{.Name}}Square(b *testing.B) {
+	var a {{.Name}}
+	a.SetRandom()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.Square(&a)
+	}
+}
+
+func Benchmark{{.Name}}Inverse(b *testing.B) {
+	var a {{.Name}}
+	a.SetRandom()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.Inverse(&a)
+	}
+}
+
+func Benchmark{{.Name}}Sqrt(b *testing.B) {
+	var a {{.Name}}
+	a.SetRandom()
+	a.Square(&a)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.Sqrt(&a)
+	}
+}
+
+func gen{{.Name}}() gopter.Gen {
+	return gopter.CombineGens(
+		{{- range $c := .Coordinates}}
+		gen(),{{end}}
+	).Map(func(values []interface{}) *{{.Name}} {
+		return &{{.Name}}{
+			{{- range $i, $c := .Coordinates}}
+			{{$c}}: values[{{$i}}].(testPair{{$B}}).element,{{end}}
+		}
+	})
+}
+`
@@ -13,6 +13,7 @@ var BLS12_377 = Curve{
 		GLV:              true,
 		CofactorCleaning: true,
 		CRange:           defaultCRange(),
+		CofactorPrime:    2,
 	},
 	G2: Point{
 		CoordType:        "fptower.E2",
@@ -23,6 +24,7 @@ var BLS12_377 = Curve{
 		CofactorCleaning: true,
 		CRange:           defaultCRange(),
 		Projective:       true,
+		CofactorPrime:    1 << 25, // the cofactor has no prime factor below 2^25
 	},
 	// 2-isogeny
 	HashE1: &HashSuiteSswu{
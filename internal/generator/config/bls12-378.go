@@ -13,6 +13,7 @@ var BLS12_378 = Curve{
 		GLV:              true,
 		CofactorCleaning: true,
 		CRange:           defaultCRange(),
+		CofactorPrime:    2,
 	},
 	G2: Point{
 		CoordType:        "fptower.E2",
@@ -22,6 +23,7 @@ var BLS12_378 = Curve{
 		CofactorCleaning: true,
 		CRange:           defaultCRange(),
 		Projective:       true,
+		CofactorPrime:    13,
 	},
 	// 2-isogeny
 	HashE1: &HashSuiteSswu{
@@ -13,6 +13,7 @@ var BLS12_381 = Curve{
 		GLV:              true,
 		CofactorCleaning: true,
 		CRange:           defaultCRange(),
+		CofactorPrime:    3,
 	},
 	G2: Point{
 		CoordType:        "fptower.E2",
@@ -23,6 +24,7 @@ var BLS12_381 = Curve{
 		CofactorCleaning: true,
 		CRange:           defaultCRange(),
 		Projective:       true,
+		CofactorPrime:    13,
 	},
 	// 11-isogeny
 	HashE1: &HashSuiteSswu{
@@ -13,6 +13,7 @@ var BLS24_315 = Curve{
 		GLV:              true,
 		CofactorCleaning: true,
 		CRange:           defaultCRange(),
+		CofactorPrime:    2,
 	},
 	G2: Point{
 		CoordType:        "fptower.E4",
@@ -22,6 +23,7 @@ var BLS24_315 = Curve{
 		CofactorCleaning: true,
 		CRange:           defaultCRange(),
 		Projective:       true,
+		CofactorPrime:    4006969,
 	},
 	// 2-isogeny
 	HashE1: &HashSuiteSswu{
@@ -13,6 +13,7 @@ var BLS24_317 = Curve{
 		GLV:              true,
 		CofactorCleaning: true,
 		CRange:           defaultCRange(),
+		CofactorPrime:    3,
 	},
 	G2: Point{
 		CoordType:        "fptower.E4",
@@ -22,6 +23,7 @@ var BLS24_317 = Curve{
 		CofactorCleaning: true,
 		CRange:           defaultCRange(),
 		Projective:       true,
+		CofactorPrime:    2,
 	},
 	// 5-isogeny
 	HashE1: &HashSuiteSswu{
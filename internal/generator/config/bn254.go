@@ -13,6 +13,7 @@ var BN254 = Curve{
 		GLV:              true,
 		CofactorCleaning: false,
 		CRange:           defaultCRange(),
+		CofactorPrime:    1,
 	},
 	G2: Point{
 		CoordType:        "fptower.E2",
@@ -22,6 +23,7 @@ var BN254 = Curve{
 		CofactorCleaning: true,
 		CRange:           defaultCRange(),
 		Projective:       true,
+		CofactorPrime:    10069,
 	},
 	HashE1: &HashSuiteSvdw{
 		z:  []string{"1"},
@@ -14,6 +14,7 @@ var BW6_761 = Curve{
 		CofactorCleaning: true,
 		CRange:           []int{4, 5, 8, 16},
 		Projective:       true,
+		CofactorPrime:    2,
 	},
 	G2: Point{
 		CoordType:        "fp.Element",
@@ -22,6 +23,8 @@ var BW6_761 = Curve{
 		GLV:              true,
 		CofactorCleaning: true,
 		CRange:           []int{4, 5, 8, 16},
+		Projective:       true,
+		CofactorPrime:    3,
 	},
 	// 2-isogeny
 	HashE1: &HashSuiteSswu{
@@ -59,6 +59,7 @@ type Point struct {
 	CofactorCleaning bool     // flag telling if the Cofactor cleaning is available
 	CRange           []int    // multiexp bucket method: generate inner methods (with const arrays) for each c
 	Projective       bool     // generate projective coordinates
+	CofactorPrime    uint64   // smallest prime factor of the cofactor, or a lower bound on it (1 if the cofactor is 1)
 	A                []string //A linear coefficient in Weierstrass form
 	B                []string //B constant term in Weierstrass form
 }
@@ -0,0 +1,14 @@
+package config
+
+// Goldilocks is the prime field of modulus 2⁶⁴ - 2³² + 1 (see field/goldilocks).
+//
+// It is not a curve, but the fft and polynomial packages generated for the curves scalar fields
+// are generated for it too, from the same templates.
+var Goldilocks = Curve{
+	Name:      "goldilocks",
+	FrModulus: "18446744069414584321",
+}
+
+func init() {
+	Goldilocks.FrInfo = newFieldInfo(Goldilocks.FrModulus)
+}
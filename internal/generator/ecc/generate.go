@@ -16,6 +16,20 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 	entries := []bavard.Entry{
 		{File: filepath.Join(baseDir, "multiexp.go"), Templates: []string{"multiexp.go.tmpl"}},
 		{File: filepath.Join(baseDir, "multiexp_test.go"), Templates: []string{"tests/multiexp.go.tmpl"}},
+		{File: filepath.Join(baseDir, "fixedbase.go"), Templates: []string{"fixedbase.go.tmpl"}},
+		{File: filepath.Join(baseDir, "fixedbase_test.go"), Templates: []string{"tests/fixedbase.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp_affine.go"), Templates: []string{"multiexp_affine.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp_affine_test.go"), Templates: []string{"tests/multiexp_affine.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp_precomputed.go"), Templates: []string{"multiexp_precomputed.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp_precomputed_test.go"), Templates: []string{"tests/multiexp_precomputed.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp_batch.go"), Templates: []string{"multiexp_batch.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp_batch_test.go"), Templates: []string{"tests/multiexp_batch.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp_reader.go"), Templates: []string{"multiexp_reader.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp_reader_test.go"), Templates: []string{"tests/multiexp_reader.go.tmpl"}},
+		{File: filepath.Join(baseDir, "scalarmul_ct.go"), Templates: []string{"scalarmul_ct.go.tmpl"}},
+		{File: filepath.Join(baseDir, "scalarmul_ct_test.go"), Templates: []string{"tests/scalarmul_ct.go.tmpl"}},
+		{File: filepath.Join(baseDir, "subgroup_batch.go"), Templates: []string{"subgroup_batch.go.tmpl"}},
+		{File: filepath.Join(baseDir, "subgroup_batch_test.go"), Templates: []string{"tests/subgroup_batch.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal_test.go"), Templates: []string{"tests/marshal.go.tmpl"}},
 	}
@@ -0,0 +1,24 @@
+package hashtofield
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+// Generate generates the RFC 9380 hash to field on the fp and fr packages of the curve
+func Generate(conf config.Curve, curveDir string, bgen *bavard.BatchGenerator) error {
+	for _, field := range []string{"fp", "fr"} {
+		conf.Package = field
+		baseDir := filepath.Join(curveDir, field)
+		entries := []bavard.Entry{
+			{File: filepath.Join(baseDir, "hash_to_field.go"), Templates: []string{"hash_to_field.go.tmpl"}},
+			{File: filepath.Join(baseDir, "hash_to_field_test.go"), Templates: []string{"tests/hash_to_field.go.tmpl"}},
+		}
+		if err := bgen.Generate(conf, conf.Package, "./hashtofield/template", entries...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/consensys/gnark-crypto/internal/generator/edwards/eddsa"
 	"github.com/consensys/gnark-crypto/internal/generator/fft"
 	fri "github.com/consensys/gnark-crypto/internal/generator/fri/template"
+	"github.com/consensys/gnark-crypto/internal/generator/hashtofield"
 	"github.com/consensys/gnark-crypto/internal/generator/kzg"
 	"github.com/consensys/gnark-crypto/internal/generator/pairing"
 	"github.com/consensys/gnark-crypto/internal/generator/permutation"
@@ -45,10 +46,11 @@ func main() {
 
 			curveDir := filepath.Join(baseDir, "ecc", conf.Name)
 			// generate base field
-			conf.Fp, err = field.NewFieldConfig("fp", "Element", conf.FpModulus, true)
+			// the elements of the curves are in Montgomery form, whatever the modulus
+			conf.Fp, err = field.NewFieldConfig("fp", "Element", conf.FpModulus, true, field.WithPseudoMersenne(field.PseudoMersenneOff))
 			assertNoError(err)
 
-			conf.Fr, err = field.NewFieldConfig("fr", "Element", conf.FrModulus, true)
+			conf.Fr, err = field.NewFieldConfig("fr", "Element", conf.FrModulus, true, field.WithPseudoMersenne(field.PseudoMersenneOff))
 			assertNoError(err)
 
 			conf.FpUnusedBits = 64 - (conf.Fp.NbBits % 64)
@@ -56,8 +58,11 @@ func main() {
 			assertNoError(generator.GenerateFF(conf.Fr, filepath.Join(curveDir, "fr")))
 			assertNoError(generator.GenerateFF(conf.Fp, filepath.Join(curveDir, "fp")))
 
+			// generate hash to field on fr and fp
+			assertNoError(hashtofield.Generate(conf, curveDir, bgen))
+
 			// generate tower of extension
-			assertNoError(tower.Generate(conf, filepath.Join(curveDir, "internal", "fptower"), bgen))
+			assertNoError(tower.Generate(conf, filepath.Join(curveDir, "fptower"), bgen))
 
 			// generate fft on fr
 			assertNoError(fft.Generate(conf, filepath.Join(curveDir, "fr", "fft"), bgen))
@@ -110,6 +115,11 @@ func main() {
 
 	wg.Wait()
 
+	// generate fft and polynomial on goldilocks
+	goldilocksDir := filepath.Join(baseDir, "field", "goldilocks")
+	assertNoError(fft.Generate(config.Goldilocks, filepath.Join(goldilocksDir, "fft"), bgen))
+	assertNoError(polynomial.Generate(config.Goldilocks, filepath.Join(goldilocksDir, "polynomial"), bgen))
+
 	// format the whole directory
 
 	cmd := exec.Command("gofmt", "-s", "-w", baseDir)
@@ -13,6 +13,13 @@ import (
 
 // Generate generates a tower 2->6->12 over fp
 func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+	// package documentation, for every tower
+	conf.Package = "fptower"
+	doc := bavard.Entry{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}}
+	if err := bgen.Generate(conf, conf.Package, "./tower/template", doc); err != nil {
+		return err
+	}
+
 	if conf.Equal(config.BW6_756) || conf.Equal(config.BW6_761) || conf.Equal(config.BW6_633) || conf.Equal(config.BLS24_315) || conf.Equal(config.BLS24_317) {
 		return nil
 	}